@@ -27,6 +27,7 @@ func NewDefraCommand(cfg *config.Config) *cobra.Command {
 	p2p_collection := MakeP2PCollectionCommand()
 	p2p_collection.AddCommand(
 		MakeP2PCollectionAddCommand(),
+		MakeP2PCollectionFilterCommand(),
 		MakeP2PCollectionRemoveCommand(),
 		MakeP2PCollectionGetAllCommand(),
 	)
@@ -68,6 +69,7 @@ func NewDefraCommand(cfg *config.Config) *cobra.Command {
 		MakeIndexCreateCommand(),
 		MakeIndexDropCommand(),
 		MakeIndexListCommand(),
+		MakeIndexVerifyCommand(),
 	)
 
 	backup := MakeBackupCommand()
@@ -17,6 +17,7 @@ import (
 const (
 	errInvalidLensConfig        string = "invalid lens configuration"
 	errSchemaVersionNotOfSchema string = "the given schema version is from a different schema"
+	errInvalidIndexFieldArg     string = "invalid index field, expected <field>[:ASC|:DESC]"
 )
 
 var (
@@ -27,6 +28,7 @@ var (
 	ErrNoLensConfig             = errors.New("lens config cannot be empty")
 	ErrInvalidLensConfig        = errors.New("invalid lens configuration")
 	ErrSchemaVersionNotOfSchema = errors.New(errSchemaVersionNotOfSchema)
+	ErrInvalidIndexFieldArg     = errors.New(errInvalidIndexFieldArg)
 )
 
 func NewErrInvalidLensConfig(inner error) error {
@@ -40,3 +42,7 @@ func NewErrSchemaVersionNotOfSchema(schemaRoot string, schemaVersionID string) e
 		errors.NewKV("SchemaVersionID", schemaVersionID),
 	)
 }
+
+func NewErrInvalidIndexFieldArg(field string) error {
+	return errors.New(errInvalidIndexFieldArg, errors.NewKV("Field", field))
+}
@@ -11,6 +11,9 @@
 package cli
 
 import (
+	"encoding/json"
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/sourcenetwork/defradb/client"
@@ -21,29 +24,98 @@ func MakeIndexCreateCommand() *cobra.Command {
 	var collectionArg string
 	var nameArg string
 	var fieldsArg []string
+	var uniqueArg bool
+	var backgroundArg bool
+	var whereArg string
+	var fullTextArg bool
+	var tokenizerArg string
+	var caseSensitiveArg bool
+	var stemArg bool
 	var cmd = &cobra.Command{
-		Use:   "create -c --collection <collection> --fields <fields> [-n --name <name>]",
+		Use:   "create -c --collection <collection> --fields <fields> [-n --name <name>] [--unique] [--background] [--where <filter>] [--full-text [--tokenizer <tokenizer>] [--case-sensitive] [--stem]]",
 		Short: "Creates a secondary index on a collection's field(s)",
 		Long: `Creates a secondary index on a collection's field(s).
 		
 The --name flag is optional. If not provided, a name will be generated automatically.
+The --unique flag is optional. If provided, the index will ensure that no two documents
+have the same value for the indexed field(s).
+The --background flag is optional. If provided, the existing documents are indexed
+in batches after the index has been created. The progress of the build is shown by
+'defradb client index list' and the index is not used by queries until it is ready.
+The --where flag is optional. If provided, only the documents matching the given filter
+are indexed. The filter has the same form as the filter of a query, and the index is
+used only by queries whose filter implies it.
+The --full-text flag is optional. If provided, the index is a full-text index on a single
+String field, which is used by queries with a '_match' filter on the field and returns
+the documents ordered by relevance. The --tokenizer flag sets how the text is split into
+terms: WORD (default) or WHITESPACE. The --case-sensitive flag keeps the case of the terms
+and the --stem flag reduces them to their stem. These flags imply --full-text.
+The direction of each field can be set by appending ':ASC' or ':DESC' to its name.
+If not provided, the field is indexed in ascending order.
+The collation of a String field can be set by appending ':CASE_INSENSITIVE' (or ':BINARY',
+the default) after the direction. A case insensitive field is indexed by its lowercased
+values, so that the index is used by the '_ilike', '_nilike' and '_ieq' operators.
+A JSON field can only be indexed by the value at a path within it, which is appended
+to its name and separated by dots, e.g. 'metadata.user.id'.
 
 Example: create an index for 'Users' collection on 'name' field:
   defradb client index create --collection Users --fields name
 
 Example: create a named index for 'Users' collection on 'name' field:
-  defradb client index create --collection Users --fields name --name UsersByName`,
-		ValidArgs: []string{"collection", "fields", "name"},
+  defradb client index create --collection Users --fields name --name UsersByName
+
+Example: create a unique index for 'Users' collection on 'name' field:
+  defradb client index create --collection Users --fields name --unique
+
+Example: create a composite index for 'Users' collection on 'name' and 'age' fields:
+  defradb client index create --collection Users --fields name:ASC,age:DESC
+
+Example: create a unique case insensitive index for 'Users' collection on 'email' field:
+  defradb client index create --collection Users --fields email:ASC:CASE_INSENSITIVE --unique
+
+Example: create an index for 'Events' collection on the 'user.id' path of 'metadata' JSON field:
+  defradb client index create --collection Events --fields metadata.user.id
+
+Example: create an index for 'Users' collection on 'name' field in the background:
+  defradb client index create --collection Users --fields name --background
+
+Example: create an index for 'Users' collection on 'name' field of the users that are not deleted:
+  defradb client index create --collection Users --fields name --where '{"deleted": {"_eq": false}}'
+
+Example: create a full-text index for 'Articles' collection on 'body' field with stemming:
+  defradb client index create --collection Articles --fields body --full-text --stem`,
+		ValidArgs: []string{
+			"collection", "fields", "name", "unique", "background", "where",
+			"full-text", "tokenizer", "case-sensitive", "stem",
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			store := mustGetStoreContext(cmd)
 
 			var fields []client.IndexedFieldDescription
-			for _, name := range fieldsArg {
-				fields = append(fields, client.IndexedFieldDescription{Name: name})
+			for _, field := range fieldsArg {
+				fieldDesc, err := parseIndexedField(field)
+				if err != nil {
+					return err
+				}
+				fields = append(fields, fieldDesc)
 			}
 			desc := client.IndexDescription{
-				Name:   nameArg,
-				Fields: fields,
+				Name:       nameArg,
+				Fields:     fields,
+				Unique:     uniqueArg,
+				Background: backgroundArg,
+			}
+			if whereArg != "" {
+				if err := json.Unmarshal([]byte(whereArg), &desc.Where); err != nil {
+					return err
+				}
+			}
+			if fullTextArg || tokenizerArg != "" || caseSensitiveArg || stemArg {
+				desc.FullText = &client.FullTextIndexOptions{
+					Tokenizer:     client.FullTextTokenizer(strings.ToUpper(tokenizerArg)),
+					CaseSensitive: caseSensitiveArg,
+					Stem:          stemArg,
+				}
 			}
 			col, err := store.GetCollectionByName(cmd.Context(), collectionArg)
 			if err != nil {
@@ -62,6 +134,41 @@ Example: create a named index for 'Users' collection on 'name' field:
 	cmd.Flags().StringVarP(&collectionArg, "collection", "c", "", "Collection name")
 	cmd.Flags().StringVarP(&nameArg, "name", "n", "", "Index name")
 	cmd.Flags().StringSliceVar(&fieldsArg, "fields", []string{}, "Fields to index")
+	cmd.Flags().BoolVarP(&uniqueArg, "unique", "u", false, "Make the index unique")
+	cmd.Flags().BoolVar(&backgroundArg, "background", false, "Index the existing documents in the background")
+	cmd.Flags().StringVar(&whereArg, "where", "", "Filter of the documents to index")
+	cmd.Flags().BoolVar(&fullTextArg, "full-text", false, "Make the index a full-text index")
+	cmd.Flags().StringVar(&tokenizerArg, "tokenizer", "", "Tokenizer of the full-text index: WORD or WHITESPACE")
+	cmd.Flags().BoolVar(&caseSensitiveArg, "case-sensitive", false, "Keep the case of the full-text index terms")
+	cmd.Flags().BoolVar(&stemArg, "stem", false, "Reduce the full-text index terms to their stem")
 
 	return cmd
 }
+
+// parseIndexedField parses an indexed field argument of the form
+// <field>[.<path>][:ASC|:DESC][:BINARY|:CASE_INSENSITIVE], where the path is the dot separated
+// path to the indexed value within a JSON field.
+func parseIndexedField(arg string) (client.IndexedFieldDescription, error) {
+	parts := strings.Split(arg, ":")
+	if parts[0] == "" || len(parts) > 3 {
+		return client.IndexedFieldDescription{}, NewErrInvalidIndexFieldArg(arg)
+	}
+	desc := client.IndexedFieldDescription{Name: parts[0]}
+	if path := strings.Split(parts[0], "."); len(path) > 1 {
+		desc.Name = path[0]
+		desc.Path = path[1:]
+	}
+	for _, part := range parts[1:] {
+		switch value := strings.ToUpper(part); {
+		case desc.Direction == "" && desc.Collation == "" &&
+			(value == string(client.Ascending) || value == string(client.Descending)):
+			desc.Direction = client.IndexDirection(value)
+		case desc.Collation == "" &&
+			(value == string(client.BinaryCollation) || value == string(client.CaseInsensitiveCollation)):
+			desc.Collation = client.IndexCollation(value)
+		default:
+			return client.IndexedFieldDescription{}, NewErrInvalidIndexFieldArg(arg)
+		}
+	}
+	return desc, nil
+}
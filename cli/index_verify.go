@@ -0,0 +1,66 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/datastore"
+)
+
+func MakeIndexVerifyCommand() *cobra.Command {
+	var collectionArg string
+	var nameArg string
+	var repairArg bool
+	var cmd = &cobra.Command{
+		Use:   "verify -c --collection <collection> -n --name <name> [--repair]",
+		Short: "Verify a collection's secondary index",
+		Long: `Verify a collection's secondary index.
+
+Compares the index against the documents of the collection and reports the
+missing, orphaned and mismatched index keys.
+If the --repair flag is provided, the found differences are repaired.
+
+Example: verify the index 'UsersByName' of 'Users' collection:
+  defradb client index verify --collection Users --name UsersByName
+
+Example: repair the index 'UsersByName' of 'Users' collection:
+  defradb client index verify --collection Users --name UsersByName --repair`,
+		ValidArgs: []string{"collection", "name"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store := mustGetStoreContext(cmd)
+
+			col, err := store.GetCollectionByName(cmd.Context(), collectionArg)
+			if err != nil {
+				return err
+			}
+			if tx, ok := cmd.Context().Value(txContextKey).(datastore.Txn); ok {
+				col = col.WithTxn(tx)
+			}
+			var result client.IndexVerification
+			if repairArg {
+				result, err = col.RebuildIndex(cmd.Context(), nameArg)
+			} else {
+				result, err = col.VerifyIndex(cmd.Context(), nameArg)
+			}
+			if err != nil {
+				return err
+			}
+			return writeJSON(cmd, result)
+		},
+	}
+	cmd.Flags().StringVarP(&collectionArg, "collection", "c", "", "Collection name")
+	cmd.Flags().StringVarP(&nameArg, "name", "n", "", "Index name")
+	cmd.Flags().BoolVar(&repairArg, "repair", false, "Repair the differences found in the index")
+
+	return cmd
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+)
+
+func MakeP2PCollectionFilterCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "filter <collectionID> [filter]",
+		Short: "Set the filter of a P2P collection",
+		Long: `Set the filter that the documents of a P2P collection must match to be synchronized
+from other nodes. The filter replaces any previous filter of the collection, it is removed if
+no filter is given.
+
+Example: synchronize only the documents of a tenant
+  defradb client p2p collection filter bae123 '{"tenant": {"_eq": "acme"}}'
+
+Example: remove the filter
+  defradb client p2p collection filter bae123
+		`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p2p := mustGetP2PContext(cmd)
+
+			var filter map[string]any
+			if len(args) == 2 {
+				if err := json.Unmarshal([]byte(args[1]), &filter); err != nil {
+					return err
+				}
+			}
+			return p2p.SetP2PCollectionFilter(cmd.Context(), args[0], filter)
+		},
+	}
+	return cmd
+}
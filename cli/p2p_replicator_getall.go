@@ -21,6 +21,10 @@ func MakeP2PReplicatorGetAllCommand() *cobra.Command {
 		Long: `Get all the replicators active in the P2P data sync system.
 A replicator synchronizes one or all collection(s) from this node to another.
 
+Along with its collections, the status of each replicator is returned: whether
+it is connected, the time of the last successful push, the number of blocks
+queued to be retried, the last error and the heads it has acknowledged per collection.
+
 Example:
   defradb client p2p replicator getall
   		`,
@@ -21,14 +21,20 @@ import (
 
 func MakeP2PReplicatorSetCommand() *cobra.Command {
 	var collections []string
+	var filter string
 	var cmd = &cobra.Command{
-		Use:   "set [-c, --collection] <peer>",
+		Use:   "set [-c, --collection] [--filter] <peer>",
 		Short: "Add replicator(s) and start synchronization",
 		Long: `Add replicator(s) and start synchronization.
 A replicator synchronizes one or all collection(s) from this node to another.
+If a filter is given, only the documents matching it are replicated.
 
 Example:
   defradb client p2p replicator set -c Users '{"ID": "12D3", "Addrs": ["/ip4/0.0.0.0/tcp/9171"]}'
+
+Example: replicate only the documents of a tenant
+  defradb client p2p replicator set -c Users --filter '{"tenant": {"_eq": "acme"}}' \
+    '{"ID": "12D3", "Addrs": ["/ip4/0.0.0.0/tcp/9171"]}'
 `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -42,11 +48,17 @@ Example:
 				Info:    info,
 				Schemas: collections,
 			}
+			if filter != "" {
+				if err := json.Unmarshal([]byte(filter), &rep.Filter); err != nil {
+					return err
+				}
+			}
 			return p2p.SetReplicator(cmd.Context(), rep)
 		},
 	}
 
 	cmd.Flags().StringSliceVarP(&collections, "collection", "c",
 		[]string{}, "Collection(s) to replicate")
+	cmd.Flags().StringVar(&filter, "filter", "", "Filter that the documents must match to be replicated")
 	return cmd
 }
@@ -160,6 +160,14 @@ type Collection interface {
 
 	// GetIndexes returns all the indexes that exist on the collection.
 	GetIndexes(ctx context.Context) ([]IndexDescription, error)
+
+	// VerifyIndex compares the index with the given name against the documents of the
+	// collection and reports missing, orphaned and mismatched index records.
+	VerifyIndex(ctx context.Context, indexName string) (IndexVerification, error)
+
+	// RebuildIndex verifies the index with the given name and repairs the differences found,
+	// without dropping and recreating the whole index.
+	RebuildIndex(ctx context.Context, indexName string) (IndexVerification, error)
 }
 
 // DocKeysResult wraps the result of an attempt at a DocKey retrieval operation.
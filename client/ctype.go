@@ -22,4 +22,61 @@ const (
 	LWW_REGISTER
 	OBJECT
 	COMPOSITE
+	// PN_COUNTER is a counter that can be incremented and decremented, concurrent
+	// increments add up instead of overwriting each other.
+	PN_COUNTER
+	// P_COUNTER is a grow-only counter, it can only be incremented.
+	P_COUNTER
+	// OR_SET is an observed-remove set of the elements of an array, concurrent additions
+	// of elements are all kept and an addition wins over a concurrent removal.
+	OR_SET
+	// RGA is a replicated growable array of the characters of a text, concurrent insertions
+	// and deletions of text are merged character-wise.
+	RGA
+	// LWW_HLC_REGISTER is a last-writer-wins register whose conflicts are resolved by the hybrid
+	// logical clock timestamps of the writes, so that the value written last wins regardless of
+	// the number of values written before it.
+	LWW_HLC_REGISTER
+	// MV_REGISTER is a multi-value register, the values written concurrently are all kept until
+	// a later write replaces them.
+	MV_REGISTER
 )
+
+// IsSupportedFieldCType returns true if the CRDT type can be assigned to a field.
+func (t CType) IsSupportedFieldCType() bool {
+	switch t {
+	case NONE_CRDT, LWW_REGISTER, PN_COUNTER, P_COUNTER, OR_SET, RGA, LWW_HLC_REGISTER, MV_REGISTER:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsCounter returns true if the CRDT type is a counter.
+func (t CType) IsCounter() bool {
+	return t == PN_COUNTER || t == P_COUNTER
+}
+
+// IsCompatibleWith returns true if the CRDT type can be assigned to a field of the given kind.
+//
+// Counters can only be assigned to Int and Float fields, sets to inline array fields
+// and text sequences to String fields.
+func (t CType) IsCompatibleWith(kind FieldKind) bool {
+	if t.IsCounter() {
+		return kind == FieldKind_INT || kind == FieldKind_FLOAT
+	}
+	if t == RGA {
+		return kind == FieldKind_STRING
+	}
+	if t == OR_SET {
+		switch kind {
+		case FieldKind_BOOL_ARRAY, FieldKind_INT_ARRAY, FieldKind_FLOAT_ARRAY, FieldKind_STRING_ARRAY,
+			FieldKind_NILLABLE_BOOL_ARRAY, FieldKind_NILLABLE_INT_ARRAY, FieldKind_NILLABLE_FLOAT_ARRAY,
+			FieldKind_NILLABLE_STRING_ARRAY:
+			return true
+		default:
+			return false
+		}
+	}
+	return true
+}
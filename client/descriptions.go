@@ -148,6 +148,8 @@ func (f FieldKind) String() string {
 		return "[String!]"
 	case FieldKind_BLOB:
 		return "Blob"
+	case FieldKind_JSON:
+		return "JSON"
 	default:
 		return fmt.Sprint(uint8(f))
 	}
@@ -169,7 +171,7 @@ const (
 	FieldKind_STRING       FieldKind = 11
 	FieldKind_STRING_ARRAY FieldKind = 12
 	FieldKind_BLOB         FieldKind = 13
-	_                      FieldKind = 14 // safe to repurpose (was never used)
+	FieldKind_JSON         FieldKind = 14
 	_                      FieldKind = 15 // safe to repurpose (was never used)
 
 	// Embedded object, but accessed via foreign keys
@@ -207,6 +209,7 @@ var FieldKindStringToEnumMapping = map[string]FieldKind{
 	"[String]":   FieldKind_NILLABLE_STRING_ARRAY,
 	"[String!]":  FieldKind_STRING_ARRAY,
 	"Blob":       FieldKind_BLOB,
+	"JSON":       FieldKind_JSON,
 }
 
 // RelationType describes the type of relation between two types.
@@ -224,6 +227,19 @@ const (
 	Relation_Type_Primary     RelationType = 128 // 0b1000 0000 Primary reference entity on relation
 )
 
+// RelationOnDelete is what happens to the documents referencing a document through a relation
+// when it is deleted.
+type RelationOnDelete string
+
+const (
+	// OnDeleteCascade deletes the referencing documents along with the deleted document.
+	OnDeleteCascade RelationOnDelete = "CASCADE"
+	// OnDeleteSetNull clears the reference of the referencing documents to the deleted document.
+	OnDeleteSetNull RelationOnDelete = "SET_NULL"
+	// OnDeleteRestrict prevents the deletion of a document while it is referenced.
+	OnDeleteRestrict RelationOnDelete = "RESTRICT"
+)
+
 // FieldID is a unique identifier for a field in a schema.
 type FieldID uint32
 
@@ -261,12 +277,28 @@ type FieldDescription struct {
 
 	// The CRDT Type of this field. If no type has been provided it will default to [LWW_REGISTER].
 	//
+	// Int and Float fields can also be counters ([PN_COUNTER] or [P_COUNTER]), in which case
+	// the values written to the field are increments of its current value.
+	//
+	// Inline array fields can also be sets ([OR_SET]), in which case elements can be added and
+	// removed without overwriting the concurrent changes made to the array.
+	//
+	// String fields can also be text sequences ([RGA]), in which case text can be inserted and
+	// deleted at positions, and concurrent edits are merged character-wise.
+	//
 	// It is currently immutable.
 	Typ CType
 
 	// RelationType contains the relationship type if this field is a relation field. Otherwise this
 	// will be empty.
 	RelationType RelationType
+
+	// OnDelete contains what happens to the documents of this field's schema when the document
+	// they reference through this field is deleted. If it is empty, they are left as they are.
+	//
+	// It can only be set on the primary side of a relation, the side holding the reference.
+	// It is currently immutable.
+	OnDelete RelationOnDelete `json:",omitempty"`
 }
 
 // IsInternal returns true if this field is internally generated.
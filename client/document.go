@@ -302,7 +302,8 @@ func (doc *Document) setAndParseType(field string, value any) error {
 		}
 
 	// string, bool, and more
-	case string, bool, int64, []any, []bool, []*bool, []int64, []*int64, []float64, []*float64, []string, []*string:
+	case string, bool, int64, []any, []bool, []*bool, []int64, []*int64, []float64, []*float64, []string, []*string,
+		SetPatch, TextPatch:
 		err := doc.setCBOR(LWW_REGISTER, field, val)
 		if err != nil {
 			return err
@@ -398,6 +399,14 @@ func (doc *Document) ToMap() (map[string]any, error) {
 	return doc.toMapWithKey()
 }
 
+// ToObject returns the document as a map[string]any object, including any sub documents.
+//
+// Unlike ToMap, the returned object doesn't hold the document key, which makes it suitable for
+// getting back the structured value a sub document has been parsed from.
+func (doc *Document) ToObject() (map[string]any, error) {
+	return doc.toMap()
+}
+
 // ToJSONPatch returns a json patch that can be used to update
 // a document by calling SetWithJSON.
 func (doc *Document) ToJSONPatch() ([]byte, error) {
@@ -450,6 +459,7 @@ func (doc *Document) toMap() (map[string]any, error) {
 				return nil, err
 			}
 			docMap[k] = subDocMap
+			continue
 		}
 
 		docMap[k] = value.Value()
@@ -470,11 +480,13 @@ func (doc *Document) toMapWithKey() (map[string]any, error) {
 
 		if value.IsDocument() {
 			subDoc := value.Value().(*Document)
-			subDocMap, err := subDoc.toMapWithKey()
+			// sub documents have no key of their own
+			subDocMap, err := subDoc.toMap()
 			if err != nil {
 				return nil, err
 			}
 			docMap[k] = subDocMap
+			continue
 		}
 
 		docMap[k] = value.Value()
@@ -17,14 +17,15 @@ import (
 )
 
 const (
-	errFieldNotExist        string = "The given field does not exist"
-	errUnexpectedType       string = "unexpected type"
-	errParsingFailed        string = "failed to parse argument"
-	errUninitializeProperty string = "invalid state, required property is uninitialized"
-	errMaxTxnRetries        string = "reached maximum transaction reties"
-	errRelationOneSided     string = "relation must be defined on both schemas"
-	errCollectionNotFound   string = "collection not found"
-	errUnknownCRDT          string = "unknown crdt"
+	errFieldNotExist          string = "The given field does not exist"
+	errUnexpectedType         string = "unexpected type"
+	errParsingFailed          string = "failed to parse argument"
+	errUninitializeProperty   string = "invalid state, required property is uninitialized"
+	errMaxTxnRetries          string = "reached maximum transaction reties"
+	errRelationOneSided       string = "relation must be defined on both schemas"
+	errCollectionNotFound     string = "collection not found"
+	errUnknownCRDT            string = "unknown crdt"
+	errTextPositionOutOfRange string = "text position out of range"
 )
 
 // Errors returnable from this package.
@@ -32,23 +33,24 @@ const (
 // This list is incomplete and undefined errors may also be returned.
 // Errors returned from this package may be tested against these errors with errors.Is.
 var (
-	ErrFieldNotExist        = errors.New(errFieldNotExist)
-	ErrUnexpectedType       = errors.New(errUnexpectedType)
-	ErrParsingFailed        = errors.New(errParsingFailed)
-	ErrUninitializeProperty = errors.New(errUninitializeProperty)
-	ErrFieldNotObject       = errors.New("trying to access field on a non object type")
-	ErrValueTypeMismatch    = errors.New("value does not match indicated type")
-	ErrIndexNotFound        = errors.New("no index found for given ID")
-	ErrDocumentNotFound     = errors.New("no document for the given key exists")
-	ErrInvalidUpdateTarget  = errors.New("the target document to update is of invalid type")
-	ErrInvalidUpdater       = errors.New("the updater of a document is of invalid type")
-	ErrInvalidDeleteTarget  = errors.New("the target document to delete is of invalid type")
-	ErrMalformedDocKey      = errors.New("malformed DocKey, missing either version or cid")
-	ErrInvalidDocKeyVersion = errors.New("invalid DocKey version")
-	ErrMaxTxnRetries        = errors.New(errMaxTxnRetries)
-	ErrRelationOneSided     = errors.New(errRelationOneSided)
-	ErrCollectionNotFound   = errors.New(errCollectionNotFound)
-	ErrUnknownCRDT          = errors.New(errUnknownCRDT)
+	ErrFieldNotExist          = errors.New(errFieldNotExist)
+	ErrUnexpectedType         = errors.New(errUnexpectedType)
+	ErrParsingFailed          = errors.New(errParsingFailed)
+	ErrUninitializeProperty   = errors.New(errUninitializeProperty)
+	ErrFieldNotObject         = errors.New("trying to access field on a non object type")
+	ErrValueTypeMismatch      = errors.New("value does not match indicated type")
+	ErrIndexNotFound          = errors.New("no index found for given ID")
+	ErrDocumentNotFound       = errors.New("no document for the given key exists")
+	ErrInvalidUpdateTarget    = errors.New("the target document to update is of invalid type")
+	ErrInvalidUpdater         = errors.New("the updater of a document is of invalid type")
+	ErrInvalidDeleteTarget    = errors.New("the target document to delete is of invalid type")
+	ErrMalformedDocKey        = errors.New("malformed DocKey, missing either version or cid")
+	ErrInvalidDocKeyVersion   = errors.New("invalid DocKey version")
+	ErrMaxTxnRetries          = errors.New(errMaxTxnRetries)
+	ErrRelationOneSided       = errors.New(errRelationOneSided)
+	ErrCollectionNotFound     = errors.New(errCollectionNotFound)
+	ErrUnknownCRDT            = errors.New(errUnknownCRDT)
+	ErrTextPositionOutOfRange = errors.New(errTextPositionOutOfRange)
 )
 
 // NewErrFieldNotExist returns an error indicating that the given field does not exist.
@@ -132,3 +134,13 @@ func NewErrUnknownCRDT(cType CType) error {
 		errors.NewKV("Type", cType),
 	)
 }
+
+// NewErrTextPositionOutOfRange returns an error indicating that a text patch refers to a position
+// outside of the text it is applied to.
+func NewErrTextPositionOutOfRange(position int, length int) error {
+	return errors.New(
+		errTextPositionOutOfRange,
+		errors.NewKV("Position", position),
+		errors.NewKV("Length", length),
+	)
+}
@@ -10,6 +10,8 @@
 
 package client
 
+import "strings"
+
 // IndexDirection is the direction of an index.
 type IndexDirection string
 
@@ -20,12 +22,40 @@ const (
 	Descending IndexDirection = "DESC"
 )
 
+// IndexCollation is the way the string values of an indexed field are compared.
+type IndexCollation string
+
+const (
+	// BinaryCollation compares strings byte by byte, it is the default collation.
+	BinaryCollation IndexCollation = "BINARY"
+	// CaseInsensitiveCollation compares strings regardless of their case.
+	CaseInsensitiveCollation IndexCollation = "CASE_INSENSITIVE"
+)
+
 // IndexFieldDescription describes how a field is being indexed.
 type IndexedFieldDescription struct {
 	// Name contains the name of the field.
 	Name string
 	// Direction contains the direction of the index.
 	Direction IndexDirection
+	// Collation contains the way the values of the field are compared.
+	// It can only be set for String fields.
+	//
+	// A case insensitive field is indexed by its lowercased values, so that the index can be used
+	// by the case insensitive operators and a unique index doesn't allow values that differ only
+	// by their case. If it is empty, the binary collation is used.
+	Collation IndexCollation
+	// Path contains the path to the indexed value within a JSON field, e.g. `["user", "id"]`.
+	// Array elements are referred to by their index.
+	//
+	// JSON fields can only be indexed by path, and only by the scalar value at the path:
+	// documents with an object or an array at the path are indexed as missing a value.
+	Path []string
+}
+
+// IsCaseInsensitive returns true if the field is indexed with the case insensitive collation.
+func (d IndexedFieldDescription) IsCaseInsensitive() bool {
+	return d.Collation == CaseInsensitiveCollation
 }
 
 // IndexDescription describes an index.
@@ -36,18 +66,167 @@ type IndexDescription struct {
 	ID uint32
 	// Fields contains the fields that are being indexed.
 	Fields []IndexedFieldDescription
+	// Unique indicates whether the index is unique.
+	//
+	// A unique index guarantees that no two documents share the same indexed value.
+	// Documents without a value for the indexed field are not subject to this constraint.
+	Unique bool
+	// Where contains the filter a document has to match in order to be indexed.
+	//
+	// It has the same form as the filter of a query, e.g. `{"deleted": {"_eq": false}}`,
+	// and can only refer to the fields of the indexed collection. An index with a filter,
+	// also called a partial index, is used by a query only if the filter of the query
+	// implies the filter of the index.
+	//
+	// If it is empty, all documents of the collection are indexed.
+	Where map[string]any
+	// FullText holds the options of a full-text index, it is nil for other indexes.
+	//
+	// A full-text index is an inverted index over a single String field: the value of the field
+	// is split into terms and every term is stored along with the documents containing it.
+	// It is used by the `_match` filter operator, and the documents it returns are ordered
+	// by relevance.
+	FullText *FullTextIndexOptions
+	// Background indicates that the existing documents of the collection are indexed in the
+	// background once the index has been created.
+	//
+	// If it is set, the index is created without indexing the existing documents in the same
+	// transaction. They are instead indexed in batches, each batch within its own transaction,
+	// and the progress of the build is reported by the status of the index.
+	Background bool
+	// EncodingVersion is the version of the encoding of the keys of the index.
+	//
+	// It is maintained by the database, an index can't be created with a version. The indexes
+	// stored with an older version are rebuilt when the database is opened.
+	EncodingVersion uint32
+	// Status contains the state of the index.
+	//
+	// It is maintained by the database and returned by GetIndexes, an index can't be created
+	// with a status.
+	Status IndexStatus
+}
+
+// FullTextTokenizer is the way the text of a full-text index field is split into terms.
+type FullTextTokenizer string
+
+const (
+	// FullTextWordTokenizer splits the text at every character that is not a letter or a digit.
+	FullTextWordTokenizer FullTextTokenizer = "WORD"
+	// FullTextWhitespaceTokenizer splits the text at whitespace only.
+	FullTextWhitespaceTokenizer FullTextTokenizer = "WHITESPACE"
+)
+
+// FullTextIndexOptions describes how the values of a full-text index field are split into terms.
+//
+// The query of a `_match` filter is split into terms the same way.
+type FullTextIndexOptions struct {
+	// Tokenizer contains the way the text is split into terms.
+	//
+	// If it is empty, the word tokenizer is used.
+	Tokenizer FullTextTokenizer
+	// CaseSensitive indicates whether the terms are kept in their original case,
+	// otherwise they are lowercased.
+	CaseSensitive bool
+	// Stem indicates whether the terms are reduced to their stem, so that different forms of
+	// a word match each other, e.g. "run" and "running".
+	//
+	// The stemmer handles the regular suffixes of English words only.
+	Stem bool
+}
+
+// IndexStatus describes the state of an index.
+type IndexStatus struct {
+	// Building indicates that the existing documents of the collection are still being added
+	// to the index in the background.
+	//
+	// A building index is kept up to date with the documents written after its creation,
+	// but it is not used by queries until it is ready.
+	Building bool
+	// BuildProgress contains the progress of the background build of the index.
+	BuildProgress IndexBuildProgress
+}
+
+// IndexBuildProgress describes the progress of a background index build.
+type IndexBuildProgress struct {
+	// IndexedDocs contains the number of existing documents that have been indexed so far.
+	IndexedDocs uint64
+	// TotalDocs contains the number of documents the collection had when the index was created.
+	//
+	// The documents created while the index is built may be indexed by the build as well,
+	// so the number of indexed documents can end up greater than it.
+	TotalDocs uint64
+	// Error contains the error that stopped the build, if any.
+	//
+	// An index whose build has failed stays in the building state until it is dropped.
+	Error string
+}
+
+// IndexVerification describes the differences found between an index and the documents
+// of its collection.
+type IndexVerification struct {
+	// Index contains the name of the verified index.
+	Index string
+	// MissingKeys contains the keys of records that documents should be stored with,
+	// but that are not present in the index.
+	MissingKeys []string
+	// OrphanedKeys contains the keys of index records that don't belong to any document.
+	OrphanedKeys []string
+	// MismatchedKeys contains the keys of index records that are stored with a different value
+	// than the one expected for their document.
+	MismatchedKeys []string
+	// Repaired indicates whether the differences have been repaired.
+	Repaired bool
+}
+
+// IsValid returns true if no differences have been found.
+func (v IndexVerification) IsValid() bool {
+	return len(v.MissingKeys) == 0 && len(v.OrphanedKeys) == 0 && len(v.MismatchedKeys) == 0
+}
+
+// ReferencedFieldNames returns the names of the fields the index depends on, i.e. the indexed
+// fields followed by the fields its filter refers to.
+func (d IndexDescription) ReferencedFieldNames() []string {
+	names := make([]string, 0, len(d.Fields))
+	for _, field := range d.Fields {
+		names = append(names, field.Name)
+	}
+	return appendFilterFieldNames(names, d.Where)
+}
+
+// appendFilterFieldNames appends the names of the fields the given filter conditions refer to.
+//
+// Keys starting with an underscore are operators or the document key, which is not a stored field.
+func appendFilterFieldNames(names []string, conditions map[string]any) []string {
+	for key, clause := range conditions {
+		if !strings.HasPrefix(key, "_") {
+			names = append(names, key)
+			continue
+		}
+		switch typedClause := clause.(type) {
+		case map[string]any:
+			names = appendFilterFieldNames(names, typedClause)
+		case []any:
+			for _, item := range typedClause {
+				if itemConditions, ok := item.(map[string]any); ok {
+					names = appendFilterFieldNames(names, itemConditions)
+				}
+			}
+		}
+	}
+	return names
 }
 
-// CollectIndexedFields returns all fields that are indexed by all collection indexes.
+// CollectIndexedFields returns all fields that are referenced by all collection indexes,
+// including the fields the filters of partial indexes refer to.
 func (d CollectionDescription) CollectIndexedFields(schema *SchemaDescription) []FieldDescription {
 	fieldsMap := make(map[string]bool)
 	fields := make([]FieldDescription, 0, len(d.Indexes))
 	for _, index := range d.Indexes {
-		for _, field := range index.Fields {
+		for _, fieldName := range index.ReferencedFieldNames() {
 			for i := range schema.Fields {
 				colField := schema.Fields[i]
-				if field.Name == colField.Name && !fieldsMap[field.Name] {
-					fieldsMap[field.Name] = true
+				if fieldName == colField.Name && !fieldsMap[fieldName] {
+					fieldsMap[fieldName] = true
 					fields = append(fields, colField)
 					break
 				}
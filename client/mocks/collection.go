@@ -861,6 +861,59 @@ func (_c *Collection_Name_Call) RunAndReturn(run func() string) *Collection_Name
 	return _c
 }
 
+// RebuildIndex provides a mock function with given fields: ctx, indexName
+func (_m *Collection) RebuildIndex(ctx context.Context, indexName string) (client.IndexVerification, error) {
+	ret := _m.Called(ctx, indexName)
+
+	var r0 client.IndexVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (client.IndexVerification, error)); ok {
+		return rf(ctx, indexName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) client.IndexVerification); ok {
+		r0 = rf(ctx, indexName)
+	} else {
+		r0 = ret.Get(0).(client.IndexVerification)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, indexName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Collection_RebuildIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RebuildIndex'
+type Collection_RebuildIndex_Call struct {
+	*mock.Call
+}
+
+// RebuildIndex is a helper method to define mock.On call
+//   - ctx context.Context
+//   - indexName string
+func (_e *Collection_Expecter) RebuildIndex(ctx interface{}, indexName interface{}) *Collection_RebuildIndex_Call {
+	return &Collection_RebuildIndex_Call{Call: _e.mock.On("RebuildIndex", ctx, indexName)}
+}
+
+func (_c *Collection_RebuildIndex_Call) Run(run func(ctx context.Context, indexName string)) *Collection_RebuildIndex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Collection_RebuildIndex_Call) Return(_a0 client.IndexVerification, _a1 error) *Collection_RebuildIndex_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Collection_RebuildIndex_Call) RunAndReturn(run func(context.Context, string) (client.IndexVerification, error)) *Collection_RebuildIndex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function with given fields: _a0, _a1
 func (_m *Collection) Save(_a0 context.Context, _a1 *client.Document) error {
 	ret := _m.Called(_a0, _a1)
@@ -1253,6 +1306,59 @@ func (_c *Collection_UpdateWithKeys_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// VerifyIndex provides a mock function with given fields: ctx, indexName
+func (_m *Collection) VerifyIndex(ctx context.Context, indexName string) (client.IndexVerification, error) {
+	ret := _m.Called(ctx, indexName)
+
+	var r0 client.IndexVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (client.IndexVerification, error)); ok {
+		return rf(ctx, indexName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) client.IndexVerification); ok {
+		r0 = rf(ctx, indexName)
+	} else {
+		r0 = ret.Get(0).(client.IndexVerification)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, indexName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Collection_VerifyIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyIndex'
+type Collection_VerifyIndex_Call struct {
+	*mock.Call
+}
+
+// VerifyIndex is a helper method to define mock.On call
+//   - ctx context.Context
+//   - indexName string
+func (_e *Collection_Expecter) VerifyIndex(ctx interface{}, indexName interface{}) *Collection_VerifyIndex_Call {
+	return &Collection_VerifyIndex_Call{Call: _e.mock.On("VerifyIndex", ctx, indexName)}
+}
+
+func (_c *Collection_VerifyIndex_Call) Run(run func(ctx context.Context, indexName string)) *Collection_VerifyIndex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Collection_VerifyIndex_Call) Return(_a0 client.IndexVerification, _a1 error) *Collection_VerifyIndex_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Collection_VerifyIndex_Call) RunAndReturn(run func(context.Context, string) (client.IndexVerification, error)) *Collection_VerifyIndex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WithTxn provides a mock function with given fields: _a0
 func (_m *Collection) WithTxn(_a0 datastore.Txn) client.Collection {
 	ret := _m.Called(_a0)
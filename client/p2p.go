@@ -38,6 +38,12 @@ type P2P interface {
 	// collection IDs are invalid.
 	AddP2PCollections(ctx context.Context, collectionIDs []string) error
 
+	// SetP2PCollectionFilter sets the filter that the documents of the given P2P collection
+	// must match to be synchronized from other peers, replacing any previous filter. All
+	// documents are synchronized if the filter is empty. It will error if the collection
+	// ID has not been added to the P2P system.
+	SetP2PCollectionFilter(ctx context.Context, collectionID string, filter map[string]any) error
+
 	// RemoveP2PCollections removes the given collection IDs from the P2P system and
 	// unsubscribes from their topics. It will error if the provided
 	// collection IDs are invalid.
@@ -10,10 +10,42 @@
 
 package client
 
-import "github.com/libp2p/go-libp2p/core/peer"
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ReplicatorStatus is the status of the connection to a replicator.
+type ReplicatorStatus string
+
+const (
+	// ReplicatorStatusConnected means that the node is connected to the replicator.
+	ReplicatorStatusConnected ReplicatorStatus = "connected"
+	// ReplicatorStatusDisconnected means that the node isn't connected to the replicator.
+	ReplicatorStatusDisconnected ReplicatorStatus = "disconnected"
+)
 
 // Replicator is a peer that a set of local collections are replicated to.
 type Replicator struct {
 	Info    peer.AddrInfo
 	Schemas []string
+	// Filter is the filter that the documents of the replicated collections must match to be
+	// replicated, for example `{"tenant": {"_eq": "acme"}}`. All documents are replicated if
+	// it is empty.
+	Filter map[string]any
+	// Status is the status of the connection to the replicator.
+	Status ReplicatorStatus
+	// LastPushedAt is the time of the last successful push to the replicator since the node
+	// started, it is zero if nothing has been pushed to it yet.
+	LastPushedAt time.Time
+	// Lag is the number of blocks that failed to be pushed to the replicator
+	// and are queued to be retried.
+	Lag int
+	// LastError is the error of the last failed push to the replicator, if any.
+	LastError string
+	// Heads maps the schema root of each replicated collection to the heads acknowledged
+	// by the replicator since the node started, that is the cid of the last update of each
+	// document of the collection that the replicator has received.
+	Heads map[string][]string
 }
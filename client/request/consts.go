@@ -36,16 +36,29 @@ const (
 	OrderClause   = "order"
 	DepthClause   = "depth"
 
-	AverageFieldName = "_avg"
-	CountFieldName   = "_count"
-	KeyFieldName     = "_key"
-	GroupFieldName   = "_group"
-	DeletedFieldName = "_deleted"
-	SumFieldName     = "_sum"
-	VersionFieldName = "_version"
+	AverageFieldName   = "_avg"
+	CountFieldName     = "_count"
+	KeyFieldName       = "_key"
+	GroupFieldName     = "_group"
+	DeletedFieldName   = "_deleted"
+	ConflictsFieldName = "_conflicts"
+	SumFieldName       = "_sum"
+	VersionFieldName   = "_version"
 
 	ExplainLabel = "explain"
 
+	// The operators adding elements to and removing elements from a set field in an update.
+	SetAddOperator    = "_add"
+	SetRemoveOperator = "_remove"
+
+	// The operators inserting text into and deleting text from a text sequence field in an update,
+	// and the properties of their operations.
+	TextInsertOperator = "_insert"
+	TextDeleteOperator = "_delete"
+	TextPositionProp   = "position"
+	TextTextProp       = "text"
+	TextLengthProp     = "length"
+
 	LatestCommitsName = "latestCommits"
 	CommitsName       = "commits"
 
@@ -74,14 +87,15 @@ var (
 	}
 
 	ReservedFields = map[string]bool{
-		TypeNameFieldName: true,
-		VersionFieldName:  true,
-		GroupFieldName:    true,
-		CountFieldName:    true,
-		SumFieldName:      true,
-		AverageFieldName:  true,
-		KeyFieldName:      true,
-		DeletedFieldName:  true,
+		TypeNameFieldName:  true,
+		VersionFieldName:   true,
+		GroupFieldName:     true,
+		CountFieldName:     true,
+		SumFieldName:       true,
+		AverageFieldName:   true,
+		KeyFieldName:       true,
+		DeletedFieldName:   true,
+		ConflictsFieldName: true,
 	}
 
 	Aggregates = map[string]struct{}{
@@ -14,6 +14,14 @@ const (
 	FilterOpOr  = "_or"
 	FilterOpAnd = "_and"
 	FilterOpNot = "_not"
+
+	// FilterOpMatch is the full-text match operator, the only one that can be served
+	// by a full-text index.
+	FilterOpMatch = "_match"
+
+	// FilterJSONPathProp is the property of the conditions on a JSON field that holds the path
+	// to the value the conditions are applied to.
+	FilterJSONPathProp = "path"
 )
 
 // Filter contains the parsed condition map to be
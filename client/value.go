@@ -43,6 +43,79 @@ type ReadableValue interface {
 	Read() (any, error)
 }
 
+// SetPatch is the value of a set field ([OR_SET]) that adds elements to and removes elements
+// from the set, instead of replacing all of its elements.
+//
+// Add and Remove hold arrays of the kind of the field, either of them can be nil.
+type SetPatch struct {
+	Add    any
+	Remove any
+}
+
+// TextPatch is the value of a text field ([RGA]) that inserts text into and deletes text from
+// the field, instead of replacing all of its text.
+//
+// The positions of the operations are character positions within the text of the field before
+// the patch is applied, the text inserted at the same position is inserted in the given order.
+type TextPatch struct {
+	Insert []TextInsert
+	Delete []TextDelete
+}
+
+// TextInsert inserts text at a position of a text field.
+type TextInsert struct {
+	Position int
+	Text     string
+}
+
+// TextDelete deletes the given number of characters from a position of a text field.
+type TextDelete struct {
+	Position int
+	Length   int
+}
+
+// Validate returns an error if the positions of the patch are outside of a text of the given
+// number of characters.
+func (p TextPatch) Validate(length int) error {
+	for _, del := range p.Delete {
+		if del.Position < 0 || del.Length < 0 || del.Position+del.Length > length {
+			return NewErrTextPositionOutOfRange(del.Position, length)
+		}
+	}
+	for _, ins := range p.Insert {
+		if ins.Position < 0 || ins.Position > length {
+			return NewErrTextPositionOutOfRange(ins.Position, length)
+		}
+	}
+	return nil
+}
+
+// Apply returns the given text once the patch is applied to it.
+func (p TextPatch) Apply(text string) (string, error) {
+	chars := []rune(text)
+	if err := p.Validate(len(chars)); err != nil {
+		return "", err
+	}
+	deleted := make([]bool, len(chars))
+	for _, del := range p.Delete {
+		for i := del.Position; i < del.Position+del.Length; i++ {
+			deleted[i] = true
+		}
+	}
+	inserted := make([][]rune, len(chars)+1)
+	for _, ins := range p.Insert {
+		inserted[ins.Position] = append(inserted[ins.Position], []rune(ins.Text)...)
+	}
+	result := make([]rune, 0, len(chars))
+	for i := 0; i <= len(chars); i++ {
+		result = append(result, inserted[i]...)
+		if i < len(chars) && !deleted[i] {
+			result = append(result, chars[i])
+		}
+	}
+	return string(result), nil
+}
+
 type simpleValue struct {
 	t       CType
 	value   any
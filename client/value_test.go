@@ -0,0 +1,38 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextPatchApply(t *testing.T) {
+	patch := TextPatch{
+		Insert: []TextInsert{{Position: 0, Text: "¡"}, {Position: 5, Text: " you"}, {Position: 5, Text: " all"}},
+		Delete: []TextDelete{{Position: 0, Length: 1}},
+	}
+
+	text, err := patch.Apply("hello")
+	require.NoError(t, err)
+	assert.Equal(t, "¡ello you all", text)
+}
+
+func TestTextPatchApply_IfPositionIsOutOfRange_ReturnError(t *testing.T) {
+	patch := TextPatch{
+		Insert: []TextInsert{{Position: 6, Text: "!"}},
+	}
+
+	_, err := patch.Apply("hello")
+	require.ErrorIs(t, err, ErrTextPositionOutOfRange)
+}
@@ -0,0 +1,26 @@
+package connor
+
+import "github.com/sourcenetwork/defradb/client"
+
+// allElements is an operator which matches a non-empty array if
+// all of its elements match the condition.
+func allElements(condition, data any) (bool, error) {
+	if data == nil {
+		return false, nil
+	}
+	elements, ok := arrayElements(data)
+	if !ok {
+		return false, client.NewErrUnhandledType("data", data)
+	}
+	if len(elements) == 0 {
+		return false, nil
+	}
+	for _, element := range elements {
+		if m, err := eq(condition, element); err != nil {
+			return false, err
+		} else if !m {
+			return false, nil
+		}
+	}
+	return true, nil
+}
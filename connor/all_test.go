@@ -0,0 +1,33 @@
+package connor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAll_IfAllElementsMatch_ReturnTrue(t *testing.T) {
+	result, err := allElements(map[FilterKey]any{&operator{"_ge"}: int64(2)}, []int64{2, 3})
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = allElements(true, []any{true, true})
+	require.NoError(t, err)
+	require.True(t, result)
+}
+
+func TestAll_IfSomeElementDoesNotMatch_ReturnFalse(t *testing.T) {
+	result, err := allElements(map[FilterKey]any{&operator{"_ge"}: int64(2)}, []int64{1, 3})
+	require.NoError(t, err)
+	require.False(t, result)
+}
+
+func TestAll_WithEmptyOrNilArray_ReturnFalse(t *testing.T) {
+	result, err := allElements(true, []bool{})
+	require.NoError(t, err)
+	require.False(t, result)
+
+	result, err = allElements(true, nil)
+	require.NoError(t, err)
+	require.False(t, result)
+}
@@ -0,0 +1,52 @@
+package connor
+
+import (
+	"reflect"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// anyElement is an operator which matches an array if at least
+// one of its elements matches the condition.
+func anyElement(condition, data any) (bool, error) {
+	if data == nil {
+		return false, nil
+	}
+	elements, ok := arrayElements(data)
+	if !ok {
+		return false, client.NewErrUnhandledType("data", data)
+	}
+	for _, element := range elements {
+		if m, err := eq(condition, element); err != nil {
+			return false, err
+		} else if m {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// arrayElements returns the elements of the given inline array value.
+//
+// Pointer elements are dereferenced, nil pointers are returned as nil.
+func arrayElements(data any) ([]any, bool) {
+	if elements, ok := data.([]any); ok {
+		return elements, true
+	}
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice {
+		return nil, false
+	}
+	elements := make([]any, value.Len())
+	for i := range elements {
+		element := value.Index(i)
+		if element.Kind() == reflect.Pointer {
+			if element.IsNil() {
+				continue
+			}
+			element = element.Elem()
+		}
+		elements[i] = element.Interface()
+	}
+	return elements, true
+}
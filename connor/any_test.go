@@ -0,0 +1,44 @@
+package connor
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAny_WithMatchingElement_ReturnTrue(t *testing.T) {
+	result, err := anyElement("urgent", []string{"later", "urgent"})
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = anyElement(map[FilterKey]any{&operator{"_gt"}: int64(2)}, []int64{1, 3})
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = anyElement(
+		int64(2),
+		[]immutable.Option[int64]{immutable.None[int64](), immutable.Some[int64](2)},
+	)
+	require.NoError(t, err)
+	require.True(t, result)
+}
+
+func TestAny_WithoutMatchingElement_ReturnFalse(t *testing.T) {
+	result, err := anyElement("urgent", []string{"later"})
+	require.NoError(t, err)
+	require.False(t, result)
+
+	result, err = anyElement("urgent", []string{})
+	require.NoError(t, err)
+	require.False(t, result)
+
+	result, err = anyElement("urgent", nil)
+	require.NoError(t, err)
+	require.False(t, result)
+}
+
+func TestAny_WithNonArrayData_ReturnError(t *testing.T) {
+	_, err := anyElement("urgent", "urgent")
+	require.Error(t, err)
+}
@@ -16,8 +16,12 @@ func Match(conditions map[FilterKey]any, data any) (bool, error) {
 // if you wish to override the behavior of another operator.
 func matchWith(op string, conditions, data any) (bool, error) {
 	switch op {
+	case "_all":
+		return allElements(conditions, data)
 	case "_and":
 		return and(conditions, data)
+	case "_any":
+		return anyElement(conditions, data)
 	case "_eq":
 		return eq(conditions, data)
 	case "_ge":
@@ -40,6 +44,14 @@ func matchWith(op string, conditions, data any) (bool, error) {
 		return like(conditions, data)
 	case "_nlike":
 		return nlike(conditions, data)
+	case "_match":
+		return match(conditions, data)
+	case "_ieq":
+		return ieq(conditions, data)
+	case "_ilike":
+		return ilike(conditions, data)
+	case "_nilike":
+		return nilike(conditions, data)
 	case "_not":
 		return not(conditions, data)
 	default:
@@ -0,0 +1,31 @@
+package connor
+
+import (
+	"strings"
+
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// ieq is an operator which performs case insensitive string equality
+// tests.
+func ieq(condition, data any) (bool, error) {
+	switch d := data.(type) {
+	case immutable.Option[string]:
+		if !d.HasValue() {
+			return condition == nil, nil
+		}
+		data = d.Value()
+	}
+
+	switch cn := condition.(type) {
+	case string:
+		d, ok := data.(string)
+		return ok && strings.ToLower(d) == strings.ToLower(cn), nil
+	case nil:
+		return data == nil, nil
+	default:
+		return false, client.NewErrUnhandledType("condition", cn)
+	}
+}
@@ -0,0 +1,24 @@
+package connor
+
+import (
+	"strings"
+
+	"github.com/sourcenetwork/immutable"
+)
+
+// ilike is an operator which performs case insensitive string
+// pattern matching, like the like operator.
+func ilike(condition, data any) (bool, error) {
+	if cn, ok := condition.(string); ok {
+		condition = strings.ToLower(cn)
+	}
+	switch d := data.(type) {
+	case string:
+		data = strings.ToLower(d)
+	case immutable.Option[string]:
+		if d.HasValue() {
+			data = immutable.Some(strings.ToLower(d.Value()))
+		}
+	}
+	return like(condition, data)
+}
@@ -0,0 +1,54 @@
+package connor
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+)
+
+func TestILike(t *testing.T) {
+	const testString = "Source is the glue of web3"
+
+	// exact match in another case
+	result, err := ilike("SOURCE IS THE GLUE OF WEB3", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// match prefix
+	result, err = ilike("source%", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// match contains
+	result, err = ilike("%GLUE%", immutable.Some(testString))
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// no match
+	result, err = ilike("%paste%", testString)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// inverted
+	result, err = nilike("%GLUE%", testString)
+	require.NoError(t, err)
+	require.False(t, result)
+}
+
+func TestIEq(t *testing.T) {
+	result, err := ieq("John@Example.com", "john@example.COM")
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = ieq("john", immutable.Some("Johnny"))
+	require.NoError(t, err)
+	require.False(t, result)
+
+	result, err = ieq(nil, immutable.None[string]())
+	require.NoError(t, err)
+	require.True(t, result)
+
+	_, err = ieq(1, "john")
+	require.Error(t, err)
+}
@@ -0,0 +1,47 @@
+package connor
+
+import (
+	"github.com/sourcenetwork/immutable"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+)
+
+// match is an operator which performs full-text matching: it matches a string
+// if the string contains every term of the condition.
+//
+// Both the condition and the data are split into terms the way a full-text index with
+// the default options does, i.e. into lowercase words.
+func match(condition, data any) (bool, error) {
+	switch d := data.(type) {
+	case immutable.Option[string]:
+		if !d.HasValue() {
+			return false, nil
+		}
+		data = d.Value()
+	}
+
+	cn, ok := condition.(string)
+	if !ok {
+		return false, client.NewErrUnhandledType("condition", condition)
+	}
+	d, ok := data.(string)
+	if !ok {
+		return false, nil
+	}
+
+	queryTerms := core.AnalyzeText(client.FullTextIndexOptions{}, cn)
+	if len(queryTerms) == 0 {
+		return false, nil
+	}
+	terms := make(map[string]struct{})
+	for _, term := range core.AnalyzeText(client.FullTextIndexOptions{}, d) {
+		terms[term] = struct{}{}
+	}
+	for _, term := range queryTerms {
+		if _, ok := terms[term]; !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
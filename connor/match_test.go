@@ -0,0 +1,45 @@
+package connor
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	const testString = "Source is the glue of web3"
+
+	// all terms in any order and case
+	result, err := match("WEB3 source", testString)
+	require.NoError(t, err)
+	require.True(t, result)
+
+	// missing term
+	result, err = match("source paste", testString)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// terms are whole words
+	result, err = match("sour", testString)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// query without terms
+	result, err = match("  ", testString)
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// optional value
+	result, err = match("glue", immutable.Some(testString))
+	require.NoError(t, err)
+	require.True(t, result)
+
+	result, err = match("glue", immutable.None[string]())
+	require.NoError(t, err)
+	require.False(t, result)
+
+	// invalid condition
+	_, err = match(1, testString)
+	require.Error(t, err)
+}
@@ -11,6 +11,7 @@
 package crdt
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 
@@ -18,6 +19,7 @@ import (
 
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/db/base"
 	"github.com/sourcenetwork/defradb/errors"
 )
 
@@ -83,3 +85,17 @@ func (base baseCRDT) getPriority(ctx context.Context, key core.DataStoreKey) (ui
 	}
 	return prio, nil
 }
+
+// getValueKey returns the key the value of the CRDT is to be stored at, which is
+// the deleted instance of its value key if its document has been deleted.
+func (c baseCRDT) getValueKey(ctx context.Context) (core.DataStoreKey, error) {
+	key := c.key.WithValueFlag()
+	marker, err := c.store.Get(ctx, c.key.ToPrimaryDataStoreKey().ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return core.DataStoreKey{}, err
+	}
+	if bytes.Equal(marker, []byte{base.DeletedObjectMarker}) {
+		key = key.WithDeletedFlag()
+	}
+	return key, nil
+}
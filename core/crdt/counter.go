@@ -0,0 +1,254 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+
+	"github.com/fxamacker/cbor/v2"
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	ds "github.com/ipfs/go-datastore"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ugorji/go/codec"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+// CounterDelta is a single delta operation for a Counter.
+//
+// Its data holds the CBOR encoded number the counter is incremented by.
+type CounterDelta struct {
+	SchemaVersionID string
+	Priority        uint64
+	// Nonce makes the blocks of identical concurrent increments distinct, so that
+	// they are all counted instead of being merged as a single block.
+	Nonce     int64
+	Data      []byte
+	DocKey    []byte
+	FieldName string
+}
+
+var _ core.Delta = (*CounterDelta)(nil)
+
+// GetPriority gets the current priority for this delta.
+func (delta *CounterDelta) GetPriority() uint64 {
+	return delta.Priority
+}
+
+// SetPriority will set the priority for this delta.
+func (delta *CounterDelta) SetPriority(prio uint64) {
+	delta.Priority = prio
+}
+
+// Marshal encodes the delta using CBOR.
+func (delta *CounterDelta) Marshal() ([]byte, error) {
+	h := &codec.CborHandle{}
+	buf := bytes.NewBuffer(nil)
+	enc := codec.NewEncoder(buf, h)
+	err := enc.Encode(struct {
+		SchemaVersionID string
+		Priority        uint64
+		Nonce           int64
+		Data            []byte
+		DocKey          []byte
+		FieldName       string
+	}{delta.SchemaVersionID, delta.Priority, delta.Nonce, delta.Data, delta.DocKey, delta.FieldName})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (delta *CounterDelta) Value() any {
+	return delta.Data
+}
+
+// Counter is a CRDT holding a number that is only ever incremented (or decremented),
+// so that concurrent increments add up instead of overwriting each other.
+//
+// A counter that doesn't allow decrements is a grow-only counter (P-Counter),
+// otherwise it is a positive-negative counter (PN-Counter).
+type Counter struct {
+	baseCRDT
+	allowDecrement bool
+}
+
+var _ core.ReplicatedData = (*Counter)(nil)
+
+// NewCounter returns a new instance of the Counter with the given ID.
+func NewCounter(
+	store datastore.DSReaderWriter,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+	allowDecrement bool,
+) Counter {
+	return Counter{
+		baseCRDT:       newBaseCRDT(store, key, schemaVersionKey, fieldName),
+		allowDecrement: allowDecrement,
+	}
+}
+
+// Value gets the current counter value
+func (c Counter) Value(ctx context.Context) ([]byte, error) {
+	valueK := c.key.WithValueFlag()
+	buf, err := c.store.Get(ctx, valueK.ToDS())
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// Increment generates a new delta incrementing the counter by the given CBOR encoded number.
+//
+// A negative number decrements the counter, which is not allowed by a grow-only counter.
+func (c Counter) Increment(value []byte) (*CounterDelta, error) {
+	number, err := decodeCounterNumber(value)
+	if err != nil {
+		return nil, err
+	}
+	if !c.allowDecrement && isNegativeNumber(number) {
+		return nil, NewErrNegativeIncrement(c.fieldName)
+	}
+	nonce, err := newNonce()
+	if err != nil {
+		return nil, err
+	}
+	return &CounterDelta{
+		Data:            value,
+		DocKey:          []byte(c.key.DocKey),
+		FieldName:       c.fieldName,
+		Nonce:           nonce,
+		SchemaVersionID: c.schemaVersionKey.SchemaVersionId,
+	}, nil
+}
+
+// Merge implements ReplicatedData interface.
+// It adds the number held by the delta to the current value of the counter.
+func (c Counter) Merge(ctx context.Context, delta core.Delta) error {
+	d, ok := delta.(*CounterDelta)
+	if !ok {
+		return ErrMismatchedMergeType
+	}
+	increment, err := decodeCounterNumber(d.Data)
+	if err != nil {
+		return err
+	}
+
+	key, err := c.getValueKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	var current any = int64(0)
+	curValue, err := c.store.Get(ctx, key.ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return err
+	}
+	if err == nil {
+		current, err = decodeCounterNumber(curValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	newValue, err := cbor.Marshal(addNumbers(current, increment))
+	if err != nil {
+		return err
+	}
+	err = c.store.Put(ctx, key.ToDS(), newValue)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+	return nil
+}
+
+// DeltaDecode is a typed helper to extract a CounterDelta from a ipld.Node
+func (c Counter) DeltaDecode(node ipld.Node) (core.Delta, error) {
+	delta := &CounterDelta{}
+	pbNode, ok := node.(*dag.ProtoNode)
+	if !ok {
+		return nil, client.NewErrUnexpectedType[*dag.ProtoNode]("ipld.Node", node)
+	}
+	data := pbNode.Data()
+	h := &codec.CborHandle{}
+	dec := codec.NewDecoderBytes(data, h)
+	err := dec.Decode(delta)
+	if err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
+
+// decodeCounterNumber decodes the given CBOR encoded number as an int64 or a float64.
+func decodeCounterNumber(data []byte) (any, error) {
+	if len(data) == 0 {
+		// the value of a deleted field
+		return nil, NewErrInvalidCounterValue(nil)
+	}
+	var val any
+	err := cbor.Unmarshal(data, &val)
+	if err != nil {
+		return nil, err
+	}
+	switch number := val.(type) {
+	case uint64:
+		return int64(number), nil
+	case int64, float64:
+		return number, nil
+	default:
+		return nil, NewErrInvalidCounterValue(val)
+	}
+}
+
+func isNegativeNumber(number any) bool {
+	switch n := number.(type) {
+	case int64:
+		return n < 0
+	case float64:
+		return n < 0
+	}
+	return false
+}
+
+// addNumbers adds the given numbers, the result is a float64 if any of them is a float64.
+func addNumbers(a, b any) any {
+	aInt, aIsInt := a.(int64)
+	bInt, bIsInt := b.(int64)
+	if aIsInt && bIsInt {
+		return aInt + bInt
+	}
+	return toFloat(a) + toFloat(b)
+}
+
+func toFloat(number any) float64 {
+	switch n := number.(type) {
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return 0
+}
+
+func newNonce() (int64, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(buf[:])), nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/core"
+)
+
+func setupCounter(allowDecrement bool) Counter {
+	store := newMockStore()
+	key := core.DataStoreKey{DocKey: "AAAA-BBBB"}
+	return NewCounter(store, core.CollectionSchemaVersionKey{}, key, "points", allowDecrement)
+}
+
+func encodeValue(t *testing.T, value any) []byte {
+	data, err := cbor.Marshal(value)
+	require.NoError(t, err)
+	return data
+}
+
+func getCounterValue(t *testing.T, ctx context.Context, counter Counter) any {
+	data, err := counter.Value(ctx)
+	require.NoError(t, err)
+	var val any
+	require.NoError(t, cbor.Unmarshal(data, &val))
+	return val
+}
+
+func TestCounterIncrement_ShouldHoldIncrementInDelta(t *testing.T) {
+	counter := setupCounter(true)
+	delta, err := counter.Increment(encodeValue(t, 5))
+	require.NoError(t, err)
+
+	assert.Equal(t, encodeValue(t, 5), delta.Data)
+	assert.Equal(t, []byte("AAAA-BBBB"), delta.DocKey)
+	assert.Equal(t, "points", delta.FieldName)
+}
+
+func TestCounterIncrement_ShouldGenerateDifferentNonces(t *testing.T) {
+	counter := setupCounter(true)
+	delta1, err := counter.Increment(encodeValue(t, 1))
+	require.NoError(t, err)
+	delta2, err := counter.Increment(encodeValue(t, 1))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, delta1.Nonce, delta2.Nonce)
+}
+
+func TestCounterIncrement_IfValueIsNotNumber_ReturnError(t *testing.T) {
+	counter := setupCounter(true)
+	_, err := counter.Increment(encodeValue(t, "five"))
+	require.ErrorIs(t, err, ErrInvalidCounterValue)
+}
+
+func TestCounterIncrement_IfGrowOnlyAndNegative_ReturnError(t *testing.T) {
+	counter := setupCounter(false)
+	_, err := counter.Increment(encodeValue(t, -1))
+	require.ErrorIs(t, err, ErrNegativeIncrement)
+}
+
+func TestCounterMerge_ShouldSumIncrements(t *testing.T) {
+	ctx := context.Background()
+	counter := setupCounter(true)
+	for _, increment := range []int{10, 5, -3} {
+		delta, err := counter.Increment(encodeValue(t, increment))
+		require.NoError(t, err)
+		require.NoError(t, counter.Merge(ctx, delta))
+	}
+
+	assert.Equal(t, uint64(12), getCounterValue(t, ctx, counter))
+}
+
+func TestCounterMerge_WithFloatIncrement_ShouldStoreFloat(t *testing.T) {
+	ctx := context.Background()
+	counter := setupCounter(true)
+	for _, increment := range []any{1, 0.5} {
+		delta, err := counter.Increment(encodeValue(t, increment))
+		require.NoError(t, err)
+		require.NoError(t, counter.Merge(ctx, delta))
+	}
+
+	assert.Equal(t, 1.5, getCounterValue(t, ctx, counter))
+}
+
+func TestCounterMerge_WithConcurrentDeltas_ShouldConverge(t *testing.T) {
+	ctx := context.Background()
+	counter1 := setupCounter(true)
+	counter2 := setupCounter(true)
+
+	delta1, err := counter1.Increment(encodeValue(t, 1))
+	require.NoError(t, err)
+	delta2, err := counter2.Increment(encodeValue(t, 1))
+	require.NoError(t, err)
+
+	require.NoError(t, counter1.Merge(ctx, delta1))
+	require.NoError(t, counter1.Merge(ctx, delta2))
+	require.NoError(t, counter2.Merge(ctx, delta2))
+	require.NoError(t, counter2.Merge(ctx, delta1))
+
+	assert.Equal(t, uint64(2), getCounterValue(t, ctx, counter1))
+	assert.Equal(t, uint64(2), getCounterValue(t, ctx, counter2))
+}
+
+func TestCounterDeltaDecode(t *testing.T) {
+	counter := setupCounter(true)
+	delta, err := counter.Increment(encodeValue(t, 7))
+	require.NoError(t, err)
+	delta.SetPriority(3)
+
+	node, err := makeNode(delta, []cid.Cid{})
+	require.NoError(t, err)
+
+	decoded, err := counter.DeltaDecode(node)
+	require.NoError(t, err)
+	assert.Equal(t, delta, decoded)
+}
@@ -17,6 +17,10 @@ import (
 const (
 	errFailedToGetPriority string = "failed to get priority"
 	errFailedToStoreValue  string = "failed to store value"
+	errNegativeIncrement   string = "a grow-only counter can not be decremented"
+	errInvalidCounterValue string = "invalid counter value, it must be a number"
+	errInvalidSetValue     string = "invalid set value, it must be an array of scalars"
+	errInvalidTextValue    string = "invalid text value, it must be a string"
 )
 
 // Errors returnable from this package.
@@ -26,8 +30,15 @@ const (
 var (
 	ErrFailedToGetPriority = errors.New(errFailedToGetPriority)
 	ErrFailedToStoreValue  = errors.New(errFailedToStoreValue)
+	ErrNegativeIncrement   = errors.New(errNegativeIncrement)
+	ErrInvalidCounterValue = errors.New(errInvalidCounterValue)
+	ErrInvalidSetValue     = errors.New(errInvalidSetValue)
+	ErrInvalidTextValue    = errors.New(errInvalidTextValue)
 	ErrEncodingPriority    = errors.New("error encoding priority")
 	ErrDecodingPriority    = errors.New("error decoding priority")
+	ErrDecodingHLC         = errors.New("error decoding HLC timestamp")
+	ErrDecodingRGAState    = errors.New("error decoding text sequence state")
+	ErrDecodingORSetState  = errors.New("error decoding set state")
 	// ErrMismatchedMergeType - Tying to merge two ReplicatedData of different types
 	ErrMismatchedMergeType = errors.New("given type to merge does not match source")
 )
@@ -41,3 +52,27 @@ func NewErrFailedToGetPriority(inner error) error {
 func NewErrFailedToStoreValue(inner error) error {
 	return errors.Wrap(errFailedToStoreValue, inner)
 }
+
+// NewErrNegativeIncrement returns an error indicating that a grow-only counter field
+// has been given a negative increment.
+func NewErrNegativeIncrement(fieldName string) error {
+	return errors.New(errNegativeIncrement, errors.NewKV("Field", fieldName))
+}
+
+// NewErrInvalidCounterValue returns an error indicating that a counter has been given
+// a value that is not a number.
+func NewErrInvalidCounterValue(value any) error {
+	return errors.New(errInvalidCounterValue, errors.NewKV("Value", value))
+}
+
+// NewErrInvalidSetValue returns an error indicating that a set has been given a value
+// that is not an array of scalars.
+func NewErrInvalidSetValue(inner error) error {
+	return errors.Wrap(errInvalidSetValue, inner)
+}
+
+// NewErrInvalidTextValue returns an error indicating that a text sequence has been given
+// a value that is not a string.
+func NewErrInvalidTextValue(value any) error {
+	return errors.New(errInvalidTextValue, errors.NewKV("Value", value))
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"sync"
+	"time"
+)
+
+// hlcLogicalBits is the number of low order bits of an HLC timestamp that hold its logical counter,
+// the high order bits hold the physical time in milliseconds.
+const hlcLogicalBits = 16
+
+// HLC is a hybrid logical clock.
+//
+// Its timestamps follow the physical time of the node, while always being greater than
+// all the timestamps the node has generated or observed so far. The timestamps of causally
+// related events are therefore ordered even if the physical clocks of the nodes have drifted,
+// and the timestamps of concurrent events are ordered by the time they happened at.
+type HLC struct {
+	mu   sync.Mutex
+	now  func() time.Time
+	last uint64
+}
+
+// NewHLC returns a new hybrid logical clock reading the physical time from the given function.
+func NewHLC(now func() time.Time) *HLC {
+	return &HLC{now: now}
+}
+
+// Now returns a new timestamp, greater than all the timestamps returned or observed so far.
+func (c *HLC) Now() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	physical := uint64(c.now().UnixMilli()) << hlcLogicalBits
+	if physical > c.last {
+		c.last = physical
+	} else {
+		c.last++
+	}
+	return c.last
+}
+
+// Observe updates the clock with a timestamp created by another node, so that the timestamps
+// returned afterwards are greater than it.
+func (c *HLC) Observe(timestamp uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if timestamp > c.last {
+		c.last = timestamp
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/core"
+)
+
+func newFixedHLC(now *time.Time) *HLC {
+	return NewHLC(func() time.Time { return *now })
+}
+
+func setupHLCRegister(clock *HLC) LWWRegister {
+	store := newMockStore()
+	key := core.DataStoreKey{DocKey: "AAAA-BBBB"}
+	return NewLWWRegisterWithHLC(store, core.CollectionSchemaVersionKey{}, key, "", clock)
+}
+
+func TestHLCNow_ShouldFollowPhysicalTime(t *testing.T) {
+	now := time.UnixMilli(1000)
+	clock := newFixedHLC(&now)
+
+	first := clock.Now()
+	now = now.Add(time.Second)
+	second := clock.Now()
+
+	assert.Equal(t, uint64(1000)<<hlcLogicalBits, first)
+	assert.Equal(t, uint64(2000)<<hlcLogicalBits, second)
+}
+
+func TestHLCNow_IfPhysicalTimeDoesNotAdvance_ShouldIncrementLogicalCounter(t *testing.T) {
+	now := time.UnixMilli(1000)
+	clock := newFixedHLC(&now)
+
+	first := clock.Now()
+	second := clock.Now()
+	now = now.Add(-time.Second)
+	third := clock.Now()
+
+	assert.Equal(t, first+1, second)
+	assert.Equal(t, second+1, third)
+}
+
+func TestHLCObserve_ShouldReturnGreaterTimestamps(t *testing.T) {
+	now := time.UnixMilli(1000)
+	clock := newFixedHLC(&now)
+
+	remote := uint64(5000) << hlcLogicalBits
+	clock.Observe(remote)
+
+	assert.Equal(t, remote+1, clock.Now())
+}
+
+func TestLWWRegisterWithHLC_ShouldSetTimestampOfDeltas(t *testing.T) {
+	ctx := context.Background()
+	now := time.UnixMilli(1000)
+	reg := setupHLCRegister(newFixedHLC(&now))
+
+	delta, err := reg.Set(ctx, []byte("test"))
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(1000)<<hlcLogicalBits, delta.HLC)
+}
+
+func TestLWWRegisterWithHLC_WithLaterDeltaOfLowerPriority_ShouldWin(t *testing.T) {
+	ctx := context.Background()
+	now := time.UnixMilli(1000)
+	reg := setupHLCRegister(newFixedHLC(&now))
+
+	earlier, err := reg.Set(ctx, []byte("earlier"))
+	require.NoError(t, err)
+	earlier.SetPriority(10)
+	now = now.Add(time.Second)
+	later, err := reg.Set(ctx, []byte("later"))
+	require.NoError(t, err)
+	later.SetPriority(2)
+
+	require.NoError(t, reg.Merge(ctx, later))
+	require.NoError(t, reg.Merge(ctx, earlier))
+
+	val, err := reg.Value(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("later"), val)
+}
+
+func TestLWWRegisterWithHLC_WithEqualTimestamps_ShouldKeepGreaterValue(t *testing.T) {
+	ctx := context.Background()
+	now := time.UnixMilli(1000)
+	reg := setupHLCRegister(newFixedHLC(&now))
+
+	b := &LWWRegDelta{Data: []byte("b"), HLC: 42}
+	a := &LWWRegDelta{Data: []byte("a"), HLC: 42}
+
+	require.NoError(t, reg.Merge(ctx, b))
+	require.NoError(t, reg.Merge(ctx, a))
+
+	val, err := reg.Value(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("b"), val)
+}
+
+func TestLWWRegisterWithHLC_AfterMerge_ShouldObserveTimestamp(t *testing.T) {
+	ctx := context.Background()
+	now := time.UnixMilli(1000)
+	clock := newFixedHLC(&now)
+	reg := setupHLCRegister(clock)
+
+	remote := &LWWRegDelta{Data: []byte("remote"), HLC: uint64(5000) << hlcLogicalBits}
+	require.NoError(t, reg.Merge(ctx, remote))
+
+	// the local clock is behind the remote one, the next local write must still win
+	local, err := reg.Set(ctx, []byte("local"))
+	require.NoError(t, err)
+	require.NoError(t, reg.Merge(ctx, local))
+
+	val, err := reg.Value(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("local"), val)
+}
+
+func TestLWWRegisterDeltaMarshal_WithoutHLC_ShouldOmitTimestamp(t *testing.T) {
+	delta := &LWWRegDelta{Data: []byte("test"), Priority: 1}
+	withoutHLC, err := delta.Marshal()
+	require.NoError(t, err)
+
+	delta.HLC = 42
+	withHLC, err := delta.Marshal()
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(withoutHLC), "HLC")
+	assert.Contains(t, string(withHLC), "HLC")
+}
+
+func TestLWWRegisterWithHLC_AfterRestart_ShouldObserveStoredTimestamp(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	key := core.DataStoreKey{DocKey: "AAAA-BBBB"}
+
+	remoteNow := time.UnixMilli(5000)
+	remote := NewLWWRegisterWithHLC(store, core.CollectionSchemaVersionKey{}, key, "", newFixedHLC(&remoteNow))
+	stored, err := remote.Set(ctx, []byte("stored"))
+	require.NoError(t, err)
+	require.NoError(t, remote.Merge(ctx, stored))
+
+	// a restarted node starts with a fresh clock that is behind the stored timestamp
+	now := time.UnixMilli(1000)
+	reg := NewLWWRegisterWithHLC(store, core.CollectionSchemaVersionKey{}, key, "", newFixedHLC(&now))
+	local, err := reg.Set(ctx, []byte("local"))
+	require.NoError(t, err)
+	require.NoError(t, reg.Merge(ctx, local))
+
+	assert.Greater(t, local.HLC, stored.HLC)
+	val, err := reg.Value(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("local"), val)
+}
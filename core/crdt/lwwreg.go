@@ -13,6 +13,7 @@ package crdt
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 
 	dag "github.com/ipfs/boxo/ipld/merkledag"
 	ds "github.com/ipfs/go-datastore"
@@ -34,6 +35,10 @@ type LWWRegDelta struct {
 	Data            []byte
 	DocKey          []byte
 	FieldName       string
+	// HLC is the hybrid logical clock timestamp of the delta.
+	//
+	// It is only set by the registers whose conflicts are resolved by HLC timestamps.
+	HLC uint64
 }
 
 var _ core.Delta = (*LWWRegDelta)(nil)
@@ -60,7 +65,10 @@ func (delta *LWWRegDelta) Marshal() ([]byte, error) {
 		Data            []byte
 		DocKey          []byte
 		FieldName       string
-	}{delta.SchemaVersionID, delta.Priority, delta.Data, delta.DocKey, delta.FieldName})
+		// omitted if not set, so that the deltas of the other registers are encoded as they
+		// were before the timestamp was introduced
+		HLC uint64 `codec:",omitempty"`
+	}{delta.SchemaVersionID, delta.Priority, delta.Data, delta.DocKey, delta.FieldName, delta.HLC})
 	if err != nil {
 		return nil, err
 	}
@@ -73,8 +81,17 @@ func (delta *LWWRegDelta) Value() any {
 
 // LWWRegister, Last-Writer-Wins Register, is a simple CRDT type that allows set/get
 // of an arbitrary data type that ensures convergence.
+//
+// By default the value with the highest priority, i.e. the height of its delta in the
+// merkle clock, wins. A register can instead resolve its conflicts by the hybrid logical
+// clock timestamps of its deltas, in which case the value written last wins regardless of
+// the number of values written before it.
 type LWWRegister struct {
 	baseCRDT
+
+	// hlc is the clock the timestamps of the deltas are read from, it is nil if conflicts
+	// are resolved by priority.
+	hlc *HLC
 }
 
 var _ core.ReplicatedData = (*LWWRegister)(nil)
@@ -86,7 +103,23 @@ func NewLWWRegister(
 	key core.DataStoreKey,
 	fieldName string,
 ) LWWRegister {
-	return LWWRegister{newBaseCRDT(store, key, schemaVersionKey, fieldName)}
+	return LWWRegister{baseCRDT: newBaseCRDT(store, key, schemaVersionKey, fieldName)}
+}
+
+// NewLWWRegisterWithHLC returns a new instance of the LWWReg with the given ID, whose
+// conflicts are resolved by the hybrid logical clock timestamps of its deltas read from
+// the given clock.
+func NewLWWRegisterWithHLC(
+	store datastore.DSReaderWriter,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+	hlc *HLC,
+) LWWRegister {
+	return LWWRegister{
+		baseCRDT: newBaseCRDT(store, key, schemaVersionKey, fieldName),
+		hlc:      hlc,
+	}
 }
 
 // Value gets the current register value
@@ -101,14 +134,27 @@ func (reg LWWRegister) Value(ctx context.Context) ([]byte, error) {
 }
 
 // Set generates a new delta with the supplied value
+//
+// The timestamp of the delta of a register resolved by HLC is greater than the timestamp of
+// the current value, as the clock observes it first, so that the new value wins even if the
+// clock has been restarted or is behind the clock the current value has been set with.
 // RETURN DELTA
-func (reg LWWRegister) Set(value []byte) *LWWRegDelta {
-	return &LWWRegDelta{
+func (reg LWWRegister) Set(ctx context.Context, value []byte) (*LWWRegDelta, error) {
+	delta := &LWWRegDelta{
 		Data:            value,
 		DocKey:          []byte(reg.key.DocKey),
 		FieldName:       reg.fieldName,
 		SchemaVersionID: reg.schemaVersionKey.SchemaVersionId,
 	}
+	if reg.hlc != nil {
+		curTimestamp, err := reg.getHLC(ctx)
+		if err != nil {
+			return nil, err
+		}
+		reg.hlc.Observe(curTimestamp)
+		delta.HLC = reg.hlc.Now()
+	}
+	return delta, nil
 }
 
 // Merge implements ReplicatedData interface
@@ -121,9 +167,63 @@ func (reg LWWRegister) Merge(ctx context.Context, delta core.Delta) error {
 		return ErrMismatchedMergeType
 	}
 
+	if reg.hlc != nil {
+		reg.hlc.Observe(d.HLC)
+		return reg.setValueByHLC(ctx, d.Data, d.HLC)
+	}
 	return reg.setValue(ctx, d.Data, d.GetPriority())
 }
 
+// setValueByHLC sets the given value if its timestamp is greater than the timestamp of the
+// current value. If both timestamps are equal, the lexicographically greater value wins.
+func (reg LWWRegister) setValueByHLC(ctx context.Context, val []byte, timestamp uint64) error {
+	key, err := reg.getValueKey(ctx)
+	if err != nil {
+		return err
+	}
+	curTimestamp, err := reg.getHLC(ctx)
+	if err != nil {
+		return err
+	}
+	if timestamp < curTimestamp {
+		return nil
+	} else if timestamp == curTimestamp {
+		curValue, err := reg.store.Get(ctx, key.ToDS())
+		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+			return err
+		}
+		if err == nil && bytes.Compare(curValue, val) >= 0 {
+			return nil
+		}
+	}
+
+	err = reg.store.Put(ctx, key.ToDS(), val)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+	buf := binary.BigEndian.AppendUint64(nil, timestamp)
+	err = reg.store.Put(ctx, reg.key.WithCRDTStateFlag().ToDS(), buf)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+	return nil
+}
+
+// getHLC returns the timestamp of the current value, it is zero if there is no value yet.
+func (reg LWWRegister) getHLC(ctx context.Context) (uint64, error) {
+	buf, err := reg.store.Get(ctx, reg.key.WithCRDTStateFlag().ToDS())
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if len(buf) != 8 {
+		return 0, ErrDecodingHLC
+	}
+	return binary.BigEndian.Uint64(buf), nil
+}
+
 func (reg LWWRegister) setValue(ctx context.Context, val []byte, priority uint64) error {
 	curPrio, err := reg.getPriority(ctx, reg.key)
 	if err != nil {
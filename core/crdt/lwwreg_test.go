@@ -38,7 +38,7 @@ func setupLWWRegister() LWWRegister {
 
 func setupLoadedLWWRegster(ctx context.Context) LWWRegister {
 	lww := setupLWWRegister()
-	addDelta := lww.Set([]byte("test"))
+	addDelta, _ := lww.Set(ctx, []byte("test"))
 	addDelta.SetPriority(1)
 	lww.Merge(ctx, addDelta)
 	return lww
@@ -46,7 +46,11 @@ func setupLoadedLWWRegster(ctx context.Context) LWWRegister {
 
 func TestLWWRegisterAddDelta(t *testing.T) {
 	lww := setupLWWRegister()
-	addDelta := lww.Set([]byte("test"))
+	addDelta, err := lww.Set(context.Background(), []byte("test"))
+	if err != nil {
+		t.Errorf("Unexpected error: %s\n", err)
+		return
+	}
 
 	if !reflect.DeepEqual(addDelta.Data, []byte("test")) {
 		t.Errorf("Delta unexpected value, was %s want %s", addDelta.Data, []byte("test"))
@@ -56,9 +60,13 @@ func TestLWWRegisterAddDelta(t *testing.T) {
 func TestLWWRegisterInitialMerge(t *testing.T) {
 	ctx := context.Background()
 	lww := setupLWWRegister()
-	addDelta := lww.Set([]byte("test"))
+	addDelta, err := lww.Set(ctx, []byte("test"))
+	if err != nil {
+		t.Errorf("Unexpected error: %s\n", err)
+		return
+	}
 	addDelta.SetPriority(1)
-	err := lww.Merge(ctx, addDelta)
+	err = lww.Merge(ctx, addDelta)
 	if err != nil {
 		t.Errorf("Unexpected error: %s\n", err)
 		return
@@ -79,7 +87,10 @@ func TestLWWRegisterInitialMerge(t *testing.T) {
 func TestLWWReisterFollowupMerge(t *testing.T) {
 	ctx := context.Background()
 	lww := setupLoadedLWWRegster(ctx)
-	addDelta := lww.Set([]byte("test2"))
+	addDelta, err := lww.Set(ctx, []byte("test2"))
+	if err != nil {
+		t.Error(err)
+	}
 	addDelta.SetPriority(2)
 	lww.Merge(ctx, addDelta)
 
@@ -96,7 +107,10 @@ func TestLWWReisterFollowupMerge(t *testing.T) {
 func TestLWWRegisterOldMerge(t *testing.T) {
 	ctx := context.Background()
 	lww := setupLoadedLWWRegster(ctx)
-	addDelta := lww.Set([]byte("test-1"))
+	addDelta, err := lww.Set(ctx, []byte("test-1"))
+	if err != nil {
+		t.Error(err)
+	}
 	addDelta.SetPriority(0)
 	lww.Merge(ctx, addDelta)
 
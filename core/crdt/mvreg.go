@@ -0,0 +1,141 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"bytes"
+	"context"
+
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ugorji/go/codec"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+)
+
+// MVRegDelta is a single delta operation for an MVRegister.
+type MVRegDelta struct {
+	SchemaVersionID string
+	Priority        uint64
+	Data            []byte
+	DocKey          []byte
+	FieldName       string
+}
+
+var _ core.MultiValueDelta = (*MVRegDelta)(nil)
+
+// GetPriority gets the current priority for this delta.
+func (delta *MVRegDelta) GetPriority() uint64 {
+	return delta.Priority
+}
+
+// SetPriority will set the priority for this delta.
+func (delta *MVRegDelta) SetPriority(prio uint64) {
+	delta.Priority = prio
+}
+
+// Marshal encodes the delta using CBOR.
+func (delta *MVRegDelta) Marshal() ([]byte, error) {
+	h := &codec.CborHandle{}
+	buf := bytes.NewBuffer(nil)
+	enc := codec.NewEncoder(buf, h)
+	err := enc.Encode(struct {
+		SchemaVersionID string
+		Priority        uint64
+		Data            []byte
+		DocKey          []byte
+		FieldName       string
+	}{delta.SchemaVersionID, delta.Priority, delta.Data, delta.DocKey, delta.FieldName})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (delta *MVRegDelta) Value() any {
+	return delta.Data
+}
+
+// HeadValue returns the value kept along with the block of the delta while it is a head.
+func (delta *MVRegDelta) HeadValue() []byte {
+	return delta.Data
+}
+
+// MVRegister, Multi-Value Register, is a register that keeps all the values written to it
+// concurrently, instead of picking one of them.
+//
+// The concurrent values are the values of the deltas at the heads of the merkle clock of the
+// register, which the clock keeps along with the heads. A later write links to all the heads
+// and therefore replaces all the concurrent values. The value of the register itself is the
+// one a LWWRegister would pick among them, so that it can be read, filtered and indexed like
+// the value of any other register.
+type MVRegister struct {
+	reg LWWRegister
+}
+
+var _ core.ReplicatedData = (*MVRegister)(nil)
+
+// NewMVRegister returns a new instance of the MVRegister with the given ID.
+func NewMVRegister(
+	store datastore.DSReaderWriter,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+) MVRegister {
+	return MVRegister{reg: NewLWWRegister(store, schemaVersionKey, key, fieldName)}
+}
+
+// Value gets the current register value.
+func (reg MVRegister) Value(ctx context.Context) ([]byte, error) {
+	return reg.reg.Value(ctx)
+}
+
+// Set generates a new delta with the supplied value.
+func (reg MVRegister) Set(value []byte) *MVRegDelta {
+	return &MVRegDelta{
+		Data:            value,
+		DocKey:          []byte(reg.reg.key.DocKey),
+		FieldName:       reg.reg.fieldName,
+		SchemaVersionID: reg.reg.schemaVersionKey.SchemaVersionId,
+	}
+}
+
+// Merge implements ReplicatedData interface.
+//
+// It sets the value of the register the same way a LWWRegister does, the concurrent values
+// are tracked by the heads of the merkle clock.
+func (reg MVRegister) Merge(ctx context.Context, delta core.Delta) error {
+	d, ok := delta.(*MVRegDelta)
+	if !ok {
+		return ErrMismatchedMergeType
+	}
+
+	return reg.reg.setValue(ctx, d.Data, d.GetPriority())
+}
+
+// DeltaDecode is a typed helper to extract a MVRegDelta from a ipld.Node.
+func (reg MVRegister) DeltaDecode(node ipld.Node) (core.Delta, error) {
+	delta := &MVRegDelta{}
+	pbNode, ok := node.(*dag.ProtoNode)
+	if !ok {
+		return nil, client.NewErrUnexpectedType[*dag.ProtoNode]("ipld.Node", node)
+	}
+	data := pbNode.Data()
+	h := &codec.CborHandle{}
+	dec := codec.NewDecoderBytes(data, h)
+	err := dec.Decode(delta)
+	if err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
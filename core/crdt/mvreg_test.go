@@ -0,0 +1,78 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"context"
+	"testing"
+
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/core"
+)
+
+func setupMVRegister() MVRegister {
+	store := newMockStore()
+	key := core.DataStoreKey{DocKey: "AAAA-BBBB"}
+	return NewMVRegister(store, core.CollectionSchemaVersionKey{}, key, "name")
+}
+
+func TestMVRegisterSet_ShouldKeepValueAsHeadValue(t *testing.T) {
+	reg := setupMVRegister()
+
+	delta := reg.Set([]byte("test"))
+
+	assert.Equal(t, []byte("test"), delta.HeadValue())
+	assert.Equal(t, "name", delta.FieldName)
+}
+
+func TestMVRegisterMerge_WithConcurrentDeltas_ShouldKeepValueOfHighestPriority(t *testing.T) {
+	ctx := context.Background()
+	reg := setupMVRegister()
+
+	first := reg.Set([]byte("b"))
+	first.SetPriority(2)
+	second := reg.Set([]byte("c"))
+	second.SetPriority(2)
+	older := reg.Set([]byte("z"))
+	older.SetPriority(1)
+
+	require.NoError(t, reg.Merge(ctx, first))
+	require.NoError(t, reg.Merge(ctx, second))
+	require.NoError(t, reg.Merge(ctx, older))
+
+	val, err := reg.Value(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("c"), val)
+}
+
+func TestMVRegisterMerge_WithOtherDeltaType_ReturnError(t *testing.T) {
+	reg := setupMVRegister()
+
+	err := reg.Merge(context.Background(), &LWWRegDelta{Data: []byte("test")})
+
+	assert.ErrorIs(t, err, ErrMismatchedMergeType)
+}
+
+func TestMVRegisterDeltaDecode(t *testing.T) {
+	reg := setupMVRegister()
+	delta := reg.Set([]byte("test"))
+	delta.SetPriority(3)
+
+	data, err := delta.Marshal()
+	require.NoError(t, err)
+
+	decoded, err := reg.DeltaDecode(dag.NodeWithData(data))
+	require.NoError(t, err)
+	assert.Equal(t, delta, decoded)
+}
@@ -0,0 +1,439 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ugorji/go/codec"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+// ORSetElement is an element added to an ORSet, along with the unique tag of the addition.
+type ORSetElement struct {
+	Tag   string
+	Value []byte
+}
+
+// ORSetDelta is a single delta operation for an ORSet.
+//
+// Elements are added with a new unique tag, and removed by the tags of their additions that
+// were observed when the delta was created, so that a concurrent addition of the same element
+// is not removed.
+type ORSetDelta struct {
+	SchemaVersionID string
+	Priority        uint64
+	Added           []ORSetElement
+	Removed         []string
+	DocKey          []byte
+	FieldName       string
+}
+
+var _ core.Delta = (*ORSetDelta)(nil)
+
+// GetPriority gets the current priority for this delta.
+func (delta *ORSetDelta) GetPriority() uint64 {
+	return delta.Priority
+}
+
+// SetPriority will set the priority for this delta.
+func (delta *ORSetDelta) SetPriority(prio uint64) {
+	delta.Priority = prio
+}
+
+// Marshal encodes the delta using CBOR.
+func (delta *ORSetDelta) Marshal() ([]byte, error) {
+	h := &codec.CborHandle{}
+	buf := bytes.NewBuffer(nil)
+	enc := codec.NewEncoder(buf, h)
+	err := enc.Encode(struct {
+		SchemaVersionID string
+		Priority        uint64
+		Added           []ORSetElement
+		Removed         []string
+		DocKey          []byte
+		FieldName       string
+	}{delta.SchemaVersionID, delta.Priority, delta.Added, delta.Removed, delta.DocKey, delta.FieldName})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (delta *ORSetDelta) Value() any {
+	return delta
+}
+
+// The state of an ORSet is stored under its CRDT state key, each element and each tag at its
+// own key, so that merging a delta only reads and writes the keys of the tags it refers to:
+//
+//   - e/<priority>/<tag> holds the value of an element that hasn't been removed, so that the
+//     elements are listed by the priority of their addition and then by their tag.
+//   - t/<tag> holds the priority of the addition of an element that hasn't been removed.
+//   - x/<tag> marks a removed tag, so that an addition merged after its removal is ignored.
+//
+// The tags are hex encoded within the keys, which keeps their order.
+
+// ORSet is an observed-remove set CRDT holding the elements of an array field.
+//
+// Concurrent additions of elements are all kept, and an addition wins over a concurrent
+// removal of the same element. The value of the set is the CBOR encoded array of its distinct
+// elements, in the order they have been added.
+type ORSet struct {
+	baseCRDT
+}
+
+var _ core.ReplicatedData = (*ORSet)(nil)
+
+// NewORSet returns a new instance of the ORSet with the given ID.
+func NewORSet(
+	store datastore.DSReaderWriter,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+) ORSet {
+	return ORSet{
+		baseCRDT: newBaseCRDT(store, key, schemaVersionKey, fieldName),
+	}
+}
+
+// Value gets the current set value
+func (s ORSet) Value(ctx context.Context) ([]byte, error) {
+	valueK := s.key.WithValueFlag()
+	buf, err := s.store.Get(ctx, valueK.ToDS())
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// Set generates a new delta replacing the elements of the set with the elements of the given
+// CBOR encoded array.
+//
+// An empty value removes all the elements of the set.
+func (s ORSet) Set(ctx context.Context, value []byte) (*ORSetDelta, error) {
+	elements, err := s.getElements(ctx)
+	if err != nil {
+		return nil, err
+	}
+	delta := s.newDelta()
+	for _, element := range elements {
+		delta.Removed = append(delta.Removed, element.Tag)
+	}
+	delta.Added, err = newORSetElements(value)
+	if err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
+
+// Patch generates a new delta adding the elements of the given CBOR encoded array to the set
+// and removing the elements of the other given CBOR encoded array from it.
+//
+// Either array can be empty.
+func (s ORSet) Patch(ctx context.Context, added []byte, removed []byte) (*ORSetDelta, error) {
+	elements, err := s.getElements(ctx)
+	if err != nil {
+		return nil, err
+	}
+	delta := s.newDelta()
+	delta.Added, err = newORSetElements(added)
+	if err != nil {
+		return nil, err
+	}
+	removedValues, err := decodeSetElements(removed)
+	if err != nil {
+		return nil, err
+	}
+	for _, removedValue := range removedValues {
+		removedElement, err := normalizeSetElement(removedValue)
+		if err != nil {
+			return nil, err
+		}
+		for _, element := range elements {
+			stateElement, err := normalizeSetElement(element.Value)
+			if err != nil {
+				return nil, err
+			}
+			if stateElement == removedElement {
+				delta.Removed = append(delta.Removed, element.Tag)
+			}
+		}
+	}
+	return delta, nil
+}
+
+func (s ORSet) newDelta() *ORSetDelta {
+	return &ORSetDelta{
+		DocKey:          []byte(s.key.DocKey),
+		FieldName:       s.fieldName,
+		SchemaVersionID: s.schemaVersionKey.SchemaVersionId,
+	}
+}
+
+// Merge implements ReplicatedData interface.
+// It removes the elements of the delta's removed tags and adds the elements of the delta
+// that have not been removed yet.
+func (s ORSet) Merge(ctx context.Context, delta core.Delta) error {
+	d, ok := delta.(*ORSetDelta)
+	if !ok {
+		return ErrMismatchedMergeType
+	}
+
+	for _, tag := range d.Removed {
+		isRemoved, err := s.store.Has(ctx, s.tombstoneKey(tag))
+		if err != nil {
+			return err
+		}
+		if isRemoved {
+			continue
+		}
+		err = s.store.Put(ctx, s.tombstoneKey(tag), []byte{})
+		if err != nil {
+			return NewErrFailedToStoreValue(err)
+		}
+		priority, exists, err := s.getTagPriority(ctx, tag)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			// the removal has been merged before the addition
+			continue
+		}
+		err = s.store.Delete(ctx, s.elementKey(priority, tag))
+		if err != nil {
+			return err
+		}
+		err = s.store.Delete(ctx, s.tagKey(tag))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, element := range d.Added {
+		isRemoved, err := s.store.Has(ctx, s.tombstoneKey(element.Tag))
+		if err != nil {
+			return err
+		}
+		exists, err := s.store.Has(ctx, s.tagKey(element.Tag))
+		if err != nil {
+			return err
+		}
+		if isRemoved || exists {
+			continue
+		}
+		err = s.store.Put(ctx, s.elementKey(d.GetPriority(), element.Tag), element.Value)
+		if err != nil {
+			return NewErrFailedToStoreValue(err)
+		}
+		buf := make([]byte, binary.MaxVarintLen64)
+		n := binary.PutUvarint(buf, d.GetPriority())
+		err = s.store.Put(ctx, s.tagKey(element.Tag), buf[:n])
+		if err != nil {
+			return NewErrFailedToStoreValue(err)
+		}
+	}
+
+	// the value is made of the elements that haven't been removed, the tombstones are not read
+	elements, err := s.getElements(ctx)
+	if err != nil {
+		return err
+	}
+	value, err := encodeORSetValue(elements)
+	if err != nil {
+		return err
+	}
+	key, err := s.getValueKey(ctx)
+	if err != nil {
+		return err
+	}
+	err = s.store.Put(ctx, key.ToDS(), value)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+	return nil
+}
+
+// getElements returns the elements of the set that haven't been removed, ordered by the
+// priority of their addition and then by their tag.
+func (s ORSet) getElements(ctx context.Context) ([]ORSetElement, error) {
+	res, err := s.store.Query(ctx, query.Query{
+		Prefix: s.key.WithCRDTStateFlag().ToDS().ChildString("e").String(),
+		Orders: []query.Order{query.OrderByKey{}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return nil, err
+	}
+	elements := make([]ORSetElement, 0, len(entries))
+	for _, entry := range entries {
+		tag, err := hex.DecodeString(ds.NewKey(entry.Key).BaseNamespace())
+		if err != nil {
+			return nil, ErrDecodingORSetState
+		}
+		elements = append(elements, ORSetElement{Tag: string(tag), Value: entry.Value})
+	}
+	return elements, nil
+}
+
+// getTagPriority returns the priority of the addition of the element with the given tag,
+// and false if there is no such element.
+func (s ORSet) getTagPriority(ctx context.Context, tag string) (uint64, bool, error) {
+	buf, err := s.store.Get(ctx, s.tagKey(tag))
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	priority, n := binary.Uvarint(buf)
+	if n <= 0 {
+		return 0, false, ErrDecodingORSetState
+	}
+	return priority, true, nil
+}
+
+func (s ORSet) elementKey(priority uint64, tag string) ds.Key {
+	return s.key.WithCRDTStateFlag().ToDS().
+		ChildString("e").
+		ChildString(fmt.Sprintf("%020d", priority)).
+		ChildString(hex.EncodeToString([]byte(tag)))
+}
+
+func (s ORSet) tagKey(tag string) ds.Key {
+	return s.key.WithCRDTStateFlag().ToDS().ChildString("t").ChildString(hex.EncodeToString([]byte(tag)))
+}
+
+func (s ORSet) tombstoneKey(tag string) ds.Key {
+	return s.key.WithCRDTStateFlag().ToDS().ChildString("x").ChildString(hex.EncodeToString([]byte(tag)))
+}
+
+// encodeORSetValue returns the CBOR encoded array of the distinct values of the given elements.
+func encodeORSetValue(elements []ORSetElement) ([]byte, error) {
+	values := make([]cbor.RawMessage, 0, len(elements))
+	seen := make(map[any]struct{}, len(elements))
+	for _, element := range elements {
+		normalized, err := normalizeSetElement(element.Value)
+		if err != nil {
+			return nil, err
+		}
+		if _, isDuplicate := seen[normalized]; isDuplicate {
+			continue
+		}
+		seen[normalized] = struct{}{}
+		values = append(values, element.Value)
+	}
+	return cbor.Marshal(values)
+}
+
+// DeltaDecode is a typed helper to extract a ORSetDelta from a ipld.Node
+func (s ORSet) DeltaDecode(node ipld.Node) (core.Delta, error) {
+	delta := &ORSetDelta{}
+	pbNode, ok := node.(*dag.ProtoNode)
+	if !ok {
+		return nil, client.NewErrUnexpectedType[*dag.ProtoNode]("ipld.Node", node)
+	}
+	data := pbNode.Data()
+	h := &codec.CborHandle{}
+	dec := codec.NewDecoderBytes(data, h)
+	err := dec.Decode(delta)
+	if err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
+
+// newORSetElements tags each element of the given CBOR encoded array.
+func newORSetElements(data []byte) ([]ORSetElement, error) {
+	values, err := decodeSetElements(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	// the tags of the elements share a random prefix followed by their index, so that the
+	// elements added by the same delta are ordered as in the given array
+	prefix, err := newRandomID()
+	if err != nil {
+		return nil, err
+	}
+	elements := make([]ORSetElement, 0, len(values))
+	for i, value := range values {
+		elements = append(elements, ORSetElement{Tag: fmt.Sprintf("%s%08x", prefix, i), Value: value})
+	}
+	return elements, nil
+}
+
+// decodeSetElements splits the given CBOR encoded array into its CBOR encoded elements.
+//
+// Empty data and null are decoded as an empty array.
+func decodeSetElements(data []byte) ([]cbor.RawMessage, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var values []cbor.RawMessage
+	err := cbor.Unmarshal(data, &values)
+	if err != nil {
+		return nil, NewErrInvalidSetValue(err)
+	}
+	return values, nil
+}
+
+// normalizeSetElement decodes the given CBOR encoded element into a comparable value.
+//
+// Integral numbers are decoded as int64 whichever way they are encoded, so that the same
+// number written as an integer or as a float is the same element.
+func normalizeSetElement(data []byte) (any, error) {
+	var val any
+	err := cbor.Unmarshal(data, &val)
+	if err != nil {
+		return nil, NewErrInvalidSetValue(err)
+	}
+	switch v := val.(type) {
+	case uint64:
+		return int64(v), nil
+	case float64:
+		if float64(int64(v)) == v {
+			return int64(v), nil
+		}
+		return v, nil
+	case nil, bool, int64, string:
+		return v, nil
+	default:
+		return nil, ErrInvalidSetValue
+	}
+}
+
+func newRandomID() (string, error) {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf[:]), nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/core"
+)
+
+func setupORSet() ORSet {
+	store := newMockStore()
+	key := core.DataStoreKey{DocKey: "AAAA-BBBB"}
+	return NewORSet(store, core.CollectionSchemaVersionKey{}, key, "tags")
+}
+
+func getSetValue(t *testing.T, ctx context.Context, set ORSet) []string {
+	data, err := set.Value(ctx)
+	require.NoError(t, err)
+	var val []string
+	require.NoError(t, cbor.Unmarshal(data, &val))
+	return val
+}
+
+func applySetDelta(t *testing.T, ctx context.Context, set ORSet, delta *ORSetDelta, priority uint64) {
+	delta.SetPriority(priority)
+	require.NoError(t, set.Merge(ctx, delta))
+}
+
+func TestORSetSet_ShouldKeepElementsOrder(t *testing.T) {
+	ctx := context.Background()
+	set := setupORSet()
+
+	delta, err := set.Set(ctx, encodeValue(t, []string{"c", "a", "b"}))
+	require.NoError(t, err)
+	applySetDelta(t, ctx, set, delta, 1)
+
+	assert.Equal(t, []string{"c", "a", "b"}, getSetValue(t, ctx, set))
+}
+
+func TestORSetSet_ShouldReplaceElements(t *testing.T) {
+	ctx := context.Background()
+	set := setupORSet()
+
+	delta, err := set.Set(ctx, encodeValue(t, []string{"a", "b"}))
+	require.NoError(t, err)
+	applySetDelta(t, ctx, set, delta, 1)
+	delta, err = set.Set(ctx, encodeValue(t, []string{"c"}))
+	require.NoError(t, err)
+	applySetDelta(t, ctx, set, delta, 2)
+
+	assert.Equal(t, []string{"c"}, getSetValue(t, ctx, set))
+}
+
+func TestORSetPatch_ShouldAddAndRemoveElements(t *testing.T) {
+	ctx := context.Background()
+	set := setupORSet()
+
+	delta, err := set.Set(ctx, encodeValue(t, []string{"a", "b"}))
+	require.NoError(t, err)
+	applySetDelta(t, ctx, set, delta, 1)
+	delta, err = set.Patch(ctx, encodeValue(t, []string{"c", "a"}), encodeValue(t, []string{"b"}))
+	require.NoError(t, err)
+	applySetDelta(t, ctx, set, delta, 2)
+
+	assert.Equal(t, []string{"a", "c"}, getSetValue(t, ctx, set))
+}
+
+func TestORSetPatch_WithIntegralFloat_ShouldRemoveInt(t *testing.T) {
+	ctx := context.Background()
+	set := setupORSet()
+
+	delta, err := set.Set(ctx, encodeValue(t, []int64{1, 2}))
+	require.NoError(t, err)
+	applySetDelta(t, ctx, set, delta, 1)
+	delta, err = set.Patch(ctx, nil, encodeValue(t, []float64{1}))
+	require.NoError(t, err)
+
+	assert.Len(t, delta.Removed, 1)
+}
+
+func TestORSetPatch_IfValueIsNotArray_ReturnError(t *testing.T) {
+	set := setupORSet()
+	_, err := set.Patch(context.Background(), encodeValue(t, "a"), nil)
+	require.ErrorIs(t, err, ErrInvalidSetValue)
+}
+
+func TestORSetMerge_WithConcurrentAddAndRemove_AddShouldWin(t *testing.T) {
+	ctx := context.Background()
+	set1 := setupORSet()
+	set2 := setupORSet()
+
+	initial, err := set1.Set(ctx, encodeValue(t, []string{"a"}))
+	require.NoError(t, err)
+	applySetDelta(t, ctx, set1, initial, 1)
+	applySetDelta(t, ctx, set2, initial, 1)
+
+	remove, err := set1.Patch(ctx, nil, encodeValue(t, []string{"a"}))
+	require.NoError(t, err)
+	add, err := set2.Patch(ctx, encodeValue(t, []string{"a"}), nil)
+	require.NoError(t, err)
+
+	applySetDelta(t, ctx, set1, remove, 2)
+	applySetDelta(t, ctx, set1, add, 2)
+	applySetDelta(t, ctx, set2, add, 2)
+	applySetDelta(t, ctx, set2, remove, 2)
+
+	assert.Equal(t, []string{"a"}, getSetValue(t, ctx, set1))
+	assert.Equal(t, []string{"a"}, getSetValue(t, ctx, set2))
+}
+
+func TestORSetMerge_WithConcurrentAdds_ShouldConverge(t *testing.T) {
+	ctx := context.Background()
+	set1 := setupORSet()
+	set2 := setupORSet()
+
+	add1, err := set1.Patch(ctx, encodeValue(t, []string{"a"}), nil)
+	require.NoError(t, err)
+	add2, err := set2.Patch(ctx, encodeValue(t, []string{"b"}), nil)
+	require.NoError(t, err)
+
+	applySetDelta(t, ctx, set1, add1, 1)
+	applySetDelta(t, ctx, set1, add2, 1)
+	applySetDelta(t, ctx, set2, add2, 1)
+	applySetDelta(t, ctx, set2, add1, 1)
+
+	assert.ElementsMatch(t, []string{"a", "b"}, getSetValue(t, ctx, set1))
+	assert.Equal(t, getSetValue(t, ctx, set1), getSetValue(t, ctx, set2))
+}
+
+func TestORSetMerge_WithRemoveBeforeAdd_ShouldIgnoreAdd(t *testing.T) {
+	ctx := context.Background()
+	set1 := setupORSet()
+	set2 := setupORSet()
+
+	add, err := set1.Patch(ctx, encodeValue(t, []string{"a"}), nil)
+	require.NoError(t, err)
+	applySetDelta(t, ctx, set1, add, 1)
+	remove, err := set1.Patch(ctx, nil, encodeValue(t, []string{"a"}))
+	require.NoError(t, err)
+
+	applySetDelta(t, ctx, set2, remove, 2)
+	applySetDelta(t, ctx, set2, add, 1)
+
+	assert.Equal(t, []string{}, getSetValue(t, ctx, set2))
+}
+
+func TestORSetMerge_ShouldOrderElementsByPriority(t *testing.T) {
+	ctx := context.Background()
+	set := setupORSet()
+
+	add1, err := set.Patch(ctx, encodeValue(t, []string{"a"}), nil)
+	require.NoError(t, err)
+	add2, err := set.Patch(ctx, encodeValue(t, []string{"b"}), nil)
+	require.NoError(t, err)
+	applySetDelta(t, ctx, set, add1, 10)
+	applySetDelta(t, ctx, set, add2, 9)
+
+	assert.Equal(t, []string{"b", "a"}, getSetValue(t, ctx, set))
+}
+
+func TestORSetMerge_WithRemovedElement_ShouldOnlyKeepItsTombstone(t *testing.T) {
+	ctx := context.Background()
+	set := setupORSet()
+
+	add, err := set.Patch(ctx, encodeValue(t, []string{"a", "b"}), nil)
+	require.NoError(t, err)
+	applySetDelta(t, ctx, set, add, 1)
+	remove, err := set.Patch(ctx, nil, encodeValue(t, []string{"a"}))
+	require.NoError(t, err)
+	applySetDelta(t, ctx, set, remove, 2)
+
+	removedTag := add.Added[0].Tag
+	_, err = set.store.Get(ctx, set.elementKey(1, removedTag))
+	require.ErrorIs(t, err, ds.ErrNotFound)
+	_, err = set.store.Get(ctx, set.tagKey(removedTag))
+	require.ErrorIs(t, err, ds.ErrNotFound)
+	isRemoved, err := set.store.Has(ctx, set.tombstoneKey(removedTag))
+	require.NoError(t, err)
+	assert.True(t, isRemoved)
+
+	elements, err := set.getElements(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []ORSetElement{add.Added[1]}, elements)
+}
+
+func TestORSetDeltaDecode(t *testing.T) {
+	set := setupORSet()
+	delta, err := set.Patch(context.Background(), encodeValue(t, []string{"a"}), nil)
+	require.NoError(t, err)
+	delta.SetPriority(3)
+
+	node, err := makeNode(delta, []cid.Cid{})
+	require.NoError(t, err)
+
+	decoded, err := set.DeltaDecode(node)
+	require.NoError(t, err)
+	assert.Equal(t, delta, decoded)
+}
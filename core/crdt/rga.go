@@ -0,0 +1,798 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/fxamacker/cbor/v2"
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ugorji/go/codec"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+// RGAID identifies a character of an RGA.
+//
+// The zero ID identifies the start of the text.
+type RGAID struct {
+	// Seq is greater than the Seq of all the characters known by the replica that inserted
+	// the character, at the time it was inserted.
+	Seq uint64
+	// Site is unique to the delta that inserted the character.
+	Site string
+}
+
+// isAfter returns true if the character of the ID is placed before the character of the other ID
+// when both are inserted at the same position, i.e. if it has been inserted after it.
+func (id RGAID) isAfter(other RGAID) bool {
+	if id.Seq != other.Seq {
+		return id.Seq > other.Seq
+	}
+	return id.Site > other.Site
+}
+
+// RGAInsert inserts a text after a character of an RGA.
+//
+// The n-th character of the text is identified by the Seq of the insertion plus n.
+type RGAInsert struct {
+	ID    RGAID
+	After RGAID
+	Text  string
+}
+
+// RGADelta is a single delta operation for an RGA.
+type RGADelta struct {
+	SchemaVersionID string
+	Priority        uint64
+	Inserts         []RGAInsert
+	Deletes         []RGAID
+	DocKey          []byte
+	FieldName       string
+}
+
+var _ core.Delta = (*RGADelta)(nil)
+
+// GetPriority gets the current priority for this delta.
+func (delta *RGADelta) GetPriority() uint64 {
+	return delta.Priority
+}
+
+// SetPriority will set the priority for this delta.
+func (delta *RGADelta) SetPriority(prio uint64) {
+	delta.Priority = prio
+}
+
+// Marshal encodes the delta using CBOR.
+func (delta *RGADelta) Marshal() ([]byte, error) {
+	h := &codec.CborHandle{}
+	buf := bytes.NewBuffer(nil)
+	enc := codec.NewEncoder(buf, h)
+	err := enc.Encode(struct {
+		SchemaVersionID string
+		Priority        uint64
+		Inserts         []RGAInsert
+		Deletes         []RGAID
+		DocKey          []byte
+		FieldName       string
+	}{delta.SchemaVersionID, delta.Priority, delta.Inserts, delta.Deletes, delta.DocKey, delta.FieldName})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (delta *RGADelta) Value() any {
+	return delta
+}
+
+// rgaMaxBlockSize is the number of characters above which a block of an RGA is split in two.
+const rgaMaxBlockSize = 256
+
+// rgaState is the stored state of an RGA.
+//
+// The characters of the text are stored in blocks, each at its own key, so that merging a delta
+// only reads and writes the blocks holding the characters it refers to. The block holding a
+// character is found through an index keyed by the ID of the character.
+type rgaState struct {
+	// Blocks lists the blocks holding the characters of the text, in order.
+	Blocks []rgaBlockRef
+	// NextBlock is the ID of the next block to be created.
+	NextBlock uint64
+	// Pending is the number of insertions merged before the character they are inserted after.
+	Pending int
+	// PendingDeletes is the number of deletions merged before the character they delete.
+	PendingDeletes int
+	// MaxSeq is the greatest Seq of the known characters.
+	MaxSeq uint64
+}
+
+type rgaBlockRef struct {
+	ID uint64
+	// Visible is the number of characters of the block that have not been deleted.
+	Visible int
+}
+
+// rgaBlock contains consecutive characters of the text, including the deleted ones
+// as other characters may have been inserted after them.
+type rgaBlock struct {
+	Elements []rgaElement
+}
+
+type rgaElement struct {
+	ID      RGAID
+	Char    rune
+	Deleted bool
+}
+
+func (block *rgaBlock) indexOf(id RGAID) int {
+	for i := range block.Elements {
+		if block.Elements[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (block *rgaBlock) visible() int {
+	count := 0
+	for _, element := range block.Elements {
+		if !element.Deleted {
+			count++
+		}
+	}
+	return count
+}
+
+func (state *rgaState) visible() int {
+	count := 0
+	for _, ref := range state.Blocks {
+		count += ref.Visible
+	}
+	return count
+}
+
+func (state *rgaState) blockIndex(blockID uint64) int {
+	for i, ref := range state.Blocks {
+		if ref.ID == blockID {
+			return i
+		}
+	}
+	return -1
+}
+
+func rgaIDKey(id RGAID) string {
+	return fmt.Sprintf("%d-%x", id.Seq, id.Site)
+}
+
+// rgaMerge holds the blocks of an RGA read and modified while merging a delta.
+type rgaMerge struct {
+	r      RGA
+	state  *rgaState
+	blocks map[uint64]*rgaBlock
+	dirty  map[uint64]struct{}
+}
+
+func (m *rgaMerge) block(ctx context.Context, index int) (*rgaBlock, error) {
+	blockID := m.state.Blocks[index].ID
+	if block, ok := m.blocks[blockID]; ok {
+		return block, nil
+	}
+	block, err := m.r.getBlock(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+	m.blocks[blockID] = block
+	return block, nil
+}
+
+// find returns the index of the block holding the character of the given ID,
+// and the index of the character in the block.
+func (m *rgaMerge) find(ctx context.Context, id RGAID) (int, int, bool, error) {
+	buf, err := m.r.store.Get(ctx, m.r.indexKey(id))
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return 0, 0, false, nil
+		}
+		return 0, 0, false, err
+	}
+	blockID, n := binary.Uvarint(buf)
+	if n <= 0 {
+		return 0, 0, false, ErrDecodingRGAState
+	}
+	blockIndex := m.state.blockIndex(blockID)
+	if blockIndex < 0 {
+		return 0, 0, false, ErrDecodingRGAState
+	}
+	block, err := m.block(ctx, blockIndex)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	index := block.indexOf(id)
+	if index < 0 {
+		return 0, 0, false, ErrDecodingRGAState
+	}
+	return blockIndex, index, true, nil
+}
+
+func (m *rgaMerge) putIndex(ctx context.Context, id RGAID, blockID uint64) error {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, blockID)
+	return m.r.store.Put(ctx, m.r.indexKey(id), buf[:n])
+}
+
+// insertAt inserts the element at the given position of the given block, splitting the block
+// if it gets too large, and returns the position the element ends up at.
+func (m *rgaMerge) insertAt(
+	ctx context.Context,
+	blockIndex int,
+	index int,
+	element rgaElement,
+) (int, int, error) {
+	if len(m.state.Blocks) == 0 {
+		m.state.Blocks = append(m.state.Blocks, rgaBlockRef{ID: m.state.NextBlock})
+		m.blocks[m.state.NextBlock] = &rgaBlock{}
+		m.state.NextBlock++
+	}
+	block, err := m.block(ctx, blockIndex)
+	if err != nil {
+		return 0, 0, err
+	}
+	blockID := m.state.Blocks[blockIndex].ID
+	block.Elements = append(block.Elements, rgaElement{})
+	copy(block.Elements[index+1:], block.Elements[index:])
+	block.Elements[index] = element
+	m.dirty[blockID] = struct{}{}
+	err = m.putIndex(ctx, element.ID, blockID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(block.Elements) <= rgaMaxBlockSize {
+		return blockIndex, index, nil
+	}
+
+	half := len(block.Elements) / 2
+	newBlock := &rgaBlock{Elements: append([]rgaElement(nil), block.Elements[half:]...)}
+	block.Elements = block.Elements[:half]
+	newBlockID := m.state.NextBlock
+	m.state.NextBlock++
+	m.blocks[newBlockID] = newBlock
+	m.dirty[newBlockID] = struct{}{}
+	m.state.Blocks = append(m.state.Blocks, rgaBlockRef{})
+	copy(m.state.Blocks[blockIndex+2:], m.state.Blocks[blockIndex+1:])
+	m.state.Blocks[blockIndex+1] = rgaBlockRef{ID: newBlockID}
+	for _, moved := range newBlock.Elements {
+		err = m.putIndex(ctx, moved.ID, newBlockID)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if index >= half {
+		return blockIndex + 1, index - half, nil
+	}
+	return blockIndex, index, nil
+}
+
+// integrate inserts the characters of the given insertion, it returns false if the character
+// they are inserted after is not known yet.
+func (m *rgaMerge) integrate(ctx context.Context, insert RGAInsert) (bool, error) {
+	blockIndex, index := 0, -1
+	if insert.After != (RGAID{}) {
+		var found bool
+		var err error
+		blockIndex, index, found, err = m.find(ctx, insert.After)
+		if err != nil || !found {
+			return false, err
+		}
+	}
+	// the characters of an insertion are all merged at once, so if the first one is known
+	// the insertion has already been merged
+	_, _, found, err := m.find(ctx, insert.ID)
+	if err != nil || found {
+		return found, err
+	}
+
+	for i, char := range []rune(insert.Text) {
+		id := RGAID{Seq: insert.ID.Seq + uint64(i), Site: insert.ID.Site}
+		// the characters inserted at the same position later are placed first, they are skipped
+		// along with the characters inserted after them, which are all inserted later too
+		index++
+		for blockIndex < len(m.state.Blocks) {
+			block, err := m.block(ctx, blockIndex)
+			if err != nil {
+				return false, err
+			}
+			if index < len(block.Elements) {
+				if !block.Elements[index].ID.isAfter(id) {
+					break
+				}
+				index++
+				continue
+			}
+			if blockIndex+1 == len(m.state.Blocks) {
+				break
+			}
+			blockIndex++
+			index = 0
+		}
+
+		element := rgaElement{ID: id, Char: char}
+		if m.state.PendingDeletes > 0 {
+			key := m.r.pendingDeleteKey(id)
+			hasDelete, err := m.r.store.Has(ctx, key)
+			if err != nil {
+				return false, err
+			}
+			if hasDelete {
+				element.Deleted = true
+				m.state.PendingDeletes--
+				err = m.r.store.Delete(ctx, key)
+				if err != nil {
+					return false, err
+				}
+			}
+		}
+		blockIndex, index, err = m.insertAt(ctx, blockIndex, index, element)
+		if err != nil {
+			return false, err
+		}
+		if id.Seq > m.state.MaxSeq {
+			m.state.MaxSeq = id.Seq
+		}
+	}
+	return true, nil
+}
+
+// takePending removes and returns the pending insertions inserted after the characters
+// of the given insertion.
+func (m *rgaMerge) takePending(ctx context.Context, insert RGAInsert) ([]RGAInsert, error) {
+	var inserts []RGAInsert
+	for i := range []rune(insert.Text) {
+		if m.state.Pending == 0 {
+			break
+		}
+		id := RGAID{Seq: insert.ID.Seq + uint64(i), Site: insert.ID.Site}
+		res, err := m.r.store.Query(ctx, query.Query{Prefix: m.r.pendingPrefix(id)})
+		if err != nil {
+			return nil, err
+		}
+		entries, err := res.Rest()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			var pending RGAInsert
+			err = cbor.Unmarshal(entry.Value, &pending)
+			if err != nil {
+				return nil, err
+			}
+			err = m.r.store.Delete(ctx, ds.NewKey(entry.Key))
+			if err != nil {
+				return nil, err
+			}
+			m.state.Pending--
+			inserts = append(inserts, pending)
+		}
+	}
+	return inserts, nil
+}
+
+// RGA is a replicated growable array CRDT holding the characters of a text field.
+//
+// Concurrent insertions and deletions are merged character-wise, the text inserted
+// concurrently at the same position is placed in a deterministic order. The value of the RGA
+// is the CBOR encoded text made of its characters that have not been deleted.
+type RGA struct {
+	baseCRDT
+}
+
+var _ core.ReplicatedData = (*RGA)(nil)
+
+// NewRGA returns a new instance of the RGA with the given ID.
+func NewRGA(
+	store datastore.DSReaderWriter,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+) RGA {
+	return RGA{
+		baseCRDT: newBaseCRDT(store, key, schemaVersionKey, fieldName),
+	}
+}
+
+// Value gets the current text value
+func (r RGA) Value(ctx context.Context) ([]byte, error) {
+	valueK := r.key.WithValueFlag()
+	buf, err := r.store.Get(ctx, valueK.ToDS())
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// Set generates a new delta replacing the text with the given CBOR encoded text.
+//
+// An empty value deletes all the text.
+func (r RGA) Set(ctx context.Context, value []byte) (*RGADelta, error) {
+	var text string
+	if len(value) > 0 {
+		var val any
+		err := cbor.Unmarshal(value, &val)
+		if err != nil {
+			return nil, err
+		}
+		if val != nil {
+			var ok bool
+			text, ok = val.(string)
+			if !ok {
+				return nil, NewErrInvalidTextValue(val)
+			}
+		}
+	}
+
+	state, err := r.getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	site, err := newRandomID()
+	if err != nil {
+		return nil, err
+	}
+	delta := r.newDelta()
+	delta.Deletes, err = r.visibleIDs(ctx, state, 0, state.visible())
+	if err != nil {
+		return nil, err
+	}
+	if text != "" {
+		delta.Inserts = append(delta.Inserts, RGAInsert{
+			ID:   RGAID{Seq: state.MaxSeq + 1, Site: site},
+			Text: text,
+		})
+	}
+	return delta, nil
+}
+
+// Patch generates a new delta inserting and deleting the text of the given patch.
+func (r RGA) Patch(ctx context.Context, patch client.TextPatch) (*RGADelta, error) {
+	state, err := r.getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = patch.Validate(state.visible())
+	if err != nil {
+		return nil, err
+	}
+	site, err := newRandomID()
+	if err != nil {
+		return nil, err
+	}
+	delta := r.newDelta()
+	for _, del := range patch.Delete {
+		ids, err := r.visibleIDs(ctx, state, del.Position, del.Length)
+		if err != nil {
+			return nil, err
+		}
+		delta.Deletes = append(delta.Deletes, ids...)
+	}
+	// the text inserted last at a position is placed first, so the insertions are given
+	// decreasing Seqs to keep the text inserted at the same position in the given order
+	seq := state.MaxSeq + 1
+	inserts := make([]RGAInsert, len(patch.Insert))
+	for i := len(patch.Insert) - 1; i >= 0; i-- {
+		ins := patch.Insert[i]
+		var after RGAID
+		if ins.Position > 0 {
+			ids, err := r.visibleIDs(ctx, state, ins.Position-1, 1)
+			if err != nil {
+				return nil, err
+			}
+			after = ids[0]
+		}
+		inserts[i] = RGAInsert{
+			ID:    RGAID{Seq: seq, Site: site},
+			After: after,
+			Text:  ins.Text,
+		}
+		seq += uint64(len([]rune(ins.Text)))
+	}
+	for _, insert := range inserts {
+		if insert.Text != "" {
+			delta.Inserts = append(delta.Inserts, insert)
+		}
+	}
+	return delta, nil
+}
+
+// visibleIDs returns the IDs of the given number of characters that have not been deleted,
+// starting at the given position of the text.
+func (r RGA) visibleIDs(ctx context.Context, state rgaState, position int, length int) ([]RGAID, error) {
+	ids := make([]RGAID, 0, length)
+	for _, ref := range state.Blocks {
+		if len(ids) == length {
+			break
+		}
+		if position >= ref.Visible {
+			position -= ref.Visible
+			continue
+		}
+		block, err := r.getBlock(ctx, ref.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, element := range block.Elements {
+			if len(ids) == length {
+				break
+			}
+			if element.Deleted {
+				continue
+			}
+			if position > 0 {
+				position--
+				continue
+			}
+			ids = append(ids, element.ID)
+		}
+	}
+	return ids, nil
+}
+
+func (r RGA) newDelta() *RGADelta {
+	return &RGADelta{
+		DocKey:          []byte(r.key.DocKey),
+		FieldName:       r.fieldName,
+		SchemaVersionID: r.schemaVersionKey.SchemaVersionId,
+	}
+}
+
+// Merge implements ReplicatedData interface.
+// It inserts the characters of the delta after the characters they have been inserted after,
+// and marks the deleted characters as such.
+func (r RGA) Merge(ctx context.Context, delta core.Delta) error {
+	d, ok := delta.(*RGADelta)
+	if !ok {
+		return ErrMismatchedMergeType
+	}
+	state, err := r.getState(ctx)
+	if err != nil {
+		return err
+	}
+	oldBlocks := append([]rgaBlockRef(nil), state.Blocks...)
+	m := &rgaMerge{
+		r:      r,
+		state:  &state,
+		blocks: map[uint64]*rgaBlock{},
+		dirty:  map[uint64]struct{}{},
+	}
+
+	queue := append([]RGAInsert(nil), d.Inserts...)
+	for len(queue) > 0 {
+		insert := queue[0]
+		queue = queue[1:]
+		integrated, err := m.integrate(ctx, insert)
+		if err != nil {
+			return err
+		}
+		if !integrated {
+			buf, err := cbor.Marshal(insert)
+			if err != nil {
+				return err
+			}
+			err = r.store.Put(ctx, r.pendingKey(insert), buf)
+			if err != nil {
+				return NewErrFailedToStoreValue(err)
+			}
+			state.Pending++
+			continue
+		}
+		unblocked, err := m.takePending(ctx, insert)
+		if err != nil {
+			return err
+		}
+		queue = append(queue, unblocked...)
+	}
+	for _, id := range d.Deletes {
+		blockIndex, index, found, err := m.find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if found {
+			block := m.blocks[state.Blocks[blockIndex].ID]
+			if !block.Elements[index].Deleted {
+				block.Elements[index].Deleted = true
+				m.dirty[state.Blocks[blockIndex].ID] = struct{}{}
+			}
+			continue
+		}
+		key := r.pendingDeleteKey(id)
+		hasDelete, err := r.store.Has(ctx, key)
+		if err != nil {
+			return err
+		}
+		if !hasDelete {
+			err = r.store.Put(ctx, key, []byte{})
+			if err != nil {
+				return NewErrFailedToStoreValue(err)
+			}
+			state.PendingDeletes++
+		}
+	}
+
+	for i, ref := range state.Blocks {
+		if _, ok := m.dirty[ref.ID]; !ok {
+			continue
+		}
+		block := m.blocks[ref.ID]
+		state.Blocks[i].Visible = block.visible()
+		blockBytes, err := cbor.Marshal(block)
+		if err != nil {
+			return err
+		}
+		err = r.store.Put(ctx, r.blockKey(ref.ID), blockBytes)
+		if err != nil {
+			return NewErrFailedToStoreValue(err)
+		}
+	}
+	stateBytes, err := cbor.Marshal(state)
+	if err != nil {
+		return err
+	}
+	err = r.store.Put(ctx, r.key.WithCRDTStateFlag().ToDS(), stateBytes)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+
+	key, err := r.getValueKey(ctx)
+	if err != nil {
+		return err
+	}
+	text, err := r.buildText(ctx, key, oldBlocks, m)
+	if err != nil {
+		return err
+	}
+	value, err := cbor.Marshal(text)
+	if err != nil {
+		return err
+	}
+	err = r.store.Put(ctx, key.ToDS(), value)
+	if err != nil {
+		return NewErrFailedToStoreValue(err)
+	}
+	return nil
+}
+
+// buildText returns the text of the RGA once the delta has been merged, the characters of the
+// blocks that have not been modified are taken from the text stored before the merge.
+func (r RGA) buildText(
+	ctx context.Context,
+	valueKey core.DataStoreKey,
+	oldBlocks []rgaBlockRef,
+	m *rgaMerge,
+) (string, error) {
+	var oldText []rune
+	buf, err := r.store.Get(ctx, valueKey.ToDS())
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return "", err
+	}
+	if len(buf) > 0 {
+		var text string
+		err = cbor.Unmarshal(buf, &text)
+		if err != nil {
+			return "", err
+		}
+		oldText = []rune(text)
+	}
+
+	oldOffsets := make(map[uint64][2]int, len(oldBlocks))
+	offset := 0
+	for _, ref := range oldBlocks {
+		oldOffsets[ref.ID] = [2]int{offset, offset + ref.Visible}
+		offset += ref.Visible
+	}
+	// the stored text can not be reused if it does not match the blocks, in which case
+	// all the blocks are read
+	reuse := offset == len(oldText)
+
+	chars := make([]rune, 0, m.state.visible())
+	for i, ref := range m.state.Blocks {
+		_, isDirty := m.dirty[ref.ID]
+		bounds, isOld := oldOffsets[ref.ID]
+		if reuse && isOld && !isDirty {
+			chars = append(chars, oldText[bounds[0]:bounds[1]]...)
+			continue
+		}
+		block, err := m.block(ctx, i)
+		if err != nil {
+			return "", err
+		}
+		for _, element := range block.Elements {
+			if !element.Deleted {
+				chars = append(chars, element.Char)
+			}
+		}
+	}
+	return string(chars), nil
+}
+
+func (r RGA) getState(ctx context.Context) (rgaState, error) {
+	var state rgaState
+	buf, err := r.store.Get(ctx, r.key.WithCRDTStateFlag().ToDS())
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return state, nil
+		}
+		return state, err
+	}
+	err = cbor.Unmarshal(buf, &state)
+	return state, err
+}
+
+func (r RGA) getBlock(ctx context.Context, blockID uint64) (*rgaBlock, error) {
+	block := &rgaBlock{}
+	buf, err := r.store.Get(ctx, r.blockKey(blockID))
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return block, nil
+		}
+		return nil, err
+	}
+	err = cbor.Unmarshal(buf, block)
+	if err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (r RGA) blockKey(blockID uint64) ds.Key {
+	return r.key.WithCRDTStateFlag().ToDS().ChildString("b").ChildString(strconv.FormatUint(blockID, 10))
+}
+
+func (r RGA) indexKey(id RGAID) ds.Key {
+	return r.key.WithCRDTStateFlag().ToDS().ChildString("i").ChildString(rgaIDKey(id))
+}
+
+func (r RGA) pendingPrefix(after RGAID) string {
+	return r.key.WithCRDTStateFlag().ToDS().ChildString("p").ChildString(rgaIDKey(after)).String() + "/"
+}
+
+func (r RGA) pendingKey(insert RGAInsert) ds.Key {
+	return ds.NewKey(r.pendingPrefix(insert.After) + rgaIDKey(insert.ID))
+}
+
+func (r RGA) pendingDeleteKey(id RGAID) ds.Key {
+	return r.key.WithCRDTStateFlag().ToDS().ChildString("d").ChildString(rgaIDKey(id))
+}
+
+// DeltaDecode is a typed helper to extract a RGADelta from a ipld.Node
+func (r RGA) DeltaDecode(node ipld.Node) (core.Delta, error) {
+	delta := &RGADelta{}
+	pbNode, ok := node.(*dag.ProtoNode)
+	if !ok {
+		return nil, client.NewErrUnexpectedType[*dag.ProtoNode]("ipld.Node", node)
+	}
+	data := pbNode.Data()
+	h := &codec.CborHandle{}
+	dec := codec.NewDecoderBytes(data, h)
+	err := dec.Decode(delta)
+	if err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+)
+
+func setupRGA() RGA {
+	store := newMockStore()
+	key := core.DataStoreKey{DocKey: "AAAA-BBBB"}
+	return NewRGA(store, core.CollectionSchemaVersionKey{}, key, "notes")
+}
+
+func getRGAValue(t *testing.T, ctx context.Context, rga RGA) string {
+	data, err := rga.Value(ctx)
+	require.NoError(t, err)
+	var val string
+	require.NoError(t, cbor.Unmarshal(data, &val))
+	return val
+}
+
+func mergeRGADelta(t *testing.T, ctx context.Context, rga RGA, delta *RGADelta) {
+	require.NoError(t, rga.Merge(ctx, delta))
+}
+
+func TestRGASet_ShouldReplaceText(t *testing.T) {
+	ctx := context.Background()
+	rga := setupRGA()
+
+	delta, err := rga.Set(ctx, encodeValue(t, "hello"))
+	require.NoError(t, err)
+	mergeRGADelta(t, ctx, rga, delta)
+	delta, err = rga.Set(ctx, encodeValue(t, "world"))
+	require.NoError(t, err)
+	mergeRGADelta(t, ctx, rga, delta)
+
+	assert.Equal(t, "world", getRGAValue(t, ctx, rga))
+}
+
+func TestRGASet_IfValueIsNotString_ReturnError(t *testing.T) {
+	rga := setupRGA()
+	_, err := rga.Set(context.Background(), encodeValue(t, 1))
+	require.ErrorIs(t, err, ErrInvalidTextValue)
+}
+
+func TestRGAPatch_ShouldInsertAndDeleteText(t *testing.T) {
+	ctx := context.Background()
+	rga := setupRGA()
+
+	delta, err := rga.Set(ctx, encodeValue(t, "hello world"))
+	require.NoError(t, err)
+	mergeRGADelta(t, ctx, rga, delta)
+	delta, err = rga.Patch(ctx, client.TextPatch{
+		Insert: []client.TextInsert{{Position: 0, Text: "Oh, "}, {Position: 11, Text: "!"}},
+		Delete: []client.TextDelete{{Position: 0, Length: 1}},
+	})
+	require.NoError(t, err)
+	mergeRGADelta(t, ctx, rga, delta)
+
+	assert.Equal(t, "Oh, ello world!", getRGAValue(t, ctx, rga))
+}
+
+func TestRGAPatch_WithInsertsAtSamePosition_ShouldKeepOrder(t *testing.T) {
+	ctx := context.Background()
+	rga := setupRGA()
+
+	delta, err := rga.Set(ctx, encodeValue(t, "ad"))
+	require.NoError(t, err)
+	mergeRGADelta(t, ctx, rga, delta)
+	delta, err = rga.Patch(ctx, client.TextPatch{
+		Insert: []client.TextInsert{{Position: 1, Text: "b"}, {Position: 1, Text: "c"}},
+	})
+	require.NoError(t, err)
+	mergeRGADelta(t, ctx, rga, delta)
+
+	assert.Equal(t, "abcd", getRGAValue(t, ctx, rga))
+}
+
+func TestRGAPatch_WithTextSpanningSeveralBlocks_ShouldInsertAndDeleteText(t *testing.T) {
+	ctx := context.Background()
+	rga := setupRGA()
+
+	text := strings.Repeat("abcdefghij", 100)
+	delta, err := rga.Set(ctx, encodeValue(t, text))
+	require.NoError(t, err)
+	mergeRGADelta(t, ctx, rga, delta)
+	for i := 0; i < 300; i++ {
+		delta, err = rga.Patch(ctx, client.TextPatch{
+			Insert: []client.TextInsert{{Position: 500, Text: "x"}},
+		})
+		require.NoError(t, err)
+		mergeRGADelta(t, ctx, rga, delta)
+	}
+	delta, err = rga.Patch(ctx, client.TextPatch{
+		Insert: []client.TextInsert{{Position: 1300, Text: "end"}},
+		Delete: []client.TextDelete{{Position: 100, Length: 600}},
+	})
+	require.NoError(t, err)
+	mergeRGADelta(t, ctx, rga, delta)
+
+	expected := text[:100] + strings.Repeat("x", 100) + text[500:] + "end"
+	assert.Equal(t, expected, getRGAValue(t, ctx, rga))
+
+	state, err := rga.getState(ctx)
+	require.NoError(t, err)
+	assert.Greater(t, len(state.Blocks), 1)
+}
+
+func TestRGAPatch_IfPositionIsOutOfRange_ReturnError(t *testing.T) {
+	ctx := context.Background()
+	rga := setupRGA()
+
+	delta, err := rga.Set(ctx, encodeValue(t, "abc"))
+	require.NoError(t, err)
+	mergeRGADelta(t, ctx, rga, delta)
+
+	_, err = rga.Patch(ctx, client.TextPatch{
+		Delete: []client.TextDelete{{Position: 2, Length: 2}},
+	})
+	require.ErrorIs(t, err, client.ErrTextPositionOutOfRange)
+}
+
+func TestRGAMerge_WithConcurrentEdits_ShouldConverge(t *testing.T) {
+	ctx := context.Background()
+	rga1 := setupRGA()
+	rga2 := setupRGA()
+
+	initial, err := rga1.Set(ctx, encodeValue(t, "abc"))
+	require.NoError(t, err)
+	mergeRGADelta(t, ctx, rga1, initial)
+	mergeRGADelta(t, ctx, rga2, initial)
+
+	edit1, err := rga1.Patch(ctx, client.TextPatch{
+		Insert: []client.TextInsert{{Position: 1, Text: "12"}},
+	})
+	require.NoError(t, err)
+	edit2, err := rga2.Patch(ctx, client.TextPatch{
+		Insert: []client.TextInsert{{Position: 1, Text: "xy"}},
+		Delete: []client.TextDelete{{Position: 2, Length: 1}},
+	})
+	require.NoError(t, err)
+
+	mergeRGADelta(t, ctx, rga1, edit1)
+	mergeRGADelta(t, ctx, rga1, edit2)
+	mergeRGADelta(t, ctx, rga2, edit2)
+	mergeRGADelta(t, ctx, rga2, edit1)
+
+	value := getRGAValue(t, ctx, rga1)
+	assert.Equal(t, value, getRGAValue(t, ctx, rga2))
+	assert.Contains(t, []string{"a12xyb", "axy12b"}, value)
+}
+
+func TestRGAMerge_WithInsertBeforeItsReference_ShouldInsertOnceReferenceIsMerged(t *testing.T) {
+	ctx := context.Background()
+	rga1 := setupRGA()
+	rga2 := setupRGA()
+
+	initial, err := rga1.Set(ctx, encodeValue(t, "ab"))
+	require.NoError(t, err)
+	mergeRGADelta(t, ctx, rga1, initial)
+	edit, err := rga1.Patch(ctx, client.TextPatch{
+		Insert: []client.TextInsert{{Position: 1, Text: "x"}},
+		Delete: []client.TextDelete{{Position: 0, Length: 1}},
+	})
+	require.NoError(t, err)
+	mergeRGADelta(t, ctx, rga1, edit)
+
+	mergeRGADelta(t, ctx, rga2, edit)
+	mergeRGADelta(t, ctx, rga2, initial)
+
+	assert.Equal(t, "xb", getRGAValue(t, ctx, rga1))
+	assert.Equal(t, "xb", getRGAValue(t, ctx, rga2))
+}
+
+func TestRGADeltaDecode(t *testing.T) {
+	rga := setupRGA()
+	delta, err := rga.Set(context.Background(), encodeValue(t, "abc"))
+	require.NoError(t, err)
+	delta.SetPriority(3)
+
+	node, err := makeNode(delta, []cid.Cid{})
+	require.NoError(t, err)
+
+	decoded, err := rga.DeltaDecode(node)
+	require.NoError(t, err)
+	assert.Equal(t, delta, decoded)
+}
@@ -29,6 +29,15 @@ type CompositeDelta interface {
 	Links() []DAGLink
 }
 
+// MultiValueDelta represents a delta-state update to a multi-value CRDT.
+//
+// The value of such a delta is kept along with its block for as long as the block is one of
+// the heads of the DAG, so that the values of concurrent deltas can all be read.
+type MultiValueDelta interface {
+	Delta
+	HeadValue() []byte
+}
+
 // DAGLink represents a link to another object in a DAG.
 type DAGLink struct {
 	Name string
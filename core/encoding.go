@@ -12,6 +12,8 @@ package core
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 
 	"github.com/sourcenetwork/immutable"
 
@@ -25,6 +27,10 @@ func DecodeFieldValue(fieldDesc client.FieldDescription, val any) (any, error) {
 		return nil, nil
 	}
 
+	if fieldDesc.Kind == client.FieldKind_JSON {
+		return convertJSONValue(fieldDesc.Name, val)
+	}
+
 	var err error
 	if array, isArray := val.([]any); isArray {
 		var ok bool
@@ -125,6 +131,81 @@ func DecodeFieldValue(fieldDesc client.FieldDescription, val any) (any, error) {
 	return val, nil
 }
 
+// convertJSONValue converts a decoded value of a JSON field to its standardized form,
+// where objects are map[string]any, arrays are []any and integral numbers are int64,
+// as JSON doesn't distinguish integers from floats.
+func convertJSONValue(propertyName string, val any) (any, error) {
+	switch v := val.(type) {
+	case map[any]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			strKey, ok := key.(string)
+			if !ok {
+				return nil, client.NewErrUnexpectedType[string](propertyName, key)
+			}
+			convertedItem, err := convertJSONValue(fmt.Sprintf("%s.%s", propertyName, strKey), item)
+			if err != nil {
+				return nil, err
+			}
+			result[strKey] = convertedItem
+		}
+		return result, nil
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			convertedItem, err := convertJSONValue(fmt.Sprintf("%s.%s", propertyName, key), item)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = convertedItem
+		}
+		return result, nil
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			convertedItem, err := convertJSONValue(fmt.Sprintf("%s[%v]", propertyName, i), item)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = convertedItem
+		}
+		return result, nil
+	case uint64:
+		return int64(v), nil
+	case int:
+		return int64(v), nil
+	case float64:
+		if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 {
+			return int64(v), nil
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+// GetJSONPathValue returns the value found at the given path within the given value of a JSON
+// field, or nil if there is no such value.
+//
+// Every element of the path is the key of an object property, or the index of an array element.
+func GetJSONPathValue(val any, path []string) any {
+	for _, key := range path {
+		switch v := val.(type) {
+		case map[string]any:
+			val = v[key]
+		case []any:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil
+			}
+			val = v[index]
+		default:
+			return nil
+		}
+	}
+	return val
+}
+
 func convertNillableArray[T any](propertyName string, items []any) ([]immutable.Option[T], error) {
 	resultArray := make([]immutable.Option[T], len(items))
 	for i, untypedValue := range items {
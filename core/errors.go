@@ -11,20 +11,41 @@
 package core
 
 import (
+	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/errors"
 )
 
 const (
-	errFailedToGetFieldIdOfKey string = "failed to get FieldID of Key"
+	errFailedToGetFieldIdOfKey   string = "failed to get FieldID of Key"
+	errInvalidIndexFieldValue    string = "invalid field value for index"
+	errInvalidIndexFieldEncoding string = "invalid encoding of an index field value"
 )
 
 var (
-	ErrFailedToGetFieldIdOfKey = errors.New(errFailedToGetFieldIdOfKey)
-	ErrEmptyKey                = errors.New("received empty key string")
-	ErrInvalidKey              = errors.New("invalid key string")
+	ErrFailedToGetFieldIdOfKey   = errors.New(errFailedToGetFieldIdOfKey)
+	ErrEmptyKey                  = errors.New("received empty key string")
+	ErrInvalidKey                = errors.New("invalid key string")
+	ErrInvalidIndexFieldValue    = errors.New(errInvalidIndexFieldValue)
+	ErrInvalidIndexFieldEncoding = errors.New(errInvalidIndexFieldEncoding)
 )
 
 // NewErrFailedToGetFieldIdOfKey returns the error indicating failure to get FieldID of Key.
 func NewErrFailedToGetFieldIdOfKey(inner error) error {
 	return errors.Wrap(errFailedToGetFieldIdOfKey, inner)
 }
+
+// NewErrInvalidIndexFieldValue returns an error indicating that the given value
+// can not be encoded as an index value of a field of the given kind.
+func NewErrInvalidIndexFieldValue(kind client.FieldKind, val any) error {
+	return errors.New(
+		errInvalidIndexFieldValue,
+		errors.NewKV("Kind", kind),
+		errors.NewKV("Value", val),
+	)
+}
+
+// NewErrInvalidIndexFieldEncoding returns an error indicating that the given bytes
+// are not a valid encoded index value.
+func NewErrInvalidIndexFieldEncoding(data []byte) error {
+	return errors.New(errInvalidIndexFieldEncoding, errors.NewKV("Data", string(data)))
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package core
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// AnalyzeText splits the given text into the terms of a full-text index with the given options.
+//
+// Terms are returned in the order they appear in the text, including duplicates.
+func AnalyzeText(opts client.FullTextIndexOptions, text string) []string {
+	var terms []string
+	switch opts.Tokenizer {
+	case client.FullTextWhitespaceTokenizer:
+		terms = strings.Fields(text)
+	default:
+		terms = strings.FieldsFunc(text, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+	}
+	for i := range terms {
+		if !opts.CaseSensitive {
+			terms[i] = strings.ToLower(terms[i])
+		}
+		if opts.Stem {
+			terms[i] = stemTerm(terms[i])
+		}
+	}
+	return terms
+}
+
+// stemTerm reduces the given term to its stem by stripping the regular suffixes
+// of English words, e.g. "stories" becomes "story" and "running" becomes "run".
+//
+// It is a light stemmer: irregular forms are left as they are, and the resulting stem
+// is not necessarily a word, it only has to be the same for the different forms of a word.
+func stemTerm(term string) string {
+	// short words are left intact, as they are rarely inflected and stripping them
+	// would make unrelated words collide
+	if len(term) <= 3 {
+		return term
+	}
+	switch {
+	case strings.HasSuffix(term, "ies") && len(term) > 4:
+		term = term[:len(term)-3] + "y"
+	case strings.HasSuffix(term, "sses"):
+		term = term[:len(term)-2]
+	case strings.HasSuffix(term, "xes"), strings.HasSuffix(term, "ches"),
+		strings.HasSuffix(term, "shes"), strings.HasSuffix(term, "zes"):
+		term = term[:len(term)-2]
+	case strings.HasSuffix(term, "s") && !strings.HasSuffix(term, "ss") &&
+		!strings.HasSuffix(term, "us") && !strings.HasSuffix(term, "is"):
+		term = term[:len(term)-1]
+	}
+
+	switch {
+	case strings.HasSuffix(term, "ing") && hasVowel(term[:len(term)-3]) && len(term) > 5:
+		term = undoubleConsonant(term[:len(term)-3])
+	case strings.HasSuffix(term, "ed") && !strings.HasSuffix(term, "eed") &&
+		hasVowel(term[:len(term)-2]) && len(term) > 4:
+		term = undoubleConsonant(term[:len(term)-2])
+	case strings.HasSuffix(term, "ly") && len(term) > 4:
+		term = term[:len(term)-2]
+	}
+
+	// a trailing silent e is dropped so that "bake" and "baking" share the same stem
+	if strings.HasSuffix(term, "e") && len(term) > 3 {
+		term = term[:len(term)-1]
+	}
+	return term
+}
+
+func isVowel(b byte) bool {
+	switch b {
+	case 'a', 'e', 'i', 'o', 'u', 'y':
+		return true
+	}
+	return false
+}
+
+func hasVowel(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if isVowel(s[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// undoubleConsonant removes the last letter of a stem ending with a doubled consonant,
+// e.g. "runn" becomes "run", except for the letters that are commonly doubled in the stem itself.
+func undoubleConsonant(stem string) string {
+	n := len(stem)
+	if n < 2 || stem[n-1] != stem[n-2] || isVowel(stem[n-1]) {
+		return stem
+	}
+	switch stem[n-1] {
+	case 'l', 's', 'z':
+		return stem
+	}
+	return stem[:n-1]
+}
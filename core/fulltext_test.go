@@ -0,0 +1,73 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func TestAnalyzeText(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     client.FullTextIndexOptions
+		text     string
+		expected []string
+	}{
+		{
+			name:     "word tokenizer",
+			text:     "Hello, World! It's web3.",
+			expected: []string{"hello", "world", "it", "s", "web3"},
+		},
+		{
+			name:     "whitespace tokenizer",
+			opts:     client.FullTextIndexOptions{Tokenizer: client.FullTextWhitespaceTokenizer},
+			text:     "Hello, World!\tweb3",
+			expected: []string{"hello,", "world!", "web3"},
+		},
+		{
+			name:     "case sensitive",
+			opts:     client.FullTextIndexOptions{CaseSensitive: true},
+			text:     "Hello World",
+			expected: []string{"Hello", "World"},
+		},
+		{
+			name:     "duplicate terms",
+			text:     "the cat and the hat",
+			expected: []string{"the", "cat", "and", "the", "hat"},
+		},
+		{
+			name:     "no terms",
+			text:     " ... ",
+			expected: []string{},
+		},
+		{
+			name:     "stemming",
+			opts:     client.FullTextIndexOptions{Stem: true},
+			text:     "Running runs run stories story baked baking bake boxes quickly",
+			expected: []string{"run", "run", "run", "story", "story", "bak", "bak", "bak", "box", "quick"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, AnalyzeText(tc.opts, tc.text))
+		})
+	}
+}
+
+func TestStemTerm_ShouldNotStripStems(t *testing.T) {
+	for _, term := range []string{"run", "this", "status", "class", "string", "feed", "fill"} {
+		assert.Equal(t, term, stemTerm(term), term)
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package core
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"time"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// Index field values are encoded in a way that preserves the order of the values: comparing
+// two encoded values byte by byte gives the same result as comparing the original values.
+// This allows the index iterators to seek to a value and to stop at a bound instead of
+// scanning the whole index.
+//
+// Every encoded value starts with a type tag that also makes null values sort first.
+// Values of descending fields have all of their bytes inverted so that they sort in reverse.
+// The result is hex encoded so that it never contains the key separator.
+const (
+	indexNullTag   byte = 0x00
+	indexFalseTag  byte = 0x10
+	indexTrueTag   byte = 0x11
+	indexIntTag    byte = 0x20
+	indexFloatTag  byte = 0x30
+	indexStringTag byte = 0x40
+	indexTimeTag   byte = 0x50
+
+	// strings are terminated with 0x00 0x01 and any 0x00 within a string is escaped as 0x00 0xFF,
+	// so that a string always sorts before any other string it is a prefix of.
+	indexStringEscape     byte = 0x00
+	indexStringTerminator byte = 0x01
+	indexStringEscaped00  byte = 0xFF
+)
+
+// IndexEncodingVersion is the version of the encoding of the index keys.
+//
+// Version 0 is the former CBOR encoding, which doesn't preserve the order of the values.
+const IndexEncodingVersion uint32 = 1
+
+// EncodeIndexFieldValue encodes the given value of a field of the given kind
+// into its order-preserving index representation.
+//
+// The values of inline array fields are encoded one element at a time.
+func EncodeIndexFieldValue(kind client.FieldKind, val any, descending bool) ([]byte, error) {
+	var b []byte
+	if val == nil {
+		b = []byte{indexNullTag}
+	} else {
+		var err error
+		b, err = encodeIndexFieldValue(kind, val)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if descending {
+		for i := range b {
+			b[i] = ^b[i]
+		}
+	}
+	result := make([]byte, hex.EncodedLen(len(b)))
+	hex.Encode(result, b)
+	return result, nil
+}
+
+func encodeIndexFieldValue(kind client.FieldKind, val any) ([]byte, error) {
+	// inline arrays are indexed by their elements
+	switch kind {
+	case client.FieldKind_BOOL_ARRAY, client.FieldKind_NILLABLE_BOOL_ARRAY:
+		kind = client.FieldKind_BOOL
+	case client.FieldKind_INT_ARRAY, client.FieldKind_NILLABLE_INT_ARRAY:
+		kind = client.FieldKind_INT
+	case client.FieldKind_FLOAT_ARRAY, client.FieldKind_NILLABLE_FLOAT_ARRAY:
+		kind = client.FieldKind_FLOAT
+	case client.FieldKind_STRING_ARRAY, client.FieldKind_NILLABLE_STRING_ARRAY:
+		kind = client.FieldKind_STRING
+	case client.FieldKind_JSON:
+		// JSON fields are indexed by the scalar value at a path, which is encoded by its type.
+		// Numbers are all encoded as floats so that the same number is the same value whichever
+		// way it is written.
+		switch val.(type) {
+		case bool:
+			kind = client.FieldKind_BOOL
+		case string:
+			kind = client.FieldKind_STRING
+		default:
+			kind = client.FieldKind_FLOAT
+		}
+	}
+
+	switch kind {
+	case client.FieldKind_STRING, client.FieldKind_FOREIGN_OBJECT, client.FieldKind_BLOB, client.FieldKind_DocKey:
+		strVal, ok := val.(string)
+		if !ok {
+			return nil, NewErrInvalidIndexFieldValue(kind, val)
+		}
+		return encodeIndexString([]byte{indexStringTag}, strVal), nil
+
+	case client.FieldKind_INT:
+		intVal, ok := toIndexInt(val)
+		if !ok {
+			return nil, NewErrInvalidIndexFieldValue(kind, val)
+		}
+		return binary.BigEndian.AppendUint64([]byte{indexIntTag}, uint64(intVal)^(1<<63)), nil
+
+	case client.FieldKind_FLOAT:
+		floatVal, ok := toIndexFloat(val)
+		if !ok {
+			return nil, NewErrInvalidIndexFieldValue(kind, val)
+		}
+		if floatVal == 0 {
+			// -0 and 0 are equal values, so they must have the same representation
+			floatVal = 0
+		}
+		bits := math.Float64bits(floatVal)
+		if floatVal < 0 {
+			bits = ^bits
+		} else {
+			bits |= 1 << 63
+		}
+		return binary.BigEndian.AppendUint64([]byte{indexFloatTag}, bits), nil
+
+	case client.FieldKind_BOOL:
+		boolVal, ok := val.(bool)
+		if !ok {
+			return nil, NewErrInvalidIndexFieldValue(kind, val)
+		}
+		if boolVal {
+			return []byte{indexTrueTag}, nil
+		}
+		return []byte{indexFalseTag}, nil
+
+	case client.FieldKind_DATETIME:
+		var timeVal time.Time
+		switch v := val.(type) {
+		case time.Time:
+			timeVal = v
+		case string:
+			var err error
+			timeVal, err = time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, NewErrInvalidIndexFieldValue(kind, val)
+			}
+		default:
+			return nil, NewErrInvalidIndexFieldValue(kind, val)
+		}
+		// only the point in time is stored, which makes values with different
+		// time zones comparable
+		return binary.BigEndian.AppendUint64([]byte{indexTimeTag}, uint64(timeVal.UnixNano())^(1<<63)), nil
+	}
+
+	return nil, NewErrInvalidIndexFieldValue(kind, val)
+}
+
+func encodeIndexString(b []byte, val string) []byte {
+	for i := 0; i < len(val); i++ {
+		if val[i] == indexStringEscape {
+			b = append(b, indexStringEscape, indexStringEscaped00)
+		} else {
+			b = append(b, val[i])
+		}
+	}
+	return append(b, indexStringEscape, indexStringTerminator)
+}
+
+func toIndexInt(val any) (int64, bool) {
+	switch v := val.(type) {
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case float64:
+		if v != math.Trunc(v) || v > math.MaxInt64 || v < math.MinInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	}
+	return 0, false
+}
+
+func toIndexFloat(val any) (float64, bool) {
+	switch v := val.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// DecodeIndexFieldValue decodes a value that was encoded with EncodeIndexFieldValue.
+//
+// DateTime values are returned as time.Time in UTC, as the original time zone is not stored.
+func DecodeIndexFieldValue(data []byte, descending bool) (any, error) {
+	b := make([]byte, hex.DecodedLen(len(data)))
+	_, err := hex.Decode(b, data)
+	if err != nil {
+		return nil, NewErrInvalidIndexFieldEncoding(data)
+	}
+	if len(b) == 0 {
+		return nil, NewErrInvalidIndexFieldEncoding(data)
+	}
+	if descending {
+		for i := range b {
+			b[i] = ^b[i]
+		}
+	}
+
+	tag, b := b[0], b[1:]
+	switch tag {
+	case indexNullTag:
+		return nil, nil
+	case indexFalseTag:
+		return false, nil
+	case indexTrueTag:
+		return true, nil
+	case indexIntTag, indexFloatTag, indexTimeTag:
+		if len(b) != 8 {
+			return nil, NewErrInvalidIndexFieldEncoding(data)
+		}
+		bits := binary.BigEndian.Uint64(b)
+		switch tag {
+		case indexIntTag:
+			return int64(bits ^ (1 << 63)), nil
+		case indexTimeTag:
+			return time.Unix(0, int64(bits^(1<<63))).UTC(), nil
+		}
+		if bits&(1<<63) != 0 {
+			bits &^= 1 << 63
+		} else {
+			bits = ^bits
+		}
+		return math.Float64frombits(bits), nil
+	case indexStringTag:
+		str := make([]byte, 0, len(b))
+		for i := 0; i < len(b); i++ {
+			if b[i] != indexStringEscape {
+				str = append(str, b[i])
+				continue
+			}
+			if i+1 >= len(b) {
+				return nil, NewErrInvalidIndexFieldEncoding(data)
+			}
+			i++
+			switch b[i] {
+			case indexStringTerminator:
+				if i != len(b)-1 {
+					return nil, NewErrInvalidIndexFieldEncoding(data)
+				}
+				return string(str), nil
+			case indexStringEscaped00:
+				str = append(str, indexStringEscape)
+			default:
+				return nil, NewErrInvalidIndexFieldEncoding(data)
+			}
+		}
+	}
+	return nil, NewErrInvalidIndexFieldEncoding(data)
+}
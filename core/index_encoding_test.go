@@ -0,0 +1,174 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package core
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func TestEncodeIndexFieldValue_ShouldPreserveOrder(t *testing.T) {
+	testCases := []struct {
+		name string
+		kind client.FieldKind
+		// values are in ascending order
+		values []any
+	}{
+		{
+			name:   "int",
+			kind:   client.FieldKind_INT,
+			values: []any{nil, int64(math.MinInt64), int64(-300), int64(-1), int64(0), int64(1), int64(256), int64(math.MaxInt64)},
+		},
+		{
+			name:   "float",
+			kind:   client.FieldKind_FLOAT,
+			values: []any{nil, math.Inf(-1), -100.5, -0.25, 0.0, 0.25, 1.0, 100.5, math.Inf(1)},
+		},
+		{
+			name:   "string",
+			kind:   client.FieldKind_STRING,
+			values: []any{nil, "", "\x00", "\x00a", "a", "a\x00", "ab", "abc", "b"},
+		},
+		{
+			name:   "bool",
+			kind:   client.FieldKind_BOOL,
+			values: []any{nil, false, true},
+		},
+		{
+			name: "datetime",
+			kind: client.FieldKind_DATETIME,
+			values: []any{
+				nil,
+				"1969-12-31T23:59:59Z",
+				"2017-07-23T03:46:56+02:00",
+				"2017-07-23T02:46:57Z",
+				"2017-07-23T03:46:56-05:00",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, descending := range []bool{false, true} {
+			encoded := make([][]byte, 0, len(tc.values))
+			for _, val := range tc.values {
+				b, err := EncodeIndexFieldValue(tc.kind, val, descending)
+				require.NoError(t, err, tc.name)
+				assert.NotContains(t, string(b), "/", tc.name)
+				encoded = append(encoded, b)
+			}
+			for i := 1; i < len(encoded); i++ {
+				cmp := bytes.Compare(encoded[i-1], encoded[i])
+				if descending {
+					assert.Equal(t, 1, cmp, "%s: %v should sort after %v", tc.name, tc.values[i-1], tc.values[i])
+				} else {
+					assert.Equal(t, -1, cmp, "%s: %v should sort before %v", tc.name, tc.values[i-1], tc.values[i])
+				}
+			}
+		}
+	}
+}
+
+func TestEncodeIndexFieldValue_ShouldNotBePrefixOfAnotherValue(t *testing.T) {
+	a, err := EncodeIndexFieldValue(client.FieldKind_STRING, "ab", true)
+	require.NoError(t, err)
+	b, err := EncodeIndexFieldValue(client.FieldKind_STRING, "abc", true)
+	require.NoError(t, err)
+
+	assert.False(t, strings.HasPrefix(string(b), string(a)))
+}
+
+func TestEncodeIndexFieldValue_WithNumbersOfOtherTypes_ShouldConvert(t *testing.T) {
+	fromInt, err := EncodeIndexFieldValue(client.FieldKind_FLOAT, int64(3), false)
+	require.NoError(t, err)
+	fromFloat, err := EncodeIndexFieldValue(client.FieldKind_FLOAT, 3.0, false)
+	require.NoError(t, err)
+	assert.Equal(t, fromFloat, fromInt)
+
+	fromFloat, err = EncodeIndexFieldValue(client.FieldKind_INT, 3.0, false)
+	require.NoError(t, err)
+	fromInt, err = EncodeIndexFieldValue(client.FieldKind_INT, 3, false)
+	require.NoError(t, err)
+	assert.Equal(t, fromInt, fromFloat)
+
+	_, err = EncodeIndexFieldValue(client.FieldKind_INT, 3.5, false)
+	assert.ErrorIs(t, err, ErrInvalidIndexFieldValue)
+}
+
+func TestEncodeIndexFieldValue_WithArrayKind_ShouldEncodeAsElementKind(t *testing.T) {
+	fromArrayKind, err := EncodeIndexFieldValue(client.FieldKind_NILLABLE_STRING_ARRAY, "abc", false)
+	require.NoError(t, err)
+	fromElementKind, err := EncodeIndexFieldValue(client.FieldKind_STRING, "abc", false)
+	require.NoError(t, err)
+	assert.Equal(t, fromElementKind, fromArrayKind)
+
+	fromArrayKind, err = EncodeIndexFieldValue(client.FieldKind_INT_ARRAY, int64(3), true)
+	require.NoError(t, err)
+	fromElementKind, err = EncodeIndexFieldValue(client.FieldKind_INT, int64(3), true)
+	require.NoError(t, err)
+	assert.Equal(t, fromElementKind, fromArrayKind)
+}
+
+func TestEncodeIndexFieldValue_WithInvalidValue_ReturnError(t *testing.T) {
+	_, err := EncodeIndexFieldValue(client.FieldKind_BOOL, "true", false)
+	assert.ErrorIs(t, err, ErrInvalidIndexFieldValue)
+
+	_, err = EncodeIndexFieldValue(client.FieldKind_DATETIME, "not a date", false)
+	assert.ErrorIs(t, err, ErrInvalidIndexFieldValue)
+}
+
+func TestDecodeIndexFieldValue_ShouldReturnEncodedValue(t *testing.T) {
+	dateTime := time.Date(2017, 7, 23, 3, 46, 56, 0, time.UTC)
+	testCases := []struct {
+		kind client.FieldKind
+		val  any
+	}{
+		{kind: client.FieldKind_INT, val: nil},
+		{kind: client.FieldKind_INT, val: int64(-42)},
+		{kind: client.FieldKind_INT, val: int64(42)},
+		{kind: client.FieldKind_FLOAT, val: -3.25},
+		{kind: client.FieldKind_FLOAT, val: 3.25},
+		{kind: client.FieldKind_STRING, val: ""},
+		{kind: client.FieldKind_STRING, val: "with \x00 zero byte"},
+		{kind: client.FieldKind_BOOL, val: true},
+		{kind: client.FieldKind_BOOL, val: false},
+		{kind: client.FieldKind_DATETIME, val: dateTime},
+	}
+
+	for _, tc := range testCases {
+		for _, descending := range []bool{false, true} {
+			b, err := EncodeIndexFieldValue(tc.kind, tc.val, descending)
+			require.NoError(t, err)
+
+			decoded, err := DecodeIndexFieldValue(b, descending)
+			require.NoError(t, err)
+			assert.Equal(t, tc.val, decoded)
+		}
+	}
+}
+
+func TestDecodeIndexFieldValue_WithInvalidData_ReturnError(t *testing.T) {
+	_, err := DecodeIndexFieldValue([]byte("not hex"), false)
+	assert.ErrorIs(t, err, ErrInvalidIndexFieldEncoding)
+
+	_, err = DecodeIndexFieldValue([]byte("2001"), false)
+	assert.ErrorIs(t, err, ErrInvalidIndexFieldEncoding)
+
+	_, err = DecodeIndexFieldValue([]byte("406162"), false)
+	assert.ErrorIs(t, err, ErrInvalidIndexFieldEncoding)
+}
@@ -39,6 +39,8 @@ const (
 	PriorityKey = InstanceType("p")
 	// DeletedKey is a type that represents a deleted document.
 	DeletedKey = InstanceType("d")
+	// CRDTStateKey is a type that represents the internal state of a CRDT, besides its value.
+	CRDTStateKey = InstanceType("s")
 )
 
 const (
@@ -46,6 +48,7 @@ const (
 	COLLECTION_NAME                = "/collection/name"
 	COLLECTION_SCHEMA_VERSION      = "/collection/version"
 	COLLECTION_INDEX               = "/collection/index"
+	INDEX_BUILD                    = "/index/build"
 	SCHEMA_MIGRATION               = "/schema/migration"
 	SCHEMA_VERSION                 = "/schema/version/v"
 	SCHEMA_VERSION_HISTORY         = "/schema/version/h"
@@ -53,6 +56,8 @@ const (
 	PRIMARY_KEY                    = "/pk"
 	DATASTORE_DOC_VERSION_FIELD_ID = "v"
 	REPLICATOR                     = "/replicator/id"
+	REPLICATOR_OUTBOX              = "/replicator/outbox"
+	REPLICATOR_DOC                 = "/replicator/doc"
 	P2P_COLLECTION                 = "/p2p/collection"
 )
 
@@ -140,6 +145,16 @@ type CollectionIndexKey struct {
 
 var _ Key = (*CollectionIndexKey)(nil)
 
+// IndexBuildKey points to the progress of the background build of an index.
+type IndexBuildKey struct {
+	// CollectionName is the name of the collection that the index is on
+	CollectionName string
+	// IndexName is the name of the index
+	IndexName string
+}
+
+var _ Key = (*IndexBuildKey)(nil)
+
 // SchemaVersionKey points to the json serialized schema at the specified version.
 //
 // It's corresponding value is immutable.
@@ -188,6 +203,32 @@ type ReplicatorKey struct {
 
 var _ Key = (*ReplicatorKey)(nil)
 
+// ReplicatorOutboxKey points to a document update that failed to be pushed to a replicator
+// and is queued to be retried.
+//
+// Its value is the schema root of the document.
+type ReplicatorOutboxKey struct {
+	ReplicatorID string
+	// Seq orders the updates queued for a replicator, it is greater than the Seq of all the
+	// updates queued before it.
+	Seq    uint64
+	DocKey string
+	Cid    string
+}
+
+var _ Key = (*ReplicatorOutboxKey)(nil)
+
+// ReplicatorDocKey points to a document that has been pushed to a replicator because it
+// matched the filter of the replicator.
+//
+// Its value is the schema root of the document.
+type ReplicatorDocKey struct {
+	ReplicatorID string
+	DocKey       string
+}
+
+var _ Key = (*ReplicatorDocKey)(nil)
+
 // Creates a new DataStoreKey from a string as best as it can,
 // splitting the input using '/' as a field deliminator.  It assumes
 // that the input string is in the following format:
@@ -340,6 +381,38 @@ func (k CollectionIndexKey) ToDS() ds.Key {
 	return ds.NewKey(k.ToString())
 }
 
+// NewIndexBuildKey creates a new IndexBuildKey from a collection name and index name.
+func NewIndexBuildKey(colName, indexName string) IndexBuildKey {
+	return IndexBuildKey{CollectionName: colName, IndexName: indexName}
+}
+
+// ToString returns the string representation of the key
+// It is in the following format:
+// /index/build/[CollectionName]/[IndexName]
+// if [CollectionName] is empty, the rest is ignored
+func (k IndexBuildKey) ToString() string {
+	result := INDEX_BUILD
+
+	if k.CollectionName != "" {
+		result = result + "/" + k.CollectionName
+		if k.IndexName != "" {
+			result = result + "/" + k.IndexName
+		}
+	}
+
+	return result
+}
+
+// Bytes returns the byte representation of the key
+func (k IndexBuildKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+// ToDS returns the datastore key
+func (k IndexBuildKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
 func NewSchemaVersionKey(schemaVersionID string) SchemaVersionKey {
 	return SchemaVersionKey{SchemaVersionID: schemaVersionID}
 }
@@ -390,6 +463,12 @@ func (k DataStoreKey) WithDeletedFlag() DataStoreKey {
 	return newKey
 }
 
+func (k DataStoreKey) WithCRDTStateFlag() DataStoreKey {
+	newKey := k
+	newKey.InstanceType = CRDTStateKey
+	return newKey
+}
+
 func (k DataStoreKey) WithDocKey(docKey string) DataStoreKey {
 	newKey := k
 	newKey.DocKey = docKey
@@ -783,6 +862,95 @@ func (k ReplicatorKey) ToDS() ds.Key {
 	return ds.NewKey(k.ToString())
 }
 
+// NewReplicatorOutboxKey creates a new ReplicatorOutboxKey from a replicator id, sequence number,
+// dockey and cid.
+func NewReplicatorOutboxKey(replicatorID string, seq uint64, docKey, cid string) ReplicatorOutboxKey {
+	return ReplicatorOutboxKey{ReplicatorID: replicatorID, Seq: seq, DocKey: docKey, Cid: cid}
+}
+
+// NewReplicatorOutboxKeyFromString creates a new ReplicatorOutboxKey from a string.
+// It expects the input string is in the following format:
+//
+// /replicator/outbox/[ReplicatorID]/[Seq]/[DocKey]/[Cid]
+//
+// Anything else will return an error.
+func NewReplicatorOutboxKeyFromString(key string) (ReplicatorOutboxKey, error) {
+	keyArr := strings.Split(key, "/")
+	if len(keyArr) != 7 || keyArr[1] != "replicator" || keyArr[2] != "outbox" {
+		return ReplicatorOutboxKey{}, errors.WithStack(ErrInvalidKey, errors.NewKV("Key", key))
+	}
+	seq, err := strconv.ParseUint(keyArr[4], 10, 64)
+	if err != nil {
+		return ReplicatorOutboxKey{}, errors.WithStack(ErrInvalidKey, errors.NewKV("Key", key))
+	}
+	return NewReplicatorOutboxKey(keyArr[3], seq, keyArr[5], keyArr[6]), nil
+}
+
+// ToString returns the string representation of the key
+// It is in the following format:
+// /replicator/outbox/[ReplicatorID]/[Seq]/[DocKey]/[Cid]
+// if [ReplicatorID] is empty, the rest is ignored, as is the [Seq] if [DocKey] is empty.
+//
+// The [Seq] is zero padded so that the keys of a replicator are sorted in the order the
+// updates have been queued in.
+func (k ReplicatorOutboxKey) ToString() string {
+	result := REPLICATOR_OUTBOX
+
+	if k.ReplicatorID != "" {
+		result = result + "/" + k.ReplicatorID
+		if k.DocKey != "" {
+			result = result + "/" + fmt.Sprintf("%020d", k.Seq) + "/" + k.DocKey
+			if k.Cid != "" {
+				result = result + "/" + k.Cid
+			}
+		}
+	}
+
+	return result
+}
+
+// Bytes returns the byte representation of the key
+func (k ReplicatorOutboxKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+// ToDS returns the datastore key
+func (k ReplicatorOutboxKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
+// NewReplicatorDocKey creates a new ReplicatorDocKey from a replicator id and dockey.
+func NewReplicatorDocKey(replicatorID string, docKey string) ReplicatorDocKey {
+	return ReplicatorDocKey{ReplicatorID: replicatorID, DocKey: docKey}
+}
+
+// ToString returns the string representation of the key
+// It is in the following format:
+// /replicator/doc/[ReplicatorID]/[DocKey]
+// if [ReplicatorID] is empty, the [DocKey] is ignored.
+func (k ReplicatorDocKey) ToString() string {
+	result := REPLICATOR_DOC
+
+	if k.ReplicatorID != "" {
+		result = result + "/" + k.ReplicatorID
+		if k.DocKey != "" {
+			result = result + "/" + k.DocKey
+		}
+	}
+
+	return result
+}
+
+// Bytes returns the byte representation of the key
+func (k ReplicatorDocKey) Bytes() []byte {
+	return []byte(k.ToString())
+}
+
+// ToDS returns the datastore key
+func (k ReplicatorDocKey) ToDS() ds.Key {
+	return ds.NewKey(k.ToString())
+}
+
 func (k HeadStoreKey) ToString() string {
 	var result string
 
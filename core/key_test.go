@@ -318,6 +318,55 @@ func TestCollectionIndexKey_Bytes(t *testing.T) {
 	assert.Equal(t, []byte(COLLECTION_INDEX+"/col/idx"), key.Bytes())
 }
 
+func TestIndexBuildKey_Bytes(t *testing.T) {
+	key := NewIndexBuildKey("col", "idx")
+	assert.Equal(t, []byte(INDEX_BUILD+"/col/idx"), key.Bytes())
+}
+
+func TestReplicatorOutboxKey_ToString(t *testing.T) {
+	assert.Equal(t, REPLICATOR_OUTBOX, NewReplicatorOutboxKey("", 1, "doc", "cid").ToString())
+	assert.Equal(t, REPLICATOR_OUTBOX+"/rep", NewReplicatorOutboxKey("rep", 0, "", "").ToString())
+	assert.Equal(
+		t,
+		REPLICATOR_OUTBOX+"/rep/00000000000000000012/doc/cid",
+		NewReplicatorOutboxKey("rep", 12, "doc", "cid").ToString(),
+	)
+}
+
+func TestReplicatorOutboxKey_ShouldSortBySeq(t *testing.T) {
+	first := NewReplicatorOutboxKey("rep", 9, "doc", "z").ToString()
+	second := NewReplicatorOutboxKey("rep", 10, "doc", "a").ToString()
+	assert.Less(t, first, second)
+}
+
+func TestNewReplicatorOutboxKeyFromString_IfFullKeyString_ReturnKey(t *testing.T) {
+	key, err := NewReplicatorOutboxKeyFromString(REPLICATOR_OUTBOX + "/rep/00000000000000000012/doc/cid")
+	assert.NoError(t, err)
+	assert.Equal(t, NewReplicatorOutboxKey("rep", 12, "doc", "cid"), key)
+}
+
+func TestNewReplicatorOutboxKeyFromString_IfInvalidString_ReturnError(t *testing.T) {
+	cases := []string{
+		"",
+		REPLICATOR_OUTBOX + "/rep",
+		REPLICATOR_OUTBOX + "/rep/1/doc",
+		REPLICATOR_OUTBOX + "/rep/doc/cid",
+		REPLICATOR_OUTBOX + "/rep/seq/doc/cid",
+		"/replicator/id/rep/1/doc/cid",
+		REPLICATOR_OUTBOX + "/rep/1/doc/cid/extra",
+	}
+	for i, c := range cases {
+		_, err := NewReplicatorOutboxKeyFromString(c)
+		assert.ErrorIs(t, err, ErrInvalidKey, "case %d", i)
+	}
+}
+
+func TestReplicatorDocKey_ToString(t *testing.T) {
+	assert.Equal(t, REPLICATOR_DOC, NewReplicatorDocKey("", "doc").ToString())
+	assert.Equal(t, REPLICATOR_DOC+"/rep", NewReplicatorDocKey("rep", "").ToString())
+	assert.Equal(t, REPLICATOR_DOC+"/rep/doc", NewReplicatorDocKey("rep", "doc").ToString())
+}
+
 func TestIndexDatastoreKey_EqualFalse(t *testing.T) {
 	cases := [][]IndexDataStoreKey{
 		{
@@ -42,7 +42,8 @@ func MakePrimaryIndexKeyForCRDT(
 	switch ctype {
 	case client.COMPOSITE:
 		return MakeCollectionKey(c).WithInstanceInfo(key).WithFieldId(core.COMPOSITE_NAMESPACE), nil
-	case client.LWW_REGISTER:
+	case client.LWW_REGISTER, client.PN_COUNTER, client.P_COUNTER, client.OR_SET, client.RGA,
+		client.LWW_HLC_REGISTER, client.MV_REGISTER:
 		field, ok := c.GetFieldByName(fieldName, &schema)
 		if !ok {
 			return core.DataStoreKey{}, client.NewErrFieldNotExist(fieldName)
@@ -27,6 +27,7 @@ import (
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/core/crdt"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/db/base"
 	"github.com/sourcenetwork/defradb/db/description"
@@ -56,6 +57,10 @@ type collection struct {
 
 	indexes        []CollectionIndex
 	fetcherFactory func() fetcher.Fetcher
+
+	// relationsOnDelete caches the relation fields with an onDelete option that reference
+	// the documents of the collection, it is nil until they are first needed.
+	relationsOnDelete []relationOnDelete
 }
 
 // @todo: Move the base Descriptions to an internal API within the db/ package.
@@ -105,6 +110,12 @@ func (db *db) createCollection(
 		return nil, ErrCollectionAlreadyExists
 	}
 
+	for _, field := range schema.Fields {
+		if err := validateFieldCRDTType(field); err != nil {
+			return nil, err
+		}
+	}
+
 	colSeq, err := db.getSequence(ctx, txn, core.COLLECTION)
 	if err != nil {
 		return nil, err
@@ -437,8 +448,12 @@ func validateUpdateSchemaFields(
 			return false, NewErrCannotMoveField(proposedField.Name, proposedIndex, existingIndex)
 		}
 
-		if proposedField.Typ != client.NONE_CRDT && proposedField.Typ != client.LWW_REGISTER {
-			return false, NewErrInvalidCRDTType(proposedField.Name, proposedField.Typ)
+		if err := validateFieldCRDTType(proposedField); err != nil {
+			return false, err
+		}
+
+		if err := validateFieldOnDelete(proposedField); err != nil {
+			return false, err
 		}
 
 		newFieldNames[proposedField.Name] = struct{}{}
@@ -453,6 +468,33 @@ func validateUpdateSchemaFields(
 	return hasChanged, nil
 }
 
+// validateFieldOnDelete checks that the onDelete option of the given field is valid, and is only set
+// on the primary side of a relation.
+func validateFieldOnDelete(field client.FieldDescription) error {
+	switch field.OnDelete {
+	case "":
+		return nil
+	case client.OnDeleteCascade, client.OnDeleteSetNull, client.OnDeleteRestrict:
+	default:
+		return NewErrInvalidRelationOnDelete(field.Name, field.OnDelete)
+	}
+	if field.Kind != client.FieldKind_FOREIGN_OBJECT || !field.IsPrimaryRelation() {
+		return NewErrOnDeleteNotPrimary(field.Name)
+	}
+	return nil
+}
+
+// validateFieldCRDTType checks that the CRDT type of the given field can be assigned to it.
+func validateFieldCRDTType(field client.FieldDescription) error {
+	if !field.Typ.IsSupportedFieldCType() {
+		return NewErrInvalidCRDTType(field.Name, field.Typ)
+	}
+	if !field.Typ.IsCompatibleWith(field.Kind) {
+		return NewErrCRDTKindMismatch(field.Name, field.Typ, field.Kind)
+	}
+	return nil
+}
+
 func (db *db) setDefaultSchemaVersion(
 	ctx context.Context,
 	txn datastore.Txn,
@@ -721,6 +763,11 @@ func (c *collection) ID() uint32 {
 	return c.Description().ID
 }
 
+// HLC returns the hybrid logical clock of the database the collection belongs to.
+func (c *collection) HLC() *crdt.HLC {
+	return c.db.hlc
+}
+
 func (c *collection) SchemaRoot() string {
 	return c.Schema().Root
 }
@@ -907,12 +954,46 @@ func (c *collection) Save(ctx context.Context, doc *client.Document) error {
 	return c.commitImplicitTxn(ctx, txn)
 }
 
+// setJSONFieldObjects replaces the sub documents of the JSON fields of the given document
+// by the objects they have been parsed from, as JSON fields hold structured values.
+func (c *collection) setJSONFieldObjects(doc *client.Document) error {
+	for _, field := range c.Schema().Fields {
+		if field.Kind != client.FieldKind_JSON {
+			continue
+		}
+		val, err := doc.GetValue(field.Name)
+		if err != nil {
+			if errors.Is(err, client.ErrFieldNotExist) {
+				continue
+			}
+			return err
+		}
+		subDoc, isSubDoc := val.Value().(*client.Document)
+		if !isSubDoc || !val.IsDirty() {
+			continue
+		}
+		obj, err := subDoc.ToObject()
+		if err != nil {
+			return err
+		}
+		err = doc.SetAs(field.Name, obj, client.LWW_REGISTER)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *collection) save(
 	ctx context.Context,
 	txn datastore.Txn,
 	doc *client.Document,
 	isCreate bool,
 ) (cid.Cid, error) {
+	err := c.setJSONFieldObjects(doc)
+	if err != nil {
+		return cid.Undef, err
+	}
 	if !isCreate {
 		err := c.updateIndexedDoc(ctx, txn, doc)
 		if err != nil {
@@ -956,6 +1037,10 @@ func (c *collection) save(
 
 			relationFieldDescription, isSecondaryRelationID := c.isSecondaryIDField(fieldDescription)
 			if isSecondaryRelationID {
+				if val.Value() == nil {
+					// The document is not linked to a primary document, so there is nothing to patch
+					continue
+				}
 				primaryId := val.Value().(string)
 
 				err = c.patchPrimaryDoc(ctx, txn, c.Name(), relationFieldDescription, primaryKey.DocKey, primaryId)
@@ -1136,7 +1221,11 @@ func (c *collection) Delete(ctx context.Context, key client.DocKey) (bool, error
 		return false, NewErrDocumentDeleted(primaryKey.DocKey)
 	}
 
-	err = c.applyDelete(ctx, txn, primaryKey)
+	relations, err := c.getRelationsOnDelete(ctx, txn)
+	if err != nil {
+		return false, err
+	}
+	err = c.applyDelete(ctx, txn, primaryKey, relations)
 	if err != nil {
 		return false, err
 	}
@@ -1213,6 +1302,83 @@ func (c *collection) saveFieldToMerkleCRDT(
 			return nil, 0, client.NewErrFieldIndexNotExist(fieldID)
 		}
 
+		if field.Typ.IsCounter() {
+			// the value written to a counter field is the increment of its current value
+			counter := merklecrdt.NewMerkleCounter(
+				txn,
+				core.NewCollectionSchemaVersionKey(schema.VersionID, c.ID()),
+				key,
+				field.Name,
+				field.Typ == client.PN_COUNTER,
+			)
+			return counter.Increment(ctx, bytes)
+		}
+
+		patch, isSetPatch := val.Value().(client.SetPatch)
+		if isSetPatch && field.Typ != client.OR_SET {
+			return nil, 0, NewErrSetOperatorOnNonSetField(field.Name)
+		}
+
+		if field.Typ == client.OR_SET {
+			set := merklecrdt.NewMerkleORSet(
+				txn,
+				core.NewCollectionSchemaVersionKey(schema.VersionID, c.ID()),
+				key,
+				field.Name,
+			)
+			if !isSetPatch {
+				return set.Set(ctx, bytes)
+			}
+			added, err := cbor.Marshal(patch.Add)
+			if err != nil {
+				return nil, 0, err
+			}
+			removed, err := cbor.Marshal(patch.Remove)
+			if err != nil {
+				return nil, 0, err
+			}
+			return set.Patch(ctx, added, removed)
+		}
+
+		textPatch, isTextPatch := val.Value().(client.TextPatch)
+		if isTextPatch && field.Typ != client.RGA {
+			return nil, 0, NewErrTextOperatorOnNonTextField(field.Name)
+		}
+
+		if field.Typ == client.RGA {
+			text := merklecrdt.NewMerkleRGA(
+				txn,
+				core.NewCollectionSchemaVersionKey(schema.VersionID, c.ID()),
+				key,
+				field.Name,
+			)
+			if !isTextPatch {
+				return text.Set(ctx, bytes)
+			}
+			return text.Patch(ctx, textPatch)
+		}
+
+		if field.Typ == client.MV_REGISTER {
+			merkleCRDT := merklecrdt.NewMerkleMVRegister(
+				txn,
+				core.NewCollectionSchemaVersionKey(schema.VersionID, c.ID()),
+				key,
+				field.Name,
+			)
+			return merkleCRDT.Set(ctx, bytes)
+		}
+
+		if field.Typ == client.LWW_HLC_REGISTER {
+			merkleCRDT := merklecrdt.NewMerkleLWWRegisterWithHLC(
+				txn,
+				core.NewCollectionSchemaVersionKey(schema.VersionID, c.ID()),
+				key,
+				field.Name,
+				c.db.hlc,
+			)
+			return merkleCRDT.Set(ctx, bytes)
+		}
+
 		merkleCRDT := merklecrdt.NewMerkleLWWRegister(
 			txn,
 			core.NewCollectionSchemaVersionKey(schema.VersionID, c.ID()),
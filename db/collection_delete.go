@@ -112,9 +112,14 @@ func (c *collection) deleteWithKey(
 	key core.PrimaryDataStoreKey,
 	status client.DocumentStatus,
 ) (*client.DeleteResult, error) {
+	relations, err := c.getRelationsOnDelete(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check the docKey we have been given to delete with actually has a corresponding
 	//  document (i.e. document actually exists in the collection).
-	err := c.applyDelete(ctx, txn, key)
+	err = c.applyDelete(ctx, txn, key, relations)
 	if err != nil {
 		return nil, err
 	}
@@ -134,6 +139,11 @@ func (c *collection) deleteWithKeys(
 	keys []client.DocKey,
 	status client.DocumentStatus,
 ) (*client.DeleteResult, error) {
+	relations, err := c.getRelationsOnDelete(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+
 	results := &client.DeleteResult{
 		DocKeys: make([]string, 0),
 	}
@@ -142,7 +152,7 @@ func (c *collection) deleteWithKeys(
 		dsKey := c.getPrimaryKeyFromDocKey(key)
 
 		// Apply the function that will perform the full deletion of this document.
-		err := c.applyDelete(ctx, txn, dsKey)
+		err := c.applyDelete(ctx, txn, dsKey, relations)
 		if err != nil {
 			return nil, err
 		}
@@ -185,6 +195,11 @@ func (c *collection) deleteWithFilter(
 		}
 	}()
 
+	relations, err := c.getRelationsOnDelete(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+
 	results := &client.DeleteResult{
 		DocKeys: make([]string, 0),
 	}
@@ -212,7 +227,7 @@ func (c *collection) deleteWithFilter(
 		}
 
 		// Delete the document that is associated with this key we got from the filter.
-		err = c.applyDelete(ctx, txn, key)
+		err = c.applyDelete(ctx, txn, key, relations)
 		if err != nil {
 			return nil, err
 		}
@@ -226,10 +241,13 @@ func (c *collection) deleteWithFilter(
 	return results, nil
 }
 
+// applyDelete deletes the document with the given key, the given relations referencing the
+// documents of the collection are resolved once per delete operation by the caller.
 func (c *collection) applyDelete(
 	ctx context.Context,
 	txn datastore.Txn,
 	key core.PrimaryDataStoreKey,
+	relations []relationOnDelete,
 ) error {
 	found, isDeleted, err := c.exists(ctx, txn, key)
 	if err != nil {
@@ -242,6 +260,16 @@ func (c *collection) applyDelete(
 		return NewErrDocumentDeleted(key.DocKey)
 	}
 
+	err = checkDeleteRestrictions(ctx, txn, relations, key.DocKey)
+	if err != nil {
+		return err
+	}
+
+	err = c.deleteIndexedDocWithKey(ctx, txn, key)
+	if err != nil {
+		return err
+	}
+
 	dsKey := key.ToDataStoreKey()
 
 	headset := clock.NewHeadSet(
@@ -289,5 +317,131 @@ func (c *collection) applyDelete(
 		)
 	}
 
+	return applyOnDelete(ctx, txn, relations, key.DocKey)
+}
+
+// relationOnDelete is a relation field referencing the documents of a collection, along with
+// the collection holding it.
+type relationOnDelete struct {
+	collection *collection
+	field      client.FieldDescription
+}
+
+// getRelationsOnDelete returns the relation fields with an onDelete option that reference
+// the documents of this collection.
+//
+// They are cached on the collection as a relation is defined on both of its sides, so the
+// relations referencing the collection can not change without its description changing.
+func (c *collection) getRelationsOnDelete(
+	ctx context.Context,
+	txn datastore.Txn,
+) ([]relationOnDelete, error) {
+	if c.relationsOnDelete != nil {
+		return c.relationsOnDelete, nil
+	}
+	cols, err := c.db.getAllCollections(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+
+	relations := []relationOnDelete{}
+	for _, col := range cols {
+		for _, field := range col.Schema().Fields {
+			if field.OnDelete == "" || field.Schema != c.Schema().Name || !field.IsPrimaryRelation() {
+				continue
+			}
+			relations = append(relations, relationOnDelete{
+				collection: col.(*collection),
+				field:      field,
+			})
+		}
+	}
+	c.relationsOnDelete = relations
+	return relations, nil
+}
+
+// referencingFilter returns a filter matching the documents referencing the given document
+// through the relation.
+func (r relationOnDelete) referencingFilter(docKey string) string {
+	return fmt.Sprintf("{%s: {_eq: %q}}", r.field.Name+request.RelatedObjectID, docKey)
+}
+
+// checkDeleteRestrictions returns an error if the document with the given key is referenced
+// through a relation with the RESTRICT onDelete option.
+func checkDeleteRestrictions(
+	ctx context.Context,
+	txn datastore.Txn,
+	relations []relationOnDelete,
+	docKey string,
+) error {
+	for _, relation := range relations {
+		if relation.field.OnDelete != client.OnDeleteRestrict {
+			continue
+		}
+		isReferenced, err := relation.collection.hasMatchingDoc(ctx, txn, relation.referencingFilter(docKey))
+		if err != nil {
+			return err
+		}
+		if isReferenced {
+			return NewErrDeleteRestricted(docKey, relation.collection.Name(), relation.field.Name)
+		}
+	}
 	return nil
 }
+
+// applyOnDelete applies the CASCADE and SET_NULL onDelete options of the relations referencing
+// the deleted document with the given key to the referencing documents.
+//
+// The referencing documents are deleted or updated within the same transaction, creating
+// the DAG updates that the peers need to converge on the same state.
+func applyOnDelete(
+	ctx context.Context,
+	txn datastore.Txn,
+	relations []relationOnDelete,
+	docKey string,
+) error {
+	var err error
+	for _, relation := range relations {
+		filter := relation.referencingFilter(docKey)
+		switch relation.field.OnDelete {
+		case client.OnDeleteCascade:
+			_, err = relation.collection.deleteWithFilter(ctx, txn, filter, client.Deleted)
+		case client.OnDeleteSetNull:
+			updater := fmt.Sprintf(`{%q: null}`, relation.field.Name+request.RelatedObjectID)
+			_, err = relation.collection.updateWithFilter(ctx, txn, filter, updater)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasMatchingDoc returns true if a document of the collection matches the given filter.
+func (c *collection) hasMatchingDoc(
+	ctx context.Context,
+	txn datastore.Txn,
+	filter string,
+) (bool, error) {
+	selectionPlan, err := c.makeSelectionPlan(ctx, txn, filter)
+	if err != nil {
+		return false, err
+	}
+
+	err = selectionPlan.Init()
+	if err != nil {
+		return false, err
+	}
+
+	if err := selectionPlan.Start(); err != nil {
+		return false, err
+	}
+
+	defer func() {
+		if err := selectionPlan.Close(); err != nil {
+			log.ErrorE(ctx, "Failed to close the request plan, after filter check", err)
+		}
+	}()
+
+	return selectionPlan.Next()
+}
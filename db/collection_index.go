@@ -11,17 +11,23 @@
 package db
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/db/base"
 	"github.com/sourcenetwork/defradb/db/fetcher"
+	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/request/graphql/schema"
 )
 
@@ -74,10 +80,12 @@ func (db *db) getAllIndexes(
 		if err != nil {
 			return nil, NewErrInvalidStoredIndexKey(indexKey.ToString())
 		}
-		indexes[indexKey.CollectionName] = append(
-			indexes[indexKey.CollectionName],
-			indexDescriptions[i],
-		)
+		desc := indexDescriptions[i]
+		desc.Status.BuildProgress, err = getIndexBuildProgress(ctx, txn, indexKey.CollectionName, desc.Name)
+		if err != nil {
+			return nil, err
+		}
+		indexes[indexKey.CollectionName] = append(indexes[indexKey.CollectionName], desc)
 	}
 
 	return indexes, nil
@@ -131,8 +139,111 @@ func (c *collection) updateIndexedDoc(
 	if err != nil {
 		return err
 	}
+	newDoc, err := c.applyFieldOperations(oldDoc, doc)
+	if err != nil {
+		return err
+	}
+	return c.updateDocIndex(ctx, txn, oldDoc, newDoc)
+}
+
+// applyFieldOperations returns a copy of the given document in which the values of the dirty
+// counter and text sequence fields are replaced by the values the fields will have once their
+// operations have been applied to the values of the old document.
+//
+// The values written to counter fields are increments and the values written to text sequence
+// fields can be text patches, so the indexes have to be updated with the resulting values instead.
+// The given document is returned as is if none of its dirty fields is such an indexed field.
+func (c *collection) applyFieldOperations(oldDoc, doc *client.Document) (*client.Document, error) {
+	var newDoc *client.Document
+	for _, field := range c.Schema().Fields {
+		if !field.Typ.IsCounter() && field.Typ != client.RGA {
+			continue
+		}
+		val, err := doc.GetValue(field.Name)
+		if err != nil || !val.IsDirty() {
+			continue
+		}
+		oldVal, err := oldDoc.Get(field.Name)
+		if err != nil {
+			// the old document holds only the indexed fields
+			continue
+		}
+		var newVal any
+		if field.Typ.IsCounter() {
+			newVal = addCounterValues(field.Kind, oldVal, val.Value())
+		} else {
+			patch, isTextPatch := val.Value().(client.TextPatch)
+			if !isTextPatch {
+				continue
+			}
+			oldText, _ := oldVal.(string)
+			newVal, err = patch.Apply(oldText)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if newDoc == nil {
+			newDoc = client.NewDocWithKey(doc.Key())
+			for name := range doc.Fields() {
+				fieldVal, err := doc.Get(name)
+				if err != nil {
+					return nil, err
+				}
+				if err := newDoc.Set(name, fieldVal); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := newDoc.Set(field.Name, newVal); err != nil {
+			return nil, err
+		}
+	}
+	if newDoc == nil {
+		return doc, nil
+	}
+	return newDoc, nil
+}
+
+// addCounterValues adds the given increment to the given value of a counter field of the given kind.
+func addCounterValues(kind client.FieldKind, val, increment any) any {
+	if kind == client.FieldKind_INT {
+		return toInt64(val) + toInt64(increment)
+	}
+	return toFloat64(val) + toFloat64(increment)
+}
+
+func toInt64(val any) int64 {
+	switch v := val.(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
+
+func toFloat64(val any) float64 {
+	switch v := val.(type) {
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+func (c *collection) updateDocIndex(
+	ctx context.Context,
+	txn datastore.Txn,
+	oldDoc *client.Document,
+	newDoc *client.Document,
+) error {
+	err := c.loadIndexes(ctx, txn)
+	if err != nil {
+		return err
+	}
 	for _, index := range c.indexes {
-		err = index.Update(ctx, txn, oldDoc, doc)
+		err = index.Update(ctx, txn, oldDoc, newDoc)
 		if err != nil {
 			return err
 		}
@@ -140,6 +251,118 @@ func (c *collection) updateIndexedDoc(
 	return nil
 }
 
+func (c *collection) deleteIndexedDoc(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+) error {
+	err := c.loadIndexes(ctx, txn)
+	if err != nil {
+		return err
+	}
+	for _, index := range c.indexes {
+		err = index.Delete(ctx, txn, doc)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *collection) deleteIndexedDocWithKey(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.PrimaryDataStoreKey,
+) error {
+	err := c.loadIndexes(ctx, txn)
+	if err != nil {
+		return err
+	}
+	if len(c.indexes) == 0 {
+		return nil
+	}
+	desc := c.Description()
+	schema := c.Schema()
+	doc, err := c.get(ctx, txn, key, desc.CollectIndexedFields(&schema), false)
+	if err != nil {
+		return err
+	}
+	return c.deleteIndexedDoc(ctx, txn, doc)
+}
+
+// CreateDocIndex adds the given document to all the indexes of the collection.
+//
+// It is meant for documents whose data was merged from a remote peer without going through
+// the collection. Such documents are indexed even if they violate a unique index,
+// as the merge can't be rejected without preventing the replicas from converging.
+func (c *collection) CreateDocIndex(ctx context.Context, doc *client.Document) error {
+	txn, err := c.getTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	err = c.loadIndexes(ctx, txn)
+	if err != nil {
+		return err
+	}
+	for _, index := range c.indexes {
+		if uniqueIndex, ok := index.(*collectionUniqueIndex); ok {
+			err = uniqueIndex.saveMerged(ctx, txn, doc)
+		} else {
+			err = index.Save(ctx, txn, doc)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return c.commitImplicitTxn(ctx, txn)
+}
+
+// UpdateDocIndex replaces the index entries of the old version of a document merged
+// from a remote peer with the entries of its new version in all the indexes of the collection.
+//
+// Like CreateDocIndex, it doesn't fail if the new version violates a unique index.
+func (c *collection) UpdateDocIndex(ctx context.Context, oldDoc, newDoc *client.Document) error {
+	txn, err := c.getTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	err = c.loadIndexes(ctx, txn)
+	if err != nil {
+		return err
+	}
+	for _, index := range c.indexes {
+		if uniqueIndex, ok := index.(*collectionUniqueIndex); ok {
+			err = uniqueIndex.updateMerged(ctx, txn, oldDoc, newDoc)
+		} else {
+			err = index.Update(ctx, txn, oldDoc, newDoc)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return c.commitImplicitTxn(ctx, txn)
+}
+
+// DeleteDocIndex removes the given document merged from a remote peer from all the indexes
+// of the collection.
+func (c *collection) DeleteDocIndex(ctx context.Context, doc *client.Document) error {
+	txn, err := c.getTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	err = c.deleteIndexedDoc(ctx, txn, doc)
+	if err != nil {
+		return err
+	}
+	return c.commitImplicitTxn(ctx, txn)
+}
+
 // CreateIndex creates a new index on the collection.
 //
 // If the index name is empty, a name will be automatically generated.
@@ -147,7 +370,8 @@ func (c *collection) updateIndexedDoc(
 // it will be validated with `schema.IsValidIndexName` method.
 //
 // The provided index description must include at least one field with
-// a name that exists in the collection schema.
+// a name that exists in the collection schema. If it has a filter, only
+// the documents matching the filter are indexed.
 // Also it's `ID` field must be zero. It will be assigned a unique
 // incremental value by the database.
 //
@@ -155,6 +379,8 @@ func (c *collection) updateIndexedDoc(
 //
 // Once finished, if there are existing documents in the collection,
 // the documents will be indexed by the new index.
+// If `IndexDescription.Background` is set, the existing documents are instead indexed
+// in the background once the transaction has been committed.
 func (c *collection) CreateIndex(
 	ctx context.Context,
 	desc client.IndexDescription,
@@ -204,6 +430,20 @@ func (c *collection) createIndex(
 		return nil, err
 	}
 	desc.ID = uint32(colID)
+	desc.EncodingVersion = core.IndexEncodingVersion
+	// only the building state is stored along with the description, the progress is stored apart
+	desc.Status.Building = desc.Background
+	if desc.Background {
+		totalDocs, err := c.countDocs(ctx, txn)
+		if err != nil {
+			return nil, err
+		}
+		progress := client.IndexBuildProgress{TotalDocs: totalDocs}
+		err = storeIndexBuildProgress(ctx, txn, c.Name(), desc.Name, progress)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	buf, err := json.Marshal(desc)
 	if err != nil {
@@ -220,6 +460,14 @@ func (c *collection) createIndex(
 	}
 	c.def.Description.Indexes = append(c.def.Description.Indexes, colIndex.Description())
 	c.indexes = append(c.indexes, colIndex)
+	if desc.Background {
+		// the build can only see the index once it has been committed
+		colName := c.Name()
+		txn.OnSuccess(func() {
+			c.db.startIndexBuild(colName, desc.Name)
+		})
+		return colIndex, nil
+	}
 	err = c.indexExistingDocs(ctx, txn, colIndex)
 	if err != nil {
 		return nil, err
@@ -227,6 +475,26 @@ func (c *collection) createIndex(
 	return colIndex, nil
 }
 
+// countDocs returns the number of documents of the collection that are not deleted.
+func (c *collection) countDocs(ctx context.Context, txn datastore.Txn) (uint64, error) {
+	prefix := core.PrimaryDataStoreKey{CollectionId: fmt.Sprint(c.ID())}
+	q, err := txn.Datastore().Query(ctx, query.Query{Prefix: prefix.ToString()})
+	if err != nil {
+		return 0, err
+	}
+	var count uint64
+	for res := range q.Next() {
+		if res.Error != nil {
+			_ = q.Close()
+			return 0, res.Error
+		}
+		if !bytes.Equal(res.Value, []byte{base.DeletedObjectMarker}) {
+			count++
+		}
+	}
+	return count, q.Close()
+}
+
 func (c *collection) iterateAllDocs(
 	ctx context.Context,
 	txn datastore.Txn,
@@ -277,20 +545,182 @@ func (c *collection) indexExistingDocs(
 	txn datastore.Txn,
 	index CollectionIndex,
 ) error {
-	fields := make([]client.FieldDescription, 0, 1)
-	for _, field := range index.Description().Fields {
+	return c.iterateAllDocs(ctx, txn, c.getIndexedFields(index), func(doc *client.Document) error {
+		return index.Save(ctx, txn, doc)
+	})
+}
+
+// getIndexedFields returns the descriptions of the fields indexed by the given index,
+// as well as of the fields its filter refers to.
+func (c *collection) getIndexedFields(index CollectionIndex) []client.FieldDescription {
+	fieldNames := index.Description().ReferencedFieldNames()
+	fields := make([]client.FieldDescription, 0, len(fieldNames))
+	added := make(map[string]bool, len(fieldNames))
+	for _, fieldName := range fieldNames {
+		if added[fieldName] {
+			continue
+		}
 		for i := range c.Schema().Fields {
 			colField := c.Schema().Fields[i]
-			if field.Name == colField.Name {
+			if fieldName == colField.Name {
+				added[fieldName] = true
 				fields = append(fields, colField)
 				break
 			}
 		}
 	}
+	return fields
+}
+
+// VerifyIndex compares the records of the index with the given name against the documents
+// of the collection and reports the missing, orphaned and mismatched records.
+func (c *collection) VerifyIndex(ctx context.Context, indexName string) (client.IndexVerification, error) {
+	txn, err := c.getTxn(ctx, true)
+	if err != nil {
+		return client.IndexVerification{}, err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
 
-	return c.iterateAllDocs(ctx, txn, fields, func(doc *client.Document) error {
-		return index.Save(ctx, txn, doc)
+	return c.verifyIndex(ctx, txn, indexName, false)
+}
+
+// RebuildIndex verifies the index with the given name and repairs the found differences.
+//
+// Only the differing records are written, so the index doesn't have to be dropped and recreated.
+func (c *collection) RebuildIndex(ctx context.Context, indexName string) (client.IndexVerification, error) {
+	txn, err := c.getTxn(ctx, false)
+	if err != nil {
+		return client.IndexVerification{}, err
+	}
+	defer c.discardImplicitTxn(ctx, txn)
+
+	result, err := c.verifyIndex(ctx, txn, indexName, true)
+	if err != nil {
+		return client.IndexVerification{}, err
+	}
+	return result, c.commitImplicitTxn(ctx, txn)
+}
+
+// verifyIndex walks all documents of the collection and compares the records they should
+// be stored with against the records under the index prefix.
+//
+// If repair is true, missing and mismatched records are written and orphaned records are deleted.
+func (c *collection) verifyIndex(
+	ctx context.Context,
+	txn datastore.Txn,
+	indexName string,
+	repair bool,
+) (client.IndexVerification, error) {
+	err := c.loadIndexes(ctx, txn)
+	if err != nil {
+		return client.IndexVerification{}, err
+	}
+	var index CollectionIndex
+	for _, colIndex := range c.indexes {
+		if colIndex.Name() == indexName {
+			index = colIndex
+			break
+		}
+	}
+	if index == nil {
+		return client.IndexVerification{}, NewErrIndexWithNameDoesNotExists(indexName)
+	}
+
+	expected := make(map[string][]byte)
+	_, isUnique := index.(*collectionUniqueIndex)
+	// records of a unique index that are claimed by more than one document merged from peers
+	conflicts := make(map[string][]indexRecord)
+	err = c.iterateAllDocs(ctx, txn, c.getIndexedFields(index), func(doc *client.Document) error {
+		records, err := index.getDocumentsIndexRecords(doc)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			key := record.key.ToDS().String()
+			if value, ok := expected[key]; ok && isUnique && !bytes.Equal(value, record.value) {
+				conflicts[key] = append(conflicts[key], record)
+				continue
+			}
+			expected[key] = record.value
+		}
+		return nil
 	})
+	if err != nil {
+		return client.IndexVerification{}, err
+	}
+	for key, records := range conflicts {
+		// the document that is stored as the owner of the record stays its owner
+		stored, err := txn.Datastore().Get(ctx, ds.NewKey(key))
+		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+			return client.IndexVerification{}, err
+		}
+		for _, record := range records {
+			if bytes.Equal(stored, record.value) {
+				record.value = expected[key]
+				expected[key] = stored
+			}
+			conflict := newConflictRecord(record)
+			expected[conflict.key.ToDS().String()] = conflict.value
+		}
+	}
+
+	result := client.IndexVerification{
+		Index:          indexName,
+		MissingKeys:    []string{},
+		OrphanedKeys:   []string{},
+		MismatchedKeys: []string{},
+	}
+
+	prefix := core.IndexDataStoreKey{CollectionID: c.ID(), IndexID: index.Description().ID}
+	q, err := txn.Datastore().Query(ctx, query.Query{Prefix: prefix.ToString()})
+	if err != nil {
+		return client.IndexVerification{}, err
+	}
+	for res := range q.Next() {
+		if res.Error != nil {
+			_ = q.Close()
+			return client.IndexVerification{}, res.Error
+		}
+		value, ok := expected[res.Key]
+		switch {
+		case !ok:
+			result.OrphanedKeys = append(result.OrphanedKeys, res.Key)
+		case !bytes.Equal(value, res.Value):
+			result.MismatchedKeys = append(result.MismatchedKeys, res.Key)
+		default:
+			// the record is stored as expected, so there is nothing to repair
+			delete(expected, res.Key)
+		}
+	}
+	if err := q.Close(); err != nil {
+		return client.IndexVerification{}, err
+	}
+	mismatched := make(map[string]struct{}, len(result.MismatchedKeys))
+	for _, key := range result.MismatchedKeys {
+		mismatched[key] = struct{}{}
+	}
+	for key := range expected {
+		if _, ok := mismatched[key]; !ok {
+			result.MissingKeys = append(result.MissingKeys, key)
+		}
+	}
+	sort.Strings(result.MissingKeys)
+
+	if !repair {
+		return result, nil
+	}
+	for _, key := range result.OrphanedKeys {
+		if err := txn.Datastore().Delete(ctx, ds.NewKey(key)); err != nil {
+			return client.IndexVerification{}, NewCanNotDeleteIndexedField(err)
+		}
+	}
+	for key, value := range expected {
+		if err := txn.Datastore().Put(ctx, ds.NewKey(key), value); err != nil {
+			return client.IndexVerification{}, NewErrFailedToStoreIndexedField(key, err)
+		}
+	}
+	result.Repaired = true
+	return result, nil
 }
 
 // DropIndex removes an index from the collection.
@@ -345,6 +775,10 @@ func (c *collection) dropIndex(ctx context.Context, txn datastore.Txn, indexName
 	if err != nil {
 		return err
 	}
+	err = txn.Systemstore().Delete(ctx, core.NewIndexBuildKey(c.Name(), indexName).ToDS())
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -397,7 +831,16 @@ func (c *collection) GetIndexes(ctx context.Context) ([]client.IndexDescription,
 	if err != nil {
 		return nil, err
 	}
-	return c.Description().Indexes, nil
+	indexes := make([]client.IndexDescription, len(c.Description().Indexes))
+	for i, desc := range c.Description().Indexes {
+		// the progress is only read here, so that writes to the collection don't depend on it
+		desc.Status.BuildProgress, err = getIndexBuildProgress(ctx, txn, c.Name(), desc.Name)
+		if err != nil {
+			return nil, err
+		}
+		indexes[i] = desc
+	}
+	return indexes, nil
 }
 
 func (c *collection) checkExistingFields(
@@ -457,16 +900,26 @@ func validateIndexDescription(desc client.IndexDescription) error {
 	if desc.ID != 0 {
 		return NewErrNonZeroIndexIDProvided(desc.ID)
 	}
+	if desc.Status != (client.IndexStatus{}) {
+		return ErrIndexStatusProvided
+	}
+	if desc.EncodingVersion != 0 {
+		return ErrIndexEncodingVersionProvided
+	}
 	if len(desc.Fields) == 0 {
 		return ErrIndexMissingFields
 	}
-	if len(desc.Fields) == 1 && desc.Fields[0].Direction == client.Descending {
-		return ErrIndexSingleFieldWrongDirection
-	}
+	fieldNames := make(map[string]struct{}, len(desc.Fields))
 	for i := range desc.Fields {
 		if desc.Fields[i].Name == "" {
 			return ErrIndexFieldMissingName
 		}
+		// a JSON field can be indexed by several paths
+		fieldName := strings.Join(append([]string{desc.Fields[i].Name}, desc.Fields[i].Path...), ".")
+		if _, exists := fieldNames[fieldName]; exists {
+			return NewErrIndexWithDuplicateField(fieldName)
+		}
+		fieldNames[fieldName] = struct{}{}
 		if desc.Fields[i].Direction == "" {
 			desc.Fields[i].Direction = client.Ascending
 		}
@@ -476,16 +929,23 @@ func validateIndexDescription(desc client.IndexDescription) error {
 
 func generateIndexName(col client.Collection, fields []client.IndexedFieldDescription, inc int) string {
 	sb := strings.Builder{}
-	// at the moment we support only single field indexes that can be stored only in
-	// ascending order. This will change once we introduce composite indexes.
-	direction := "ASC"
 	sb.WriteString(col.Name())
-	sb.WriteByte('_')
 	// we can safely assume that there is at least one field in the slice
 	// because we validate it before calling this function
-	sb.WriteString(fields[0].Name)
-	sb.WriteByte('_')
-	sb.WriteString(direction)
+	for _, field := range fields {
+		sb.WriteByte('_')
+		sb.WriteString(field.Name)
+		for _, key := range field.Path {
+			sb.WriteByte('_')
+			sb.WriteString(key)
+		}
+		sb.WriteByte('_')
+		direction := field.Direction
+		if direction == "" {
+			direction = client.Ascending
+		}
+		sb.WriteString(string(direction))
+	}
 	if inc > 1 {
 		sb.WriteByte('_')
 		sb.WriteString(strconv.Itoa(inc))
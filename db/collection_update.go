@@ -315,11 +315,59 @@ func (c *collection) applyMergeToDoc(
 			}
 		}
 
+		if fd.IsArray() && mval.Type() == fastjson.TypeObject {
+			if fd.Typ != client.OR_SET {
+				return NewErrSetOperatorOnNonSetField(fd.Name)
+			}
+			patch, err := getSetPatch(mval, fd)
+			if err != nil {
+				return err
+			}
+			err = doc.Set(fd.Name, patch)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if fd.Kind == client.FieldKind_STRING && mval.Type() == fastjson.TypeObject {
+			if fd.Typ != client.RGA {
+				return NewErrTextOperatorOnNonTextField(fd.Name)
+			}
+			patch, err := getTextPatch(mval, fd)
+			if err != nil {
+				return err
+			}
+			err = doc.Set(fd.Name, patch)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if fd.Kind == client.FieldKind_DocKey && mval.Type() == fastjson.TypeNull {
+			// the relation is cleared
+			err := doc.Set(fd.Name, nil)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		cborVal, err := validateFieldSchema(mval, fd)
 		if err != nil {
 			return err
 		}
 
+		if fd.Kind == client.FieldKind_JSON {
+			// the value is set as is, as objects would otherwise be parsed as sub documents
+			err = doc.SetAs(fd.Name, cborVal, client.LWW_REGISTER)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		err = doc.Set(fd.Name, cborVal)
 		if err != nil {
 			return err
@@ -473,11 +521,138 @@ func validateFieldSchema(val *fastjson.Value, field client.FieldDescription) (an
 
 	case client.FieldKind_BLOB:
 		return getString(val)
+
+	case client.FieldKind_JSON:
+		return getJSON(val)
 	}
 
 	return nil, client.NewErrUnhandledType("FieldKind", field.Kind)
 }
 
+// getSetPatch returns the elements added to and removed from the given set field
+// by the `_add` and `_remove` operators of the given value.
+func getSetPatch(val *fastjson.Value, field client.FieldDescription) (client.SetPatch, error) {
+	var patch client.SetPatch
+	obj, err := val.Object()
+	if err != nil {
+		return patch, err
+	}
+	obj.Visit(func(k []byte, v *fastjson.Value) {
+		if err != nil {
+			return
+		}
+		switch string(k) {
+		case request.SetAddOperator:
+			patch.Add, err = validateFieldSchema(v, field)
+		case request.SetRemoveOperator:
+			patch.Remove, err = validateFieldSchema(v, field)
+		default:
+			err = NewErrInvalidSetOperator(field.Name, string(k))
+		}
+	})
+	return patch, err
+}
+
+// getTextPatch returns the text inserted into and deleted from the given text sequence field
+// by the `_insert` and `_delete` operators of the given value.
+//
+// The value of an operator is either a single operation or an array of operations.
+func getTextPatch(val *fastjson.Value, field client.FieldDescription) (client.TextPatch, error) {
+	var patch client.TextPatch
+	obj, err := val.Object()
+	if err != nil {
+		return patch, err
+	}
+	obj.Visit(func(k []byte, v *fastjson.Value) {
+		if err != nil {
+			return
+		}
+		var operations []*fastjson.Object
+		operations, err = getTextOperations(v)
+		if err != nil {
+			return
+		}
+		for _, operation := range operations {
+			switch string(k) {
+			case request.TextInsertOperator:
+				var insert client.TextInsert
+				insert, err = getTextInsert(operation, field)
+				patch.Insert = append(patch.Insert, insert)
+			case request.TextDeleteOperator:
+				var del client.TextDelete
+				del, err = getTextDelete(operation, field)
+				patch.Delete = append(patch.Delete, del)
+			default:
+				err = NewErrInvalidTextOperator(field.Name, string(k))
+			}
+			if err != nil {
+				return
+			}
+		}
+	})
+	return patch, err
+}
+
+func getTextOperations(val *fastjson.Value) ([]*fastjson.Object, error) {
+	if val.Type() != fastjson.TypeArray {
+		operation, err := val.Object()
+		if err != nil {
+			return nil, err
+		}
+		return []*fastjson.Object{operation}, nil
+	}
+	items, err := val.Array()
+	if err != nil {
+		return nil, err
+	}
+	operations := make([]*fastjson.Object, len(items))
+	for i, item := range items {
+		operations[i], err = item.Object()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return operations, nil
+}
+
+func getTextInsert(operation *fastjson.Object, field client.FieldDescription) (client.TextInsert, error) {
+	var insert client.TextInsert
+	var err error
+	operation.Visit(func(k []byte, v *fastjson.Value) {
+		if err != nil {
+			return
+		}
+		switch string(k) {
+		case request.TextPositionProp:
+			insert.Position, err = v.Int()
+		case request.TextTextProp:
+			insert.Text, err = getString(v)
+		default:
+			err = NewErrInvalidTextOperator(field.Name, request.TextInsertOperator+"."+string(k))
+		}
+	})
+	return insert, err
+}
+
+func getTextDelete(operation *fastjson.Object, field client.FieldDescription) (client.TextDelete, error) {
+	var del client.TextDelete
+	var err error
+	operation.Visit(func(k []byte, v *fastjson.Value) {
+		if err != nil {
+			return
+		}
+		switch string(k) {
+		case request.TextPositionProp:
+			del.Position, err = v.Int()
+		case request.TextLengthProp:
+			del.Length, err = v.Int()
+		default:
+			err = NewErrInvalidTextOperator(field.Name, request.TextDeleteOperator+"."+string(k))
+		}
+	})
+	return del, err
+}
+
 func getString(v *fastjson.Value) (string, error) {
 	b, err := v.StringBytes()
 	return string(b), err
@@ -495,6 +670,49 @@ func getInt64(v *fastjson.Value) (int64, error) {
 	return v.Int64()
 }
 
+// getJSON returns the value of a JSON field, integral numbers are returned as int64.
+func getJSON(v *fastjson.Value) (any, error) {
+	switch v.Type() {
+	case fastjson.TypeObject:
+		obj, err := v.Object()
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[string]any, obj.Len())
+		obj.Visit(func(k []byte, item *fastjson.Value) {
+			if err != nil {
+				return
+			}
+			result[string(k)], err = getJSON(item)
+		})
+		return result, err
+	case fastjson.TypeArray:
+		items, err := v.Array()
+		if err != nil {
+			return nil, err
+		}
+		result := make([]any, len(items))
+		for i, item := range items {
+			result[i], err = getJSON(item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	case fastjson.TypeNumber:
+		if intVal, err := v.Int64(); err == nil {
+			return intVal, nil
+		}
+		return v.Float64()
+	case fastjson.TypeString:
+		return getString(v)
+	case fastjson.TypeTrue, fastjson.TypeFalse:
+		return getBool(v)
+	default:
+		return nil, nil
+	}
+}
+
 func getArray[T any](
 	val *fastjson.Value,
 	typeGetter func(*fastjson.Value) (T, error),
@@ -18,6 +18,7 @@ import (
 	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	blockstore "github.com/ipfs/boxo/blockstore"
 	ds "github.com/ipfs/go-datastore"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/core/crdt"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/events"
@@ -71,6 +73,17 @@ type db struct {
 
 	// The ID of the last transaction created.
 	previousTxnID atomic.Uint64
+
+	// indexBuildCtx is the context of the background index builds.
+	// It is cancelled when the database is closed.
+	indexBuildCtx    context.Context
+	cancelIndexBuild context.CancelFunc
+	// indexBuilds is used to wait for the background index builds to stop.
+	indexBuilds sync.WaitGroup
+
+	// hlc is the hybrid logical clock the timestamps of the writes to the fields
+	// resolved by HLC are read from.
+	hlc *crdt.HLC
 }
 
 // Functional option type.
@@ -123,7 +136,10 @@ func newDB(ctx context.Context, rootstore datastore.RootStore, options ...Option
 
 		parser:  parser,
 		options: options,
+
+		hlc: crdt.NewHLC(time.Now),
 	}
+	db.indexBuildCtx, db.cancelIndexBuild = context.WithCancel(context.Background())
 
 	// apply options
 	for _, opt := range options {
@@ -142,6 +158,16 @@ func newDB(ctx context.Context, rootstore datastore.RootStore, options ...Option
 		return nil, err
 	}
 
+	err = db.rebuildOutdatedIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.resumeIndexBuilds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &implicitTxnDB{db}, nil
 }
 
@@ -262,6 +288,9 @@ func (db *db) PrintDump(ctx context.Context) error {
 // This is the place for any last minute cleanup or releasing of resources (i.e.: Badger instance).
 func (db *db) Close() {
 	log.Info(context.Background(), "Closing DefraDB process...")
+	db.cancelIndexBuild()
+	db.indexBuilds.Wait()
+
 	if db.events.Updates.HasValue() {
 		db.events.Updates.Value().Close()
 	}
@@ -42,7 +42,12 @@ const (
 	errDuplicateField                     string = "duplicate field"
 	errCannotMutateField                  string = "mutating an existing field is not supported"
 	errCannotMoveField                    string = "moving fields is not currently supported"
-	errInvalidCRDTType                    string = "only default or LWW (last writer wins) CRDT types are supported"
+	errInvalidCRDTType                    string = "only default, LWW (last writer wins) or counter CRDT types are supported"
+	errCRDTKindMismatch                   string = "CRDT type not supported for the field kind"
+	errInvalidSetOperator                 string = "invalid set operator, it must be _add or _remove"
+	errSetOperatorOnNonSetField           string = "set operators can only be used on set fields"
+	errInvalidTextOperator                string = "invalid text operator, it must be _insert or _delete"
+	errTextOperatorOnNonTextField         string = "text operators can only be used on text sequence fields"
 	errCannotDeleteField                  string = "deleting an existing field is not supported"
 	errFieldKindNotFound                  string = "no type found for given name"
 	errFieldKindDoesNotMatchFieldSchema   string = "field Kind does not match field Schema"
@@ -51,11 +56,13 @@ const (
 	errDocumentDeleted                    string = "a document with the given dockey has been deleted"
 	errIndexMissingFields                 string = "index missing fields"
 	errNonZeroIndexIDProvided             string = "non-zero index ID provided"
+	errIndexStatusProvided                string = "index status can not be provided"
+	errIndexEncodingVersionProvided       string = "index encoding version can not be provided"
 	errIndexFieldMissingName              string = "index field missing name"
 	errIndexFieldMissingDirection         string = "index field missing direction"
-	errIndexSingleFieldWrongDirection     string = "wrong direction for index with a single field"
 	errIndexWithNameAlreadyExists         string = "index with name already exists"
 	errInvalidStoredIndex                 string = "invalid stored index"
+	errInvalidStoredIndexBuildProgress    string = "invalid stored index build progress"
 	errInvalidStoredIndexKey              string = "invalid stored index key"
 	errNonExistingFieldForIndex           string = "creating an index on a non-existing property"
 	errCollectionDoesntExisting           string = "collection with given name doesn't exist"
@@ -66,7 +73,7 @@ const (
 	errCanNotDropIndexWithPatch           string = "dropping indexes via patch is not supported"
 	errCanNotChangeIndexWithPatch         string = "changing indexes via patch is not supported"
 	errIndexWithNameDoesNotExists         string = "index with name doesn't exists"
-	errCorruptedIndex                     string = "corrupted index. Please delete and recreate the index"
+	errCorruptedIndex                     string = "corrupted index. Please rebuild the index"
 	errInvalidFieldValue                  string = "invalid field value"
 	errUnsupportedIndexFieldType          string = "unsupported index field type"
 	errIndexDescriptionHasNoFields        string = "index description has no fields"
@@ -86,6 +93,19 @@ const (
 	errExpectedJSONArray                  string = "expected JSON array"
 	errOneOneAlreadyLinked                string = "target document is already linked to another document"
 	errIndexDoesNotMatchName              string = "the index used does not match the given name"
+	errIndexWithDuplicateField            string = "index contains the same field more than once"
+	errCanNotIndexNonUniqueFields         string = "can not index a doc's field(s) that violates unique index"
+	errInvalidIndexFilter                 string = "invalid index filter"
+	errFullTextIndexWithMultipleFields    string = "full-text index must have exactly one field"
+	errUniqueFullTextIndex                string = "full-text index can not be unique"
+	errUnsupportedFullTextIndexFieldType  string = "full-text index field must be of type String"
+	errUnknownFullTextTokenizer           string = "unknown full-text tokenizer"
+	errInvalidIndexCollation              string = "invalid index collation"
+	errIndexJSONFieldWithoutPath          string = "JSON fields can only be indexed by path"
+	errIndexPathOnNonJSONField            string = "only JSON fields can be indexed by path"
+	errInvalidRelationOnDelete            string = "invalid onDelete option of relation"
+	errOnDeleteNotPrimary                 string = "onDelete can only be set on the primary side of a relation"
+	errDeleteRestricted                   string = "cannot delete a document that is still referenced"
 )
 
 var (
@@ -139,14 +159,20 @@ var (
 	ErrCannotMutateField                  = errors.New(errCannotMutateField)
 	ErrCannotMoveField                    = errors.New(errCannotMoveField)
 	ErrInvalidCRDTType                    = errors.New(errInvalidCRDTType)
+	ErrCRDTKindMismatch                   = errors.New(errCRDTKindMismatch)
+	ErrInvalidSetOperator                 = errors.New(errInvalidSetOperator)
+	ErrSetOperatorOnNonSetField           = errors.New(errSetOperatorOnNonSetField)
+	ErrInvalidTextOperator                = errors.New(errInvalidTextOperator)
+	ErrTextOperatorOnNonTextField         = errors.New(errTextOperatorOnNonTextField)
 	ErrCannotDeleteField                  = errors.New(errCannotDeleteField)
 	ErrFieldKindNotFound                  = errors.New(errFieldKindNotFound)
 	ErrFieldKindDoesNotMatchFieldSchema   = errors.New(errFieldKindDoesNotMatchFieldSchema)
 	ErrSchemaNotFound                     = errors.New(errSchemaNotFound)
 	ErrIndexMissingFields                 = errors.New(errIndexMissingFields)
+	ErrIndexStatusProvided                = errors.New(errIndexStatusProvided)
+	ErrIndexEncodingVersionProvided       = errors.New(errIndexEncodingVersionProvided)
 	ErrIndexFieldMissingName              = errors.New(errIndexFieldMissingName)
 	ErrIndexFieldMissingDirection         = errors.New(errIndexFieldMissingDirection)
-	ErrIndexSingleFieldWrongDirection     = errors.New(errIndexSingleFieldWrongDirection)
 	ErrCorruptedIndex                     = errors.New(errCorruptedIndex)
 	ErrCanNotChangeIndexWithPatch         = errors.New(errCanNotChangeIndexWithPatch)
 	ErrFieldOrAliasToFieldNotExist        = errors.New(errFieldOrAliasToFieldNotExist)
@@ -164,6 +190,17 @@ var (
 	ErrExpectedJSONArray                  = errors.New(errExpectedJSONArray)
 	ErrOneOneAlreadyLinked                = errors.New(errOneOneAlreadyLinked)
 	ErrIndexDoesNotMatchName              = errors.New(errIndexDoesNotMatchName)
+	ErrCanNotIndexNonUniqueFields         = errors.New(errCanNotIndexNonUniqueFields)
+	ErrFullTextIndexWithMultipleFields    = errors.New(errFullTextIndexWithMultipleFields)
+	ErrUniqueFullTextIndex                = errors.New(errUniqueFullTextIndex)
+	ErrUnsupportedFullTextIndexFieldType  = errors.New(errUnsupportedFullTextIndexFieldType)
+	ErrUnknownFullTextTokenizer           = errors.New(errUnknownFullTextTokenizer)
+	ErrInvalidIndexCollation              = errors.New(errInvalidIndexCollation)
+	ErrIndexJSONFieldWithoutPath          = errors.New(errIndexJSONFieldWithoutPath)
+	ErrIndexPathOnNonJSONField            = errors.New(errIndexPathOnNonJSONField)
+	ErrInvalidRelationOnDelete            = errors.New(errInvalidRelationOnDelete)
+	ErrOnDeleteNotPrimary                 = errors.New(errOnDeleteNotPrimary)
+	ErrDeleteRestricted                   = errors.New(errDeleteRestricted)
 )
 
 // NewErrFieldOrAliasToFieldNotExist returns an error indicating that the given field or an alias field does not exist.
@@ -189,6 +226,12 @@ func NewErrInvalidStoredIndex(inner error) error {
 	return errors.Wrap(errInvalidStoredIndex, inner)
 }
 
+// NewErrInvalidStoredIndexBuildProgress returns a new error indicating that the stored
+// progress of a background index build is invalid.
+func NewErrInvalidStoredIndexBuildProgress(inner error) error {
+	return errors.Wrap(errInvalidStoredIndexBuildProgress, inner)
+}
+
 // NewErrInvalidStoredIndexKey returns a new error indicating that the stored
 // index in the database is invalid.
 func NewErrInvalidStoredIndexKey(key string) error {
@@ -439,6 +482,77 @@ func NewErrInvalidCRDTType(name string, crdtType client.CType) error {
 	)
 }
 
+func NewErrCRDTKindMismatch(name string, crdtType client.CType, kind client.FieldKind) error {
+	return errors.New(
+		errCRDTKindMismatch,
+		errors.NewKV("Name", name),
+		errors.NewKV("CRDTType", crdtType),
+		errors.NewKV("Kind", kind),
+	)
+}
+
+// NewErrInvalidRelationOnDelete returns an error indicating that the onDelete option of the given
+// relation field is unknown.
+func NewErrInvalidRelationOnDelete(name string, onDelete client.RelationOnDelete) error {
+	return errors.New(
+		errInvalidRelationOnDelete,
+		errors.NewKV("Name", name),
+		errors.NewKV("OnDelete", onDelete),
+	)
+}
+
+// NewErrOnDeleteNotPrimary returns an error indicating that the onDelete option is set on
+// a field that is not the primary side of a relation.
+func NewErrOnDeleteNotPrimary(name string) error {
+	return errors.New(
+		errOnDeleteNotPrimary,
+		errors.NewKV("Name", name),
+	)
+}
+
+// NewErrDeleteRestricted returns an error indicating that the given document can not be deleted
+// as it is referenced by a document of a relation with the RESTRICT onDelete option.
+func NewErrDeleteRestricted(docKey string, collection string, field string) error {
+	return errors.New(
+		errDeleteRestricted,
+		errors.NewKV("DocKey", docKey),
+		errors.NewKV("Collection", collection),
+		errors.NewKV("Field", field),
+	)
+}
+
+// NewErrInvalidSetOperator returns an error indicating that the update of a set field
+// contains an unknown operator.
+func NewErrInvalidSetOperator(fieldName string, operator string) error {
+	return errors.New(
+		errInvalidSetOperator,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("Operator", operator),
+	)
+}
+
+// NewErrSetOperatorOnNonSetField returns an error indicating that elements are added to or
+// removed from a field that is not a set.
+func NewErrSetOperatorOnNonSetField(fieldName string) error {
+	return errors.New(errSetOperatorOnNonSetField, errors.NewKV("Field", fieldName))
+}
+
+// NewErrInvalidTextOperator returns an error indicating that the update of a text sequence field
+// contains an unknown operator, or an operation with unknown properties.
+func NewErrInvalidTextOperator(fieldName string, operator string) error {
+	return errors.New(
+		errInvalidTextOperator,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("Operator", operator),
+	)
+}
+
+// NewErrTextOperatorOnNonTextField returns an error indicating that text is inserted into or
+// deleted from a field that is not a text sequence.
+func NewErrTextOperatorOnNonTextField(fieldName string) error {
+	return errors.New(errTextOperatorOnNonTextField, errors.NewKV("Field", fieldName))
+}
+
 func NewErrCannotDeleteField(name string, id client.FieldID) error {
 	return errors.New(
 		errCannotDeleteField,
@@ -544,6 +658,78 @@ func NewErrIndexDescHasNonExistingField(desc client.IndexDescription, fieldName
 	)
 }
 
+// NewErrInvalidIndexFilterField returns a new error indicating that the filter of an index
+// refers to a field that does not exist or that is not a field of the indexed collection.
+func NewErrInvalidIndexFilterField(fieldName string) error {
+	return errors.New(errInvalidIndexFilter, errors.NewKV("Field", fieldName))
+}
+
+// NewErrInvalidIndexFilterOperator returns a new error indicating that the filter of an index
+// uses an operator in a place where it can not be applied.
+func NewErrInvalidIndexFilterOperator(operator string) error {
+	return errors.New(errInvalidIndexFilter, errors.NewKV("Operator", operator))
+}
+
+// NewErrFullTextIndexWithMultipleFields returns a new error indicating that a full-text index
+// description has more than one field.
+func NewErrFullTextIndexWithMultipleFields(fieldsCount int) error {
+	return errors.New(errFullTextIndexWithMultipleFields, errors.NewKV("FieldsCount", fieldsCount))
+}
+
+// NewErrUnsupportedFullTextIndexFieldType returns a new error indicating that the given field kind
+// can not be indexed by a full-text index.
+func NewErrUnsupportedFullTextIndexFieldType(kind client.FieldKind) error {
+	return errors.New(errUnsupportedFullTextIndexFieldType, errors.NewKV("Kind", kind))
+}
+
+// NewErrUnknownFullTextTokenizer returns a new error indicating that the tokenizer of a full-text
+// index is not one of the supported ones.
+func NewErrUnknownFullTextTokenizer(tokenizer client.FullTextTokenizer) error {
+	return errors.New(errUnknownFullTextTokenizer, errors.NewKV("Tokenizer", tokenizer))
+}
+
+// NewErrInvalidIndexCollation returns a new error indicating that the collation of an indexed field
+// is unknown or can not be applied to the kind of the field.
+func NewErrInvalidIndexCollation(fieldName string, collation client.IndexCollation) error {
+	return errors.New(
+		errInvalidIndexCollation,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("Collation", collation),
+	)
+}
+
+// NewErrIndexJSONFieldWithoutPath returns a new error indicating that a JSON field is indexed
+// without a path to the indexed value.
+func NewErrIndexJSONFieldWithoutPath(fieldName string) error {
+	return errors.New(errIndexJSONFieldWithoutPath, errors.NewKV("Field", fieldName))
+}
+
+// NewErrIndexPathOnNonJSONField returns a new error indicating that a field that is not a JSON
+// field is indexed by path.
+func NewErrIndexPathOnNonJSONField(fieldName string, kind client.FieldKind) error {
+	return errors.New(
+		errIndexPathOnNonJSONField,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("Kind", kind),
+	)
+}
+
+// NewErrIndexWithDuplicateField returns a new error indicating that the given field
+// is listed more than once in an index description.
+func NewErrIndexWithDuplicateField(fieldName string) error {
+	return errors.New(errIndexWithDuplicateField, errors.NewKV("Field", fieldName))
+}
+
+// NewErrCanNotIndexNonUniqueFields returns a new error indicating that the field values
+// of the given document violate the constraint of a unique index.
+func NewErrCanNotIndexNonUniqueFields(dockey string, fieldValues ...errors.KV) error {
+	kvPairs := make([]errors.KV, 0, len(fieldValues)+1)
+	kvPairs = append(kvPairs, errors.NewKV("Dockey", dockey))
+	kvPairs = append(kvPairs, fieldValues...)
+
+	return errors.New(errCanNotIndexNonUniqueFields, kvPairs...)
+}
+
 // NewErrCreateFile returns a new error indicating there was a failure in creating a file.
 func NewErrCreateFile(inner error, filepath string) error {
 	return errors.Wrap(errCreateFile, inner, errors.NewKV("Filepath", filepath))
@@ -26,6 +26,11 @@ const (
 	errVFetcherFailedToGetDagLink   string = "(version fetcher) failed to get node link from DAG"
 	errFailedToGetDagNode           string = "failed to get DAG Node"
 	errMissingMapper                string = "missing document mapper"
+	errInvalidInOperatorValue       string = "invalid _in/_nin value"
+	errInvalidLikeOperatorValue     string = "invalid _like/_nlike value"
+	errInvalidMatchOperatorValue    string = "invalid _match value"
+	errInvalidFullTextIndexValue    string = "invalid full-text index record value"
+	errInvalidIndexFilterCondition  string = "invalid index filter condition"
 )
 
 var (
@@ -40,9 +45,20 @@ var (
 	ErrVFetcherFailedToGetDagLink   = errors.New(errVFetcherFailedToGetDagLink)
 	ErrFailedToGetDagNode           = errors.New(errFailedToGetDagNode)
 	ErrMissingMapper                = errors.New(errMissingMapper)
+	ErrInvalidInOperatorValue       = errors.New(errInvalidInOperatorValue)
+	ErrInvalidLikeOperatorValue     = errors.New(errInvalidLikeOperatorValue)
+	ErrInvalidMatchOperatorValue    = errors.New(errInvalidMatchOperatorValue)
+	ErrInvalidFullTextIndexValue    = errors.New(errInvalidFullTextIndexValue)
+	ErrInvalidIndexFilterCondition  = errors.New(errInvalidIndexFilterCondition)
 	ErrSingleSpanOnly               = errors.New("spans must contain only a single entry")
 )
 
+// NewErrInvalidIndexFilterCondition returns an error indicating that the given filter
+// operator can not be evaluated by an index.
+func NewErrInvalidIndexFilterCondition(op string) error {
+	return errors.New(errInvalidIndexFilterCondition, errors.NewKV("Operator", op))
+}
+
 // NewErrFieldIdNotFound returns an error indicating that the given FieldId was not found.
 func NewErrFieldIdNotFound(fieldId uint32) error {
 	return errors.New(errFieldIdNotFound, errors.NewKV("FieldId", fieldId))
@@ -35,6 +35,8 @@ type ExecInfo struct {
 	FieldsFetched uint64
 	// Number of indexes fetched.
 	IndexesFetched uint64
+	// Number of documents built from index records alone, without fetching their fields.
+	CoveredDocsFetched uint64
 }
 
 // Add adds the other ExecInfo to the current ExecInfo.
@@ -42,6 +44,7 @@ func (s *ExecInfo) Add(other ExecInfo) {
 	s.DocsFetched += other.DocsFetched
 	s.FieldsFetched += other.FieldsFetched
 	s.IndexesFetched += other.IndexesFetched
+	s.CoveredDocsFetched += other.CoveredDocsFetched
 }
 
 // Reset resets the ExecInfo.
@@ -49,6 +52,7 @@ func (s *ExecInfo) Reset() {
 	s.DocsFetched = 0
 	s.FieldsFetched = 0
 	s.IndexesFetched = 0
+	s.CoveredDocsFetched = 0
 }
 
 // Fetcher is the interface for collecting documents from the underlying data store.
@@ -349,9 +353,14 @@ func (df *DocumentFetcher) nextKey(ctx context.Context, seekNext bool) (spanDone
 		}
 	}
 
-	if df.kv != nil && (df.kv.Key.InstanceType != core.ValueKey && df.kv.Key.InstanceType != core.DeletedKey) {
+	instanceType := core.ValueKey
+	if df.deletedDocs {
+		instanceType = core.DeletedKey
+	}
+	if df.kv != nil && df.kv.Key.InstanceType != instanceType {
 		// We can only ready value values, if we escape the collection's value keys
-		// then we must be done and can stop reading
+		// (or deleted keys, when reading the deleted documents) then we must be done
+		// and can stop reading
 		spanDone = true
 	}
 
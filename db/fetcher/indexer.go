@@ -14,6 +14,7 @@ import (
 	"context"
 
 	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/connor"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/db/base"
@@ -21,7 +22,8 @@ import (
 )
 
 // IndexFetcher is a fetcher that fetches documents by index.
-// It fetches only the indexed field and the rest of the fields are fetched by the internal fetcher.
+// It fetches only the indexed fields and the rest of the fields are fetched by the internal fetcher.
+// If the index covers all the requested fields, the documents are built from the index records alone.
 type IndexFetcher struct {
 	docFetcher        Fetcher
 	col               client.Collection
@@ -30,11 +32,25 @@ type IndexFetcher struct {
 	docFilter         *mapper.Filter
 	doc               *encodedDocument
 	mapping           *core.DocumentMapping
-	indexedField      client.FieldDescription
+	indexedFields     []client.FieldDescription
+	indexDesc         client.IndexDescription
 	docFields         []client.FieldDescription
 	indexIter         indexIterator
 	indexDataStoreKey core.IndexDataStoreKey
-	execInfo          ExecInfo
+	// isCovering is true if all the requested fields are read from the index keys
+	// and documents don't need to be fetched.
+	isCovering bool
+	// checkIndexFilter is true if the index iterator yields records that don't necessarily
+	// match the index filter, like for compound conditions (_or) or array fields. In that case
+	// the index filter is run against the fetched documents.
+	checkIndexFilter bool
+	// fullTextDocFilter is the part of the index filter that is run against the fetched documents
+	// if the index is a full-text index, as the index only evaluates the _match condition.
+	fullTextDocFilter *mapper.Filter
+	// seenDocKeys holds the keys of the returned documents if the index has array fields,
+	// as a document has an index record for every distinct element of its arrays.
+	seenDocKeys map[string]struct{}
+	execInfo    ExecInfo
 }
 
 var _ Fetcher = (*IndexFetcher)(nil)
@@ -42,13 +58,13 @@ var _ Fetcher = (*IndexFetcher)(nil)
 // NewIndexFetcher creates a new IndexFetcher.
 func NewIndexFetcher(
 	docFetcher Fetcher,
-	indexedFieldDesc client.FieldDescription,
+	indexDesc client.IndexDescription,
 	indexFilter *mapper.Filter,
 ) *IndexFetcher {
 	return &IndexFetcher{
-		docFetcher:   docFetcher,
-		indexedField: indexedFieldDesc,
-		indexFilter:  indexFilter,
+		docFetcher:  docFetcher,
+		indexDesc:   indexDesc,
+		indexFilter: indexFilter,
 	}
 }
 
@@ -68,35 +84,162 @@ func (f *IndexFetcher) Init(
 	f.mapping = docMapper
 	f.txn = txn
 
-	for _, index := range col.Description().Indexes {
-		if index.Fields[0].Name == f.indexedField.Name {
-			f.indexDataStoreKey.IndexID = index.ID
-			break
+	f.indexedFields = make([]client.FieldDescription, 0, len(f.indexDesc.Fields))
+	fieldsFilters := make([]indexedFieldFilter, 0, len(f.indexDesc.Fields))
+	for _, indexedField := range f.indexDesc.Fields {
+		field, ok := col.Schema().GetField(indexedField.Name)
+		if !ok {
+			return client.NewErrFieldNotExist(indexedField.Name)
 		}
+		f.indexedFields = append(f.indexedFields, field)
+		var conds []fieldFilterCond
+		if f.mapping != nil {
+			if mappingIndexes, ok := f.mapping.IndexesByName[field.Name]; ok && len(mappingIndexes) > 0 {
+				if len(indexedField.Path) > 0 {
+					conds = getJSONPathFilterConds(f.indexFilter, mappingIndexes[0], indexedField.Path)
+				} else {
+					conds = getFieldFilterConds(f.indexFilter, mappingIndexes[0])
+				}
+			}
+		}
+		if indexedField.IsCaseInsensitive() {
+			conds = getCaseInsensitiveFieldFilterConds(conds)
+		}
+		fieldsFilters = append(fieldsFilters, indexedFieldFilter{
+			kind:            field.Kind,
+			descending:      indexedField.Direction == client.Descending,
+			caseInsensitive: indexedField.IsCaseInsensitive(),
+			conds:           conds,
+		})
 	}
 
 	f.indexDataStoreKey.CollectionID = f.col.ID()
+	f.indexDataStoreKey.IndexID = f.indexDesc.ID
 
+	f.docFields = make([]client.FieldDescription, 0, len(fields))
+outer:
 	for i := range fields {
-		if fields[i].Name == f.indexedField.Name {
-			f.docFields = append(fields[:i], fields[i+1:]...)
-			break
+		for j := range f.indexedFields {
+			if fields[i].Name == f.indexedFields[j].Name && isFieldValueInIndexKey(f.indexDesc, j, f.indexedFields[j]) {
+				continue outer
+			}
 		}
+		f.docFields = append(f.docFields, fields[i])
 	}
 
-	iter, err := createIndexIterator(f.indexDataStoreKey, f.indexFilter, &f.execInfo)
-	if err != nil {
+	f.checkIndexFilter = false
+	f.seenDocKeys = nil
+	if f.indexFilter != nil {
+		for filterKey := range f.indexFilter.Conditions {
+			if _, isProp := filterKey.(*mapper.PropertyIndex); !isProp {
+				f.checkIndexFilter = true
+				break
+			}
+		}
+	}
+	for i, indexedField := range f.indexedFields {
+		// the index holds only the lowercased values of case insensitive fields and only the
+		// values at a path of JSON fields, so the conditions on such fields have to be checked
+		// against their actual values
+		if f.indexDesc.Fields[i].IsCaseInsensitive() || len(f.indexDesc.Fields[i].Path) > 0 {
+			f.checkIndexFilter = true
+			if !containsField(f.docFields, indexedField.Name) {
+				f.docFields = append(f.docFields, indexedField)
+			}
+		}
+		if !indexedField.IsArray() {
+			continue
+		}
+		f.checkIndexFilter = true
+		f.seenDocKeys = make(map[string]struct{})
+		// the arrays are needed to check the index filter
+		if !containsField(f.docFields, indexedField.Name) {
+			f.docFields = append(f.docFields, indexedField)
+		}
+	}
+
+	// the fetcher might be reinitialized without being closed
+	if err := f.Close(); err != nil {
 		return err
 	}
-	f.indexIter = iter
+	if f.indexDesc.FullText != nil {
+		err := f.initFullTextIndexIterator()
+		if err != nil {
+			return err
+		}
+	} else {
+		iter, err := createIndexIterator(f.indexDataStoreKey, fieldsFilters, f.indexDesc.Unique, &f.execInfo)
+		if err != nil {
+			return err
+		}
+		f.indexIter = iter
+	}
 
-	if f.docFetcher != nil && len(f.docFields) > 0 {
+	f.isCovering = f.docFetcher == nil || len(f.docFields) == 0
+	var err error
+	if !f.isCovering {
 		err = f.docFetcher.Init(ctx, f.txn, f.col, f.docFields, f.docFilter, f.mapping, false, false)
 	}
 
 	return err
 }
 
+// initFullTextIndexIterator creates the iterator over the documents that match the _match
+// condition of the indexed field.
+//
+// The other conditions of the field are removed from the index filter and run against
+// the fetched documents, along with any compound conditions. The _match condition is not run again,
+// as the terms of the documents may differ from the ones the _match operator compares.
+func (f *IndexFetcher) initFullTextIndexIterator() error {
+	indexedField := f.indexedFields[0]
+	fieldMappingIndex := -1
+	if f.mapping != nil {
+		if mappingIndexes, ok := f.mapping.IndexesByName[indexedField.Name]; ok && len(mappingIndexes) > 0 {
+			fieldMappingIndex = mappingIndexes[0]
+		}
+	}
+	var matchQuery any
+	docFilter := &mapper.Filter{Conditions: make(map[connor.FilterKey]any)}
+	if f.indexFilter != nil {
+		for filterKey, cond := range f.indexFilter.Conditions {
+			propKey, isProp := filterKey.(*mapper.PropertyIndex)
+			condMap, isMap := cond.(map[connor.FilterKey]any)
+			if !isProp || !isMap || propKey.Index != fieldMappingIndex {
+				docFilter.Conditions[filterKey] = cond
+				continue
+			}
+			fieldConds := make(map[connor.FilterKey]any, len(condMap))
+			for opKey, opCond := range condMap {
+				if op, ok := opKey.(*mapper.Operator); ok && op.Operation == opMatch {
+					matchQuery = opCond
+					continue
+				}
+				fieldConds[opKey] = opCond
+			}
+			if len(fieldConds) > 0 {
+				docFilter.Conditions[filterKey] = fieldConds
+			}
+		}
+	}
+
+	f.fullTextDocFilter = nil
+	f.checkIndexFilter = false
+	if len(docFilter.Conditions) > 0 {
+		f.fullTextDocFilter = docFilter
+		f.checkIndexFilter = true
+		if !containsField(f.docFields, indexedField.Name) {
+			f.docFields = append(f.docFields, indexedField)
+		}
+	}
+
+	iter, err := newFullTextIndexIterator(f.indexDataStoreKey, *f.indexDesc.FullText, matchQuery, &f.execInfo)
+	if err != nil {
+		return err
+	}
+	f.indexIter = iter
+	return nil
+}
+
 func (f *IndexFetcher) Start(ctx context.Context, spans core.Spans) error {
 	err := f.indexIter.Init(ctx, f.txn.Datastore())
 	if err != nil {
@@ -112,25 +255,59 @@ func (f *IndexFetcher) FetchNext(ctx context.Context) (EncodedDocument, ExecInfo
 	for {
 		f.doc.Reset()
 
-		indexKey, hasValue, err := f.indexIter.Next()
+		res, err := f.indexIter.Next()
 		if err != nil {
 			return nil, ExecInfo{}, err
 		}
 
-		if !hasValue {
+		if !res.foundKey {
 			return nil, f.execInfo, nil
 		}
 
-		property := &encProperty{
-			Desc: f.indexedField,
-			Raw:  indexKey.FieldValues[0],
+		for i, indexedField := range f.indexedFields {
+			if !isFieldValueInIndexKey(f.indexDesc, i, indexedField) {
+				continue
+			}
+			property, err := f.newIndexedProperty(indexedField, i, res.key.FieldValues[i])
+			if err != nil {
+				return nil, ExecInfo{}, err
+			}
+			f.doc.properties[indexedField] = property
+			f.execInfo.FieldsFetched++
 		}
 
-		f.doc.key = indexKey.FieldValues[1]
-		f.doc.properties[f.indexedField] = property
-		f.execInfo.FieldsFetched++
+		// records of unique indexes store the document key as the value,
+		// unless one of the indexed fields has no value in which case it is part of the key
+		if f.indexDesc.Unique && len(res.value) > 0 {
+			f.doc.key = res.value
+		} else {
+			f.doc.key = res.key.FieldValues[len(f.indexedFields)]
+		}
+
+		if f.seenDocKeys != nil {
+			if _, ok := f.seenDocKeys[string(f.doc.key)]; ok {
+				continue
+			}
+			f.seenDocKeys[string(f.doc.key)] = struct{}{}
+		}
 
-		if f.docFetcher != nil && len(f.docFields) > 0 {
+		if f.isCovering {
+			f.execInfo.DocsFetched++
+			f.execInfo.CoveredDocsFetched++
+			// index records are kept in sync with the latest version of the documents
+			f.doc.schemaVersionID = f.col.Schema().VersionID
+			f.doc.status = client.Active
+			// the document filter is otherwise run by the document fetcher
+			if f.docFilter != nil {
+				passed, err := f.passesFilter(f.docFilter)
+				if err != nil {
+					return nil, ExecInfo{}, err
+				}
+				if !passed {
+					continue
+				}
+			}
+		} else {
 			targetKey := base.MakeDocKey(f.col.Description(), string(f.doc.key))
 			spans := core.NewSpans(core.NewSpan(targetKey, targetKey.PrefixEnd()))
 			err = f.docFetcher.Start(ctx, spans)
@@ -150,13 +327,73 @@ func (f *IndexFetcher) FetchNext(ctx context.Context) (EncodedDocument, ExecInfo
 				continue
 			}
 			f.doc.MergeProperties(encDoc)
-		} else {
-			f.execInfo.DocsFetched++
+		}
+		if f.checkIndexFilter {
+			filter := f.indexFilter
+			if f.indexDesc.FullText != nil {
+				filter = f.fullTextDocFilter
+			}
+			passed, err := f.passesFilter(filter)
+			if err != nil {
+				return nil, ExecInfo{}, err
+			}
+			if !passed {
+				continue
+			}
 		}
 		return f.doc, f.execInfo, nil
 	}
 }
 
+// passesFilter runs the given filter against the current document.
+func (f *IndexFetcher) passesFilter(filter *mapper.Filter) (bool, error) {
+	doc, err := DecodeToDoc(f.doc, f.mapping, false)
+	if err != nil {
+		return false, err
+	}
+	return mapper.RunFilter(doc, filter)
+}
+
+// newIndexedProperty decodes the value of an indexed field stored in the index key.
+func (f *IndexFetcher) newIndexedProperty(
+	field client.FieldDescription,
+	fieldIndex int,
+	encodedValue []byte,
+) (*encProperty, error) {
+	descending := f.indexDesc.Fields[fieldIndex].Direction == client.Descending
+	val, err := core.DecodeIndexFieldValue(encodedValue, descending)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := client.NewCBORValue(client.LWW_REGISTER, val).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return &encProperty{Desc: field, Raw: raw}, nil
+}
+
+// isFieldValueInIndexKey returns true if the value of the indexed field at the given position can be
+// read from the index key.
+//
+// Index keys of DateTime fields hold only the point in time but not the original time zone,
+// index keys of array fields hold only one of the elements, index keys of full-text indexes
+// hold only one of the terms, index keys of case insensitive fields hold the lowercased value
+// and index keys of JSON fields hold only the value at a path, so the values of such fields
+// are read from the document.
+func isFieldValueInIndexKey(index client.IndexDescription, fieldIndex int, field client.FieldDescription) bool {
+	return index.FullText == nil && !index.Fields[fieldIndex].IsCaseInsensitive() &&
+		len(index.Fields[fieldIndex].Path) == 0 && field.Kind != client.FieldKind_DATETIME && !field.IsArray()
+}
+
+func containsField(fields []client.FieldDescription, name string) bool {
+	for _, field := range fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (f *IndexFetcher) Close() error {
 	if f.indexIter != nil {
 		return f.indexIter.Close()
@@ -13,31 +13,40 @@ package fetcher
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
+	"math"
+	"sort"
 	"strings"
 
-	"github.com/fxamacker/cbor/v2"
-
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/connor"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/datastore/iterable"
 	"github.com/sourcenetwork/defradb/planner/mapper"
 
+	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
 )
 
 const (
-	opEq    = "_eq"
-	opGt    = "_gt"
-	opGe    = "_ge"
-	opLt    = "_lt"
-	opLe    = "_le"
-	opNe    = "_ne"
-	opIn    = "_in"
-	opNin   = "_nin"
-	opLike  = "_like"
-	opNlike = "_nlike"
+	opEq     = "_eq"
+	opGt     = "_gt"
+	opGe     = "_ge"
+	opLt     = "_lt"
+	opLe     = "_le"
+	opNe     = "_ne"
+	opIn     = "_in"
+	opNin    = "_nin"
+	opLike   = "_like"
+	opNlike  = "_nlike"
+	opMatch  = "_match"
+	opIeq    = "_ieq"
+	opIlike  = "_ilike"
+	opNilike = "_nilike"
+	opAny    = "_any"
+	opAll    = "_all"
 )
 
 // indexIterator is an iterator over index keys.
@@ -45,45 +54,62 @@ const (
 // For example, iteration over condition _eq and _gt will have completely different logic.
 type indexIterator interface {
 	Init(context.Context, datastore.DSReaderWriter) error
-	Next() (core.IndexDataStoreKey, bool, error)
+	Next() (indexIterResult, error)
 	Close() error
 }
 
+// indexIterResult is the result of a single iteration step over index records.
+type indexIterResult struct {
+	// key is the index key of the found record.
+	key core.IndexDataStoreKey
+	// foundKey is true if a record was found and false if the iteration is over.
+	foundKey bool
+	// value is the value stored in the index record.
+	// For unique indexes it contains the document key.
+	value []byte
+}
+
 type queryResultIterator struct {
 	resultIter query.Results
 }
 
-func (i queryResultIterator) Next() (core.IndexDataStoreKey, bool, error) {
+func (i *queryResultIterator) Next() (indexIterResult, error) {
 	res, hasVal := i.resultIter.NextSync()
 	if res.Error != nil {
-		return core.IndexDataStoreKey{}, false, res.Error
+		return indexIterResult{}, res.Error
 	}
 	if !hasVal {
-		return core.IndexDataStoreKey{}, false, nil
+		return indexIterResult{}, nil
 	}
 	key, err := core.NewIndexDataStoreKey(res.Key)
 	if err != nil {
-		return core.IndexDataStoreKey{}, false, err
+		return indexIterResult{}, err
 	}
-	return key, true, nil
+	return indexIterResult{key: key, value: res.Value, foundKey: true}, nil
 }
 
-func (i queryResultIterator) Close() error {
-	return i.resultIter.Close()
+func (i *queryResultIterator) Close() error {
+	if i.resultIter == nil {
+		return nil
+	}
+	err := i.resultIter.Close()
+	i.resultIter = nil
+	return err
 }
 
-type eqIndexIterator struct {
+// eqPrefixIndexIterator is an iterator over index records that start with the given field values.
+// It is used for _eq conditions on a leading subset of the index fields.
+type eqPrefixIndexIterator struct {
 	queryResultIterator
-	indexKey  core.IndexDataStoreKey
-	filterVal []byte
-	execInfo  *ExecInfo
+	indexKey core.IndexDataStoreKey
+	values   [][]byte
+	execInfo *ExecInfo
 }
 
-func (i *eqIndexIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
-	i.indexKey.FieldValues = [][]byte{i.filterVal}
+func (i *eqPrefixIndexIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
+	i.indexKey.FieldValues = i.values
 	resultIter, err := store.Query(ctx, query.Query{
-		Prefix:   i.indexKey.ToString(),
-		KeysOnly: true,
+		Prefix: i.indexKey.ToString(),
 	})
 	if err != nil {
 		return err
@@ -92,85 +118,128 @@ func (i *eqIndexIterator) Init(ctx context.Context, store datastore.DSReaderWrit
 	return nil
 }
 
-func (i *eqIndexIterator) Next() (core.IndexDataStoreKey, bool, error) {
-	key, hasValue, err := i.queryResultIterator.Next()
-	if hasValue {
+func (i *eqPrefixIndexIterator) Next() (indexIterResult, error) {
+	res, err := i.queryResultIterator.Next()
+	if res.foundKey {
 		i.execInfo.IndexesFetched++
 	}
-	return key, hasValue, err
+	return res, err
 }
 
-type inIndexIterator struct {
-	eqIndexIterator
-	filterValues [][]byte
-	nextValIndex int
-	ctx          context.Context
-	store        datastore.DSReaderWriter
-	hasIterator  bool
+// eqSingleIndexIterator is an iterator over a unique index that fetches
+// the single record matching _eq conditions on all index fields by a direct key lookup.
+//
+// Documents merged from remote peers that conflict with the document of the record
+// are stored under the record's key, so they are iterated after it.
+type eqSingleIndexIterator struct {
+	queryResultIterator
+	indexKey core.IndexDataStoreKey
+	values   [][]byte
+	execInfo *ExecInfo
+
+	ctx   context.Context
+	store datastore.DSReaderWriter
+	// done is set once the lookup was performed
+	done bool
 }
 
-func newInIndexIterator(
-	indexKey core.IndexDataStoreKey,
-	filterValues [][]byte,
-	execInfo *ExecInfo,
-) *inIndexIterator {
-	return &inIndexIterator{
-		eqIndexIterator: eqIndexIterator{
-			indexKey: indexKey,
-			execInfo: execInfo,
-		},
-		filterValues: filterValues,
+func (i *eqSingleIndexIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
+	i.ctx = ctx
+	i.store = store
+	i.done = false
+	return nil
+}
+
+func (i *eqSingleIndexIterator) Next() (indexIterResult, error) {
+	if !i.done {
+		i.done = true
+		i.indexKey.FieldValues = i.values
+		val, err := i.store.Get(i.ctx, i.indexKey.ToDS())
+		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+			return indexIterResult{}, err
+		}
+		resultIter, queryErr := i.store.Query(i.ctx, query.Query{
+			Prefix: i.indexKey.ToString(),
+		})
+		if queryErr != nil {
+			return indexIterResult{}, queryErr
+		}
+		i.resultIter = resultIter
+		if err == nil {
+			i.execInfo.IndexesFetched++
+			return indexIterResult{key: i.indexKey, value: val, foundKey: true}, nil
+		}
+	}
+	res, err := i.queryResultIterator.Next()
+	if res.foundKey {
+		i.execInfo.IndexesFetched++
 	}
+	return res, err
 }
 
-func (i *inIndexIterator) nextIterator() (bool, error) {
-	if i.nextValIndex > 0 {
-		err := i.eqIndexIterator.Close()
+// multiIndexIterator is an iterator that sequentially iterates over the given iterators.
+// It is used, for example, for _in conditions where every value of the condition
+// requires a separate lookup.
+type multiIndexIterator struct {
+	iterators   []indexIterator
+	nextIterIdx int
+	currentIter indexIterator
+	ctx         context.Context
+	store       datastore.DSReaderWriter
+}
+
+func (i *multiIndexIterator) nextIterator() (bool, error) {
+	if i.currentIter != nil {
+		err := i.currentIter.Close()
 		if err != nil {
 			return false, err
 		}
+		i.currentIter = nil
 	}
 
-	if i.nextValIndex >= len(i.filterValues) {
+	if i.nextIterIdx >= len(i.iterators) {
 		return false, nil
 	}
 
-	i.filterVal = i.filterValues[i.nextValIndex]
-	err := i.eqIndexIterator.Init(i.ctx, i.store)
+	i.currentIter = i.iterators[i.nextIterIdx]
+	err := i.currentIter.Init(i.ctx, i.store)
 	if err != nil {
 		return false, err
 	}
-	i.nextValIndex++
+	i.nextIterIdx++
 	return true, nil
 }
 
-func (i *inIndexIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
+func (i *multiIndexIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
 	i.ctx = ctx
 	i.store = store
-	var err error
-	i.hasIterator, err = i.nextIterator()
+	i.nextIterIdx = 0
+	_, err := i.nextIterator()
 	return err
 }
 
-func (i *inIndexIterator) Next() (core.IndexDataStoreKey, bool, error) {
-	for i.hasIterator {
-		key, hasValue, err := i.eqIndexIterator.Next()
+func (i *multiIndexIterator) Next() (indexIterResult, error) {
+	for i.currentIter != nil {
+		res, err := i.currentIter.Next()
 		if err != nil {
-			return core.IndexDataStoreKey{}, false, err
+			return indexIterResult{}, err
 		}
-		if !hasValue {
-			i.hasIterator, err = i.nextIterator()
+		if !res.foundKey {
+			_, err = i.nextIterator()
 			if err != nil {
-				return core.IndexDataStoreKey{}, false, err
+				return indexIterResult{}, err
 			}
 			continue
 		}
-		return key, true, nil
+		return res, nil
 	}
-	return core.IndexDataStoreKey{}, false, nil
+	return indexIterResult{}, nil
 }
 
-func (i *inIndexIterator) Close() error {
+func (i *multiIndexIterator) Close() error {
+	if i.currentIter != nil {
+		return i.currentIter.Close()
+	}
 	return nil
 }
 
@@ -208,9 +277,13 @@ func (d *execInfoIndexMatcherDecorator) Match(key core.IndexDataStoreKey) (bool,
 	return d.matcher.Match(key)
 }
 
+// scanningIndexIterator iterates over all index records that start with the given
+// field values (or over the whole index if there are none) and returns only those
+// that satisfy the matcher.
 type scanningIndexIterator struct {
 	queryResultIterator
 	indexKey core.IndexDataStoreKey
+	values   [][]byte
 	matcher  indexMatcher
 	filter   errorCheckingFilter
 	execInfo *ExecInfo
@@ -218,11 +291,11 @@ type scanningIndexIterator struct {
 
 func (i *scanningIndexIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
 	i.filter.matcher = &execInfoIndexMatcherDecorator{matcher: i.matcher, execInfo: i.execInfo}
+	i.indexKey.FieldValues = i.values
 
 	iter, err := store.Query(ctx, query.Query{
-		Prefix:   i.indexKey.ToString(),
-		KeysOnly: true,
-		Filters:  []query.Filter{&i.filter},
+		Prefix:  i.indexKey.ToString(),
+		Filters: []query.Filter{&i.filter},
 	})
 	if err != nil {
 		return err
@@ -232,12 +305,131 @@ func (i *scanningIndexIterator) Init(ctx context.Context, store datastore.DSRead
 	return nil
 }
 
-func (i *scanningIndexIterator) Next() (core.IndexDataStoreKey, bool, error) {
-	key, hasValue, err := i.queryResultIterator.Next()
+func (i *scanningIndexIterator) Next() (indexIterResult, error) {
+	res, err := i.queryResultIterator.Next()
 	if i.filter.err != nil {
-		return core.IndexDataStoreKey{}, false, i.filter.err
+		return indexIterResult{}, i.filter.err
 	}
-	return key, hasValue, err
+	return res, err
+}
+
+// rangeIndexIterator iterates over index records whose value of the field at fieldIndex
+// lies between the given bounds. All records must start with the given field values.
+//
+// As index values are stored in an order-preserving encoding, the iterator seeks directly
+// to the lower bound and stops as soon as it passes the upper bound.
+type rangeIndexIterator struct {
+	indexKey core.IndexDataStoreKey
+	values   [][]byte
+	// fieldIndex is the position of the ranged field in the index key.
+	// It always follows the given values.
+	fieldIndex int
+	// lower and upper are the encoded bounds. nil means the range is unbounded on that side.
+	lower          []byte
+	lowerInclusive bool
+	upper          []byte
+	upperInclusive bool
+	// matcher is an optional matcher for conditions that can not be expressed as bounds.
+	matcher  indexMatcher
+	execInfo *ExecInfo
+
+	keysPrefix string
+	iter       iterable.Iterator
+	resultIter query.Results
+}
+
+func (i *rangeIndexIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
+	i.indexKey.FieldValues = i.values
+	i.keysPrefix = i.indexKey.ToString() + "/"
+
+	start := i.keysPrefix
+	if i.lower != nil {
+		start += string(i.lower)
+		if !i.lowerInclusive {
+			start = string(bytesPrefixEnd([]byte(start)))
+		}
+	}
+	// all keys under the prefix sort before the prefix with its trailing separator incremented
+	end := string(bytesPrefixEnd([]byte(i.keysPrefix)))
+
+	var err error
+	if i.iter == nil {
+		i.iter, err = store.GetIterator(query.Query{})
+		if err != nil {
+			return err
+		}
+	}
+	i.resultIter, err = i.iter.IteratePrefix(ctx, ds.NewKey(start), ds.NewKey(end))
+	return err
+}
+
+func (i *rangeIndexIterator) Next() (indexIterResult, error) {
+	for {
+		res, hasVal := i.resultIter.NextSync()
+		if res.Error != nil {
+			return indexIterResult{}, res.Error
+		}
+		if !hasVal || !strings.HasPrefix(res.Key, i.keysPrefix) {
+			return indexIterResult{}, nil
+		}
+		key, err := core.NewIndexDataStoreKey(res.Key)
+		if err != nil {
+			return indexIterResult{}, err
+		}
+		i.execInfo.IndexesFetched++
+
+		value := key.FieldValues[i.fieldIndex]
+		if i.upper != nil {
+			cmp := bytes.Compare(value, i.upper)
+			if cmp > 0 || (cmp == 0 && !i.upperInclusive) {
+				return indexIterResult{}, nil
+			}
+		}
+		if i.lower != nil {
+			cmp := bytes.Compare(value, i.lower)
+			if cmp < 0 || (cmp == 0 && !i.lowerInclusive) {
+				continue
+			}
+		}
+		if i.matcher != nil {
+			matches, err := i.matcher.Match(key)
+			if err != nil {
+				return indexIterResult{}, err
+			}
+			if !matches {
+				continue
+			}
+		}
+		return indexIterResult{key: key, value: res.Value, foundKey: true}, nil
+	}
+}
+
+func (i *rangeIndexIterator) Close() error {
+	if i.resultIter != nil {
+		err := i.resultIter.Close()
+		if err != nil {
+			return err
+		}
+		i.resultIter = nil
+	}
+	if i.iter != nil {
+		err := i.iter.Close()
+		i.iter = nil
+		return err
+	}
+	return nil
+}
+
+func bytesPrefixEnd(b []byte) []byte {
+	end := make([]byte, len(b))
+	copy(end, b)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i] = end[i] + 1
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return b
 }
 
 // checks if the stored index value satisfies the condition
@@ -248,56 +440,114 @@ type indexMatcher interface {
 // indexByteValuesMatcher is a filter that compares the index value with a given value.
 // It uses bytes.Compare to compare the values and evaluate the result with evalFunc.
 type indexByteValuesMatcher struct {
-	value []byte
+	fieldIndex int
+	value      []byte
+	// descending is true if the values of the field are stored in descending order,
+	// in which case the result of the comparison is inverted.
+	descending bool
+	// nilValue is the encoded nil value. If set, records without a value never match.
+	nilValue []byte
 	// evalFunc receives a result of bytes.Compare
 	evalFunc func(int) bool
 }
 
 func (m *indexByteValuesMatcher) Match(key core.IndexDataStoreKey) (bool, error) {
-	res := bytes.Compare(key.FieldValues[0], m.value)
+	fieldValue := key.FieldValues[m.fieldIndex]
+	if m.nilValue != nil && bytes.Equal(fieldValue, m.nilValue) {
+		return false, nil
+	}
+	res := bytes.Compare(fieldValue, m.value)
+	if m.descending {
+		res = -res
+	}
 	return m.evalFunc(res), nil
 }
 
 // matcher if _ne condition is met
 type neIndexMatcher struct {
-	value []byte
+	fieldIndex int
+	value      []byte
 }
 
 func (m *neIndexMatcher) Match(key core.IndexDataStoreKey) (bool, error) {
-	return !bytes.Equal(key.FieldValues[0], m.value), nil
+	return !bytes.Equal(key.FieldValues[m.fieldIndex], m.value), nil
 }
 
 // checks if the index value is or is not in the given array
 type indexInArrayMatcher struct {
-	values map[string]bool
-	isIn   bool
+	fieldIndex int
+	values     map[string]bool
+	isIn       bool
 }
 
-func newNinIndexCmp(values [][]byte, isIn bool) *indexInArrayMatcher {
+func newNinIndexCmp(fieldIndex int, values [][]byte, isIn bool) *indexInArrayMatcher {
 	valuesMap := make(map[string]bool)
 	for _, v := range values {
 		valuesMap[string(v)] = true
 	}
-	return &indexInArrayMatcher{values: valuesMap, isIn: isIn}
+	return &indexInArrayMatcher{fieldIndex: fieldIndex, values: valuesMap, isIn: isIn}
 }
 
 func (m *indexInArrayMatcher) Match(key core.IndexDataStoreKey) (bool, error) {
-	_, found := m.values[string(key.FieldValues[0])]
+	_, found := m.values[string(key.FieldValues[m.fieldIndex])]
 	return found == m.isIn, nil
 }
 
+// allIndexMatcher checks if all of the given matchers are satisfied
+type allIndexMatcher struct {
+	matchers []indexMatcher
+}
+
+func (m *allIndexMatcher) Match(key core.IndexDataStoreKey) (bool, error) {
+	for _, matcher := range m.matchers {
+		res, err := matcher.Match(key)
+		if err != nil || !res {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func newAllIndexMatcher(matchers []indexMatcher) indexMatcher {
+	switch len(matchers) {
+	case 0:
+		return nil
+	case 1:
+		return matchers[0]
+	default:
+		return &allIndexMatcher{matchers: matchers}
+	}
+}
+
 // checks if the index value satisfies the LIKE condition
 type indexLikeMatcher struct {
+	fieldIndex  int
+	descending  bool
 	hasPrefix   bool
 	hasSuffix   bool
 	startAndEnd []string
 	isLike      bool
 	value       string
+	// caseInsensitive is true for the _ilike and _nilike conditions, in which case
+	// both the pattern and the index value are lowercased.
+	caseInsensitive bool
 }
 
-func newLikeIndexCmp(filterValue string, isLike bool) *indexLikeMatcher {
+func newLikeIndexCmp(
+	fieldIndex int,
+	descending bool,
+	filterValue string,
+	isLike bool,
+	caseInsensitive bool,
+) *indexLikeMatcher {
 	matcher := &indexLikeMatcher{
-		isLike: isLike,
+		fieldIndex:      fieldIndex,
+		descending:      descending,
+		isLike:          isLike,
+		caseInsensitive: caseInsensitive,
+	}
+	if caseInsensitive {
+		filterValue = strings.ToLower(filterValue)
 	}
 	if len(filterValue) >= 2 {
 		if filterValue[0] == '%' {
@@ -318,15 +568,38 @@ func newLikeIndexCmp(filterValue string, isLike bool) *indexLikeMatcher {
 }
 
 func (m *indexLikeMatcher) Match(key core.IndexDataStoreKey) (bool, error) {
-	var currentVal string
-	err := cbor.Unmarshal(key.FieldValues[0], &currentVal)
+	val, err := core.DecodeIndexFieldValue(key.FieldValues[m.fieldIndex], m.descending)
 	if err != nil {
 		return false, err
 	}
+	currentVal, ok := val.(string)
+	if !ok {
+		return !m.isLike, nil
+	}
+	if m.caseInsensitive {
+		currentVal = strings.ToLower(currentVal)
+	}
 
 	return m.doesMatch(currentVal) == m.isLike, nil
 }
 
+// indexIeqMatcher checks if the index value satisfies the _ieq condition.
+type indexIeqMatcher struct {
+	fieldIndex int
+	descending bool
+	// value is the lowercased value of the condition.
+	value string
+}
+
+func (m *indexIeqMatcher) Match(key core.IndexDataStoreKey) (bool, error) {
+	val, err := core.DecodeIndexFieldValue(key.FieldValues[m.fieldIndex], m.descending)
+	if err != nil {
+		return false, err
+	}
+	currentVal, ok := val.(string)
+	return ok && strings.ToLower(currentVal) == m.value, nil
+}
+
 func (m *indexLikeMatcher) doesMatch(currentVal string) bool {
 	switch {
 	case m.hasPrefix && m.hasSuffix:
@@ -344,121 +617,668 @@ func (m *indexLikeMatcher) doesMatch(currentVal string) bool {
 	}
 }
 
-func createIndexIterator(
+// indexMatchMatcher checks if the index value satisfies the _match condition.
+//
+// The value is matched the same way as by the _match operator of a document filter,
+// as only full-text indexes store the terms of a value.
+type indexMatchMatcher struct {
+	fieldIndex int
+	descending bool
+	value      string
+}
+
+func (m *indexMatchMatcher) Match(key core.IndexDataStoreKey) (bool, error) {
+	val, err := core.DecodeIndexFieldValue(key.FieldValues[m.fieldIndex], m.descending)
+	if err != nil {
+		return false, err
+	}
+	if val == nil {
+		return false, nil
+	}
+	return connor.Match(map[connor.FilterKey]any{&mapper.Operator{Operation: opMatch}: m.value}, val)
+}
+
+// fullTextIndexIterator iterates over the documents of a full-text index that contain every
+// one of the given terms, from the most to the least relevant one.
+//
+// The records of all terms are read on the first iteration step. A document is ranked by the sum of the
+// tf-idf weights of the terms: the number of occurrences of a term relative to the number of terms
+// of the document, weighted by how rare the term is among the documents containing any of the terms.
+type fullTextIndexIterator struct {
+	indexKey core.IndexDataStoreKey
+	// terms are the encoded distinct terms of the query.
+	terms    [][]byte
+	execInfo *ExecInfo
+
+	ctx   context.Context
+	store datastore.DSReaderWriter
+	// results holds the ranked documents once the records have been read
+	results []indexIterResult
+	next    int
+}
+
+func (i *fullTextIndexIterator) Init(ctx context.Context, store datastore.DSReaderWriter) error {
+	i.ctx = ctx
+	i.store = store
+	i.results = nil
+	i.next = 0
+	return nil
+}
+
+// rankDocs reads the records of all terms and ranks the documents containing all of them.
+func (i *fullTextIndexIterator) rankDocs(ctx context.Context, store datastore.DSReaderWriter) error {
+	i.results = []indexIterResult{}
+	if len(i.terms) == 0 {
+		return nil
+	}
+
+	// termFrequencies holds for every term the relative frequency of the term in the documents
+	termFrequencies := make([]map[string]float64, len(i.terms))
+	allDocs := make(map[string]struct{})
+	for termIndex, term := range i.terms {
+		frequencies, err := i.fetchTermFrequencies(ctx, store, term)
+		if err != nil {
+			return err
+		}
+		if len(frequencies) == 0 {
+			// no document contains all terms
+			return nil
+		}
+		for docKey := range frequencies {
+			allDocs[docKey] = struct{}{}
+		}
+		termFrequencies[termIndex] = frequencies
+	}
+
+	scores := make(map[string]float64)
+outer:
+	for docKey := range termFrequencies[0] {
+		score := 0.0
+		for _, frequencies := range termFrequencies {
+			frequency, ok := frequencies[docKey]
+			if !ok {
+				continue outer
+			}
+			idf := math.Log(1 + float64(len(allDocs))/float64(len(frequencies)))
+			score += idf * frequency
+		}
+		scores[docKey] = score
+	}
+
+	docKeys := make([]string, 0, len(scores))
+	for docKey := range scores {
+		docKeys = append(docKeys, docKey)
+	}
+	sort.Slice(docKeys, func(a, b int) bool {
+		if scores[docKeys[a]] != scores[docKeys[b]] {
+			return scores[docKeys[a]] > scores[docKeys[b]]
+		}
+		return docKeys[a] < docKeys[b]
+	})
+
+	i.results = make([]indexIterResult, 0, len(docKeys))
+	for _, docKey := range docKeys {
+		key := i.indexKey
+		key.FieldValues = [][]byte{i.terms[0], []byte(docKey)}
+		i.results = append(i.results, indexIterResult{key: key, foundKey: true})
+	}
+	return nil
+}
+
+// fetchTermFrequencies returns the relative frequency of the given term in every document
+// containing it.
+func (i *fullTextIndexIterator) fetchTermFrequencies(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	term []byte,
+) (map[string]float64, error) {
+	prefix := i.indexKey
+	prefix.FieldValues = [][]byte{term}
+	resultIter, err := store.Query(ctx, query.Query{Prefix: prefix.ToString()})
+	if err != nil {
+		return nil, err
+	}
+	iter := queryResultIterator{resultIter: resultIter}
+	defer func() { _ = iter.Close() }()
+
+	frequencies := make(map[string]float64)
+	for {
+		res, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !res.foundKey {
+			return frequencies, iter.Close()
+		}
+		i.execInfo.IndexesFetched++
+		termCount, n := binary.Uvarint(res.value)
+		if n <= 0 {
+			return nil, ErrInvalidFullTextIndexValue
+		}
+		docTermsCount, m := binary.Uvarint(res.value[n:])
+		if m <= 0 || docTermsCount == 0 || len(res.key.FieldValues) != 2 {
+			return nil, ErrInvalidFullTextIndexValue
+		}
+		frequencies[string(res.key.FieldValues[1])] = float64(termCount) / float64(docTermsCount)
+	}
+}
+
+func (i *fullTextIndexIterator) Next() (indexIterResult, error) {
+	if i.results == nil {
+		if err := i.rankDocs(i.ctx, i.store); err != nil {
+			return indexIterResult{}, err
+		}
+	}
+	if i.next >= len(i.results) {
+		return indexIterResult{}, nil
+	}
+	res := i.results[i.next]
+	i.next++
+	return res, nil
+}
+
+func (i *fullTextIndexIterator) Close() error {
+	i.results = nil
+	return nil
+}
+
+// newFullTextIndexIterator creates an iterator over the documents of a full-text index that
+// match the given _match query.
+//
+// The query is split into terms with the options of the index, so that they can be looked up
+// the way the documents have been indexed.
+func newFullTextIndexIterator(
 	indexDataStoreKey core.IndexDataStoreKey,
-	indexFilterConditions *mapper.Filter,
+	opts client.FullTextIndexOptions,
+	matchQuery any,
 	execInfo *ExecInfo,
-) (indexIterator, error) {
-	var op string
-	var filterVal any
-	for _, indexFilterCond := range indexFilterConditions.Conditions {
-		condMap := indexFilterCond.(map[connor.FilterKey]any)
-		var key connor.FilterKey
-		for key, filterVal = range condMap {
-			break
+) (*fullTextIndexIterator, error) {
+	queryStr, ok := matchQuery.(string)
+	if !ok {
+		return nil, ErrInvalidMatchOperatorValue
+	}
+	seen := make(map[string]struct{})
+	var terms [][]byte
+	for _, term := range core.AnalyzeText(opts, queryStr) {
+		if _, ok := seen[term]; ok {
+			continue
 		}
-		opKey := key.(*mapper.Operator)
-		op = opKey.Operation
-		break
+		seen[term] = struct{}{}
+		encodedTerm, err := core.EncodeIndexFieldValue(client.FieldKind_STRING, term, false)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, encodedTerm)
 	}
+	return &fullTextIndexIterator{indexKey: indexDataStoreKey, terms: terms, execInfo: execInfo}, nil
+}
 
-	switch op {
-	case opEq, opGt, opGe, opLt, opLe, opNe:
-		writableValue := client.NewCBORValue(client.LWW_REGISTER, filterVal)
+// fieldFilterCond is a single filter condition on an indexed field, e.g. {_gt: 30}
+type fieldFilterCond struct {
+	op  string
+	val any
+}
 
-		valueBytes, err := writableValue.Bytes()
+// indexedFieldFilter holds the filter conditions of an indexed field along with
+// the field properties needed to encode the conditions' values.
+type indexedFieldFilter struct {
+	kind       client.FieldKind
+	descending bool
+	// caseInsensitive is true if the field is indexed by its lowercased values.
+	caseInsensitive bool
+	conds           []fieldFilterCond
+}
+
+func (f indexedFieldFilter) encode(val any) ([]byte, error) {
+	if strVal, ok := val.(string); ok && f.caseInsensitive {
+		val = strings.ToLower(strVal)
+	}
+	return core.EncodeIndexFieldValue(f.kind, val, f.descending)
+}
+
+func (f indexedFieldFilter) encodeArray(val any) ([][]byte, error) {
+	inArr, ok := val.([]any)
+	if !ok {
+		return nil, ErrInvalidInOperatorValue
+	}
+	valArr := make([][]byte, 0, len(inArr))
+	for _, v := range inArr {
+		valueBytes, err := f.encode(v)
 		if err != nil {
 			return nil, err
 		}
+		valArr = append(valArr, valueBytes)
+	}
+	return valArr, nil
+}
+
+// getFieldFilterConds returns the filter conditions of the field with the given
+// document mapping index, or nil if the filter does not constrain the field.
+func getFieldFilterConds(filter *mapper.Filter, fieldIndex int) []fieldFilterCond {
+	if filter == nil {
+		return nil
+	}
+	var result []fieldFilterCond
+	for filterKey, indexFilterCond := range filter.Conditions {
+		propKey, ok := filterKey.(*mapper.PropertyIndex)
+		if !ok || propKey.Index != fieldIndex {
+			continue
+		}
+		condMap, ok := indexFilterCond.(map[connor.FilterKey]any)
+		if !ok {
+			continue
+		}
+		for key, filterVal := range condMap {
+			opKey, ok := key.(*mapper.Operator)
+			if !ok {
+				continue
+			}
+			if opKey.Operation == opAny || opKey.Operation == opAll {
+				// every element of an array field has its own index record, so the conditions
+				// on the elements can be matched against the records directly
+				result = append(result, getElementFilterConds(filterVal)...)
+				continue
+			}
+			result = append(result, fieldFilterCond{op: opKey.Operation, val: filterVal})
+		}
+	}
+	return result
+}
 
-		switch op {
+// getJSONPathFilterConds returns the conditions on the value at the given path of the JSON field
+// of the given mapping index.
+//
+// Only the conditions that compare the value to scalar values are returned, as the index holds
+// the scalar values at the path. Since values of different types are all held by the index,
+// the fetched documents are checked against the original conditions.
+func getJSONPathFilterConds(filter *mapper.Filter, fieldIndex int, path []string) []fieldFilterCond {
+	if filter == nil {
+		return nil
+	}
+	var result []fieldFilterCond
+	for filterKey, indexFilterCond := range filter.Conditions {
+		propKey, ok := filterKey.(*mapper.PropertyIndex)
+		if !ok || propKey.Index != fieldIndex {
+			continue
+		}
+		condMap, ok := indexFilterCond.(map[connor.FilterKey]any)
+		if !ok {
+			continue
+		}
+		for key, pathCond := range condMap {
+			if !key.Equal(&mapper.JSONPath{Path: path}) {
+				continue
+			}
+			pathCondMap, ok := pathCond.(map[connor.FilterKey]any)
+			if !ok {
+				continue
+			}
+			for key, filterVal := range pathCondMap {
+				opKey, ok := key.(*mapper.Operator)
+				if !ok {
+					continue
+				}
+				switch opKey.Operation {
+				case opEq, opGt, opGe, opLt, opLe, opNe:
+					if isJSONScalarValue(filterVal) {
+						result = append(result, fieldFilterCond{op: opKey.Operation, val: filterVal})
+					}
+				case opIn, opNin:
+					vals, ok := filterVal.([]any)
+					if !ok {
+						continue
+					}
+					isScalar := true
+					for _, val := range vals {
+						isScalar = isScalar && isJSONScalarValue(val)
+					}
+					if isScalar {
+						result = append(result, fieldFilterCond{op: opKey.Operation, val: filterVal})
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+func isJSONScalarValue(val any) bool {
+	switch val.(type) {
+	case nil, bool, string, float64, int64:
+		return true
+	}
+	return false
+}
+
+// getElementFilterConds returns the conditions of an _any or _all array filter that can be
+// matched against the index records of the array elements.
+//
+// Any other conditions are left to the check of the fetched document.
+func getElementFilterConds(elementFilter any) []fieldFilterCond {
+	condMap, ok := elementFilter.(map[connor.FilterKey]any)
+	if !ok {
+		return nil
+	}
+	var result []fieldFilterCond
+	for key, filterVal := range condMap {
+		opKey, ok := key.(*mapper.Operator)
+		if !ok {
+			continue
+		}
+		switch opKey.Operation {
+		case opEq, opGt, opGe, opLt, opLe, opNe, opIn, opNin, opLike, opNlike, opIeq, opIlike, opNilike:
+			result = append(result, fieldFilterCond{op: opKey.Operation, val: filterVal})
+		}
+	}
+	return result
+}
+
+// getCaseInsensitiveFieldFilterConds converts the conditions on a case insensitive field into
+// conditions on its lowercased index values.
+//
+// Case sensitive conditions are replaced by their case insensitive counterparts, which match
+// a superset of the documents. Conditions that can't be evaluated against the lowercased values,
+// like range conditions, are dropped. Either way the fetched documents are checked against
+// the original conditions.
+func getCaseInsensitiveFieldFilterConds(conds []fieldFilterCond) []fieldFilterCond {
+	result := make([]fieldFilterCond, 0, len(conds))
+	for _, cond := range conds {
+		switch cond.op {
+		case opEq, opIeq:
+			// the value is lowercased when it is encoded
+			result = append(result, fieldFilterCond{op: opEq, val: cond.val})
+		case opIn:
+			result = append(result, cond)
+		case opLike, opIlike:
+			result = append(result, fieldFilterCond{op: opIlike, val: cond.val})
+		case opNilike:
+			result = append(result, cond)
+		}
+	}
+	return result
+}
+
+func isRangeCond(cond fieldFilterCond) bool {
+	switch cond.op {
+	case opGt, opGe, opLt, opLe:
+		return cond.val != nil
+	}
+	return false
+}
+
+func hasRangeCond(conds []fieldFilterCond) bool {
+	for _, cond := range conds {
+		if isRangeCond(cond) {
+			return true
+		}
+	}
+	return false
+}
+
+func createIndexMatcher(field indexedFieldFilter, cond fieldFilterCond, fieldIndex int) (indexMatcher, error) {
+	switch cond.op {
+	case opEq, opGt, opGe, opLt, opLe, opNe:
+		valueBytes, err := field.encode(cond.val)
+		if err != nil {
+			return nil, err
+		}
+
+		var evalFunc func(int) bool
+		switch cond.op {
 		case opEq:
-			return &eqIndexIterator{
-				indexKey:  indexDataStoreKey,
-				filterVal: valueBytes,
-				execInfo:  execInfo,
-			}, nil
+			evalFunc = func(res int) bool { return res == 0 }
 		case opGt:
-			return &scanningIndexIterator{
-				indexKey: indexDataStoreKey,
-				matcher: &indexByteValuesMatcher{
-					value:    valueBytes,
-					evalFunc: func(res int) bool { return res > 0 },
-				},
-				execInfo: execInfo,
-			}, nil
+			evalFunc = func(res int) bool { return res > 0 }
 		case opGe:
-			return &scanningIndexIterator{
-				indexKey: indexDataStoreKey,
-				matcher: &indexByteValuesMatcher{
-					value:    valueBytes,
-					evalFunc: func(res int) bool { return res > 0 || res == 0 },
-				},
-				execInfo: execInfo,
-			}, nil
+			evalFunc = func(res int) bool { return res > 0 || res == 0 }
 		case opLt:
-			return &scanningIndexIterator{
-				indexKey: indexDataStoreKey,
-				matcher: &indexByteValuesMatcher{
-					value:    valueBytes,
-					evalFunc: func(res int) bool { return res < 0 },
-				},
-				execInfo: execInfo,
-			}, nil
+			evalFunc = func(res int) bool { return res < 0 }
 		case opLe:
-			return &scanningIndexIterator{
-				indexKey: indexDataStoreKey,
-				matcher: &indexByteValuesMatcher{
-					value:    valueBytes,
-					evalFunc: func(res int) bool { return res < 0 || res == 0 },
-				},
-				execInfo: execInfo,
-			}, nil
+			evalFunc = func(res int) bool { return res < 0 || res == 0 }
 		case opNe:
-			return &scanningIndexIterator{
-				indexKey: indexDataStoreKey,
-				matcher: &neIndexMatcher{
-					value: valueBytes,
-				},
-				execInfo: execInfo,
-			}, nil
+			return &neIndexMatcher{fieldIndex: fieldIndex, value: valueBytes}, nil
+		}
+		matcher := &indexByteValuesMatcher{
+			fieldIndex: fieldIndex,
+			value:      valueBytes,
+			descending: field.descending,
+			evalFunc:   evalFunc,
+		}
+		if isRangeCond(cond) {
+			// nothing is greater or less than a missing value
+			matcher.nilValue, err = field.encode(nil)
+			if err != nil {
+				return nil, err
+			}
 		}
+		return matcher, nil
 	case opIn, opNin:
-		inArr, ok := filterVal.([]any)
+		valArr, err := field.encodeArray(cond.val)
+		if err != nil {
+			return nil, err
+		}
+		return newNinIndexCmp(fieldIndex, valArr, cond.op == opIn), nil
+	case opLike, opNlike:
+		strVal, ok := cond.val.(string)
+		if !ok {
+			return nil, ErrInvalidLikeOperatorValue
+		}
+		return newLikeIndexCmp(fieldIndex, field.descending, strVal, cond.op == opLike, false), nil
+	case opIlike, opNilike:
+		strVal, ok := cond.val.(string)
 		if !ok {
-			return nil, errors.New("invalid _in/_nin value")
+			return nil, ErrInvalidLikeOperatorValue
 		}
-		valArr := make([][]byte, 0, len(inArr))
-		for _, v := range inArr {
-			writableValue := client.NewCBORValue(client.LWW_REGISTER, v)
-			valueBytes, err := writableValue.Bytes()
+		return newLikeIndexCmp(fieldIndex, field.descending, strVal, cond.op == opIlike, true), nil
+	case opIeq:
+		strVal, ok := cond.val.(string)
+		if !ok {
+			// only a missing value is equal to a nil condition
+			return createIndexMatcher(field, fieldFilterCond{op: opEq, val: cond.val}, fieldIndex)
+		}
+		return &indexIeqMatcher{fieldIndex: fieldIndex, descending: field.descending, value: strings.ToLower(strVal)}, nil
+	case opMatch:
+		strVal, ok := cond.val.(string)
+		if !ok {
+			return nil, ErrInvalidMatchOperatorValue
+		}
+		return &indexMatchMatcher{fieldIndex: fieldIndex, descending: field.descending, value: strVal}, nil
+	}
+
+	return nil, NewErrInvalidIndexFilterCondition(cond.op)
+}
+
+// newRangeIndexIterator creates a range iterator for the range conditions of the given field.
+// Other conditions of the field, if any, are returned as matchers.
+func newRangeIndexIterator(
+	field indexedFieldFilter,
+	fieldIndex int,
+	execInfo *ExecInfo,
+) (*rangeIndexIterator, []indexMatcher, error) {
+	iter := &rangeIndexIterator{fieldIndex: fieldIndex, execInfo: execInfo}
+	var matchers []indexMatcher
+	for _, cond := range field.conds {
+		if !isRangeCond(cond) {
+			matcher, err := createIndexMatcher(field, cond, fieldIndex)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
-			valArr = append(valArr, valueBytes)
+			matchers = append(matchers, matcher)
+			continue
+		}
+		value, err := field.encode(cond.val)
+		if err != nil {
+			return nil, nil, err
 		}
-		if op == opIn {
-			return newInIndexIterator(indexDataStoreKey, valArr, execInfo), nil
+		inclusive := cond.op == opGe || cond.op == opLe
+		// values of descending fields are stored in reverse order,
+		// so a lower bound on the value is an upper bound on the stored bytes
+		isLowerBound := (cond.op == opGt || cond.op == opGe) != field.descending
+		if isLowerBound {
+			cmp := bytes.Compare(value, iter.lower)
+			if iter.lower == nil || cmp > 0 || (cmp == 0 && !inclusive) {
+				iter.lower, iter.lowerInclusive = value, inclusive
+			}
 		} else {
-			return &scanningIndexIterator{
+			cmp := bytes.Compare(value, iter.upper)
+			if iter.upper == nil || cmp < 0 || (cmp == 0 && !inclusive) {
+				iter.upper, iter.upperInclusive = value, inclusive
+			}
+		}
+	}
+
+	// records without a value never satisfy a range condition. Such records are stored
+	// at the start of an ascending and at the end of a descending field, so we exclude them
+	// by bounding the open side of the range.
+	nilValue, err := field.encode(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !field.descending && iter.lower == nil {
+		iter.lower, iter.lowerInclusive = nilValue, false
+	}
+	if field.descending && iter.upper == nil {
+		iter.upper, iter.upperInclusive = nilValue, false
+	}
+
+	return iter, matchers, nil
+}
+
+// createIndexIterator creates an iterator over the index records that satisfy the given
+// conditions.
+//
+// fieldsFilters contains the conditions of every index field in the order they are defined
+// in the index. The leading fields that are constrained only by _eq or _in are used to build
+// key prefixes that are looked up directly. If the field that follows them has range conditions
+// (_gt, _ge, _lt, _le), the iterator seeks to the range within each prefix. Conditions on the
+// rest of the fields are checked by scanning all records under those prefixes.
+func createIndexIterator(
+	indexDataStoreKey core.IndexDataStoreKey,
+	fieldsFilters []indexedFieldFilter,
+	isUnique bool,
+	execInfo *ExecInfo,
+) (indexIterator, error) {
+	prefixes := [][][]byte{{}}
+	prefixLen := 0
+	for ; prefixLen < len(fieldsFilters); prefixLen++ {
+		field := fieldsFilters[prefixLen]
+		if len(field.conds) != 1 {
+			break
+		}
+		var vals [][]byte
+		var err error
+		switch field.conds[0].op {
+		case opEq:
+			var val []byte
+			val, err = field.encode(field.conds[0].val)
+			vals = [][]byte{val}
+		case opIn:
+			vals, err = field.encodeArray(field.conds[0].val)
+			// iterating the values in the order of the index keeps the results in index order
+			sort.Slice(vals, func(i, j int) bool {
+				return bytes.Compare(vals[i], vals[j]) < 0
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+		if vals == nil {
+			break
+		}
+		newPrefixes := make([][][]byte, 0, len(prefixes)*len(vals))
+		for _, prefix := range prefixes {
+			for _, val := range vals {
+				newPrefix := make([][]byte, 0, len(prefix)+1)
+				newPrefix = append(newPrefix, prefix...)
+				newPrefixes = append(newPrefixes, append(newPrefix, val))
+			}
+		}
+		prefixes = newPrefixes
+	}
+
+	var rangeIter *rangeIndexIterator
+	matchers := make([]indexMatcher, 0)
+	for fieldIndex := prefixLen; fieldIndex < len(fieldsFilters); fieldIndex++ {
+		field := fieldsFilters[fieldIndex]
+		if fieldIndex == prefixLen && hasRangeCond(field.conds) {
+			var rangeMatchers []indexMatcher
+			var err error
+			rangeIter, rangeMatchers, err = newRangeIndexIterator(field, fieldIndex, execInfo)
+			if err != nil {
+				return nil, err
+			}
+			matchers = append(matchers, rangeMatchers...)
+			continue
+		}
+		for _, cond := range field.conds {
+			matcher, err := createIndexMatcher(field, cond, fieldIndex)
+			if err != nil {
+				return nil, err
+			}
+			matchers = append(matchers, matcher)
+		}
+	}
+
+	isNilPrefix := func(prefix [][]byte) (bool, error) {
+		for i := range prefix {
+			nilValue, err := fieldsFilters[i].encode(nil)
+			if err != nil {
+				return false, err
+			}
+			if bytes.Equal(prefix[i], nilValue) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	iterators := make([]indexIterator, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		switch {
+		case rangeIter != nil:
+			iter := *rangeIter
+			iter.indexKey = indexDataStoreKey
+			iter.values = prefix
+			iter.matcher = newAllIndexMatcher(matchers)
+			iterators = append(iterators, &iter)
+		case len(matchers) > 0:
+			iterators = append(iterators, &scanningIndexIterator{
 				indexKey: indexDataStoreKey,
-				matcher:  newNinIndexCmp(valArr, false),
+				values:   prefix,
+				matcher:  newAllIndexMatcher(matchers),
 				execInfo: execInfo,
-			}, nil
-		}
-	case opLike:
-		return &scanningIndexIterator{
-			indexKey: indexDataStoreKey,
-			matcher:  newLikeIndexCmp(filterVal.(string), true),
-			execInfo: execInfo,
-		}, nil
-	case opNlike:
-		return &scanningIndexIterator{
-			indexKey: indexDataStoreKey,
-			matcher:  newLikeIndexCmp(filterVal.(string), false),
-			execInfo: execInfo,
-		}, nil
-	}
-
-	return nil, errors.New("invalid index filter condition")
+			})
+		case isUnique && len(prefix) == len(fieldsFilters):
+			hasNil, err := isNilPrefix(prefix)
+			if err != nil {
+				return nil, err
+			}
+			if hasNil {
+				// records of documents that have no value for one of the fields are
+				// stored with the document key appended, so they can't be looked up directly
+				iterators = append(iterators, &eqPrefixIndexIterator{
+					indexKey: indexDataStoreKey,
+					values:   prefix,
+					execInfo: execInfo,
+				})
+			} else {
+				iterators = append(iterators, &eqSingleIndexIterator{
+					indexKey: indexDataStoreKey,
+					values:   prefix,
+					execInfo: execInfo,
+				})
+			}
+		default:
+			iterators = append(iterators, &eqPrefixIndexIterator{
+				indexKey: indexDataStoreKey,
+				values:   prefix,
+				execInfo: execInfo,
+			})
+		}
+	}
+
+	if len(iterators) == 1 {
+		return iterators[0], nil
+	}
+	return &multiIndexIterator{iterators: iterators}, nil
 }
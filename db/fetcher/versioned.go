@@ -13,6 +13,7 @@ package fetcher
 import (
 	"container/list"
 	"context"
+	"time"
 
 	dag "github.com/ipfs/boxo/ipld/merkledag"
 	"github.com/ipfs/go-cid"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/datastore/memory"
 	"github.com/sourcenetwork/defradb/db/base"
@@ -94,6 +96,9 @@ type VersionedFetcher struct {
 	col client.Collection
 	// @todo index  *client.IndexDescription
 	mCRDTs map[uint32]merklecrdt.MerkleCRDT
+
+	// hlc is the clock of the registers resolved by HLC replayed in the transient store.
+	hlc *corecrdt.HLC
 }
 
 // Init initializes the VersionedFetcher.
@@ -110,6 +115,7 @@ func (vf *VersionedFetcher) Init(
 	vf.col = col
 	vf.queuedCids = list.New()
 	vf.mCRDTs = make(map[uint32]merklecrdt.MerkleCRDT)
+	vf.hlc = corecrdt.NewHLC(time.Now)
 	vf.txn = txn
 
 	// create store
@@ -361,9 +367,7 @@ func (vf *VersionedFetcher) merge(c cid.Cid) error {
 		if !ok {
 			return client.NewErrFieldNotExist(l.Name)
 		}
-		// @todo: Right now we ONLY handle LWW_REGISTER, need to swith on this and
-		//        get CType from descriptions
-		if err := vf.processNode(uint32(field.ID), subNd, client.LWW_REGISTER, l.Name); err != nil {
+		if err := vf.processNode(uint32(field.ID), subNd, field.Typ, l.Name); err != nil {
 			return err
 		}
 	}
@@ -390,6 +394,7 @@ func (vf *VersionedFetcher) processNode(
 			ctype,
 			key,
 			fieldName,
+			vf.hlc,
 		)
 		if err != nil {
 			return err
@@ -11,13 +11,25 @@
 package db
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"strings"
 	"time"
 
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/sourcenetwork/immutable"
+
 	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/logging"
+	"github.com/sourcenetwork/defradb/planner/mapper"
 	"github.com/sourcenetwork/defradb/request/graphql/schema/types"
 )
 
@@ -29,12 +41,22 @@ type CollectionIndex interface {
 	Save(context.Context, datastore.Txn, *client.Document) error
 	// Update updates an existing document in the index
 	Update(context.Context, datastore.Txn, *client.Document, *client.Document) error
+	// Delete removes a document from the index
+	Delete(context.Context, datastore.Txn, *client.Document) error
 	// RemoveAll removes all documents from the index
 	RemoveAll(context.Context, datastore.Txn) error
 	// Name returns the name of the index
 	Name() string
 	// Description returns the description of the index
 	Description() client.IndexDescription
+	// getDocumentsIndexRecords returns the records the given document is stored with in the index
+	getDocumentsIndexRecords(*client.Document) ([]indexRecord, error)
+}
+
+// indexRecord is a key and value pair stored by an index.
+type indexRecord struct {
+	key   core.IndexDataStoreKey
+	value []byte
 }
 
 func canConvertIndexFieldValue[T any](val any) bool {
@@ -44,12 +66,19 @@ func canConvertIndexFieldValue[T any](val any) bool {
 
 func getValidateIndexFieldFunc(kind client.FieldKind) func(any) bool {
 	switch kind {
-	case client.FieldKind_STRING, client.FieldKind_FOREIGN_OBJECT:
+	case client.FieldKind_STRING, client.FieldKind_FOREIGN_OBJECT, client.FieldKind_DocKey:
 		return canConvertIndexFieldValue[string]
 	case client.FieldKind_INT:
 		return canConvertIndexFieldValue[int64]
 	case client.FieldKind_FLOAT:
-		return canConvertIndexFieldValue[float64]
+		return func(val any) bool {
+			// whole numbers of float fields may be stored as integers
+			switch val.(type) {
+			case float64, int64:
+				return true
+			}
+			return false
+		}
 	case client.FieldKind_BOOL:
 		return canConvertIndexFieldValue[bool]
 	case client.FieldKind_BLOB:
@@ -69,6 +98,33 @@ func getValidateIndexFieldFunc(kind client.FieldKind) func(any) bool {
 			_, err := time.Parse(time.RFC3339, timeStrVal)
 			return err == nil
 		}
+	// inline arrays are indexed by their elements, so the returned functions validate single elements
+	case client.FieldKind_BOOL_ARRAY, client.FieldKind_NILLABLE_BOOL_ARRAY:
+		return canConvertIndexFieldValue[bool]
+	case client.FieldKind_INT_ARRAY, client.FieldKind_NILLABLE_INT_ARRAY:
+		return func(val any) bool {
+			// elements of arrays parsed from JSON are floats
+			switch v := val.(type) {
+			case int64:
+				return true
+			case float64:
+				return v == math.Trunc(v)
+			}
+			return false
+		}
+	case client.FieldKind_FLOAT_ARRAY, client.FieldKind_NILLABLE_FLOAT_ARRAY:
+		return getValidateIndexFieldFunc(client.FieldKind_FLOAT)
+	case client.FieldKind_STRING_ARRAY, client.FieldKind_NILLABLE_STRING_ARRAY:
+		return canConvertIndexFieldValue[string]
+	// JSON fields are indexed by the scalar value at a path
+	case client.FieldKind_JSON:
+		return func(val any) bool {
+			switch val.(type) {
+			case bool, string, float64, int64:
+				return true
+			}
+			return false
+		}
 	default:
 		return nil
 	}
@@ -90,60 +146,434 @@ func NewCollectionIndex(
 	if len(desc.Fields) == 0 {
 		return nil, NewErrIndexDescHasNoFields(desc)
 	}
-	index := &collectionSimpleIndex{collection: collection, desc: desc}
-	field, foundField := collection.Schema().GetField(desc.Fields[0].Name)
-	if !foundField {
-		return nil, NewErrIndexDescHasNonExistingField(desc, desc.Fields[0].Name)
+	base := collectionBaseIndex{collection: collection, desc: desc}
+	base.fieldsDescs = make([]client.FieldDescription, 0, len(desc.Fields))
+	base.validateFieldFuncs = make([]func(any) bool, 0, len(desc.Fields))
+	for _, indexedField := range desc.Fields {
+		field, foundField := collection.Schema().GetField(indexedField.Name)
+		if !foundField {
+			return nil, NewErrIndexDescHasNonExistingField(desc, indexedField.Name)
+		}
+		validateFunc, err := getFieldValidateFunc(field.Kind)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateIndexCollation(indexedField, field); err != nil {
+			return nil, err
+		}
+		if err := validateIndexPath(indexedField, field); err != nil {
+			return nil, err
+		}
+		base.fieldsDescs = append(base.fieldsDescs, field)
+		base.validateFieldFuncs = append(base.validateFieldFuncs, validateFunc)
 	}
-	var e error
-	index.fieldDesc = field
-	index.validateFieldFunc, e = getFieldValidateFunc(field.Kind)
-	return index, e
+	if len(desc.Where) > 0 {
+		err := validateIndexFilter(collection.Schema(), desc.Where)
+		if err != nil {
+			return nil, err
+		}
+		base.filterFieldNames, base.filter = newIndexFilter(collection.Schema(), desc.Where)
+	}
+	if desc.FullText != nil {
+		err := validateFullTextIndex(desc, base.fieldsDescs)
+		if err != nil {
+			return nil, err
+		}
+		return &collectionFullTextIndex{collectionBaseIndex: base}, nil
+	}
+	if desc.Unique {
+		return &collectionUniqueIndex{collectionBaseIndex: base}, nil
+	}
+	return &collectionSimpleIndex{collectionBaseIndex: base}, nil
 }
 
-// collectionSimpleIndex is an non-unique index that indexes documents by a single field.
-// Single-field indexes store values only in ascending order.
-type collectionSimpleIndex struct {
-	collection        client.Collection
-	desc              client.IndexDescription
-	validateFieldFunc func(any) bool
-	fieldDesc         client.FieldDescription
+// validateIndexCollation checks that the collation of an indexed field is known
+// and that only string fields are case insensitive.
+func validateIndexCollation(indexedField client.IndexedFieldDescription, field client.FieldDescription) error {
+	switch indexedField.Collation {
+	case "", client.BinaryCollation:
+		return nil
+	case client.CaseInsensitiveCollation:
+		switch field.Kind {
+		case client.FieldKind_STRING, client.FieldKind_STRING_ARRAY, client.FieldKind_NILLABLE_STRING_ARRAY:
+			return nil
+		}
+	}
+	return NewErrInvalidIndexCollation(indexedField.Name, indexedField.Collation)
 }
 
-var _ CollectionIndex = (*collectionSimpleIndex)(nil)
+// validateIndexPath checks that JSON fields, and only them, are indexed by path.
+func validateIndexPath(indexedField client.IndexedFieldDescription, field client.FieldDescription) error {
+	if field.Kind == client.FieldKind_JSON {
+		if len(indexedField.Path) == 0 {
+			return NewErrIndexJSONFieldWithoutPath(indexedField.Name)
+		}
+		return nil
+	}
+	if len(indexedField.Path) > 0 {
+		return NewErrIndexPathOnNonJSONField(indexedField.Name, field.Kind)
+	}
+	return nil
+}
 
-func (i *collectionSimpleIndex) getDocumentsIndexKey(
+// validateFullTextIndex checks that a full-text index has a single String field and
+// supported options.
+func validateFullTextIndex(desc client.IndexDescription, fields []client.FieldDescription) error {
+	if len(fields) != 1 {
+		return NewErrFullTextIndexWithMultipleFields(len(fields))
+	}
+	if desc.Unique {
+		return ErrUniqueFullTextIndex
+	}
+	if fields[0].Kind != client.FieldKind_STRING {
+		return NewErrUnsupportedFullTextIndexFieldType(fields[0].Kind)
+	}
+	switch desc.FullText.Tokenizer {
+	case "", client.FullTextWordTokenizer, client.FullTextWhitespaceTokenizer:
+		return nil
+	default:
+		return NewErrUnknownFullTextTokenizer(desc.FullText.Tokenizer)
+	}
+}
+
+// collectionBaseIndex holds the functionality shared by all index types.
+type collectionBaseIndex struct {
+	collection client.Collection
+	desc       client.IndexDescription
+	// fieldsDescs and validateFieldFuncs are in the same order as the index fields
+	fieldsDescs        []client.FieldDescription
+	validateFieldFuncs []func(any) bool
+	// filter is the filter of a partial index, it is nil if all documents are indexed.
+	// The properties of the filter are the collection fields in the order of filterFieldNames.
+	filter           *mapper.Filter
+	filterFieldNames []string
+}
+
+// validateIndexFilter checks that the filter of an index only refers to the fields of the
+// indexed collection, as the index can't be kept up to date with the fields of related documents.
+func validateIndexFilter(schema client.SchemaDescription, conditions map[string]any) error {
+	for key, clause := range conditions {
+		if strings.HasPrefix(key, "_") && key != request.KeyFieldName {
+			switch key {
+			case request.FilterOpAnd, request.FilterOpOr:
+				items, ok := clause.([]any)
+				if !ok {
+					return NewErrInvalidIndexFilterOperator(key)
+				}
+				for _, item := range items {
+					itemConditions, ok := item.(map[string]any)
+					if !ok {
+						return NewErrInvalidIndexFilterOperator(key)
+					}
+					if err := validateIndexFilter(schema, itemConditions); err != nil {
+						return err
+					}
+				}
+			case request.FilterOpNot:
+				notConditions, ok := clause.(map[string]any)
+				if !ok {
+					return NewErrInvalidIndexFilterOperator(key)
+				}
+				if err := validateIndexFilter(schema, notConditions); err != nil {
+					return err
+				}
+			default:
+				// comparison operators have to be applied to a field
+				return NewErrInvalidIndexFilterOperator(key)
+			}
+			continue
+		}
+		field, ok := schema.GetField(key)
+		if !ok || field.IsObject() {
+			return NewErrInvalidIndexFilterField(key)
+		}
+		fieldConditions, ok := clause.(map[string]any)
+		if !ok {
+			return NewErrInvalidIndexFilterField(key)
+		}
+		for operator := range fieldConditions {
+			if !strings.HasPrefix(operator, "_") {
+				return NewErrInvalidIndexFilterField(key)
+			}
+		}
+	}
+	return nil
+}
+
+// newIndexFilter converts the filter conditions of an index into a filter that can be run
+// against the documents built by `isDocIndexed`.
+func newIndexFilter(schema client.SchemaDescription, conditions map[string]any) ([]string, *mapper.Filter) {
+	mapping := core.NewDocumentMapping()
+	fieldNames := make([]string, 0, len(schema.Fields))
+	for i, field := range schema.Fields {
+		mapping.Add(i, field.Name)
+		fieldNames = append(fieldNames, field.Name)
+	}
+	return fieldNames, mapper.ToFilter(request.Filter{Conditions: conditions}, mapping)
+}
+
+// isDocIndexed returns true if the given document matches the filter of the index.
+func (i *collectionBaseIndex) isDocIndexed(doc *client.Document) (bool, error) {
+	if i.filter == nil {
+		return true, nil
+	}
+	filterDoc := core.Doc{Fields: make(core.DocFields, len(i.filterFieldNames))}
+	for fieldIndex, fieldName := range i.filterFieldNames {
+		if fieldName == request.KeyFieldName {
+			filterDoc.Fields[fieldIndex] = doc.Key().String()
+			continue
+		}
+		fieldVal, err := doc.GetValue(fieldName)
+		if err != nil {
+			if errors.Is(err, client.ErrFieldNotExist) {
+				continue
+			}
+			return false, err
+		}
+		filterDoc.Fields[fieldIndex] = fieldVal.Value()
+	}
+	return mapper.RunFilter(filterDoc, i.filter)
+}
+
+// indexedFieldValue is the encoded value of an indexed field.
+type indexedFieldValue struct {
+	value []byte
+	isNil bool
+}
+
+// indexedValues are the encoded values of all indexed fields for a single index record.
+type indexedValues struct {
+	values      [][]byte
+	hasNilValue bool
+}
+
+// getDocFieldValues returns the encoded values of all indexed fields of the given document
+// in the order they are defined in the index.
+//
+// Inline array fields are indexed by every distinct element, so a document gets an index record
+// for every combination of the values of its fields. An empty array is indexed as a missing value.
+func (i *collectionBaseIndex) getDocFieldValues(doc *client.Document) ([]indexedValues, error) {
+	result := []indexedValues{{values: make([][]byte, 0, len(i.fieldsDescs))}}
+	for fieldIndex := range i.fieldsDescs {
+		fieldValues, err := i.getDocFieldValue(doc, fieldIndex)
+		if err != nil {
+			return nil, err
+		}
+		newResult := make([]indexedValues, 0, len(result)*len(fieldValues))
+		for _, prev := range result {
+			for _, fieldValue := range fieldValues {
+				values := make([][]byte, 0, len(i.fieldsDescs))
+				values = append(values, prev.values...)
+				newResult = append(newResult, indexedValues{
+					values:      append(values, fieldValue.value),
+					hasNilValue: prev.hasNilValue || fieldValue.isNil,
+				})
+			}
+		}
+		result = newResult
+	}
+	return result, nil
+}
+
+func (i *collectionBaseIndex) getDocFieldValue(
 	doc *client.Document,
-) (core.IndexDataStoreKey, error) {
-	fieldValue, err := i.getDocFieldValue(doc)
+	fieldIndex int,
+) ([]indexedFieldValue, error) {
+	fieldDesc := i.fieldsDescs[fieldIndex]
+	fieldVal, err := doc.GetValue(fieldDesc.Name)
 	if err != nil {
-		return core.IndexDataStoreKey{}, err
+		if errors.Is(err, client.ErrFieldNotExist) {
+			return i.encodeFieldValues(fieldIndex, []any{nil})
+		} else {
+			return nil, err
+		}
+	}
+	if fieldVal.Value() == nil {
+		return i.encodeFieldValues(fieldIndex, []any{nil})
+	}
+	if path := i.desc.Fields[fieldIndex].Path; len(path) > 0 {
+		val := core.GetJSONPathValue(fieldVal.Value(), path)
+		if val != nil && !i.validateFieldFuncs[fieldIndex](val) {
+			// objects and arrays are not indexed
+			val = nil
+		}
+		return i.encodeFieldValues(fieldIndex, []any{val})
+	}
+	if !fieldDesc.IsArray() {
+		if !i.validateFieldFuncs[fieldIndex](fieldVal.Value()) {
+			return nil, NewErrInvalidFieldValue(fieldDesc.Kind, fieldVal)
+		}
+		return i.encodeFieldValues(fieldIndex, []any{fieldVal.Value()})
+	}
+	elements, ok := getArrayElements(fieldVal.Value())
+	if !ok {
+		return nil, NewErrInvalidFieldValue(fieldDesc.Kind, fieldVal)
+	}
+	for _, element := range elements {
+		if element != nil && !i.validateFieldFuncs[fieldIndex](element) {
+			return nil, NewErrInvalidFieldValue(fieldDesc.Kind, fieldVal)
+		}
+	}
+	if len(elements) == 0 {
+		elements = []any{nil}
+	}
+	return i.encodeFieldValues(fieldIndex, elements)
+}
+
+// encodeFieldValues encodes the given values of the indexed field, skipping duplicates.
+//
+// Values of case insensitive fields are lowercased.
+func (i *collectionBaseIndex) encodeFieldValues(fieldIndex int, vals []any) ([]indexedFieldValue, error) {
+	fieldDesc := i.fieldsDescs[fieldIndex]
+	descending := i.desc.Fields[fieldIndex].Direction == client.Descending
+	caseInsensitive := i.desc.Fields[fieldIndex].IsCaseInsensitive()
+	result := make([]indexedFieldValue, 0, len(vals))
+	seen := make(map[string]struct{}, len(vals))
+	for _, val := range vals {
+		if strVal, ok := val.(string); ok && caseInsensitive {
+			val = strings.ToLower(strVal)
+		}
+		encoded, err := core.EncodeIndexFieldValue(fieldDesc.Kind, val, descending)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[string(encoded)]; ok {
+			continue
+		}
+		seen[string(encoded)] = struct{}{}
+		result = append(result, indexedFieldValue{value: encoded, isNil: val == nil})
 	}
+	return result, nil
+}
 
+// getArrayElements returns the elements of the given inline array value.
+// Missing elements of nillable arrays are returned as nil.
+func getArrayElements(val any) ([]any, bool) {
+	if elements, ok := val.([]any); ok {
+		return elements, true
+	}
+	arr := reflect.ValueOf(val)
+	if arr.Kind() != reflect.Slice {
+		return nil, false
+	}
+	elements := make([]any, arr.Len())
+	for i := range elements {
+		switch element := arr.Index(i).Interface().(type) {
+		case immutable.Option[bool]:
+			elements[i] = getOptionValue(element)
+		case immutable.Option[int64]:
+			elements[i] = getOptionValue(element)
+		case immutable.Option[float64]:
+			elements[i] = getOptionValue(element)
+		case immutable.Option[string]:
+			elements[i] = getOptionValue(element)
+		default:
+			elementVal := arr.Index(i)
+			if elementVal.Kind() == reflect.Pointer {
+				if elementVal.IsNil() {
+					continue
+				}
+				elementVal = elementVal.Elem()
+			}
+			elements[i] = elementVal.Interface()
+		}
+	}
+	return elements, true
+}
+
+func getOptionValue[T any](opt immutable.Option[T]) any {
+	if !opt.HasValue() {
+		return nil
+	}
+	return opt.Value()
+}
+
+func (i *collectionBaseIndex) newIndexKey(fieldValues ...[]byte) core.IndexDataStoreKey {
 	indexDataStoreKey := core.IndexDataStoreKey{}
 	indexDataStoreKey.CollectionID = i.collection.ID()
 	indexDataStoreKey.IndexID = i.desc.ID
-	indexDataStoreKey.FieldValues = [][]byte{fieldValue, []byte(doc.Key().String())}
-	return indexDataStoreKey, nil
+	indexDataStoreKey.FieldValues = fieldValues
+	return indexDataStoreKey
 }
 
-func (i *collectionSimpleIndex) getDocFieldValue(doc *client.Document) ([]byte, error) {
-	// collectionSimpleIndex only supports single field indexes, that's why we
-	// can safely access the first field
-	indexedFieldName := i.desc.Fields[0].Name
-	fieldVal, err := doc.GetValue(indexedFieldName)
+func (i *collectionBaseIndex) deleteIndexKey(
+	ctx context.Context,
+	txn datastore.Txn,
+	key core.IndexDataStoreKey,
+) error {
+	exists, err := txn.Datastore().Has(ctx, key.ToDS())
 	if err != nil {
-		if errors.Is(err, client.ErrFieldNotExist) {
-			return client.NewCBORValue(client.LWW_REGISTER, nil).Bytes()
-		} else {
-			return nil, err
+		return err
+	}
+	if !exists {
+		if i.desc.Status.Building {
+			// the document has not been reached by the background build yet
+			return nil
+		}
+		return NewErrCorruptedIndex(i.desc.Name)
+	}
+	return txn.Datastore().Delete(ctx, key.ToDS())
+}
+
+// RemoveAll remove all artifacts of the index from the storage, i.e. all index
+// field values for all documents.
+func (i *collectionBaseIndex) RemoveAll(ctx context.Context, txn datastore.Txn) error {
+	prefixKey := core.IndexDataStoreKey{}
+	prefixKey.CollectionID = i.collection.ID()
+	prefixKey.IndexID = i.desc.ID
+
+	keys, err := datastore.FetchKeysForPrefix(ctx, prefixKey.ToString(), txn.Datastore())
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		err := txn.Datastore().Delete(ctx, key)
+		if err != nil {
+			return NewCanNotDeleteIndexedField(err)
 		}
 	}
-	writeableVal, ok := fieldVal.(client.WriteableValue)
-	if !ok || !i.validateFieldFunc(fieldVal.Value()) {
-		return nil, NewErrInvalidFieldValue(i.fieldDesc.Kind, writeableVal)
+
+	return nil
+}
+
+// Name returns the name of the index
+func (i *collectionBaseIndex) Name() string {
+	return i.desc.Name
+}
+
+// Description returns the description of the index
+func (i *collectionBaseIndex) Description() client.IndexDescription {
+	return i.desc
+}
+
+// collectionSimpleIndex is an non-unique index that indexes documents by one or more fields.
+type collectionSimpleIndex struct {
+	collectionBaseIndex
+}
+
+var _ CollectionIndex = (*collectionSimpleIndex)(nil)
+
+// getDocumentsIndexRecords returns the index records of the given document.
+// Records of a non-unique index hold the document key as the last segment of the key
+// and have no value. Documents that don't match the filter of a partial index have no records.
+func (i *collectionSimpleIndex) getDocumentsIndexRecords(
+	doc *client.Document,
+) ([]indexRecord, error) {
+	isIndexed, err := i.isDocIndexed(doc)
+	if err != nil || !isIndexed {
+		return nil, err
+	}
+	docValues, err := i.getDocFieldValues(doc)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]indexRecord, 0, len(docValues))
+	for _, entry := range docValues {
+		key := i.newIndexKey(append(entry.values, []byte(doc.Key().String()))...)
+		records = append(records, indexRecord{key: key, value: []byte{}})
 	}
-	return writeableVal.Bytes()
+	return records, nil
 }
 
 // Save indexes a document by storing the indexed field value.
@@ -152,13 +582,15 @@ func (i *collectionSimpleIndex) Save(
 	txn datastore.Txn,
 	doc *client.Document,
 ) error {
-	key, err := i.getDocumentsIndexKey(doc)
+	records, err := i.getDocumentsIndexRecords(doc)
 	if err != nil {
 		return err
 	}
-	err = txn.Datastore().Put(ctx, key.ToDS(), []byte{})
-	if err != nil {
-		return NewErrFailedToStoreIndexedField(key.ToDS().String(), err)
+	for _, record := range records {
+		err = txn.Datastore().Put(ctx, record.key.ToDS(), record.value)
+		if err != nil {
+			return NewErrFailedToStoreIndexedField(record.key.ToDS().String(), err)
+		}
 	}
 	return nil
 }
@@ -171,52 +603,360 @@ func (i *collectionSimpleIndex) Update(
 	oldDoc *client.Document,
 	newDoc *client.Document,
 ) error {
-	key, err := i.getDocumentsIndexKey(oldDoc)
+	err := i.Delete(ctx, txn, oldDoc)
 	if err != nil {
 		return err
 	}
-	exists, err := txn.Datastore().Has(ctx, key.ToDS())
+	return i.Save(ctx, txn, newDoc)
+}
+
+// Delete removes the index entries of the given document.
+func (i *collectionSimpleIndex) Delete(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+) error {
+	records, err := i.getDocumentsIndexRecords(doc)
 	if err != nil {
 		return err
 	}
-	if !exists {
-		return NewErrCorruptedIndex(i.desc.Name)
+	for _, record := range records {
+		if err := i.deleteIndexKey(ctx, txn, record.key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectionUniqueIndex is an index that guarantees that no two documents
+// have the same values of the indexed fields.
+//
+// The index key of a unique index consists only of the field values and the document
+// key is stored as the value of the index record. Documents that have no value for
+// any of the indexed fields are not constrained and are stored the same way as in
+// a non-unique index, i.e. with the document key as the last segment of the index key.
+//
+// Documents merged from remote peers can't be rejected by the constraint, as the replicas
+// would otherwise never converge. If such a document has the same values as another document,
+// it is stored as a conflict, the same way as a document without a value, so that both
+// documents are found by the index.
+type collectionUniqueIndex struct {
+	collectionBaseIndex
+}
+
+var _ CollectionIndex = (*collectionUniqueIndex)(nil)
+
+// getDocumentsIndexRecords returns the index keys and the values to be stored for the given document.
+// Documents that don't match the filter of a partial index have no records.
+func (i *collectionUniqueIndex) getDocumentsIndexRecords(
+	doc *client.Document,
+) ([]indexRecord, error) {
+	isIndexed, err := i.isDocIndexed(doc)
+	if err != nil || !isIndexed {
+		return nil, err
+	}
+	docValues, err := i.getDocFieldValues(doc)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]indexRecord, 0, len(docValues))
+	for _, entry := range docValues {
+		if entry.hasNilValue {
+			key := i.newIndexKey(append(entry.values, []byte(doc.Key().String()))...)
+			records = append(records, indexRecord{key: key, value: []byte{}})
+		} else {
+			records = append(records, indexRecord{key: i.newIndexKey(entry.values...), value: []byte(doc.Key().String())})
+		}
+	}
+	return records, nil
+}
+
+// newConflictRecord returns the record the document of the given record is stored with
+// when another document is already stored with the same values.
+func newConflictRecord(record indexRecord) indexRecord {
+	key := record.key
+	key.FieldValues = make([][]byte, 0, len(record.key.FieldValues)+1)
+	key.FieldValues = append(key.FieldValues, record.key.FieldValues...)
+	key.FieldValues = append(key.FieldValues, record.value)
+	return indexRecord{key: key, value: []byte{}}
+}
+
+func (i *collectionUniqueIndex) newUniqueIndexError(doc *client.Document) error {
+	kvs := make([]errors.KV, 0, len(i.fieldsDescs))
+	for _, fieldDesc := range i.fieldsDescs {
+		fieldVal, err := doc.GetValue(fieldDesc.Name)
+		if err != nil {
+			return err
+		}
+		kvs = append(kvs, errors.NewKV(fieldDesc.Name, fieldVal.Value()))
+	}
+	return NewErrCanNotIndexNonUniqueFields(doc.Key().String(), kvs...)
+}
+
+// isValueTaken returns true if a document is already stored with the values of the given record,
+// either as the owner of the record or as a conflict.
+func (i *collectionUniqueIndex) isValueTaken(
+	ctx context.Context,
+	txn datastore.Txn,
+	record indexRecord,
+) (bool, error) {
+	exists, err := txn.Datastore().Has(ctx, record.key.ToDS())
+	if err != nil || exists {
+		return exists, err
 	}
-	err = txn.Datastore().Delete(ctx, key.ToDS())
+	// conflicts can outlive the document that was stored as the owner of the record
+	q, err := txn.Datastore().Query(ctx, query.Query{
+		Prefix:   record.key.ToString(),
+		KeysOnly: true,
+		Limit:    1,
+	})
+	if err != nil {
+		return false, err
+	}
+	res, hasConflict := q.NextSync()
+	if err := q.Close(); err != nil {
+		return false, err
+	}
+	return hasConflict, res.Error
+}
+
+// Save indexes a document by storing the indexed field values.
+// It returns an error if another document is already indexed with the same value.
+func (i *collectionUniqueIndex) Save(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+) error {
+	records, err := i.getDocumentsIndexRecords(doc)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if len(record.value) > 0 {
+			taken, err := i.isValueTaken(ctx, txn, record)
+			if err != nil {
+				return err
+			}
+			if taken {
+				return i.newUniqueIndexError(doc)
+			}
+		}
+		err = txn.Datastore().Put(ctx, record.key.ToDS(), record.value)
+		if err != nil {
+			return NewErrFailedToStoreIndexedField(record.key.ToDS().String(), err)
+		}
+	}
+	return nil
+}
+
+// saveMerged indexes a document merged from a remote peer.
+//
+// Unlike Save, it doesn't fail if another document is already indexed with the same value,
+// the document is stored as a conflict instead.
+func (i *collectionUniqueIndex) saveMerged(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+) error {
+	records, err := i.getDocumentsIndexRecords(doc)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if len(record.value) > 0 {
+			exists, err := txn.Datastore().Has(ctx, record.key.ToDS())
+			if err != nil {
+				return err
+			}
+			if exists {
+				log.Info(
+					ctx,
+					"Merged document violates unique index",
+					logging.NewKV("Index", i.desc.Name),
+					logging.NewKV("DocKey", doc.Key().String()),
+				)
+				record = newConflictRecord(record)
+			}
+		}
+		err = txn.Datastore().Put(ctx, record.key.ToDS(), record.value)
+		if err != nil {
+			return NewErrFailedToStoreIndexedField(record.key.ToDS().String(), err)
+		}
+	}
+	return nil
+}
+
+// Update updates indexed field values of an existing document.
+// It removes the old document from the index and adds the new one, checking
+// that the new value does not violate the uniqueness constraint.
+func (i *collectionUniqueIndex) Update(
+	ctx context.Context,
+	txn datastore.Txn,
+	oldDoc *client.Document,
+	newDoc *client.Document,
+) error {
+	err := i.Delete(ctx, txn, oldDoc)
 	if err != nil {
 		return err
 	}
 	return i.Save(ctx, txn, newDoc)
 }
 
-// RemoveAll remove all artifacts of the index from the storage, i.e. all index
-// field values for all documents.
-func (i *collectionSimpleIndex) RemoveAll(ctx context.Context, txn datastore.Txn) error {
-	prefixKey := core.IndexDataStoreKey{}
-	prefixKey.CollectionID = i.collection.ID()
-	prefixKey.IndexID = i.desc.ID
+// updateMerged updates indexed field values of a document merged from a remote peer.
+func (i *collectionUniqueIndex) updateMerged(
+	ctx context.Context,
+	txn datastore.Txn,
+	oldDoc *client.Document,
+	newDoc *client.Document,
+) error {
+	err := i.Delete(ctx, txn, oldDoc)
+	if err != nil {
+		return err
+	}
+	return i.saveMerged(ctx, txn, newDoc)
+}
 
-	keys, err := datastore.FetchKeysForPrefix(ctx, prefixKey.ToString(), txn.Datastore())
+// Delete removes the index records of the given document.
+//
+// If another document owns the record with the values of the document, the document
+// is stored as a conflict and only the conflict is removed.
+func (i *collectionUniqueIndex) Delete(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+) error {
+	records, err := i.getDocumentsIndexRecords(doc)
 	if err != nil {
 		return err
 	}
+	for _, record := range records {
+		if len(record.value) > 0 {
+			value, err := txn.Datastore().Get(ctx, record.key.ToDS())
+			if err != nil && !errors.Is(err, ds.ErrNotFound) {
+				return err
+			}
+			if !bytes.Equal(value, record.value) {
+				record = newConflictRecord(record)
+			}
+		}
+		if err := i.deleteIndexKey(ctx, txn, record.key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	for _, key := range keys {
-		err := txn.Datastore().Delete(ctx, key)
+// collectionFullTextIndex is an inverted index that indexes documents by the terms
+// of a single String field.
+//
+// Every distinct term of a document has its own record, whose key consists of the term and
+// the document key. The value of the record holds the number of occurrences of the term
+// followed by the number of terms of the document, both as uvarints, which are used
+// to rank the documents by relevance.
+type collectionFullTextIndex struct {
+	collectionBaseIndex
+}
+
+var _ CollectionIndex = (*collectionFullTextIndex)(nil)
+
+// getDocumentsIndexRecords returns the index records of the given document.
+// Documents without a value for the indexed field, or whose value has no terms, have no records,
+// just like documents that don't match the filter of a partial index.
+func (i *collectionFullTextIndex) getDocumentsIndexRecords(
+	doc *client.Document,
+) ([]indexRecord, error) {
+	isIndexed, err := i.isDocIndexed(doc)
+	if err != nil || !isIndexed {
+		return nil, err
+	}
+	fieldDesc := i.fieldsDescs[0]
+	fieldVal, err := doc.GetValue(fieldDesc.Name)
+	if err != nil {
+		if errors.Is(err, client.ErrFieldNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if fieldVal.Value() == nil {
+		return nil, nil
+	}
+	text, ok := fieldVal.Value().(string)
+	if !ok {
+		return nil, NewErrInvalidFieldValue(fieldDesc.Kind, fieldVal)
+	}
+
+	terms := core.AnalyzeText(*i.desc.FullText, text)
+	termFrequencies := make(map[string]uint64, len(terms))
+	distinctTerms := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if termFrequencies[term] == 0 {
+			distinctTerms = append(distinctTerms, term)
+		}
+		termFrequencies[term]++
+	}
+
+	records := make([]indexRecord, 0, len(distinctTerms))
+	for _, term := range distinctTerms {
+		encodedTerm, err := core.EncodeIndexFieldValue(fieldDesc.Kind, term, false)
 		if err != nil {
-			return NewCanNotDeleteIndexedField(err)
+			return nil, err
 		}
+		value := binary.AppendUvarint(nil, termFrequencies[term])
+		value = binary.AppendUvarint(value, uint64(len(terms)))
+		key := i.newIndexKey(encodedTerm, []byte(doc.Key().String()))
+		records = append(records, indexRecord{key: key, value: value})
 	}
+	return records, nil
+}
 
+// Save indexes a document by storing a record for every distinct term of the indexed field.
+func (i *collectionFullTextIndex) Save(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+) error {
+	records, err := i.getDocumentsIndexRecords(doc)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		err = txn.Datastore().Put(ctx, record.key.ToDS(), record.value)
+		if err != nil {
+			return NewErrFailedToStoreIndexedField(record.key.ToDS().String(), err)
+		}
+	}
 	return nil
 }
 
-// Name returns the name of the index
-func (i *collectionSimpleIndex) Name() string {
-	return i.desc.Name
+// Update updates the terms of an existing document.
+// It removes the old document from the index and adds the new one.
+func (i *collectionFullTextIndex) Update(
+	ctx context.Context,
+	txn datastore.Txn,
+	oldDoc *client.Document,
+	newDoc *client.Document,
+) error {
+	err := i.Delete(ctx, txn, oldDoc)
+	if err != nil {
+		return err
+	}
+	return i.Save(ctx, txn, newDoc)
 }
 
-// Description returns the description of the index
-func (i *collectionSimpleIndex) Description() client.IndexDescription {
-	return i.desc
+// Delete removes the index records of the given document.
+func (i *collectionFullTextIndex) Delete(
+	ctx context.Context,
+	txn datastore.Txn,
+	doc *client.Document,
+) error {
+	records, err := i.getDocumentsIndexRecords(doc)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := i.deleteIndexKey(ctx, txn, record.key); err != nil {
+			return err
+		}
+	}
+	return nil
 }
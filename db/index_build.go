@@ -0,0 +1,437 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	badgerds "github.com/sourcenetwork/defradb/datastore/badger/v4"
+	"github.com/sourcenetwork/defradb/db/base"
+	"github.com/sourcenetwork/defradb/db/fetcher"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/logging"
+)
+
+// indexBuildBatchSize is the number of documents a background index build
+// indexes within a single transaction.
+var indexBuildBatchSize = 100
+
+// startIndexBuild starts indexing the existing documents of the collection in the background.
+//
+// The build is stopped when the database is closed, and resumed the next time it is opened.
+func (db *db) startIndexBuild(colName, indexName string) {
+	db.indexBuilds.Add(1)
+	go func() {
+		defer db.indexBuilds.Done()
+		err := db.buildIndex(db.indexBuildCtx, colName, indexName)
+		if err != nil && db.indexBuildCtx.Err() == nil {
+			log.ErrorE(
+				db.indexBuildCtx,
+				"Failed to build index",
+				err,
+				logging.NewKV("Collection", colName),
+				logging.NewKV("Index", indexName),
+			)
+		}
+	}()
+}
+
+// rebuildOutdatedIndexes removes the records of the indexes stored with an older encoding
+// and marks them as building, so that they are rebuilt in the background by resumeIndexBuilds.
+//
+// The indexes are not used by queries until they have been rebuilt.
+func (db *db) rebuildOutdatedIndexes(ctx context.Context) error {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	indexes, err := db.getAllIndexes(ctx, txn)
+	if err != nil {
+		return err
+	}
+	for colName, colIndexes := range indexes {
+		var col *collection
+		for _, desc := range colIndexes {
+			if desc.EncodingVersion >= core.IndexEncodingVersion {
+				continue
+			}
+			if col == nil {
+				c, err := db.getCollectionByName(ctx, txn, colName)
+				if err != nil {
+					return NewErrCanNotReadCollection(colName, err)
+				}
+				col = c.WithTxn(txn).(*collection)
+			}
+			if err := col.rebuildIndex(ctx, txn, desc.Name); err != nil {
+				return err
+			}
+			log.Info(
+				ctx,
+				"Rebuilding index stored with an outdated encoding",
+				logging.NewKV("Collection", colName),
+				logging.NewKV("Index", desc.Name),
+			)
+		}
+	}
+	return txn.Commit(ctx)
+}
+
+// rebuildIndex removes the records of the index and marks it as building with the current
+// encoding, the build itself is left to resumeIndexBuilds.
+func (c *collection) rebuildIndex(ctx context.Context, txn datastore.Txn, indexName string) error {
+	for _, index := range c.indexes {
+		if index.Name() != indexName {
+			continue
+		}
+		if err := index.RemoveAll(ctx, txn); err != nil {
+			return err
+		}
+		totalDocs, err := c.countDocs(ctx, txn)
+		if err != nil {
+			return err
+		}
+		progress := client.IndexBuildProgress{TotalDocs: totalDocs}
+		if err := storeIndexBuildProgress(ctx, txn, c.Name(), indexName, progress); err != nil {
+			return err
+		}
+		desc := index.Description()
+		desc.EncodingVersion = core.IndexEncodingVersion
+		desc.Status = client.IndexStatus{Building: true}
+		return storeIndexDescription(ctx, txn, c.Name(), desc)
+	}
+	return NewErrIndexWithNameDoesNotExists(indexName)
+}
+
+// resumeIndexBuilds starts the background builds of the indexes that were not finished
+// when the database was closed.
+//
+// The builds start over from the first document, as indexing a document twice has no effect.
+func (db *db) resumeIndexBuilds(ctx context.Context) error {
+	txn, err := db.NewTxn(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	indexes, err := db.getAllIndexes(ctx, txn)
+	if err != nil {
+		return err
+	}
+	for colName, colIndexes := range indexes {
+		for _, index := range colIndexes {
+			// a failed build would fail again, the index has to be dropped instead
+			if index.Status.Building && index.Status.BuildProgress.Error == "" {
+				db.startIndexBuild(colName, index.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// buildIndex indexes the existing documents of the collection in batches, each batch
+// within its own transaction, until all documents are indexed.
+//
+// If the build fails, the error is stored in the build progress of the index.
+func (db *db) buildIndex(ctx context.Context, colName, indexName string) error {
+	var lastDocKey string
+	var indexedDocs uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var batch indexBuildBatch
+		var err error
+		for i := 0; i < db.MaxTxnRetries(); i++ {
+			batch, err = db.buildIndexBatch(ctx, colName, indexName, lastDocKey, indexedDocs)
+			if !errors.Is(err, badgerds.ErrTxnConflict) {
+				break
+			}
+		}
+		if errors.Is(err, badgerds.ErrTxnConflict) {
+			err = client.NewErrMaxTxnRetries(err)
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				db.storeIndexBuildError(ctx, colName, indexName, err)
+			}
+			return err
+		}
+		if batch.done {
+			return nil
+		}
+		lastDocKey = batch.lastDocKey
+		indexedDocs += batch.indexedDocs
+	}
+}
+
+// indexBuildBatch is the result of a single batch of a background index build.
+type indexBuildBatch struct {
+	// lastDocKey is the key of the last document indexed by the batch.
+	lastDocKey string
+	// indexedDocs is the number of documents indexed by the batch.
+	indexedDocs uint64
+	// done is true if there are no more documents to index.
+	done bool
+}
+
+// buildIndexBatch indexes the documents following the one with the given key.
+//
+// The progress of the build is stored apart from the index description, so that
+// the writes to the collection don't conflict with every batch, but only with the last one,
+// which marks the index as ready.
+//
+// If the index has been dropped or has already been built, the batch is done without indexing anything.
+func (db *db) buildIndexBatch(
+	ctx context.Context,
+	colName string,
+	indexName string,
+	lastDocKey string,
+	indexedDocs uint64,
+) (indexBuildBatch, error) {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return indexBuildBatch{}, err
+	}
+	defer txn.Discard(ctx)
+
+	col, err := db.getCollectionByName(ctx, txn, colName)
+	if err != nil {
+		return indexBuildBatch{}, NewErrCanNotReadCollection(colName, err)
+	}
+	c := col.WithTxn(txn).(*collection)
+	var index CollectionIndex
+	for _, colIndex := range c.indexes {
+		if colIndex.Name() == indexName {
+			index = colIndex
+			break
+		}
+	}
+	if index == nil || !index.Description().Status.Building {
+		return indexBuildBatch{done: true}, nil
+	}
+
+	batch, err := c.indexDocsBatch(ctx, txn, index, lastDocKey)
+	if err != nil {
+		return indexBuildBatch{}, err
+	}
+
+	progress, err := getIndexBuildProgress(ctx, txn, colName, indexName)
+	if err != nil {
+		return indexBuildBatch{}, err
+	}
+	progress.IndexedDocs = indexedDocs + batch.indexedDocs
+	err = storeIndexBuildProgress(ctx, txn, colName, indexName, progress)
+	if err != nil {
+		return indexBuildBatch{}, err
+	}
+	if batch.done {
+		desc := index.Description()
+		desc.Status = client.IndexStatus{}
+		err = storeIndexDescription(ctx, txn, colName, desc)
+		if err != nil {
+			return indexBuildBatch{}, err
+		}
+	}
+	return batch, txn.Commit(ctx)
+}
+
+// indexDocsBatch indexes up to `indexBuildBatchSize` documents following the one with the given key.
+func (c *collection) indexDocsBatch(
+	ctx context.Context,
+	txn datastore.Txn,
+	index CollectionIndex,
+	lastDocKey string,
+) (indexBuildBatch, error) {
+	df := c.newFetcher()
+	err := df.Init(ctx, txn, c, c.getIndexedFields(index), nil, nil, false, false)
+	if err != nil {
+		_ = df.Close()
+		return indexBuildBatch{}, err
+	}
+	start := base.MakeCollectionKey(c.Description())
+	end := start.PrefixEnd()
+	if lastDocKey != "" {
+		start = base.MakeDocKey(c.Description(), lastDocKey).PrefixEnd()
+	}
+	err = df.Start(ctx, core.NewSpans(core.NewSpan(start, end)))
+	if err != nil {
+		_ = df.Close()
+		return indexBuildBatch{}, err
+	}
+
+	batch := indexBuildBatch{lastDocKey: lastDocKey}
+	for batch.indexedDocs < uint64(indexBuildBatchSize) {
+		encodedDoc, _, err := df.FetchNext(ctx)
+		if err != nil {
+			_ = df.Close()
+			return indexBuildBatch{}, err
+		}
+		if encodedDoc == nil {
+			batch.done = true
+			break
+		}
+
+		doc, err := fetcher.Decode(encodedDoc)
+		if err != nil {
+			_ = df.Close()
+			return indexBuildBatch{}, err
+		}
+		err = indexDocIfMissing(ctx, txn, index, doc)
+		if err != nil {
+			_ = df.Close()
+			return indexBuildBatch{}, err
+		}
+		batch.lastDocKey = doc.Key().String()
+		batch.indexedDocs++
+	}
+
+	return batch, df.Close()
+}
+
+// indexDocIfMissing stores the index records of the given document that are not stored yet.
+//
+// Documents written after the index has been created are indexed by the write itself,
+// so their records are already stored with the expected values.
+func indexDocIfMissing(
+	ctx context.Context,
+	txn datastore.Txn,
+	index CollectionIndex,
+	doc *client.Document,
+) error {
+	records, err := index.getDocumentsIndexRecords(doc)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		value, err := txn.Datastore().Get(ctx, record.key.ToDS())
+		switch {
+		case err == nil && bytes.Equal(value, record.value):
+			continue
+		case err == nil:
+			// only unique indexes store records with the same key for different documents
+			if uniqueIndex, ok := index.(*collectionUniqueIndex); ok {
+				return uniqueIndex.newUniqueIndexError(doc)
+			}
+		case !errors.Is(err, ds.ErrNotFound):
+			return err
+		}
+		err = txn.Datastore().Put(ctx, record.key.ToDS(), record.value)
+		if err != nil {
+			return NewErrFailedToStoreIndexedField(record.key.ToDS().String(), err)
+		}
+	}
+	return nil
+}
+
+// storeIndexBuildError stores the error that stopped the build of the index in its build progress.
+func (db *db) storeIndexBuildError(ctx context.Context, colName, indexName string, buildErr error) {
+	err := db.storeIndexBuildErrorInTxn(ctx, colName, indexName, buildErr)
+	if err != nil {
+		log.ErrorE(
+			ctx,
+			"Failed to store index build error",
+			err,
+			logging.NewKV("Collection", colName),
+			logging.NewKV("Index", indexName),
+		)
+	}
+}
+
+func (db *db) storeIndexBuildErrorInTxn(ctx context.Context, colName, indexName string, buildErr error) error {
+	txn, err := db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	exists, err := txn.Systemstore().Has(ctx, core.NewCollectionIndexKey(colName, indexName).ToDS())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		// the index has been dropped in the meantime
+		return nil
+	}
+	progress, err := getIndexBuildProgress(ctx, txn, colName, indexName)
+	if err != nil {
+		return err
+	}
+	progress.Error = buildErr.Error()
+	err = storeIndexBuildProgress(ctx, txn, colName, indexName, progress)
+	if err != nil {
+		return err
+	}
+	return txn.Commit(ctx)
+}
+
+// getIndexBuildProgress returns the stored progress of the background build of the index.
+//
+// If the index has not been built in the background, the progress is empty.
+func getIndexBuildProgress(
+	ctx context.Context,
+	txn datastore.Txn,
+	colName string,
+	indexName string,
+) (client.IndexBuildProgress, error) {
+	buf, err := txn.Systemstore().Get(ctx, core.NewIndexBuildKey(colName, indexName).ToDS())
+	if err != nil {
+		if errors.Is(err, ds.ErrNotFound) {
+			return client.IndexBuildProgress{}, nil
+		}
+		return client.IndexBuildProgress{}, err
+	}
+	var progress client.IndexBuildProgress
+	err = json.Unmarshal(buf, &progress)
+	if err != nil {
+		return client.IndexBuildProgress{}, NewErrInvalidStoredIndexBuildProgress(err)
+	}
+	return progress, nil
+}
+
+// storeIndexBuildProgress replaces the stored progress of the background build of the index.
+func storeIndexBuildProgress(
+	ctx context.Context,
+	txn datastore.Txn,
+	colName string,
+	indexName string,
+	progress client.IndexBuildProgress,
+) error {
+	buf, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	return txn.Systemstore().Put(ctx, core.NewIndexBuildKey(colName, indexName).ToDS(), buf)
+}
+
+// storeIndexDescription replaces the stored description of the index.
+func storeIndexDescription(
+	ctx context.Context,
+	txn datastore.Txn,
+	colName string,
+	desc client.IndexDescription,
+) error {
+	buf, err := json.Marshal(desc)
+	if err != nil {
+		return err
+	}
+	key := core.NewCollectionIndexKey(colName, desc.Name)
+	return txn.Systemstore().Put(ctx, key.ToDS(), buf)
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"testing"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+)
+
+func (f *indexTestFixture) createUserCollectionIndexInBackground(unique bool) client.IndexDescription {
+	desc := getUsersIndexDescOnName()
+	desc.Unique = unique
+	desc.Background = true
+	newDesc, err := f.createCollectionIndexFor(f.users.Name(), desc)
+	require.NoError(f.t, err)
+	f.commitTxn()
+	return newDesc
+}
+
+// waitForIndexBuild waits until the background build of the index stops and returns its description.
+func (f *indexTestFixture) waitForIndexBuild(indexName string) client.IndexDescription {
+	var desc client.IndexDescription
+	require.Eventually(f.t, func() bool {
+		txn, err := f.db.NewTxn(f.ctx, true)
+		require.NoError(f.t, err)
+		defer txn.Discard(f.ctx)
+
+		indexes, err := f.db.getAllIndexes(f.ctx, txn)
+		require.NoError(f.t, err)
+		for _, index := range indexes[usersColName] {
+			if index.Name == indexName {
+				desc = index
+				return !index.Status.Building || index.Status.BuildProgress.Error != ""
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+	return desc
+}
+
+func setIndexBuildBatchSize(t *testing.T, size int) {
+	prevSize := indexBuildBatchSize
+	indexBuildBatchSize = size
+	t.Cleanup(func() {
+		indexBuildBatchSize = prevSize
+	})
+}
+
+func TestCreateIndex_IfBuildingInBackground_ShouldReturnBuildingIndex(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	f.saveDocToCollection(f.newUserDoc("John", 21), f.users)
+
+	desc := f.createUserCollectionIndexInBackground(false)
+	assert.True(t, desc.Status.Building)
+	assert.Equal(t, client.IndexBuildProgress{}, desc.Status.BuildProgress)
+}
+
+func TestCreateIndex_IfStatusIsProvided_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	desc := getUsersIndexDescOnName()
+	desc.Status.Building = true
+	_, err := f.createCollectionIndexFor(f.users.Name(), desc)
+	require.ErrorIs(t, err, ErrIndexStatusProvided)
+}
+
+func TestCreateIndex_IfEncodingVersionIsProvided_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	desc := getUsersIndexDescOnName()
+	desc.EncodingVersion = core.IndexEncodingVersion
+	_, err := f.createCollectionIndexFor(f.users.Name(), desc)
+	require.ErrorIs(t, err, ErrIndexEncodingVersionProvided)
+}
+
+func TestCreateIndex_IfBuildingInBackground_ShouldIndexExistingDocsInBatches(t *testing.T) {
+	setIndexBuildBatchSize(t, 2)
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	docs := []*client.Document{
+		f.newUserDoc("John", 21),
+		f.newUserDoc("Islam", 18),
+		f.newUserDoc("Andy", 33),
+		f.newUserDoc("Shahzad", 48),
+		f.newUserDoc("Fred", 28),
+	}
+	for _, doc := range docs {
+		f.saveDocToCollection(doc, f.users)
+	}
+
+	desc := f.createUserCollectionIndexInBackground(false)
+	desc = f.waitForIndexBuild(desc.Name)
+	assert.False(t, desc.Status.Building)
+	assert.Equal(
+		t,
+		client.IndexBuildProgress{IndexedDocs: uint64(len(docs)), TotalDocs: uint64(len(docs))},
+		desc.Status.BuildProgress,
+	)
+
+	f.commitTxn()
+	for _, doc := range docs {
+		key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Build()
+		_, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+		require.NoError(t, err)
+	}
+}
+
+func TestCreateIndex_IfBuildingInBackgroundAndValuesAreNotUnique_StoreBuildError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	f.saveDocToCollection(f.newUserDoc("John", 21), f.users)
+	f.saveDocToCollection(f.newUserDoc("John", 18), f.users)
+
+	desc := f.createUserCollectionIndexInBackground(true)
+	desc = f.waitForIndexBuild(desc.Name)
+	assert.True(t, desc.Status.Building)
+	assert.Contains(t, desc.Status.BuildProgress.Error, errCanNotIndexNonUniqueFields)
+}
+
+func TestCreateIndex_IfBuildingIndexIsNotReady_ShouldNotFailOnUpdateOfNotIndexedDoc(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	desc := getUsersIndexDescOnName()
+	desc.Status.Building = true
+	index, err := NewCollectionIndex(f.users, desc)
+	require.NoError(t, err)
+
+	err = index.Delete(f.ctx, f.txn, doc)
+	require.NoError(t, err)
+}
+
+func TestResumeIndexBuilds_ShouldBuildUnfinishedIndexes(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	// store the index as if the database had been closed during its build
+	desc := getUsersIndexDescOnName()
+	desc.Status.Building = true
+	err := storeIndexDescription(f.ctx, f.txn, usersColName, desc)
+	require.NoError(t, err)
+	f.commitTxn()
+
+	err = f.db.resumeIndexBuilds(f.ctx)
+	require.NoError(t, err)
+
+	desc = f.waitForIndexBuild(desc.Name)
+	assert.False(t, desc.Status.Building)
+	assert.Equal(t, uint64(1), desc.Status.BuildProgress.IndexedDocs)
+
+	f.commitTxn()
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Build()
+	_, err = f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+}
+
+func TestRebuildOutdatedIndexes_ShouldRebuildIndexesWithOldEncoding(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+	desc := f.createUserCollectionIndexOnName()
+	assert.Equal(t, core.IndexEncodingVersion, desc.EncodingVersion)
+
+	// store the index as if it had been created before the current encoding
+	staleKey := core.IndexDataStoreKey{
+		CollectionID: f.users.ID(),
+		IndexID:      desc.ID,
+		FieldValues:  [][]byte{[]byte("stale"), []byte(doc.Key().String())},
+	}
+	err := f.txn.Datastore().Put(f.ctx, staleKey.ToDS(), []byte{})
+	require.NoError(t, err)
+	desc.EncodingVersion = 0
+	err = storeIndexDescription(f.ctx, f.txn, usersColName, desc)
+	require.NoError(t, err)
+	f.commitTxn()
+
+	err = f.db.rebuildOutdatedIndexes(f.ctx)
+	require.NoError(t, err)
+	err = f.db.resumeIndexBuilds(f.ctx)
+	require.NoError(t, err)
+
+	desc = f.waitForIndexBuild(desc.Name)
+	assert.False(t, desc.Status.Building)
+	assert.Equal(t, core.IndexEncodingVersion, desc.EncodingVersion)
+
+	f.commitTxn()
+	_, err = f.txn.Datastore().Get(f.ctx, staleKey.ToDS())
+	require.ErrorIs(t, err, ds.ErrNotFound)
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Build()
+	_, err = f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+}
@@ -307,7 +307,7 @@ func TestCreateIndex_IfFieldHasNoDirection_DefaultToAsc(t *testing.T) {
 	assert.Equal(t, client.Ascending, newDesc.Fields[0].Direction)
 }
 
-func TestCreateIndex_IfSingleFieldInDescOrder_ReturnError(t *testing.T) {
+func TestCreateIndex_IfSingleFieldInDescOrder_ShouldCreate(t *testing.T) {
 	f := newIndexTestFixture(t)
 	defer f.db.Close()
 
@@ -316,8 +316,38 @@ func TestCreateIndex_IfSingleFieldInDescOrder_ReturnError(t *testing.T) {
 			{Name: usersNameFieldName, Direction: client.Descending},
 		},
 	}
+	newDesc, err := f.createCollectionIndex(desc)
+	require.NoError(t, err)
+	assert.Equal(t, client.Descending, newDesc.Fields[0].Direction)
+}
+
+func TestCreateIndex_IfFieldIsRepeated_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	desc := client.IndexDescription{
+		Fields: []client.IndexedFieldDescription{
+			{Name: usersNameFieldName, Direction: client.Ascending},
+			{Name: usersNameFieldName, Direction: client.Descending},
+		},
+	}
 	_, err := f.createCollectionIndex(desc)
-	assert.EqualError(t, err, errIndexSingleFieldWrongDirection)
+	assert.ErrorIs(t, err, NewErrIndexWithDuplicateField(usersNameFieldName))
+}
+
+func TestCreateIndex_IfCompositeIndexWithoutName_GenerateNameFromAllFields(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	desc := client.IndexDescription{
+		Fields: []client.IndexedFieldDescription{
+			{Name: usersNameFieldName, Direction: client.Ascending},
+			{Name: usersAgeFieldName, Direction: client.Descending},
+		},
+	}
+	newDesc, err := f.createCollectionIndex(desc)
+	require.NoError(t, err)
+	assert.Equal(t, usersColName+"_"+usersNameFieldName+"_ASC_"+usersAgeFieldName+"_DESC", newDesc.Name)
 }
 
 func TestCreateIndex_IfIndexWithNameAlreadyExists_ReturnError(t *testing.T) {
@@ -384,6 +414,7 @@ func TestCreateIndex_ShouldSaveToSystemStorage(t *testing.T) {
 	err = json.Unmarshal(data, &deserialized)
 	assert.NoError(t, err)
 	desc.ID = 1
+	desc.EncodingVersion = core.IndexEncodingVersion
 	assert.Equal(t, desc, deserialized)
 }
 
@@ -487,12 +518,15 @@ func TestCreateIndex_ShouldUpdateCollectionsDescription(t *testing.T) {
 func TestCreateIndex_IfAttemptToIndexOnUnsupportedType_ReturnError(t *testing.T) {
 	f := newIndexTestFixtureBare(t)
 
-	const unsupportedKind = client.FieldKind_BOOL_ARRAY
+	const unsupportedKind = client.FieldKind_FOREIGN_OBJECT_ARRAY
 
 	_, err := f.db.AddSchema(
 		f.ctx,
 		`type testTypeCol {
-			field: [Boolean!]
+			field: [testTypeItem]
+		}
+		type testTypeItem {
+			col: testTypeCol
 		}`,
 	)
 	require.NoError(f.t, err)
@@ -669,12 +703,14 @@ func TestGetCollectionIndexes_ShouldReturnListOfCollectionIndexes(t *testing.T)
 	assert.NoError(t, err)
 	require.Equal(t, 1, len(userIndexes))
 	usersIndexDesc.ID = 1
+	usersIndexDesc.EncodingVersion = core.IndexEncodingVersion
 	assert.Equal(t, usersIndexDesc, userIndexes[0])
 
 	productIndexes, err := f.getCollectionIndexes(productsColName)
 	assert.NoError(t, err)
 	require.Equal(t, 1, len(productIndexes))
 	productsIndexDesc.ID = 1
+	productsIndexDesc.EncodingVersion = core.IndexEncodingVersion
 	assert.Equal(t, productsIndexDesc, productIndexes[0])
 }
 
@@ -858,12 +894,15 @@ func TestCollectionGetIndexes_IfStoredIndexWithUnsupportedType_ReturnError(t *te
 	f := newIndexTestFixtureBare(t)
 	f.getUsersCollectionDesc()
 
-	const unsupportedKind = client.FieldKind_BOOL_ARRAY
+	const unsupportedKind = client.FieldKind_FOREIGN_OBJECT_ARRAY
 	_, err := f.db.AddSchema(
 		f.ctx,
 		`type testTypeCol {
 			name: String
-			field: [Boolean!]
+			field: [testTypeItem]
+		}
+		type testTypeItem {
+			col: testTypeCol
 		}`,
 	)
 	require.NoError(f.t, err)
@@ -1262,3 +1301,77 @@ func TestNewCollectionIndex_IfDescriptionHasNonExistingField_ReturnError(t *test
 	_, err := NewCollectionIndex(f.users, desc)
 	require.ErrorIs(t, err, NewErrIndexDescHasNonExistingField(desc, desc.Fields[0].Name))
 }
+
+func TestNewCollectionIndex_IfFilterHasNonExistingField_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	desc := getUsersIndexDescOnName()
+	desc.Where = map[string]any{"non_existing_field": map[string]any{"_eq": 1}}
+	_, err := NewCollectionIndex(f.users, desc)
+	require.ErrorIs(t, err, NewErrInvalidIndexFilterField("non_existing_field"))
+}
+
+func TestNewCollectionIndex_IfFilterHasOperatorWithoutField_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	desc := getUsersIndexDescOnName()
+	desc.Where = map[string]any{"_eq": 1}
+	_, err := NewCollectionIndex(f.users, desc)
+	require.ErrorIs(t, err, NewErrInvalidIndexFilterOperator("_eq"))
+}
+
+func TestNewCollectionIndex_IfFullTextHasMultipleFields_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	desc := getUsersIndexDescOnName()
+	desc.Fields = append(desc.Fields, client.IndexedFieldDescription{Name: usersAgeFieldName})
+	desc.FullText = &client.FullTextIndexOptions{}
+	_, err := NewCollectionIndex(f.users, desc)
+	require.ErrorIs(t, err, NewErrFullTextIndexWithMultipleFields(2))
+}
+
+func TestNewCollectionIndex_IfFullTextIsUnique_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	desc := getUsersIndexDescOnName()
+	desc.Unique = true
+	desc.FullText = &client.FullTextIndexOptions{}
+	_, err := NewCollectionIndex(f.users, desc)
+	require.ErrorIs(t, err, ErrUniqueFullTextIndex)
+}
+
+func TestNewCollectionIndex_IfFullTextFieldIsNotString_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	desc := getUsersIndexDescOnAge()
+	desc.FullText = &client.FullTextIndexOptions{}
+	_, err := NewCollectionIndex(f.users, desc)
+	require.ErrorIs(t, err, NewErrUnsupportedFullTextIndexFieldType(client.FieldKind_INT))
+}
+
+func TestNewCollectionIndex_IfFullTextTokenizerIsUnknown_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	desc := getUsersIndexDescOnName()
+	desc.FullText = &client.FullTextIndexOptions{Tokenizer: "NGRAM"}
+	_, err := NewCollectionIndex(f.users, desc)
+	require.ErrorIs(t, err, NewErrUnknownFullTextTokenizer("NGRAM"))
+}
+
+func TestNewCollectionIndex_IfCollationIsUnknown_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	desc := getUsersIndexDescOnName()
+	desc.Fields[0].Collation = "UPPERCASE"
+	_, err := NewCollectionIndex(f.users, desc)
+	require.ErrorIs(t, err, NewErrInvalidIndexCollation(usersNameFieldName, "UPPERCASE"))
+}
+
+func TestNewCollectionIndex_IfCaseInsensitiveFieldIsNotString_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	desc := getUsersIndexDescOnAge()
+	desc.Fields[0].Collation = client.CaseInsensitiveCollation
+	_, err := NewCollectionIndex(f.users, desc)
+	require.ErrorIs(t, err, NewErrInvalidIndexCollation(usersAgeFieldName, client.CaseInsensitiveCollation))
+}
@@ -12,6 +12,7 @@ package db
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -77,13 +78,15 @@ func (f *indexTestFixture) newProdDoc(id int, price float64, cat string) *client
 // indexKeyBuilder is a helper for building index keys that can be turned into a string.
 // The format of the non-unique index key is: "/<collection_id>/<index_id>/<value>/<doc_id>"
 // Example: "/5/1/12/bae-61cd6879-63ca-5ca9-8731-470a3c1dac69"
+// The format of the unique index key is: "/<collection_id>/<index_id>/<value>"
+// Example: "/5/1/12"
 type indexKeyBuilder struct {
-	f         *indexTestFixture
-	colName   string
-	fieldName string
-	doc       *client.Document
-	values    [][]byte
-	isUnique  bool
+	f           *indexTestFixture
+	colName     string
+	fieldsNames []string
+	doc         *client.Document
+	values      []any
+	isUnique    bool
 }
 
 func newIndexKeyBuilder(f *indexTestFixture) *indexKeyBuilder {
@@ -99,7 +102,14 @@ func (b *indexKeyBuilder) Col(colName string) *indexKeyBuilder {
 // If the field name is not set, the index key will contain only collection id.
 // When building a key it will it will find the field id to use in the key.
 func (b *indexKeyBuilder) Field(fieldName string) *indexKeyBuilder {
-	b.fieldName = fieldName
+	b.fieldsNames = []string{fieldName}
+	return b
+}
+
+// Fields sets the fields names for the index key of a composite index.
+// The index is looked up by the exact list of fields in the given order.
+func (b *indexKeyBuilder) Fields(fieldsNames ...string) *indexKeyBuilder {
+	b.fieldsNames = fieldsNames
 	return b
 }
 
@@ -114,7 +124,7 @@ func (b *indexKeyBuilder) Doc(doc *client.Document) *indexKeyBuilder {
 
 // Values sets the values for the index key.
 // It will override the field values stored in the document.
-func (b *indexKeyBuilder) Values(values ...[]byte) *indexKeyBuilder {
+func (b *indexKeyBuilder) Values(values ...any) *indexKeyBuilder {
 	b.values = values
 	return b
 }
@@ -145,37 +155,55 @@ func (b *indexKeyBuilder) Build() core.IndexDataStoreKey {
 	}
 	key.CollectionID = collection.ID()
 
-	if b.fieldName == "" {
+	if len(b.fieldsNames) == 0 {
 		return key
 	}
 
 	indexes, err := collection.GetIndexes(b.f.ctx)
 	require.NoError(b.f.t, err)
+	var indexDesc client.IndexDescription
 	for _, index := range indexes {
-		if index.Fields[0].Name == b.fieldName {
+		if len(index.Fields) != len(b.fieldsNames) {
+			continue
+		}
+		matches := true
+		for i := range index.Fields {
+			if index.Fields[i].Name != b.fieldsNames[i] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			indexDesc = index
 			key.IndexID = index.ID
 			break
 		}
 	}
 
 	if b.doc != nil {
-		var fieldBytesVal []byte
-		var writeableVal client.WriteableValue
-		if len(b.values) == 0 {
-			fieldVal, err := b.doc.GetValue(b.fieldName)
-			require.NoError(b.f.t, err)
-			var ok bool
-			writeableVal, ok = fieldVal.(client.WriteableValue)
+		hasNilValue := false
+		for i, fieldName := range b.fieldsNames {
+			var fieldVal any
+			if len(b.values) == 0 {
+				docVal, err := b.doc.GetValue(fieldName)
+				require.NoError(b.f.t, err)
+				fieldVal = docVal.Value()
+			} else {
+				fieldVal = b.values[i]
+			}
+			fieldDesc, ok := collection.Schema().GetField(fieldName)
 			require.True(b.f.t, ok)
-		} else {
-			writeableVal = client.NewCBORValue(client.LWW_REGISTER, b.values[0])
+			descending := len(indexDesc.Fields) > i && indexDesc.Fields[i].Direction == client.Descending
+			fieldBytesVal, err := core.EncodeIndexFieldValue(fieldDesc.Kind, fieldVal, descending)
+			require.NoError(b.f.t, err)
+
+			hasNilValue = hasNilValue || fieldVal == nil
+			key.FieldValues = append(key.FieldValues, fieldBytesVal)
 		}
-		fieldBytesVal, err = writeableVal.Bytes()
-		require.NoError(b.f.t, err)
 
-		key.FieldValues = [][]byte{fieldBytesVal, []byte(b.doc.Key().String())}
-	} else if len(b.values) > 0 {
-		key.FieldValues = b.values
+		if !b.isUnique || hasNilValue {
+			key.FieldValues = append(key.FieldValues, []byte(b.doc.Key().String()))
+		}
 	}
 
 	return key
@@ -506,13 +534,85 @@ func TestNonUnique_IfIndexedFieldIsNil_StoreItAsNil(t *testing.T) {
 	f.saveDocToCollection(doc, f.users)
 
 	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).
-		Values([]byte(nil)).Build()
+		Values(nil).Build()
 
 	data, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
 	require.NoError(t, err)
 	assert.Len(t, data, 0)
 }
 
+func (f *indexTestFixture) createTagsCollectionWithIndex(unique bool) client.Collection {
+	_, err := f.db.AddSchema(
+		f.ctx,
+		`type Notes {
+			title: String
+			tags: [String!]
+		}`,
+	)
+	require.NoError(f.t, err)
+
+	collection, err := f.db.GetCollectionByName(f.ctx, "Notes")
+	require.NoError(f.t, err)
+
+	f.txn, err = f.db.NewTxn(f.ctx, false)
+	require.NoError(f.t, err)
+
+	_, err = f.createCollectionIndexFor(collection.Name(), client.IndexDescription{
+		Fields: []client.IndexedFieldDescription{{Name: "tags", Direction: client.Ascending}},
+		Unique: unique,
+	})
+	require.NoError(f.t, err)
+	f.commitTxn()
+	return collection
+}
+
+func TestNonUnique_IfIndexedFieldIsArray_StoreEveryDistinctElement(t *testing.T) {
+	f := newIndexTestFixtureBare(t)
+	defer f.db.Close()
+	collection := f.createTagsCollectionWithIndex(false)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"title": "Review", "tags": ["urgent", "later", "urgent"]}`))
+	require.NoError(f.t, err)
+	f.saveDocToCollection(doc, collection)
+
+	for _, tag := range []string{"urgent", "later"} {
+		key := newIndexKeyBuilder(f).Col(collection.Name()).Field("tags").Doc(doc).Values(tag).Build()
+		data, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+		require.NoError(t, err)
+		assert.Len(t, data, 0)
+	}
+
+	prefix := newIndexKeyBuilder(f).Col(collection.Name()).Field("tags").Build()
+	assert.Len(t, f.getPrefixFromDataStore(prefix.ToString()), 2)
+}
+
+func TestNonUnique_IfIndexedArrayIsEmpty_StoreItAsNil(t *testing.T) {
+	f := newIndexTestFixtureBare(t)
+	defer f.db.Close()
+	collection := f.createTagsCollectionWithIndex(false)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"title": "Review", "tags": []}`))
+	require.NoError(f.t, err)
+	f.saveDocToCollection(doc, collection)
+
+	key := newIndexKeyBuilder(f).Col(collection.Name()).Field("tags").Doc(doc).Values(nil).Build()
+	data, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+	assert.Len(t, data, 0)
+}
+
+func TestNonUnique_IfIndexedArrayElementIsInvalid_ReturnError(t *testing.T) {
+	f := newIndexTestFixtureBare(t)
+	defer f.db.Close()
+	collection := f.createTagsCollectionWithIndex(false)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"title": "Review", "tags": ["urgent", 1]}`))
+	require.NoError(f.t, err)
+
+	err = collection.Create(f.ctx, doc)
+	require.ErrorIs(t, err, NewErrInvalidFieldValue(client.FieldKind_STRING_ARRAY, nil))
+}
+
 func TestNonUniqueCreate_ShouldIndexExistingDocs(t *testing.T) {
 	f := newIndexTestFixture(t)
 	defer f.db.Close()
@@ -956,7 +1056,7 @@ func TestNonUpdate_IfIndexedFieldWasNil_ShouldDeleteIt(t *testing.T) {
 	f.saveDocToCollection(doc, f.users)
 
 	oldKey := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).
-		Values([]byte(nil)).Build()
+		Values(nil).Build()
 
 	err = doc.Set(usersNameFieldName, "John")
 	require.NoError(f.t, err)
@@ -973,6 +1073,684 @@ func TestNonUpdate_IfIndexedFieldWasNil_ShouldDeleteIt(t *testing.T) {
 	require.Error(t, err)
 }
 
+func (f *indexTestFixture) createUserCollectionUniqueIndexOnName() client.IndexDescription {
+	desc := getUsersIndexDescOnName()
+	desc.Unique = true
+	newDesc, err := f.createCollectionIndexFor(f.users.Name(), desc)
+	require.NoError(f.t, err)
+	f.commitTxn()
+	return newDesc
+}
+
+func TestUnique_IfDocIsAdded_ShouldBeIndexedWithDocKeyAsValue(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionUniqueIndexOnName()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Unique().Build()
+
+	data, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+	assert.Equal(t, []byte(doc.Key().String()), data)
+}
+
+func TestUnique_IfDocWithSameValueIsAdded_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionUniqueIndexOnName()
+
+	doc1 := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc1, f.users)
+
+	doc2 := f.newUserDoc("John", 18)
+	err := f.users.Create(f.ctx, doc2)
+	require.ErrorIs(t, err, ErrCanNotIndexNonUniqueFields)
+}
+
+func TestUnique_IfIndexedFieldIsNil_StoreAllDocs(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionUniqueIndexOnName()
+
+	docs := make([]*client.Document, 0, 2)
+	for _, age := range []int{44, 45} {
+		docJSON, err := json.Marshal(struct {
+			Age int `json:"age"`
+		}{Age: age})
+		require.NoError(f.t, err)
+
+		doc, err := client.NewDocFromJSON(docJSON)
+		require.NoError(f.t, err)
+
+		f.saveDocToCollection(doc, f.users)
+		docs = append(docs, doc)
+	}
+
+	for _, doc := range docs {
+		key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).
+			Values(nil).Unique().Build()
+
+		data, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+		require.NoError(t, err)
+		assert.Len(t, data, 0)
+	}
+}
+
+func TestUnique_IfArrayElementIsTakenByAnotherDoc_ReturnError(t *testing.T) {
+	f := newIndexTestFixtureBare(t)
+	defer f.db.Close()
+	collection := f.createTagsCollectionWithIndex(true)
+
+	doc1, err := client.NewDocFromJSON([]byte(`{"title": "Review", "tags": ["urgent", "urgent"]}`))
+	require.NoError(f.t, err)
+	f.saveDocToCollection(doc1, collection)
+
+	doc2, err := client.NewDocFromJSON([]byte(`{"title": "Release", "tags": ["later", "urgent"]}`))
+	require.NoError(f.t, err)
+	err = collection.Create(f.ctx, doc2)
+	require.ErrorIs(t, err, ErrCanNotIndexNonUniqueFields)
+}
+
+func (f *indexTestFixture) createUserCollectionIndexOnNameAndAge(unique bool) client.IndexDescription {
+	desc := client.IndexDescription{
+		Fields: []client.IndexedFieldDescription{
+			{Name: usersNameFieldName, Direction: client.Ascending},
+			{Name: usersAgeFieldName, Direction: client.Descending},
+		},
+		Unique: unique,
+	}
+	newDesc, err := f.createCollectionIndexFor(f.users.Name(), desc)
+	require.NoError(f.t, err)
+	f.commitTxn()
+	return newDesc
+}
+
+func TestComposite_IfDocIsAdded_ShouldBeIndexedWithAllFieldValues(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionIndexOnNameAndAge(false)
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Fields(usersNameFieldName, usersAgeFieldName).Doc(doc).Build()
+	require.Len(t, key.FieldValues, 3)
+
+	data, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+	assert.Len(t, data, 0)
+}
+
+func TestCompositeUnique_IfDocWithSameValuesIsAdded_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionIndexOnNameAndAge(true)
+
+	f.saveDocToCollection(f.newUserDoc("John", 21), f.users)
+	f.saveDocToCollection(f.newUserDoc("John", 18), f.users)
+
+	docJSON, err := json.Marshal(userDoc{Name: "John", Age: 21, Weight: 180.5})
+	require.NoError(t, err)
+	doc, err := client.NewDocFromJSON(docJSON)
+	require.NoError(t, err)
+
+	err = f.users.Create(f.ctx, doc)
+	require.ErrorIs(t, err, ErrCanNotIndexNonUniqueFields)
+}
+
+func TestCompositeUnique_IfDocIsAdded_ShouldBeIndexedWithDocKeyAsValue(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionIndexOnNameAndAge(true)
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Fields(usersNameFieldName, usersAgeFieldName).
+		Doc(doc).Unique().Build()
+	require.Len(t, key.FieldValues, 2)
+
+	data, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+	assert.Equal(t, []byte(doc.Key().String()), data)
+}
+
+func TestUniqueCreate_IfExistingDocsHaveSameValue_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	doc1 := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc1, f.users)
+	doc2 := f.newUserDoc("John", 18)
+	f.saveDocToCollection(doc2, f.users)
+
+	desc := getUsersIndexDescOnName()
+	desc.Unique = true
+	_, err := f.createCollectionIndexFor(f.users.Name(), desc)
+	require.ErrorIs(t, err, ErrCanNotIndexNonUniqueFields)
+}
+
+func TestUniqueCreate_ShouldIndexExistingDocs(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	doc1 := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc1, f.users)
+	doc2 := f.newUserDoc("Islam", 18)
+	f.saveDocToCollection(doc2, f.users)
+
+	f.createUserCollectionUniqueIndexOnName()
+
+	key1 := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc1).Unique().Build()
+	key2 := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc2).Unique().Build()
+
+	data, err := f.txn.Datastore().Get(f.ctx, key1.ToDS())
+	require.NoError(t, err, key1.ToString())
+	assert.Equal(t, []byte(doc1.Key().String()), data)
+	data, err = f.txn.Datastore().Get(f.ctx, key2.ToDS())
+	require.NoError(t, err)
+	assert.Equal(t, []byte(doc2.Key().String()), data)
+}
+
+func TestUniqueUpdate_ShouldDeleteOldValueAndStoreNewOne(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionUniqueIndexOnName()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	oldKey := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Unique().Build()
+
+	err := doc.Set(usersNameFieldName, "Islam")
+	require.NoError(t, err)
+	err = f.users.Update(f.ctx, doc)
+	require.NoError(t, err)
+	f.commitTxn()
+
+	newKey := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Unique().Build()
+
+	_, err = f.txn.Datastore().Get(f.ctx, oldKey.ToDS())
+	require.Error(t, err)
+	_, err = f.txn.Datastore().Get(f.ctx, newKey.ToDS())
+	require.NoError(t, err)
+}
+
+func TestUniqueUpdate_IfValueIsTakenByAnotherDoc_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionUniqueIndexOnName()
+
+	doc1 := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc1, f.users)
+	doc2 := f.newUserDoc("Islam", 18)
+	f.saveDocToCollection(doc2, f.users)
+
+	err := doc2.Set(usersNameFieldName, "John")
+	require.NoError(t, err)
+	err = f.users.Update(f.ctx, doc2)
+	require.ErrorIs(t, err, ErrCanNotIndexNonUniqueFields)
+}
+
+func TestNonUniqueDelete_ShouldDeleteIndexedDoc(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionIndexOnName()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Build()
+
+	_, err := f.users.Delete(f.ctx, doc.Key())
+	require.NoError(t, err)
+	f.commitTxn()
+
+	_, err = f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.Error(t, err)
+}
+
+func TestUniqueDelete_ShouldAllowToReuseValue(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionUniqueIndexOnName()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	_, err := f.users.Delete(f.ctx, doc.Key())
+	require.NoError(t, err)
+
+	f.saveDocToCollection(f.newUserDoc("John", 18), f.users)
+}
+
+func TestDeleteDocIndex_ShouldDeleteIndexedDoc(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionIndexOnName()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	err := f.users.WithTxn(f.txn).(*collection).DeleteDocIndex(f.ctx, doc)
+	require.NoError(t, err)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Build()
+	_, err = f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.Error(t, err)
+}
+
+func TestCreateDocIndex_WithUniqueIndexViolation_ShouldStoreConflict(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionUniqueIndexOnName()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+	mergedDoc := f.newUserDoc("John", 18)
+
+	col := f.users.WithTxn(f.txn).(*collection)
+	err := col.CreateDocIndex(f.ctx, mergedDoc)
+	require.NoError(t, err)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Unique().Build()
+	val, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+	require.Equal(t, []byte(doc.Key().String()), val)
+
+	conflictKey := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(mergedDoc).Build()
+	_, err = f.txn.Datastore().Get(f.ctx, conflictKey.ToDS())
+	require.NoError(t, err)
+
+	err = col.DeleteDocIndex(f.ctx, mergedDoc)
+	require.NoError(t, err)
+
+	_, err = f.txn.Datastore().Get(f.ctx, conflictKey.ToDS())
+	require.Error(t, err)
+	_, err = f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+}
+
+func TestUniqueCreate_WithConflictOfDeletedDoc_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionUniqueIndexOnName()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	col := f.users.WithTxn(f.txn).(*collection)
+	err := col.CreateDocIndex(f.ctx, f.newUserDoc("John", 18))
+	require.NoError(t, err)
+	err = col.DeleteDocIndex(f.ctx, doc)
+	require.NoError(t, err)
+
+	err = f.users.WithTxn(f.txn).Create(f.ctx, f.newUserDoc("John", 30))
+	require.ErrorIs(t, err, ErrCanNotIndexNonUniqueFields)
+}
+
+func (f *indexTestFixture) createUserCollectionIndexOnNameWithFilter(
+	unique bool,
+	where map[string]any,
+) client.IndexDescription {
+	desc := getUsersIndexDescOnName()
+	desc.Unique = unique
+	desc.Where = where
+	newDesc, err := f.createCollectionIndexFor(f.users.Name(), desc)
+	require.NoError(f.t, err)
+	f.commitTxn()
+	return newDesc
+}
+
+func TestPartial_IfDocMatchesFilter_ShouldBeIndexed(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionIndexOnNameWithFilter(false, map[string]any{
+		usersAgeFieldName: map[string]any{"_gt": 20},
+	})
+
+	matchingDoc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(matchingDoc, f.users)
+	notMatchingDoc := f.newUserDoc("Islam", 18)
+	f.saveDocToCollection(notMatchingDoc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(matchingDoc).Build()
+	_, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+
+	key = newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(notMatchingDoc).Build()
+	_, err = f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.Error(t, err)
+}
+
+func TestPartial_IfDocDoesNotHaveFilteredField_SkipIndex(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionIndexOnNameWithFilter(false, map[string]any{
+		usersWeightFieldName: map[string]any{"_ne": nil},
+	})
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "John", "age": 21}`))
+	require.NoError(f.t, err)
+	f.saveDocToCollection(doc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Build()
+	_, err = f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.Error(t, err)
+}
+
+func TestPartialCreate_ShouldIndexOnlyMatchingExistingDocs(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+
+	matchingDoc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(matchingDoc, f.users)
+	f.saveDocToCollection(f.newUserDoc("Islam", 18), f.users)
+
+	f.createUserCollectionIndexOnNameWithFilter(false, map[string]any{
+		"_or": []any{
+			map[string]any{usersAgeFieldName: map[string]any{"_gt": 20}},
+			map[string]any{usersNameFieldName: map[string]any{"_eq": "Andy"}},
+		},
+	})
+
+	prefix := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Build()
+	assert.Len(t, f.getPrefixFromDataStore(prefix.ToString()), 1)
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(matchingDoc).Build()
+	_, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+}
+
+func TestPartialUpdate_IfDocStopsMatchingFilter_ShouldDeleteIt(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionIndexOnNameWithFilter(false, map[string]any{
+		usersAgeFieldName: map[string]any{"_gt": 20},
+	})
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Build()
+
+	err := doc.Set(usersAgeFieldName, 18)
+	require.NoError(t, err)
+	err = f.users.Update(f.ctx, doc)
+	require.NoError(t, err)
+	f.commitTxn()
+
+	_, err = f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.Error(t, err)
+}
+
+func TestPartialUpdate_IfDocStartsMatchingFilter_ShouldIndexIt(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionIndexOnNameWithFilter(true, map[string]any{
+		usersAgeFieldName: map[string]any{"_gt": 20},
+	})
+
+	doc := f.newUserDoc("John", 18)
+	f.saveDocToCollection(doc, f.users)
+
+	err := doc.Set(usersAgeFieldName, 21)
+	require.NoError(t, err)
+	err = f.users.Update(f.ctx, doc)
+	require.NoError(t, err)
+	f.commitTxn()
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Unique().Doc(doc).Build()
+	_, err = f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+}
+
+func TestPartialUnique_IfNotMatchingDocsHaveSameValue_ShouldNotReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionIndexOnNameWithFilter(true, map[string]any{
+		usersAgeFieldName: map[string]any{"_gt": 20},
+	})
+
+	f.saveDocToCollection(f.newUserDoc("John", 18), f.users)
+	f.saveDocToCollection(f.newUserDoc("John", 19), f.users)
+	f.saveDocToCollection(f.newUserDoc("John", 21), f.users)
+
+	err := f.users.Create(f.ctx, f.newUserDoc("John", 22))
+	require.ErrorIs(t, err, ErrCanNotIndexNonUniqueFields)
+}
+
+func (f *indexTestFixture) createUserCollectionFullTextIndexOnName(
+	opts client.FullTextIndexOptions,
+) client.IndexDescription {
+	desc := getUsersIndexDescOnName()
+	desc.FullText = &opts
+	newDesc, err := f.createCollectionIndexFor(f.users.Name(), desc)
+	require.NoError(f.t, err)
+	f.commitTxn()
+	return newDesc
+}
+
+// getFullTextIndexRecords returns the values of the full-text index records of the given document
+// by their terms.
+func (f *indexTestFixture) getFullTextIndexRecords(
+	indexDesc client.IndexDescription,
+	doc *client.Document,
+) map[string][]byte {
+	prefix := core.IndexDataStoreKey{CollectionID: f.users.ID(), IndexID: indexDesc.ID}
+	res, err := f.txn.Datastore().Query(f.ctx, query.Query{Prefix: prefix.ToString()})
+	require.NoError(f.t, err)
+
+	records := make(map[string][]byte)
+	for r := range res.Next() {
+		require.NoError(f.t, r.Error)
+		key, err := core.NewIndexDataStoreKey(r.Key)
+		require.NoError(f.t, err)
+		require.Len(f.t, key.FieldValues, 2)
+		if string(key.FieldValues[1]) != doc.Key().String() {
+			continue
+		}
+		term, err := core.DecodeIndexFieldValue(key.FieldValues[0], false)
+		require.NoError(f.t, err)
+		records[term.(string)] = r.Value
+	}
+	return records
+}
+
+func encodeFullTextIndexValue(termCount, docTermsCount uint64) []byte {
+	return binary.AppendUvarint(binary.AppendUvarint(nil, termCount), docTermsCount)
+}
+
+func TestFullText_ShouldStoreRecordForEveryDistinctTerm(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	indexDesc := f.createUserCollectionFullTextIndexOnName(client.FullTextIndexOptions{})
+
+	doc := f.newUserDoc("John, john Smith", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	assert.Equal(t, map[string][]byte{
+		"john":  encodeFullTextIndexValue(2, 3),
+		"smith": encodeFullTextIndexValue(1, 3),
+	}, f.getFullTextIndexRecords(indexDesc, doc))
+}
+
+func TestFullText_WithOptions_ShouldStoreAnalyzedTerms(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	indexDesc := f.createUserCollectionFullTextIndexOnName(client.FullTextIndexOptions{
+		Tokenizer:     client.FullTextWhitespaceTokenizer,
+		CaseSensitive: true,
+		Stem:          true,
+	})
+
+	doc := f.newUserDoc("Running Smith-Jones", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	assert.Equal(t, map[string][]byte{
+		"Run":       encodeFullTextIndexValue(1, 2),
+		"Smith-Jon": encodeFullTextIndexValue(1, 2),
+	}, f.getFullTextIndexRecords(indexDesc, doc))
+}
+
+func TestFullTextUpdate_ShouldReplaceTerms(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	indexDesc := f.createUserCollectionFullTextIndexOnName(client.FullTextIndexOptions{})
+
+	doc := f.newUserDoc("John Smith", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	err := doc.Set(usersNameFieldName, "John Doe")
+	require.NoError(t, err)
+	err = f.users.Update(f.ctx, doc)
+	require.NoError(t, err)
+	f.commitTxn()
+
+	assert.Equal(t, map[string][]byte{
+		"john": encodeFullTextIndexValue(1, 2),
+		"doe":  encodeFullTextIndexValue(1, 2),
+	}, f.getFullTextIndexRecords(indexDesc, doc))
+}
+
+func TestFullTextDelete_ShouldRemoveAllTerms(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	indexDesc := f.createUserCollectionFullTextIndexOnName(client.FullTextIndexOptions{})
+
+	doc := f.newUserDoc("John Smith", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	_, err := f.users.Delete(f.ctx, doc.Key())
+	require.NoError(t, err)
+	f.commitTxn()
+
+	assert.Empty(t, f.getFullTextIndexRecords(indexDesc, doc))
+}
+
+func TestVerifyIndex_IfIndexIsConsistent_ReportNoDifferences(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	indexDesc := f.createUserCollectionIndexOnName()
+
+	f.saveDocToCollection(f.newUserDoc("John", 21), f.users)
+	f.saveDocToCollection(f.newUserDoc("Islam", 18), f.users)
+
+	result, err := f.users.WithTxn(f.txn).VerifyIndex(f.ctx, indexDesc.Name)
+	require.NoError(t, err)
+	assert.True(t, result.IsValid())
+	assert.False(t, result.Repaired)
+	assert.Equal(t, indexDesc.Name, result.Index)
+}
+
+func TestVerifyIndex_IfIndexRecordIsMissing_ReportIt(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	indexDesc := f.createUserCollectionIndexOnName()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Build()
+	err := f.txn.Datastore().Delete(f.ctx, key.ToDS())
+	require.NoError(t, err)
+
+	result, err := f.users.WithTxn(f.txn).VerifyIndex(f.ctx, indexDesc.Name)
+	require.NoError(t, err)
+	assert.Equal(t, []string{key.ToString()}, result.MissingKeys)
+	assert.Empty(t, result.OrphanedKeys)
+	assert.Empty(t, result.MismatchedKeys)
+}
+
+func TestVerifyIndex_IfIndexHasRecordOfNonExistingDoc_ReportItAsOrphaned(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	indexDesc := f.createUserCollectionIndexOnName()
+
+	f.saveDocToCollection(f.newUserDoc("John", 21), f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(f.newUserDoc("Andy", 33)).Build()
+	err := f.txn.Datastore().Put(f.ctx, key.ToDS(), []byte{})
+	require.NoError(t, err)
+
+	result, err := f.users.WithTxn(f.txn).VerifyIndex(f.ctx, indexDesc.Name)
+	require.NoError(t, err)
+	assert.Equal(t, []string{key.ToString()}, result.OrphanedKeys)
+	assert.Empty(t, result.MissingKeys)
+	assert.Empty(t, result.MismatchedKeys)
+}
+
+func TestVerifyIndex_IfUniqueIndexRecordHasWrongValue_ReportItAsMismatched(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	indexDesc := f.createUserCollectionUniqueIndexOnName()
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Unique().Build()
+	err := f.txn.Datastore().Put(f.ctx, key.ToDS(), []byte("invalid"))
+	require.NoError(t, err)
+
+	result, err := f.users.WithTxn(f.txn).VerifyIndex(f.ctx, indexDesc.Name)
+	require.NoError(t, err)
+	assert.Equal(t, []string{key.ToString()}, result.MismatchedKeys)
+	assert.Empty(t, result.MissingKeys)
+	assert.Empty(t, result.OrphanedKeys)
+}
+
+func TestVerifyIndex_IfIndexDoesNotExist_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionIndexOnName()
+
+	_, err := f.users.WithTxn(f.txn).VerifyIndex(f.ctx, "non_existing_index")
+	require.ErrorIs(t, err, NewErrIndexWithNameDoesNotExists("non_existing_index"))
+}
+
+func TestRebuildIndex_ShouldRepairAllDifferences(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	indexDesc := f.createUserCollectionUniqueIndexOnName()
+
+	doc1 := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc1, f.users)
+	doc2 := f.newUserDoc("Islam", 18)
+	f.saveDocToCollection(doc2, f.users)
+
+	missingKey := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc1).Unique().Build()
+	err := f.txn.Datastore().Delete(f.ctx, missingKey.ToDS())
+	require.NoError(t, err)
+	mismatchedKey := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc2).Unique().Build()
+	err = f.txn.Datastore().Put(f.ctx, mismatchedKey.ToDS(), []byte("invalid"))
+	require.NoError(t, err)
+	orphanedKey := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).
+		Doc(f.newUserDoc("Andy", 33)).Unique().Build()
+	err = f.txn.Datastore().Put(f.ctx, orphanedKey.ToDS(), []byte("invalid"))
+	require.NoError(t, err)
+
+	result, err := f.users.WithTxn(f.txn).RebuildIndex(f.ctx, indexDesc.Name)
+	require.NoError(t, err)
+	assert.True(t, result.Repaired)
+	assert.Equal(t, []string{missingKey.ToString()}, result.MissingKeys)
+	assert.Equal(t, []string{mismatchedKey.ToString()}, result.MismatchedKeys)
+	assert.Equal(t, []string{orphanedKey.ToString()}, result.OrphanedKeys)
+
+	data, err := f.txn.Datastore().Get(f.ctx, missingKey.ToDS())
+	require.NoError(t, err)
+	assert.Equal(t, []byte(doc1.Key().String()), data)
+	data, err = f.txn.Datastore().Get(f.ctx, mismatchedKey.ToDS())
+	require.NoError(t, err)
+	assert.Equal(t, []byte(doc2.Key().String()), data)
+	_, err = f.txn.Datastore().Get(f.ctx, orphanedKey.ToDS())
+	require.Error(t, err)
+
+	result, err = f.users.WithTxn(f.txn).VerifyIndex(f.ctx, indexDesc.Name)
+	require.NoError(t, err)
+	assert.True(t, result.IsValid())
+}
+
 type shimEncodedDocument struct {
 	key             []byte
 	schemaVersionID string
@@ -1004,3 +1782,42 @@ func (encdoc *shimEncodedDocument) Reset() {
 	encdoc.status = 0
 	encdoc.properties = map[client.FieldDescription]any{}
 }
+
+func (f *indexTestFixture) createUserCollectionCaseInsensitiveIndexOnName(unique bool) client.IndexDescription {
+	desc := getUsersIndexDescOnName()
+	desc.Fields[0].Collation = client.CaseInsensitiveCollation
+	desc.Unique = unique
+	newDesc, err := f.createCollectionIndexFor(f.users.Name(), desc)
+	require.NoError(f.t, err)
+	f.commitTxn()
+	return newDesc
+}
+
+func TestCaseInsensitive_IfDocIsAdded_ShouldStoreLowercasedValue(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionCaseInsensitiveIndexOnName(false)
+
+	doc := f.newUserDoc("John", 21)
+	f.saveDocToCollection(doc, f.users)
+
+	key := newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Values("john").Build()
+	data, err := f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.NoError(t, err)
+	assert.Len(t, data, 0)
+
+	key = newIndexKeyBuilder(f).Col(usersColName).Field(usersNameFieldName).Doc(doc).Build()
+	_, err = f.txn.Datastore().Get(f.ctx, key.ToDS())
+	require.ErrorIs(t, err, ipfsDatastore.ErrNotFound)
+}
+
+func TestCaseInsensitiveUnique_IfDocWithValueOfDifferentCaseIsAdded_ReturnError(t *testing.T) {
+	f := newIndexTestFixture(t)
+	defer f.db.Close()
+	f.createUserCollectionCaseInsensitiveIndexOnName(true)
+
+	f.saveDocToCollection(f.newUserDoc("John", 21), f.users)
+
+	err := f.users.Create(f.ctx, f.newUserDoc("JOHN", 22))
+	require.ErrorIs(t, err, ErrCanNotIndexNonUniqueFields)
+}
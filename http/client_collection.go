@@ -424,5 +424,33 @@ func (c *Collection) GetIndexes(ctx context.Context) ([]client.IndexDescription,
 	if err := c.http.requestJson(req, &indexes); err != nil {
 		return nil, err
 	}
-	return c.Description().Indexes, nil
+	return indexes, nil
+}
+
+func (c *Collection) VerifyIndex(ctx context.Context, indexName string) (client.IndexVerification, error) {
+	methodURL := c.http.baseURL.JoinPath("collections", c.Description().Name, "indexes", indexName, "verify")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, methodURL.String(), nil)
+	if err != nil {
+		return client.IndexVerification{}, err
+	}
+	var result client.IndexVerification
+	if err := c.http.requestJson(req, &result); err != nil {
+		return client.IndexVerification{}, err
+	}
+	return result, nil
+}
+
+func (c *Collection) RebuildIndex(ctx context.Context, indexName string) (client.IndexVerification, error) {
+	methodURL := c.http.baseURL.JoinPath("collections", c.Description().Name, "indexes", indexName, "rebuild")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, methodURL.String(), nil)
+	if err != nil {
+		return client.IndexVerification{}, err
+	}
+	var result client.IndexVerification
+	if err := c.http.requestJson(req, &result); err != nil {
+		return client.IndexVerification{}, err
+	}
+	return result, nil
 }
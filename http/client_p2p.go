@@ -94,6 +94,26 @@ func (c *Client) AddP2PCollections(ctx context.Context, collectionIDs []string)
 	return err
 }
 
+type p2pCollectionFilterRequest struct {
+	CollectionID string
+	Filter       map[string]any
+}
+
+func (c *Client) SetP2PCollectionFilter(ctx context.Context, collectionID string, filter map[string]any) error {
+	methodURL := c.http.baseURL.JoinPath("p2p", "collections", "filter")
+
+	body, err := json.Marshal(p2pCollectionFilterRequest{collectionID, filter})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, methodURL.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	_, err = c.http.request(req)
+	return err
+}
+
 func (c *Client) RemoveP2PCollections(ctx context.Context, collectionIDs []string) error {
 	methodURL := c.http.baseURL.JoinPath("p2p", "collections")
 
@@ -333,6 +333,28 @@ func (s *collectionHandler) DropIndex(rw http.ResponseWriter, req *http.Request)
 	rw.WriteHeader(http.StatusOK)
 }
 
+func (s *collectionHandler) VerifyIndex(rw http.ResponseWriter, req *http.Request) {
+	col := req.Context().Value(colContextKey).(client.Collection)
+
+	result, err := col.VerifyIndex(req.Context(), chi.URLParam(req, "index"))
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	responseJSON(rw, http.StatusOK, result)
+}
+
+func (s *collectionHandler) RebuildIndex(rw http.ResponseWriter, req *http.Request) {
+	col := req.Context().Value(colContextKey).(client.Collection)
+
+	result, err := col.RebuildIndex(req.Context(), chi.URLParam(req, "index"))
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	responseJSON(rw, http.StatusOK, result)
+}
+
 func (h *collectionHandler) bindRoutes(router *Router) {
 	errorResponse := &openapi3.ResponseRef{
 		Ref: "#/components/responses/error",
@@ -474,6 +496,31 @@ func (h *collectionHandler) bindRoutes(router *Router) {
 	dropIndex.Responses["200"] = successResponse
 	dropIndex.Responses["400"] = errorResponse
 
+	indexVerificationSchema := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/index_verification",
+	}
+	indexVerificationResponse := openapi3.NewResponse().
+		WithDescription("Differences between the index and the collection documents").
+		WithJSONSchemaRef(indexVerificationSchema)
+
+	verifyIndex := openapi3.NewOperation()
+	verifyIndex.OperationID = "index_verify"
+	verifyIndex.Description = "Verify a secondary index against the collection documents"
+	verifyIndex.Tags = []string{"index"}
+	verifyIndex.AddParameter(collectionNamePathParam)
+	verifyIndex.AddParameter(indexPathParam)
+	verifyIndex.AddResponse(200, indexVerificationResponse)
+	verifyIndex.Responses["400"] = errorResponse
+
+	rebuildIndex := openapi3.NewOperation()
+	rebuildIndex.OperationID = "index_rebuild"
+	rebuildIndex.Description = "Repair the differences between a secondary index and the collection documents"
+	rebuildIndex.Tags = []string{"index"}
+	rebuildIndex.AddParameter(collectionNamePathParam)
+	rebuildIndex.AddParameter(indexPathParam)
+	rebuildIndex.AddResponse(200, indexVerificationResponse)
+	rebuildIndex.Responses["400"] = errorResponse
+
 	documentKeyPathParam := openapi3.NewPathParameter("key").
 		WithRequired(true).
 		WithSchema(openapi3.NewStringSchema())
@@ -527,6 +574,8 @@ func (h *collectionHandler) bindRoutes(router *Router) {
 	router.AddRoute("/collections/{name}/indexes", http.MethodPost, createIndex, h.CreateIndex)
 	router.AddRoute("/collections/{name}/indexes", http.MethodGet, getIndexes, h.GetIndexes)
 	router.AddRoute("/collections/{name}/indexes/{index}", http.MethodDelete, dropIndex, h.DropIndex)
+	router.AddRoute("/collections/{name}/indexes/{index}/verify", http.MethodGet, verifyIndex, h.VerifyIndex)
+	router.AddRoute("/collections/{name}/indexes/{index}/rebuild", http.MethodPost, rebuildIndex, h.RebuildIndex)
 	router.AddRoute("/collections/{name}/{key}", http.MethodGet, collectionGet, h.Get)
 	router.AddRoute("/collections/{name}/{key}", http.MethodPatch, collectionUpdate, h.Update)
 	router.AddRoute("/collections/{name}/{key}", http.MethodDelete, collectionDelete, h.Delete)
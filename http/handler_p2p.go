@@ -104,6 +104,26 @@ func (s *p2pHandler) AddP2PCollection(rw http.ResponseWriter, req *http.Request)
 	rw.WriteHeader(http.StatusOK)
 }
 
+func (s *p2pHandler) SetP2PCollectionFilter(rw http.ResponseWriter, req *http.Request) {
+	p2p, ok := req.Context().Value(dbContextKey).(client.P2P)
+	if !ok {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{ErrP2PDisabled})
+		return
+	}
+
+	var message p2pCollectionFilterRequest
+	if err := requestJSON(req, &message); err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	err := p2p.SetP2PCollectionFilter(req.Context(), message.CollectionID, message.Filter)
+	if err != nil {
+		responseJSON(rw, http.StatusBadRequest, errorResponse{err})
+		return
+	}
+	rw.WriteHeader(http.StatusOK)
+}
+
 func (s *p2pHandler) RemoveP2PCollection(rw http.ResponseWriter, req *http.Request) {
 	p2p, ok := req.Context().Value(dbContextKey).(client.P2P)
 	if !ok {
@@ -152,6 +172,9 @@ func (h *p2pHandler) bindRoutes(router *Router) {
 	replicatorSchema := &openapi3.SchemaRef{
 		Ref: "#/components/schemas/replicator",
 	}
+	peerCollectionFilterSchema := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/peer_collection_filter_request",
+	}
 
 	peerInfoResponse := openapi3.NewResponse().
 		WithDescription("Peer network info").
@@ -231,6 +254,21 @@ func (h *p2pHandler) bindRoutes(router *Router) {
 	addPeerCollections.Responses["200"] = successResponse
 	addPeerCollections.Responses["400"] = errorResponse
 
+	peerCollectionFilterRequest := openapi3.NewRequestBody().
+		WithRequired(true).
+		WithJSONSchemaRef(peerCollectionFilterSchema)
+
+	setPeerCollectionFilter := openapi3.NewOperation()
+	setPeerCollectionFilter.Description = "Set the filter of a peer collection"
+	setPeerCollectionFilter.OperationID = "peer_collection_filter_set"
+	setPeerCollectionFilter.Tags = []string{"p2p"}
+	setPeerCollectionFilter.RequestBody = &openapi3.RequestBodyRef{
+		Value: peerCollectionFilterRequest,
+	}
+	setPeerCollectionFilter.Responses = make(openapi3.Responses)
+	setPeerCollectionFilter.Responses["200"] = successResponse
+	setPeerCollectionFilter.Responses["400"] = errorResponse
+
 	removePeerCollections := openapi3.NewOperation()
 	removePeerCollections.Description = "Remove peer collections"
 	removePeerCollections.OperationID = "peer_collection_remove"
@@ -248,5 +286,6 @@ func (h *p2pHandler) bindRoutes(router *Router) {
 	router.AddRoute("/p2p/replicators", http.MethodDelete, deleteReplicator, h.DeleteReplicator)
 	router.AddRoute("/p2p/collections", http.MethodGet, getPeerCollections, h.GetAllP2PCollections)
 	router.AddRoute("/p2p/collections", http.MethodPost, addPeerCollections, h.AddP2PCollection)
+	router.AddRoute("/p2p/collections/filter", http.MethodPost, setPeerCollectionFilter, h.SetP2PCollectionFilter)
 	router.AddRoute("/p2p/collections", http.MethodDelete, removePeerCollections, h.RemoveP2PCollection)
 }
@@ -20,24 +20,26 @@ import (
 
 // openApiSchemas is a mapping of types to auto generate schemas for.
 var openApiSchemas = map[string]any{
-	"error":                &errorResponse{},
-	"create_tx":            &CreateTxResponse{},
-	"collection_update":    &CollectionUpdateRequest{},
-	"collection_delete":    &CollectionDeleteRequest{},
-	"peer_info":            &peer.AddrInfo{},
-	"graphql_request":      &GraphQLRequest{},
-	"graphql_response":     &GraphQLResponse{},
-	"backup_config":        &client.BackupConfig{},
-	"collection":           &client.CollectionDescription{},
-	"schema":               &client.SchemaDescription{},
-	"index":                &client.IndexDescription{},
-	"delete_result":        &client.DeleteResult{},
-	"update_result":        &client.UpdateResult{},
-	"lens_config":          &client.LensConfig{},
-	"replicator":           &client.Replicator{},
-	"ccip_request":         &CCIPRequest{},
-	"ccip_response":        &CCIPResponse{},
-	"patch_schema_request": &patchSchemaRequest{},
+	"error":                          &errorResponse{},
+	"create_tx":                      &CreateTxResponse{},
+	"collection_update":              &CollectionUpdateRequest{},
+	"collection_delete":              &CollectionDeleteRequest{},
+	"peer_info":                      &peer.AddrInfo{},
+	"graphql_request":                &GraphQLRequest{},
+	"graphql_response":               &GraphQLResponse{},
+	"backup_config":                  &client.BackupConfig{},
+	"collection":                     &client.CollectionDescription{},
+	"schema":                         &client.SchemaDescription{},
+	"index":                          &client.IndexDescription{},
+	"index_verification":             &client.IndexVerification{},
+	"delete_result":                  &client.DeleteResult{},
+	"update_result":                  &client.UpdateResult{},
+	"lens_config":                    &client.LensConfig{},
+	"replicator":                     &client.Replicator{},
+	"ccip_request":                   &CCIPRequest{},
+	"ccip_response":                  &CCIPResponse{},
+	"patch_schema_request":           &patchSchemaRequest{},
+	"peer_collection_filter_request": &p2pCollectionFilterRequest{},
 }
 
 func NewOpenAPISpec() (*openapi3.T, error) {
@@ -121,6 +121,12 @@ func (mc *MerkleClock) ProcessNode(
 	nodeCid := node.Cid()
 	priority := delta.GetPriority()
 
+	// the values of multi-value deltas are kept along with the heads
+	var headValue []byte
+	if mvDelta, ok := delta.(core.MultiValueDelta); ok {
+		headValue = mvDelta.HeadValue()
+	}
+
 	log.Debug(ctx, "Running ProcessNode", logging.NewKV("CID", nodeCid))
 	err := mc.crdt.Merge(ctx, delta)
 	if err != nil {
@@ -140,7 +146,7 @@ func (mc *MerkleClock) ProcessNode(
 	}
 	if !hasHeads { // reached the bottom, at a leaf
 		log.Debug(ctx, "No heads found")
-		err := mc.headset.Write(ctx, nodeCid, priority)
+		err := mc.headset.WriteWithValue(ctx, nodeCid, priority, headValue)
 		if err != nil {
 			return NewErrAddingHead(nodeCid, err)
 		}
@@ -158,7 +164,7 @@ func (mc *MerkleClock) ProcessNode(
 			log.Debug(ctx, "Found head, replacing!")
 			// reached one of the current heads, replace it with the tip
 			// of current branch
-			err = mc.headset.Replace(ctx, linkCid, nodeCid, priority)
+			err = mc.headset.ReplaceWithValue(ctx, linkCid, nodeCid, priority, headValue)
 			if err != nil {
 				return NewErrReplacingHead(linkCid, nodeCid, err)
 			}
@@ -174,7 +180,7 @@ func (mc *MerkleClock) ProcessNode(
 			// we reached a non-head node in the known tree.
 			// This means our root block is a new head
 			log.Debug(ctx, "Adding head")
-			err := mc.headset.Write(ctx, nodeCid, priority)
+			err := mc.headset.WriteWithValue(ctx, nodeCid, priority, headValue)
 			if err != nil {
 				log.ErrorE(
 					ctx,
@@ -156,6 +156,62 @@ func TestMerkleClockAddDAGNodeWithHeads(t *testing.T) {
 	}
 }
 
+func TestMerkleClockWithMultiValueDeltas_ShouldKeepValuesOfConcurrentHeads(t *testing.T) {
+	ctx := context.Background()
+	multistore := datastore.MultiStoreFrom(newDS())
+	reg := crdt.NewMVRegister(multistore.Rootstore(), core.CollectionSchemaVersionKey{}, core.DataStoreKey{}, "")
+	clk := NewMerkleClock(
+		multistore.Headstore(),
+		multistore.DAGstore(),
+		core.HeadStoreKey{DocKey: "dockey", FieldId: "1"},
+		reg,
+	).(*MerkleClock)
+
+	_, err := clk.AddDAGNode(ctx, &crdt.MVRegDelta{Data: []byte("a")})
+	if err != nil {
+		t.Error("Failed to add dag node:", err)
+		return
+	}
+
+	// a concurrent delta, unaware of the first one
+	concurrent := &crdt.MVRegDelta{Data: []byte("b"), Priority: 1}
+	node, err := clk.putBlock(ctx, nil, concurrent)
+	if err != nil {
+		t.Error("Failed to putBlock:", err)
+		return
+	}
+	err = clk.ProcessNode(ctx, concurrent, node)
+	if err != nil {
+		t.Error("Failed to process node:", err)
+		return
+	}
+
+	values, err := clk.headset.Values(ctx)
+	if err != nil {
+		t.Error("Failed to get head values:", err)
+		return
+	}
+	if len(values) != 2 {
+		t.Errorf("Incorrect number of head values, have %v, want %v", len(values), 2)
+		return
+	}
+
+	_, err = clk.AddDAGNode(ctx, &crdt.MVRegDelta{Data: []byte("c")})
+	if err != nil {
+		t.Error("Failed to add dag node:", err)
+		return
+	}
+
+	values, err = clk.headset.Values(ctx)
+	if err != nil {
+		t.Error("Failed to get head values:", err)
+		return
+	}
+	if len(values) != 1 || string(values[0]) != "c" {
+		t.Errorf("Incorrect head values, have %s, want %s", values, []string{"c"})
+	}
+}
+
 // func TestMerkleClockProcessNode(t *testing.T) {
 // 	t.Error("Test not implemented")
 // }
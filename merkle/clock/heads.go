@@ -42,10 +42,18 @@ func (hh *heads) key(c cid.Cid) core.HeadStoreKey {
 }
 
 func (hh *heads) Write(ctx context.Context, c cid.Cid, height uint64) error {
-	buf := make([]byte, binary.MaxVarintLen64)
+	return hh.WriteWithValue(ctx, c, height, nil)
+}
+
+// WriteWithValue adds a head, keeping the given value along with it until it is replaced.
+//
+// The value is stored after the height of the head.
+func (hh *heads) WriteWithValue(ctx context.Context, c cid.Cid, height uint64, value []byte) error {
+	buf := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(value))
 	n := binary.PutUvarint(buf, height)
+	buf = append(buf[0:n], value...)
 
-	return hh.store.Put(ctx, hh.key(c).ToDS(), buf[0:n])
+	return hh.store.Put(ctx, hh.key(c).ToDS(), buf)
 }
 
 // IsHead returns if a given cid is among the current heads.
@@ -55,6 +63,17 @@ func (hh *heads) IsHead(ctx context.Context, c cid.Cid) (bool, error) {
 
 // Replace replaces a head with a new CID.
 func (hh *heads) Replace(ctx context.Context, old cid.Cid, new cid.Cid, height uint64) error {
+	return hh.ReplaceWithValue(ctx, old, new, height, nil)
+}
+
+// ReplaceWithValue replaces a head with a new CID, keeping the given value along with it.
+func (hh *heads) ReplaceWithValue(
+	ctx context.Context,
+	old cid.Cid,
+	new cid.Cid,
+	height uint64,
+	value []byte,
+) error {
 	log.Info(
 		ctx,
 		"Replacing DAG head",
@@ -67,7 +86,7 @@ func (hh *heads) Replace(ctx context.Context, old cid.Cid, new cid.Cid, height u
 		return err
 	}
 
-	err = hh.Write(ctx, new, height)
+	err = hh.WriteWithValue(ctx, new, height, value)
 	if err != nil {
 		return err
 	}
@@ -78,6 +97,52 @@ func (hh *heads) Replace(ctx context.Context, old cid.Cid, new cid.Cid, height u
 // List returns the list of current heads plus the max height.
 // @todo Document Heads.List function
 func (hh *heads) List(ctx context.Context) ([]cid.Cid, uint64, error) {
+	entries, err := hh.entries(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	heads := make([]cid.Cid, len(entries))
+	var maxHeight uint64
+	for i, entry := range entries {
+		heads[i] = entry.cid
+		if entry.height > maxHeight {
+			maxHeight = entry.height
+		}
+	}
+
+	return heads, maxHeight, nil
+}
+
+// Values returns the values kept along with the current heads, in the order of the heads
+// returned by List.
+//
+// Heads written without a value are skipped.
+func (hh *heads) Values(ctx context.Context) ([][]byte, error) {
+	entries, err := hh.entries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([][]byte, 0, len(entries))
+	for _, entry := range entries {
+		if len(entry.value) > 0 {
+			values = append(values, entry.value)
+		}
+	}
+
+	return values, nil
+}
+
+// headEntry is a head along with its height and the value kept with it, if any.
+type headEntry struct {
+	cid    cid.Cid
+	height uint64
+	value  []byte
+}
+
+// entries returns the current heads ordered by CID.
+func (hh *heads) entries(ctx context.Context) ([]headEntry, error) {
 	q := query.Query{
 		Prefix:   hh.namespace.ToString(),
 		KeysOnly: false,
@@ -85,7 +150,7 @@ func (hh *heads) List(ctx context.Context) ([]cid.Cid, uint64, error) {
 
 	results, err := hh.store.Query(ctx, q)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
 	defer func() {
@@ -95,32 +160,32 @@ func (hh *heads) List(ctx context.Context) ([]cid.Cid, uint64, error) {
 		}
 	}()
 
-	heads := make([]cid.Cid, 0)
-	var maxHeight uint64
+	entries := make([]headEntry, 0)
 	for r := range results.Next() {
 		if r.Error != nil {
-			return nil, 0, NewErrFailedToGetNextQResult(r.Error)
+			return nil, NewErrFailedToGetNextQResult(r.Error)
 		}
 
 		headKey, err := core.NewHeadStoreKey(r.Key)
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 
 		height, n := binary.Uvarint(r.Value)
 		if n <= 0 {
-			return nil, 0, ErrDecodingHeight
-		}
-		heads = append(heads, headKey.Cid)
-		if height > maxHeight {
-			maxHeight = height
+			return nil, ErrDecodingHeight
 		}
+		entries = append(entries, headEntry{
+			cid:    headKey.Cid,
+			height: height,
+			value:  r.Value[n:],
+		})
 	}
-	sort.Slice(heads, func(i, j int) bool {
-		ci := heads[i].Bytes()
-		cj := heads[j].Bytes()
+	sort.Slice(entries, func(i, j int) bool {
+		ci := entries[i].cid.Bytes()
+		cj := entries[j].cid.Bytes()
 		return bytes.Compare(ci, cj) < 0
 	})
 
-	return heads, maxHeight, nil
+	return entries, nil
 }
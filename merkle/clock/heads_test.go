@@ -136,3 +136,49 @@ func TestHeaddsList(t *testing.T) {
 		return
 	}
 }
+
+func TestHeadsValues(t *testing.T) {
+	ctx := context.Background()
+	heads := newHeadSet()
+	c1 := newRandomCID()
+	c2 := newRandomCID()
+	c3 := newRandomCID()
+	heads.WriteWithValue(ctx, c1, uint64(1), []byte("a"))
+	heads.Write(ctx, c2, uint64(1))
+	heads.WriteWithValue(ctx, c3, uint64(1), []byte("b"))
+
+	values, err := heads.Values(ctx)
+	if err != nil {
+		t.Error("Failed to get head set values:", err)
+		return
+	}
+	if len(values) != 2 {
+		t.Errorf("Invalid number of values returned from Values, have %v, want %v", len(values), 2)
+		return
+	}
+
+	err = heads.ReplaceWithValue(ctx, c1, c2, uint64(2), []byte("c"))
+	if err != nil {
+		t.Error("Failed to Replace items in head set:", err)
+		return
+	}
+
+	_, h, err := heads.List(ctx)
+	if err != nil {
+		t.Error("Failed to List head set:", err)
+		return
+	}
+	if h != uint64(2) {
+		t.Errorf("Invalid max height from List, have %v, want %v", h, uint64(2))
+		return
+	}
+
+	values, err = heads.Values(ctx)
+	if err != nil {
+		t.Error("Failed to get head set values:", err)
+		return
+	}
+	if len(values) != 2 {
+		t.Errorf("Invalid number of values returned from Values, have %v, want %v", len(values), 2)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package merklecrdt
+
+import (
+	"context"
+
+	ipld "github.com/ipfs/go-ipld-format"
+
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+// MerkleCounter is a MerkleCRDT implementation of the Counter using MerkleClocks.
+type MerkleCounter struct {
+	*baseMerkleCRDT
+
+	counter corecrdt.Counter
+}
+
+// NewMerkleCounter creates a new instance (or loaded from DB) of a MerkleCRDT
+// backed by a Counter CRDT.
+//
+// If allowDecrement is false, the counter is a grow-only counter.
+func NewMerkleCounter(
+	store Stores,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+	allowDecrement bool,
+) *MerkleCounter {
+	counter := corecrdt.NewCounter(store.Datastore(), schemaVersionKey, key, fieldName, allowDecrement)
+	clk := clock.NewMerkleClock(store.Headstore(), store.DAGstore(), key.ToHeadStoreKey(), counter)
+	base := &baseMerkleCRDT{clock: clk, crdt: counter}
+	return &MerkleCounter{
+		baseMerkleCRDT: base,
+		counter:        counter,
+	}
+}
+
+// Increment the counter by the given CBOR encoded number.
+func (mc *MerkleCounter) Increment(ctx context.Context, value []byte) (ipld.Node, uint64, error) {
+	delta, err := mc.counter.Increment(value)
+	if err != nil {
+		return nil, 0, err
+	}
+	nd, err := mc.clock.AddDAGNode(ctx, delta)
+	return nd, delta.GetPriority(), err
+}
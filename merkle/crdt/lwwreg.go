@@ -44,11 +44,32 @@ func NewMerkleLWWRegister(
 	}
 }
 
+// NewMerkleLWWRegisterWithHLC creates a new instance (or loaded from DB) of a MerkleCRDT
+// backed by a LWWRegister CRDT whose conflicts are resolved by the timestamps of the given HLC.
+func NewMerkleLWWRegisterWithHLC(
+	store Stores,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+	hlc *corecrdt.HLC,
+) *MerkleLWWRegister {
+	register := corecrdt.NewLWWRegisterWithHLC(store.Datastore(), schemaVersionKey, key, fieldName, hlc)
+	clk := clock.NewMerkleClock(store.Headstore(), store.DAGstore(), key.ToHeadStoreKey(), register)
+	base := &baseMerkleCRDT{clock: clk, crdt: register}
+	return &MerkleLWWRegister{
+		baseMerkleCRDT: base,
+		reg:            register,
+	}
+}
+
 // Set the value of the register.
 func (mlwwreg *MerkleLWWRegister) Set(ctx context.Context, value []byte) (ipld.Node, uint64, error) {
 	// Set() call on underlying LWWRegister CRDT
 	// persist/publish delta
-	delta := mlwwreg.reg.Set(value)
+	delta, err := mlwwreg.reg.Set(ctx, value)
+	if err != nil {
+		return nil, 0, err
+	}
 	nd, err := mlwwreg.clock.AddDAGNode(ctx, delta)
 	return nd, delta.GetPriority(), err
 }
@@ -20,6 +20,7 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/logging"
 )
@@ -73,6 +74,7 @@ func InstanceWithStore(
 	ctype client.CType,
 	key core.DataStoreKey,
 	fieldName string,
+	hlc *corecrdt.HLC,
 ) (MerkleCRDT, error) {
 	switch ctype {
 	case client.LWW_REGISTER:
@@ -82,6 +84,43 @@ func InstanceWithStore(
 			key,
 			fieldName,
 		), nil
+	case client.LWW_HLC_REGISTER:
+		return NewMerkleLWWRegisterWithHLC(
+			store,
+			schemaVersionKey,
+			key,
+			fieldName,
+			hlc,
+		), nil
+	case client.MV_REGISTER:
+		return NewMerkleMVRegister(
+			store,
+			schemaVersionKey,
+			key,
+			fieldName,
+		), nil
+	case client.PN_COUNTER, client.P_COUNTER:
+		return NewMerkleCounter(
+			store,
+			schemaVersionKey,
+			key,
+			fieldName,
+			ctype == client.PN_COUNTER,
+		), nil
+	case client.OR_SET:
+		return NewMerkleORSet(
+			store,
+			schemaVersionKey,
+			key,
+			fieldName,
+		), nil
+	case client.RGA:
+		return NewMerkleRGA(
+			store,
+			schemaVersionKey,
+			key,
+			fieldName,
+		), nil
 	case client.COMPOSITE:
 		return NewMerkleCompositeDAG(
 			store,
@@ -0,0 +1,52 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package merklecrdt
+
+import (
+	"context"
+
+	ipld "github.com/ipfs/go-ipld-format"
+
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+// MerkleMVRegister is a MerkleCRDT implementation of the MVRegister using MerkleClocks.
+type MerkleMVRegister struct {
+	*baseMerkleCRDT
+
+	reg corecrdt.MVRegister
+}
+
+// NewMerkleMVRegister creates a new instance (or loaded from DB) of a MerkleCRDT
+// backed by a MVRegister CRDT.
+func NewMerkleMVRegister(
+	store Stores,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+) *MerkleMVRegister {
+	register := corecrdt.NewMVRegister(store.Datastore(), schemaVersionKey, key, fieldName)
+	clk := clock.NewMerkleClock(store.Headstore(), store.DAGstore(), key.ToHeadStoreKey(), register)
+	base := &baseMerkleCRDT{clock: clk, crdt: register}
+	return &MerkleMVRegister{
+		baseMerkleCRDT: base,
+		reg:            register,
+	}
+}
+
+// Set the value of the register.
+func (mmvreg *MerkleMVRegister) Set(ctx context.Context, value []byte) (ipld.Node, uint64, error) {
+	delta := mmvreg.reg.Set(value)
+	nd, err := mmvreg.clock.AddDAGNode(ctx, delta)
+	return nd, delta.GetPriority(), err
+}
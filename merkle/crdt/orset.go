@@ -0,0 +1,66 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package merklecrdt
+
+import (
+	"context"
+
+	ipld "github.com/ipfs/go-ipld-format"
+
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+// MerkleORSet is a MerkleCRDT implementation of the ORSet using MerkleClocks.
+type MerkleORSet struct {
+	*baseMerkleCRDT
+
+	set corecrdt.ORSet
+}
+
+// NewMerkleORSet creates a new instance (or loaded from DB) of a MerkleCRDT
+// backed by an ORSet CRDT.
+func NewMerkleORSet(
+	store Stores,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+) *MerkleORSet {
+	set := corecrdt.NewORSet(store.Datastore(), schemaVersionKey, key, fieldName)
+	clk := clock.NewMerkleClock(store.Headstore(), store.DAGstore(), key.ToHeadStoreKey(), set)
+	base := &baseMerkleCRDT{clock: clk, crdt: set}
+	return &MerkleORSet{
+		baseMerkleCRDT: base,
+		set:            set,
+	}
+}
+
+// Set replaces the elements of the set with the elements of the given CBOR encoded array.
+func (ms *MerkleORSet) Set(ctx context.Context, value []byte) (ipld.Node, uint64, error) {
+	delta, err := ms.set.Set(ctx, value)
+	if err != nil {
+		return nil, 0, err
+	}
+	nd, err := ms.clock.AddDAGNode(ctx, delta)
+	return nd, delta.GetPriority(), err
+}
+
+// Patch adds the elements of the given CBOR encoded array to the set and removes the elements
+// of the other given CBOR encoded array from it.
+func (ms *MerkleORSet) Patch(ctx context.Context, added []byte, removed []byte) (ipld.Node, uint64, error) {
+	delta, err := ms.set.Patch(ctx, added, removed)
+	if err != nil {
+		return nil, 0, err
+	}
+	nd, err := ms.clock.AddDAGNode(ctx, delta)
+	return nd, delta.GetPriority(), err
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package merklecrdt
+
+import (
+	"context"
+
+	ipld "github.com/ipfs/go-ipld-format"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/merkle/clock"
+)
+
+// MerkleRGA is a MerkleCRDT implementation of the RGA using MerkleClocks.
+type MerkleRGA struct {
+	*baseMerkleCRDT
+
+	rga corecrdt.RGA
+}
+
+// NewMerkleRGA creates a new instance (or loaded from DB) of a MerkleCRDT
+// backed by an RGA CRDT.
+func NewMerkleRGA(
+	store Stores,
+	schemaVersionKey core.CollectionSchemaVersionKey,
+	key core.DataStoreKey,
+	fieldName string,
+) *MerkleRGA {
+	rga := corecrdt.NewRGA(store.Datastore(), schemaVersionKey, key, fieldName)
+	clk := clock.NewMerkleClock(store.Headstore(), store.DAGstore(), key.ToHeadStoreKey(), rga)
+	base := &baseMerkleCRDT{clock: clk, crdt: rga}
+	return &MerkleRGA{
+		baseMerkleCRDT: base,
+		rga:            rga,
+	}
+}
+
+// Set replaces the text with the given CBOR encoded text.
+func (mr *MerkleRGA) Set(ctx context.Context, value []byte) (ipld.Node, uint64, error) {
+	delta, err := mr.rga.Set(ctx, value)
+	if err != nil {
+		return nil, 0, err
+	}
+	nd, err := mr.clock.AddDAGNode(ctx, delta)
+	return nd, delta.GetPriority(), err
+}
+
+// Patch inserts and deletes the text of the given patch.
+func (mr *MerkleRGA) Patch(ctx context.Context, patch client.TextPatch) (ipld.Node, uint64, error) {
+	delta, err := mr.rga.Patch(ctx, patch)
+	if err != nil {
+		return nil, 0, err
+	}
+	nd, err := mr.clock.AddDAGNode(ctx, delta)
+	return nd, delta.GetPriority(), err
+}
@@ -95,6 +95,20 @@ func (m *Meter) GetSyncCounter(
 	)
 }
 
+// GetAsyncGauge returns a new gauge with the given name and unit.
+//
+// The value of the gauge is observed by a callback registered with the meter,
+// whenever the metrics are collected.
+func (m *Meter) GetAsyncGauge(
+	name string,
+	unit string,
+) (metric.Int64ObservableGauge, error) {
+	return m.meter.Int64ObservableGauge(
+		name,
+		metric.WithUnit(unit),
+	)
+}
+
 // DumpScopeMetricsString returns a string representation of the metrics.
 func (m *Meter) DumpScopeMetricsString(ctx context.Context) (string, error) {
 	out := &metricdata.ResourceMetrics{}
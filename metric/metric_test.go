@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
@@ -119,6 +120,58 @@ func TestMetricSyncCounter(t *testing.T) {
 	}
 }
 
+func TestMetricAsyncGauge(t *testing.T) {
+	meter := NewMeter()
+	meter.Register("GaugeOnly")
+	queueSize, err := meter.GetAsyncGauge(
+		"queueSize",
+		"1",
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	size := int64(3)
+	_, err = meter.Get().RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(queueSize, size)
+		return nil
+	}, queueSize)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	data, err := meter.Dump(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, 1, len(data.ScopeMetrics))
+	assert.Equal(t, "GaugeOnly", data.ScopeMetrics[0].Scope.Name)
+	assert.Equal(t, 1, len(data.ScopeMetrics[0].Metrics))
+	assert.Equal(t, "queueSize", data.ScopeMetrics[0].Metrics[0].Name)
+
+	gaugeData, isGauge := data.ScopeMetrics[0].Metrics[0].Data.(metricdata.Gauge[int64])
+	if !isGauge {
+		t.Error(err)
+	}
+	assert.Equal(t, 1, len(gaugeData.DataPoints))
+	assert.Equal(t, int64(3), gaugeData.DataPoints[0].Value)
+
+	// The gauge is observed again on each collection.
+	size = 1
+	data, err = meter.Dump(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	gaugeData = data.ScopeMetrics[0].Metrics[0].Data.(metricdata.Gauge[int64])
+	assert.Equal(t, int64(1), gaugeData.DataPoints[0].Value)
+
+	if meter.Close(ctx) != nil {
+		t.Error(err)
+	}
+}
+
 func TestMetricWithCounterAndHistogramIntrumentOnOneMeter(t *testing.T) {
 	meter := NewMeter()
 
@@ -13,9 +13,11 @@
 package net
 
 import (
+	"bytes"
 	"context"
 	"time"
 
+	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/sourcenetwork/defradb/errors"
@@ -32,7 +34,7 @@ var (
 
 // pushLog creates a pushLog request and sends it to another node
 // over libp2p grpc connection
-func (s *server) pushLog(ctx context.Context, evt events.Update, pid peer.ID) error {
+func (s *server) pushLog(ctx context.Context, evt events.Update, pid peer.ID, filtered bool) error {
 	log.Debug(
 		ctx,
 		"Preparing pushLog request",
@@ -48,6 +50,7 @@ func (s *server) pushLog(ctx context.Context, evt events.Update, pid peer.ID) er
 		Log: &pb.Document_Log{
 			Block: evt.Block.RawData(),
 		},
+		Filtered: filtered,
 	}
 	req := &pb.PushLogRequest{
 		Body: body,
@@ -78,3 +81,156 @@ func (s *server) pushLog(ctx context.Context, evt events.Update, pid peer.ID) er
 	}
 	return nil
 }
+
+// getHeadLog requests the composite heads of the given documents, and of all the documents
+// of the given collections, from another node over libp2p grpc connection
+func (s *server) getHeadLog(
+	ctx context.Context,
+	pid peer.ID,
+	dockeys []string,
+	schemaRoots []string,
+) (*pb.GetHeadLogReply, error) {
+	req := &pb.GetHeadLogRequest{}
+	for _, dockey := range dockeys {
+		req.DocKeys = append(req.DocKeys, []byte(dockey))
+	}
+	for _, schemaRoot := range schemaRoots {
+		req.SchemaRoots = append(req.SchemaRoots, []byte(schemaRoot))
+	}
+
+	client, err := s.dial(pid) // grpc dial over P2P stream
+	if err != nil {
+		return nil, NewErrGetHeadLog(err)
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, PullTimeout)
+	defer cancel()
+
+	reply, err := client.GetHeadLog(cctx, req)
+	if err != nil {
+		return nil, NewErrGetHeadLog(err, errors.NewKV("PeerID", pid))
+	}
+	return reply, nil
+}
+
+// getDocGraph requests the graph of a document from another node over libp2p grpc connection,
+// without the blocks that can be reached from the given heads.
+//
+// The graph is requested page by page until the other node has sent all of it,
+// the pages are merged into a single reply.
+func (s *server) getDocGraph(
+	ctx context.Context,
+	pid peer.ID,
+	dockey string,
+	heads []cid.Cid,
+) (*pb.GetDocGraphReply, error) {
+	req := &pb.GetDocGraphRequest{
+		DocKey: []byte(dockey),
+	}
+	for _, head := range heads {
+		req.Heads = append(req.Heads, head.Bytes())
+	}
+
+	client, err := s.dial(pid) // grpc dial over P2P stream
+	if err != nil {
+		return nil, NewErrGetDocGraph(err)
+	}
+
+	reply := &pb.GetDocGraphReply{}
+	for {
+		page, err := s.getDocGraphPage(ctx, client, req)
+		if err != nil {
+			return nil, NewErrGetDocGraph(
+				err,
+				errors.NewKV("DocKey", dockey),
+				errors.NewKV("PeerID", pid),
+			)
+		}
+		// the heads are only sent along with the first page
+		if len(req.Cursor) == 0 {
+			reply.SchemaRoot = page.SchemaRoot
+			reply.Heads = page.Heads
+		}
+		reply.Blocks = append(reply.Blocks, page.Blocks...)
+		if len(page.Cursor) == 0 {
+			return reply, nil
+		}
+		req.Cursor = page.Cursor
+	}
+}
+
+// getDocGraphPage requests a single page of a document graph with its own timeout.
+func (s *server) getDocGraphPage(
+	ctx context.Context,
+	client pb.ServiceClient,
+	req *pb.GetDocGraphRequest,
+) (*pb.GetDocGraphReply, error) {
+	cctx, cancel := context.WithTimeout(ctx, PullTimeout)
+	defer cancel()
+
+	return client.GetDocGraph(cctx, req)
+}
+
+// getCollectionGraph requests the graphs of the given documents of a collection from another
+// node over libp2p grpc connection, without the blocks that are in the given filter.
+//
+// The graphs are requested page by page until the other node has sent all of them,
+// the pages are merged into a single reply.
+func (s *server) getCollectionGraph(
+	ctx context.Context,
+	pid peer.ID,
+	schemaRoot string,
+	dockeys []string,
+	filter *cidFilter,
+) (*pb.GetCollectionGraphReply, error) {
+	req := &pb.GetCollectionGraphRequest{
+		SchemaRoot: []byte(schemaRoot),
+		Filter:     filter.encode(),
+	}
+	for _, dockey := range dockeys {
+		req.DocKeys = append(req.DocKeys, []byte(dockey))
+	}
+
+	client, err := s.dial(pid) // grpc dial over P2P stream
+	if err != nil {
+		return nil, NewErrGetCollectionGraph(err)
+	}
+
+	reply := &pb.GetCollectionGraphReply{}
+	for {
+		page, err := s.getCollectionGraphPage(ctx, client, req)
+		if err != nil {
+			return nil, NewErrGetCollectionGraph(
+				err,
+				errors.NewKV("SchemaRoot", schemaRoot),
+				errors.NewKV("PeerID", pid),
+			)
+		}
+		for i, graph := range page.Graphs {
+			last := len(reply.Graphs) - 1
+			// a graph split across pages continues at the start of the next page
+			if i == 0 && last >= 0 && bytes.Equal(reply.Graphs[last].DocKey, graph.DocKey) {
+				reply.Graphs[last].Heads = append(reply.Graphs[last].Heads, graph.Heads...)
+				reply.Graphs[last].Blocks = append(reply.Graphs[last].Blocks, graph.Blocks...)
+				continue
+			}
+			reply.Graphs = append(reply.Graphs, graph)
+		}
+		if len(page.Cursor) == 0 {
+			return reply, nil
+		}
+		req.Cursor = page.Cursor
+	}
+}
+
+// getCollectionGraphPage requests a single page of collection graphs with its own timeout.
+func (s *server) getCollectionGraphPage(
+	ctx context.Context,
+	client pb.ServiceClient,
+	req *pb.GetCollectionGraphRequest,
+) (*pb.GetCollectionGraphReply, error) {
+	cctx, cancel := context.WithTimeout(ctx, PullTimeout)
+	defer cancel()
+
+	return client.GetCollectionGraph(cctx, req)
+}
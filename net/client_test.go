@@ -45,7 +45,7 @@ func TestPushlogWithDialFailure(t *testing.T) {
 		SchemaRoot: "test",
 		Block:      &EmptyNode{},
 		Priority:   1,
-	}, peer.ID("some-peer-id"))
+	}, peer.ID("some-peer-id"), false)
 	require.Contains(t, err.Error(), "no transport security set")
 }
 
@@ -66,7 +66,7 @@ func TestPushlogWithInvalidPeerID(t *testing.T) {
 		SchemaRoot: "test",
 		Block:      &EmptyNode{},
 		Priority:   1,
-	}, peer.ID("some-peer-id"))
+	}, peer.ID("some-peer-id"), false)
 	require.Contains(t, err.Error(), "failed to parse peer ID")
 }
 
@@ -114,6 +114,6 @@ func TestPushlogW_WithValidPeerID_NoError(t *testing.T) {
 		SchemaRoot: col.SchemaRoot(),
 		Block:      &EmptyNode{},
 		Priority:   1,
-	}, n2.PeerInfo().ID)
+	}, n2.PeerInfo().ID, false)
 	require.NoError(t, err)
 }
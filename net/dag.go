@@ -17,10 +17,14 @@ import (
 	"sync"
 	"time"
 
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
 	ipld "github.com/ipfs/go-ipld-format"
 
+	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/logging"
+	pb "github.com/sourcenetwork/defradb/net/pb"
 )
 
 var (
@@ -172,3 +176,115 @@ func (s *cidSafeSet) Remove(c cid.Cid) {
 	}
 	s.mux.Unlock()
 }
+
+// graphNodeGetter gets the nodes from the blocks of a graph received from another peer,
+// falling back to the given getter for the nodes that are not part of the graph.
+type graphNodeGetter struct {
+	blocks   map[cid.Cid][]byte
+	fallback ipld.NodeGetter
+}
+
+var _ ipld.NodeGetter = (*graphNodeGetter)(nil)
+
+func (g *graphNodeGetter) Get(ctx context.Context, c cid.Cid) (ipld.Node, error) {
+	if block, ok := g.blocks[c]; ok {
+		return decodeBlockBuffer(block, c)
+	}
+	return g.fallback.Get(ctx, c)
+}
+
+func (g *graphNodeGetter) GetMany(ctx context.Context, cids []cid.Cid) <-chan *ipld.NodeOption {
+	out := make(chan *ipld.NodeOption, len(cids))
+	go func() {
+		defer close(out)
+		for _, c := range cids {
+			nd, err := g.Get(ctx, c)
+			out <- &ipld.NodeOption{Node: nd, Err: err}
+		}
+	}()
+	return out
+}
+
+// walkDAG visits the blocks that can be reached from the given roots and are present
+// in the store, skipping the ones that have already been visited.
+//
+// If skip is not nil, the blocks for which it returns true are neither visited nor walked through.
+// The walk stops once visit returns false.
+func walkDAG(
+	ctx context.Context,
+	store datastore.DAGStore,
+	roots []cid.Cid,
+	visited map[cid.Cid]struct{},
+	skip func(cid.Cid) bool,
+	visit func(blocks.Block) bool,
+) error {
+	queue := append([]cid.Cid{}, roots...)
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[c]; ok {
+			continue
+		}
+		visited[c] = struct{}{}
+		if skip != nil && skip(c) {
+			continue
+		}
+
+		exists, err := store.Has(ctx, c)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		block, err := store.Get(ctx, c)
+		if err != nil {
+			return err
+		}
+		nd, err := dag.DecodeProtobufBlock(block)
+		if err != nil {
+			return err
+		}
+		if !visit(block) {
+			return nil
+		}
+		for _, link := range nd.Links() {
+			queue = append(queue, link.Cid)
+		}
+	}
+	return nil
+}
+
+// blocksFromProto returns the raw data of the given blocks by CID.
+//
+// The blocks are received from other peers so their data is checked against their CID.
+func blocksFromProto(pbBlocks []*pb.Block) (map[cid.Cid][]byte, error) {
+	blocks := make(map[cid.Cid][]byte, len(pbBlocks))
+	for _, block := range pbBlocks {
+		c, err := cid.Cast(block.Cid)
+		if err != nil {
+			return nil, err
+		}
+		sum, err := c.Prefix().Sum(block.Data)
+		if err != nil {
+			return nil, err
+		}
+		if !sum.Equals(c) {
+			return nil, NewErrBlockCIDMismatch(c.String())
+		}
+		blocks[c] = block.Data
+	}
+	return blocks, nil
+}
+
+func cidsFromBytes(data [][]byte) ([]cid.Cid, error) {
+	cids := make([]cid.Cid, 0, len(data))
+	for _, d := range data {
+		c, err := cid.Cast(d)
+		if err != nil {
+			return nil, err
+		}
+		cids = append(cids, c)
+	}
+	return cids, nil
+}
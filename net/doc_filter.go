@@ -0,0 +1,384 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/connor"
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/events"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+var _ connor.FilterKey = (*docField)(nil)
+
+// docField is a FilterKey that represents a field of a document, given as a map of its field values.
+type docField struct {
+	name string
+}
+
+func (k *docField) GetProp(data any) any {
+	fields, ok := data.(map[string]any)
+	if !ok {
+		return nil
+	}
+	return fields[k.name]
+}
+
+func (k *docField) GetOperatorOrDefault(defaultOp string) string {
+	return defaultOp
+}
+
+func (k *docField) Equal(other connor.FilterKey) bool {
+	otherKey, ok := other.(*docField)
+	return ok && *k == *otherKey
+}
+
+// docFilter is a filter that the documents must match to be synchronized with a peer.
+//
+// It is expressed like the filter of a request, for example `{"tenant": {"_eq": "acme"}}`,
+// and only applies to the fields of the document itself.
+type docFilter struct {
+	// source is the filter as given by the user.
+	source map[string]any
+	// conditions is the filter as evaluated by connor.
+	conditions map[connor.FilterKey]any
+}
+
+// newDocFilter returns the filter with the given conditions, or nil if there are none.
+func newDocFilter(source map[string]any) (*docFilter, error) {
+	if len(source) == 0 {
+		return nil, nil
+	}
+	// The filter is normalized to its JSON form, as it is persisted, so that its values
+	// are of the same types whether it is given in process, over the API, or loaded.
+	data, err := json.Marshal(source)
+	if err != nil {
+		return nil, NewErrInvalidDocFilter(err)
+	}
+	source = nil
+	if err := json.Unmarshal(data, &source); err != nil {
+		return nil, NewErrInvalidDocFilter(err)
+	}
+	f := &docFilter{
+		source:     source,
+		conditions: toDocFilterConditions(source),
+	}
+	// Evaluate the filter once so that unknown operators are reported early.
+	if _, err := connor.Match(f.conditions, map[string]any{}); err != nil {
+		return nil, NewErrInvalidDocFilter(err)
+	}
+	return f, nil
+}
+
+// toDocFilterConditions converts the given filter conditions into connor conditions.
+func toDocFilterConditions(source map[string]any) map[connor.FilterKey]any {
+	conditions := make(map[connor.FilterKey]any, len(source))
+	for key, clause := range source {
+		var filterKey connor.FilterKey
+		if strings.HasPrefix(key, "_") && key != request.KeyFieldName {
+			filterKey = &mapper.Operator{Operation: key}
+		} else {
+			filterKey = &docField{name: key}
+		}
+		conditions[filterKey] = toDocFilterClause(clause)
+	}
+	return conditions
+}
+
+// toDocFilterClause converts the inner maps of the given clause into connor conditions.
+func toDocFilterClause(clause any) any {
+	switch typedClause := clause.(type) {
+	case map[string]any:
+		return toDocFilterConditions(typedClause)
+	case []any:
+		clauses := make([]any, len(typedClause))
+		for i, inner := range typedClause {
+			clauses[i] = toDocFilterClause(inner)
+		}
+		return clauses
+	default:
+		return clause
+	}
+}
+
+// matches returns true if the given document of the collection matches the filter.
+//
+// A nil filter matches all documents. Deleted documents are matched against their last values.
+func (f *docFilter) matches(ctx context.Context, col client.Collection, dockey client.DocKey) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+	doc, err := col.Get(ctx, dockey, true)
+	if err != nil {
+		return false, err
+	}
+	fields, err := doc.ToMap()
+	if err != nil {
+		return false, err
+	}
+	return connor.Match(f.conditions, fields)
+}
+
+// matchesAt returns true if the given document of the collection, as of the given composite
+// block of its graph, matches the filter.
+//
+// The document is read at the block with a time travel query, so that an update is matched
+// against the values the document had once it was made rather than its current ones. A deleted
+// document has no values at its delete block and is matched against its last values.
+func (f *docFilter) matchesAt(
+	ctx context.Context,
+	store client.Store,
+	col client.Collection,
+	dockey client.DocKey,
+	c cid.Cid,
+) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+	fieldNames := []string{request.KeyFieldName}
+	for _, field := range col.Schema().Fields {
+		if field.Name == request.KeyFieldName || field.IsObject() {
+			continue
+		}
+		fieldNames = append(fieldNames, field.Name)
+	}
+	query := fmt.Sprintf(
+		`query { %s(%s: %q, %s: %q) { %s } }`,
+		col.Name(),
+		request.Cid,
+		c.String(),
+		request.DocKey,
+		dockey.String(),
+		strings.Join(fieldNames, " "),
+	)
+	res := store.ExecRequest(ctx, query)
+	if len(res.GQL.Errors) > 0 {
+		return false, res.GQL.Errors[0]
+	}
+	docs, ok := res.GQL.Data.([]map[string]any)
+	if !ok || len(docs) == 0 {
+		return f.matches(ctx, col, dockey)
+	}
+	return connor.Match(f.conditions, docs[0])
+}
+
+// equal returns true if both filters have the same conditions.
+func (f *docFilter) equal(other *docFilter) bool {
+	if f == nil || other == nil {
+		return f == other
+	}
+	return reflect.DeepEqual(f.source, other.source)
+}
+
+// matchesDocFilter returns true if the given document of the collection with the given schema root
+// matches the filter.
+func (p *Peer) matchesDocFilter(ctx context.Context, filter *docFilter, schemaRoot, docKey string) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+	dockey, col, err := p.getFilteredDoc(ctx, schemaRoot, docKey)
+	if err != nil {
+		return false, err
+	}
+	return filter.matches(ctx, col, dockey)
+}
+
+// getFilteredDoc returns the key of the given document along with the collection with the given
+// schema root that it resides in.
+func (p *Peer) getFilteredDoc(
+	ctx context.Context,
+	schemaRoot string,
+	docKey string,
+) (client.DocKey, client.Collection, error) {
+	dockey, err := client.NewDocKeyFromString(docKey)
+	if err != nil {
+		return client.DocKey{}, nil, err
+	}
+	cols, err := p.db.GetCollectionsBySchemaRoot(ctx, schemaRoot)
+	if err != nil {
+		return client.DocKey{}, nil, err
+	}
+	if len(cols) == 0 {
+		return client.DocKey{}, nil, client.NewErrCollectionNotFoundForSchema(schemaRoot)
+	}
+	return dockey, cols[0], nil
+}
+
+// matchesReplicatorFilter returns true if the update of the document must be pushed to the
+// replicator with the given filter.
+//
+// The updates of the documents that match the filter are pushed, and the documents are recorded
+// as held by the replicator. The update that takes a held document out of the filter is pushed
+// too, so that the replicator doesn't keep a stale copy of it, but its later updates are not
+// until it matches the filter again.
+//
+// The document is matched as of the block of the update, as it may have been updated again
+// by the time the update is handled.
+func (p *Peer) matchesReplicatorFilter(
+	ctx context.Context,
+	filter *docFilter,
+	pid peer.ID,
+	evt events.Update,
+) (bool, error) {
+	dockey, col, err := p.getFilteredDoc(ctx, evt.SchemaRoot, evt.DocKey)
+	if err != nil {
+		return false, err
+	}
+	matches, err := filter.matchesAt(ctx, p.db, col, dockey, evt.Cid)
+	if err != nil {
+		return false, err
+	}
+
+	txn, err := p.db.NewTxn(ctx, false)
+	if err != nil {
+		return false, err
+	}
+	defer txn.Discard(ctx)
+
+	key := core.NewReplicatorDocKey(pid.String(), evt.DocKey)
+	held, err := txn.Systemstore().Has(ctx, key.ToDS())
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case matches && !held:
+		err = txn.Systemstore().Put(ctx, key.ToDS(), []byte(evt.SchemaRoot))
+	case !matches && held:
+		err = txn.Systemstore().Delete(ctx, key.ToDS())
+	default:
+		return matches, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := txn.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// deleteReplicatorDocs removes the records of the documents held by the replicator that belong
+// to the collections with the given schema roots, or of all its documents if there are none.
+func deleteReplicatorDocs(
+	ctx context.Context,
+	store datastore.DSReaderWriter,
+	pid peer.ID,
+	schemaRoots map[string]struct{},
+) error {
+	query := dsq.Query{
+		Prefix: core.NewReplicatorDocKey(pid.String(), "").ToString(),
+	}
+	results, err := store.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if _, ok := schemaRoots[string(entry.Value)]; len(schemaRoots) > 0 && !ok {
+			continue
+		}
+		if err := store.Delete(ctx, ds.NewKey(entry.Key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileReplicatorDocs updates the documents of the collection held by the replicator once
+// its filter changed from the given previous one.
+//
+// The records of the held documents that don't match the new filter are removed, and the documents
+// that match it are recorded and pushed unless the replicator already has them.
+func (p *Peer) reconcileReplicatorDocs(
+	ctx context.Context,
+	txn datastore.Txn,
+	col client.Collection,
+	pid peer.ID,
+	prevFilter *docFilter,
+	filter *docFilter,
+) error {
+	prefix := core.NewReplicatorDocKey(pid.String(), "").ToString() + "/"
+	results, err := txn.Systemstore().Query(ctx, dsq.Query{Prefix: prefix})
+	if err != nil {
+		return err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if string(entry.Value) != col.SchemaRoot() {
+			continue
+		}
+		// no documents are recorded if the replicator has no filter
+		matches := false
+		if filter != nil {
+			dockey, err := client.NewDocKeyFromString(strings.TrimPrefix(entry.Key, prefix))
+			if err != nil {
+				return err
+			}
+			matches, err = filter.matches(ctx, col.WithTxn(txn), dockey)
+			if err != nil {
+				return err
+			}
+		}
+		if matches {
+			continue
+		}
+		if err := txn.Systemstore().Delete(ctx, ds.NewKey(entry.Key)); err != nil {
+			return err
+		}
+	}
+
+	keysCh, err := col.WithTxn(txn).GetAllDocKeys(ctx)
+	if err != nil {
+		return NewErrReplicatorDocKey(err, col.Name(), pid)
+	}
+	if prevFilter != nil {
+		p.pushToReplicator(ctx, txn, col, keysCh, pid, filter)
+		return nil
+	}
+	// the replicator already has all the documents, the ones that match are only recorded
+	for key := range keysCh {
+		if key.Err != nil {
+			return key.Err
+		}
+		matches, err := filter.matches(ctx, col.WithTxn(txn), key.Key)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			continue
+		}
+		repDocKey := core.NewReplicatorDocKey(pid.String(), key.Key.String())
+		if err := txn.Systemstore().Put(ctx, repDocKey.ToDS(), []byte(col.SchemaRoot())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
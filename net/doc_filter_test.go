@@ -0,0 +1,50 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/connor"
+)
+
+func TestNewDocFilter_WithEmptyFilter_ReturnNil(t *testing.T) {
+	filter, err := newDocFilter(map[string]any{})
+	require.NoError(t, err)
+	require.Nil(t, filter)
+}
+
+func TestNewDocFilter_WithUnknownOperator_Error(t *testing.T) {
+	_, err := newDocFilter(map[string]any{
+		"age": map[string]any{"_unknown": 30},
+	})
+	require.ErrorContains(t, err, errInvalidDocFilter)
+}
+
+func TestDocFilter_WithNestedConditions_MatchFields(t *testing.T) {
+	filter, err := newDocFilter(map[string]any{
+		"_or": []any{
+			map[string]any{"name": map[string]any{"_eq": "John"}},
+			map[string]any{"age": map[string]any{"_gt": 30}},
+		},
+	})
+	require.NoError(t, err)
+
+	match, err := connor.Match(filter.conditions, map[string]any{"name": "John", "age": float64(21)})
+	require.NoError(t, err)
+	require.True(t, match)
+
+	match, err = connor.Match(filter.conditions, map[string]any{"name": "Shahzad", "age": float64(21)})
+	require.NoError(t, err)
+	require.False(t, match)
+}
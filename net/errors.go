@@ -20,12 +20,20 @@ import (
 
 const (
 	errPushLog                 = "failed to push log"
+	errGetHeadLog              = "failed to get head log"
+	errGetDocGraph             = "failed to get document graph"
+	errGetCollectionGraph      = "failed to get collection graph"
+	errInvalidCIDFilter        = "invalid CID filter"
+	errInvalidDocFilter        = "invalid document filter"
+	errP2PCollectionNotFound   = "P2P collection %s not found"
 	errFailedToGetDockey       = "failed to get DocKey from broadcast message"
 	errPublishingToDockeyTopic = "can't publish log %s for dockey %s"
 	errPublishingToSchemaTopic = "can't publish log %s for schema %s"
 	errReplicatorExists        = "replicator already exists for %s with peerID %s"
 	errReplicatorDocKey        = "failed to get dockey for replicator %s with peerID %s"
 	errReplicatorCollections   = "failed to get collections for replicator"
+	errBlockCIDMismatch        = "block data doesn't match its CID %s"
+	errMissingHeadBlock        = "missing block of head %s"
 )
 
 var (
@@ -35,12 +43,40 @@ var (
 	ErrNilDB                    = errors.New("database object can't be nil")
 	ErrNilUpdateChannel         = errors.New("tried to subscribe to update channel, but update channel is nil")
 	ErrSelfTargetForReplicator  = errors.New("can't target ourselves as a replicator")
+	ErrInvalidCIDFilterSize     = errors.New("CID filter size is not a power of two within the accepted range")
+	ErrInvalidCIDFilterLocs     = errors.New("CID filter hash locations are not within the accepted range")
+	ErrMissingHLC               = errors.New("collection does not provide a hybrid logical clock")
+	ErrInvalidGraphCursor       = errors.New("invalid graph cursor")
 )
 
 func NewErrPushLog(inner error, kv ...errors.KV) error {
 	return errors.Wrap(errPushLog, inner, kv...)
 }
 
+func NewErrGetHeadLog(inner error, kv ...errors.KV) error {
+	return errors.Wrap(errGetHeadLog, inner, kv...)
+}
+
+func NewErrGetDocGraph(inner error, kv ...errors.KV) error {
+	return errors.Wrap(errGetDocGraph, inner, kv...)
+}
+
+func NewErrGetCollectionGraph(inner error, kv ...errors.KV) error {
+	return errors.Wrap(errGetCollectionGraph, inner, kv...)
+}
+
+func NewErrInvalidCIDFilter(inner error, kv ...errors.KV) error {
+	return errors.Wrap(errInvalidCIDFilter, inner, kv...)
+}
+
+func NewErrInvalidDocFilter(inner error, kv ...errors.KV) error {
+	return errors.Wrap(errInvalidDocFilter, inner, kv...)
+}
+
+func NewErrP2PCollectionNotFound(collectionID string, kv ...errors.KV) error {
+	return errors.New(fmt.Sprintf(errP2PCollectionNotFound, collectionID), kv...)
+}
+
 func NewErrFailedToGetDockey(inner error, kv ...errors.KV) error {
 	return errors.Wrap(errFailedToGetDockey, inner, kv...)
 }
@@ -64,3 +100,11 @@ func NewErrReplicatorDocKey(inner error, collection string, peerID peer.ID, kv .
 func NewErrReplicatorCollections(inner error, kv ...errors.KV) error {
 	return errors.Wrap(errReplicatorCollections, inner, kv...)
 }
+
+func NewErrBlockCIDMismatch(cid string, kv ...errors.KV) error {
+	return errors.New(fmt.Sprintf(errBlockCIDMismatch, cid), kv...)
+}
+
+func NewErrMissingHeadBlock(cid string, kv ...errors.KV) error {
+	return errors.New(fmt.Sprintf(errMissingHeadBlock, cid), kv...)
+}
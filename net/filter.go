@@ -0,0 +1,93 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"encoding/json"
+
+	"github.com/ipfs/bbloom"
+	"github.com/ipfs/go-cid"
+)
+
+const (
+	// cidFilterFalsePositiveRate is the rate of false positives of the CID filters.
+	//
+	// A false positive only causes a block to be fetched block by block instead of being sent
+	// with the rest of the graph.
+	cidFilterFalsePositiveRate = 0.01
+	// cidFilterMinSize is the size, in bytes, of the smallest filter that can be built.
+	cidFilterMinSize = 64
+	// cidFilterMaxSize is the size, in bytes, of the largest filter that is accepted.
+	cidFilterMaxSize = 1 << 24
+	// cidFilterMaxLocs is the largest number of hash locations per entry that is accepted.
+	cidFilterMaxLocs = 64
+)
+
+// cidFilter is a Bloom filter over a set of CIDs.
+type cidFilter struct {
+	bloom *bbloom.Bloom
+}
+
+// cidFilterData is the encoded form of a cidFilter, as exported by the Bloom filter.
+type cidFilterData struct {
+	FilterSet []byte
+	SetLocs   uint64
+}
+
+// newCIDFilter returns an empty filter sized for the given number of CIDs.
+func newCIDFilter(size int) (*cidFilter, error) {
+	if size < 1 {
+		size = 1
+	}
+	bf, err := bbloom.New(float64(size), cidFilterFalsePositiveRate)
+	if err != nil {
+		return nil, err
+	}
+	return &cidFilter{bloom: bf}, nil
+}
+
+// decodeCIDFilter decodes a filter received from another peer.
+//
+// An empty filter matches no CIDs.
+func decodeCIDFilter(data []byte) (*cidFilter, error) {
+	if len(data) == 0 {
+		return newCIDFilter(0)
+	}
+	var fd cidFilterData
+	if err := json.Unmarshal(data, &fd); err != nil {
+		return nil, NewErrInvalidCIDFilter(err)
+	}
+	size := len(fd.FilterSet)
+	// The filter is received from another peer so its size is validated before use,
+	// the Bloom filter panics on sizes that are not a power of two.
+	if size < cidFilterMinSize || size > cidFilterMaxSize || size&(size-1) != 0 {
+		return nil, NewErrInvalidCIDFilter(ErrInvalidCIDFilterSize)
+	}
+	if fd.SetLocs < 1 || fd.SetLocs > cidFilterMaxLocs {
+		return nil, NewErrInvalidCIDFilter(ErrInvalidCIDFilterLocs)
+	}
+	return &cidFilter{bloom: bbloom.NewWithBoolset(fd.FilterSet, fd.SetLocs)}, nil
+}
+
+// add adds the CID to the filter.
+func (f *cidFilter) add(c cid.Cid) {
+	f.bloom.Add(c.Bytes())
+}
+
+// has returns true if the CID may have been added to the filter.
+func (f *cidFilter) has(c cid.Cid) bool {
+	return f.bloom.Has(c.Bytes())
+}
+
+// encode returns the encoded form of the filter, to be sent to another peer.
+func (f *cidFilter) encode() []byte {
+	return f.bloom.JSONMarshal()
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCID(t *testing.T, data string) cid.Cid {
+	c, err := cid.V1Builder{Codec: cid.Raw, MhType: mh.SHA2_256}.Sum([]byte(data))
+	require.NoError(t, err)
+	return c
+}
+
+func TestCIDFilter_EncodeDecode(t *testing.T) {
+	known := newTestCID(t, "known")
+	unknown := newTestCID(t, "unknown")
+
+	filter, err := newCIDFilter(1)
+	require.NoError(t, err)
+	filter.add(known)
+
+	decoded, err := decodeCIDFilter(filter.encode())
+	require.NoError(t, err)
+	require.True(t, decoded.has(known))
+	require.False(t, decoded.has(unknown))
+}
+
+func TestDecodeCIDFilter_WithEmptyData_MatchNothing(t *testing.T) {
+	filter, err := decodeCIDFilter(nil)
+	require.NoError(t, err)
+	require.False(t, filter.has(newTestCID(t, "known")))
+}
+
+func TestDecodeCIDFilter_WithInvalidJSON_Error(t *testing.T) {
+	_, err := decodeCIDFilter([]byte("{"))
+	require.ErrorContains(t, err, errInvalidCIDFilter)
+}
+
+func TestDecodeCIDFilter_WithInvalidSize_Error(t *testing.T) {
+	// the size of the filter is not a power of two
+	data, err := json.Marshal(cidFilterData{FilterSet: make([]byte, 96), SetLocs: 7})
+	require.NoError(t, err)
+
+	_, err = decodeCIDFilter(data)
+	require.ErrorIs(t, err, ErrInvalidCIDFilterSize)
+}
+
+func TestDecodeCIDFilter_WithInvalidLocs_Error(t *testing.T) {
+	filter, err := newCIDFilter(1)
+	require.NoError(t, err)
+	data := filter.encode()
+
+	var fd cidFilterData
+	require.NoError(t, json.Unmarshal(data, &fd))
+	fd.SetLocs = 0
+	data, err = json.Marshal(fd)
+	require.NoError(t, err)
+
+	_, err = decodeCIDFilter(data)
+	require.ErrorIs(t, err, ErrInvalidCIDFilterLocs)
+}
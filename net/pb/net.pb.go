@@ -78,16 +78,82 @@ func (x *Document) GetHead() []byte {
 	return nil
 }
 
+// Block is a block of the DAG of a document.
+type Block struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// cid is the CID of the block.
+	Cid []byte `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// data is the raw data of the block.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_net_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_net_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_net_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Block) GetCid() []byte {
+	if x != nil {
+		return x.Cid
+	}
+	return nil
+}
+
+func (x *Block) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
 type GetDocGraphRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// docKey is the DocKey of the document to get the graph of.
+	DocKey []byte `protobuf:"bytes,1,opt,name=docKey,proto3" json:"docKey,omitempty"`
+	// heads are the CIDs of the heads of the document that the requester already has,
+	// the blocks they link to are not returned.
+	Heads [][]byte `protobuf:"bytes,2,rep,name=heads,proto3" json:"heads,omitempty"`
+	// cursor is the cursor of the previous reply when requesting the next page of the graph.
+	Cursor []byte `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
 }
 
 func (x *GetDocGraphRequest) Reset() {
 	*x = GetDocGraphRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_net_proto_msgTypes[1]
+		mi := &file_net_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -100,7 +166,7 @@ func (x *GetDocGraphRequest) String() string {
 func (*GetDocGraphRequest) ProtoMessage() {}
 
 func (x *GetDocGraphRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_net_proto_msgTypes[1]
+	mi := &file_net_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -113,19 +179,49 @@ func (x *GetDocGraphRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDocGraphRequest.ProtoReflect.Descriptor instead.
 func (*GetDocGraphRequest) Descriptor() ([]byte, []int) {
-	return file_net_proto_rawDescGZIP(), []int{1}
+	return file_net_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetDocGraphRequest) GetDocKey() []byte {
+	if x != nil {
+		return x.DocKey
+	}
+	return nil
+}
+
+func (x *GetDocGraphRequest) GetHeads() [][]byte {
+	if x != nil {
+		return x.Heads
+	}
+	return nil
+}
+
+func (x *GetDocGraphRequest) GetCursor() []byte {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
 }
 
 type GetDocGraphReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// schemaRoot is the SchemaRoot of the collection that the document resides in.
+	SchemaRoot []byte `protobuf:"bytes,1,opt,name=schemaRoot,proto3" json:"schemaRoot,omitempty"`
+	// heads are the CIDs of the composite heads of the document.
+	Heads [][]byte `protobuf:"bytes,2,rep,name=heads,proto3" json:"heads,omitempty"`
+	// blocks hold the blocks of the graph of the document.
+	Blocks []*Block `protobuf:"bytes,3,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	// cursor is set if the graph doesn't fit in the reply, the rest of it is requested with it.
+	Cursor []byte `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
 }
 
 func (x *GetDocGraphReply) Reset() {
 	*x = GetDocGraphReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_net_proto_msgTypes[2]
+		mi := &file_net_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -138,7 +234,7 @@ func (x *GetDocGraphReply) String() string {
 func (*GetDocGraphReply) ProtoMessage() {}
 
 func (x *GetDocGraphReply) ProtoReflect() protoreflect.Message {
-	mi := &file_net_proto_msgTypes[2]
+	mi := &file_net_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -151,19 +247,58 @@ func (x *GetDocGraphReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDocGraphReply.ProtoReflect.Descriptor instead.
 func (*GetDocGraphReply) Descriptor() ([]byte, []int) {
-	return file_net_proto_rawDescGZIP(), []int{2}
+	return file_net_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetDocGraphReply) GetSchemaRoot() []byte {
+	if x != nil {
+		return x.SchemaRoot
+	}
+	return nil
+}
+
+func (x *GetDocGraphReply) GetHeads() [][]byte {
+	if x != nil {
+		return x.Heads
+	}
+	return nil
+}
+
+func (x *GetDocGraphReply) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *GetDocGraphReply) GetCursor() []byte {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
 }
 
 type PushDocGraphRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// docKey is the DocKey of the document that the graph belongs to.
+	DocKey []byte `protobuf:"bytes,1,opt,name=docKey,proto3" json:"docKey,omitempty"`
+	// schemaRoot is the SchemaRoot of the collection that the document resides in.
+	SchemaRoot []byte `protobuf:"bytes,2,opt,name=schemaRoot,proto3" json:"schemaRoot,omitempty"`
+	// creator is the PeerID of the peer that pushed the graph.
+	Creator string `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	// heads are the CIDs of the composite heads of the document.
+	Heads [][]byte `protobuf:"bytes,4,rep,name=heads,proto3" json:"heads,omitempty"`
+	// blocks hold the blocks of the graph of the document.
+	Blocks []*Block `protobuf:"bytes,5,rep,name=blocks,proto3" json:"blocks,omitempty"`
 }
 
 func (x *PushDocGraphRequest) Reset() {
 	*x = PushDocGraphRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_net_proto_msgTypes[3]
+		mi := &file_net_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -176,7 +311,7 @@ func (x *PushDocGraphRequest) String() string {
 func (*PushDocGraphRequest) ProtoMessage() {}
 
 func (x *PushDocGraphRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_net_proto_msgTypes[3]
+	mi := &file_net_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -189,7 +324,42 @@ func (x *PushDocGraphRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PushDocGraphRequest.ProtoReflect.Descriptor instead.
 func (*PushDocGraphRequest) Descriptor() ([]byte, []int) {
-	return file_net_proto_rawDescGZIP(), []int{3}
+	return file_net_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PushDocGraphRequest) GetDocKey() []byte {
+	if x != nil {
+		return x.DocKey
+	}
+	return nil
+}
+
+func (x *PushDocGraphRequest) GetSchemaRoot() []byte {
+	if x != nil {
+		return x.SchemaRoot
+	}
+	return nil
+}
+
+func (x *PushDocGraphRequest) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *PushDocGraphRequest) GetHeads() [][]byte {
+	if x != nil {
+		return x.Heads
+	}
+	return nil
+}
+
+func (x *PushDocGraphRequest) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
 }
 
 type PushDocGraphReply struct {
@@ -201,7 +371,7 @@ type PushDocGraphReply struct {
 func (x *PushDocGraphReply) Reset() {
 	*x = PushDocGraphReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_net_proto_msgTypes[4]
+		mi := &file_net_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -214,7 +384,7 @@ func (x *PushDocGraphReply) String() string {
 func (*PushDocGraphReply) ProtoMessage() {}
 
 func (x *PushDocGraphReply) ProtoReflect() protoreflect.Message {
-	mi := &file_net_proto_msgTypes[4]
+	mi := &file_net_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -227,19 +397,24 @@ func (x *PushDocGraphReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PushDocGraphReply.ProtoReflect.Descriptor instead.
 func (*PushDocGraphReply) Descriptor() ([]byte, []int) {
-	return file_net_proto_rawDescGZIP(), []int{4}
+	return file_net_proto_rawDescGZIP(), []int{5}
 }
 
 type GetLogRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// docKey is the DocKey of the document that the blocks belong to.
+	DocKey []byte `protobuf:"bytes,1,opt,name=docKey,proto3" json:"docKey,omitempty"`
+	// cids are the CIDs of the blocks to get.
+	Cids [][]byte `protobuf:"bytes,2,rep,name=cids,proto3" json:"cids,omitempty"`
 }
 
 func (x *GetLogRequest) Reset() {
 	*x = GetLogRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_net_proto_msgTypes[5]
+		mi := &file_net_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -252,7 +427,7 @@ func (x *GetLogRequest) String() string {
 func (*GetLogRequest) ProtoMessage() {}
 
 func (x *GetLogRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_net_proto_msgTypes[5]
+	mi := &file_net_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -265,19 +440,36 @@ func (x *GetLogRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLogRequest.ProtoReflect.Descriptor instead.
 func (*GetLogRequest) Descriptor() ([]byte, []int) {
-	return file_net_proto_rawDescGZIP(), []int{5}
+	return file_net_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetLogRequest) GetDocKey() []byte {
+	if x != nil {
+		return x.DocKey
+	}
+	return nil
+}
+
+func (x *GetLogRequest) GetCids() [][]byte {
+	if x != nil {
+		return x.Cids
+	}
+	return nil
 }
 
 type GetLogReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// blocks hold the requested blocks that were found.
+	Blocks []*Block `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
 }
 
 func (x *GetLogReply) Reset() {
 	*x = GetLogReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_net_proto_msgTypes[6]
+		mi := &file_net_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -290,7 +482,7 @@ func (x *GetLogReply) String() string {
 func (*GetLogReply) ProtoMessage() {}
 
 func (x *GetLogReply) ProtoReflect() protoreflect.Message {
-	mi := &file_net_proto_msgTypes[6]
+	mi := &file_net_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -303,7 +495,14 @@ func (x *GetLogReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLogReply.ProtoReflect.Descriptor instead.
 func (*GetLogReply) Descriptor() ([]byte, []int) {
-	return file_net_proto_rawDescGZIP(), []int{6}
+	return file_net_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetLogReply) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
 }
 
 type PushLogRequest struct {
@@ -317,7 +516,7 @@ type PushLogRequest struct {
 func (x *PushLogRequest) Reset() {
 	*x = PushLogRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_net_proto_msgTypes[7]
+		mi := &file_net_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -330,7 +529,7 @@ func (x *PushLogRequest) String() string {
 func (*PushLogRequest) ProtoMessage() {}
 
 func (x *PushLogRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_net_proto_msgTypes[7]
+	mi := &file_net_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -343,7 +542,7 @@ func (x *PushLogRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PushLogRequest.ProtoReflect.Descriptor instead.
 func (*PushLogRequest) Descriptor() ([]byte, []int) {
-	return file_net_proto_rawDescGZIP(), []int{7}
+	return file_net_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PushLogRequest) GetBody() *PushLogRequest_Body {
@@ -357,12 +556,17 @@ type GetHeadLogRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// docKeys are the DocKeys of the documents to get the heads of.
+	DocKeys [][]byte `protobuf:"bytes,1,rep,name=docKeys,proto3" json:"docKeys,omitempty"`
+	// schemaRoots are the SchemaRoots of the collections to get the heads of all the documents of.
+	SchemaRoots [][]byte `protobuf:"bytes,2,rep,name=schemaRoots,proto3" json:"schemaRoots,omitempty"`
 }
 
 func (x *GetHeadLogRequest) Reset() {
 	*x = GetHeadLogRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_net_proto_msgTypes[8]
+		mi := &file_net_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -375,7 +579,7 @@ func (x *GetHeadLogRequest) String() string {
 func (*GetHeadLogRequest) ProtoMessage() {}
 
 func (x *GetHeadLogRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_net_proto_msgTypes[8]
+	mi := &file_net_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -388,7 +592,21 @@ func (x *GetHeadLogRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetHeadLogRequest.ProtoReflect.Descriptor instead.
 func (*GetHeadLogRequest) Descriptor() ([]byte, []int) {
-	return file_net_proto_rawDescGZIP(), []int{8}
+	return file_net_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetHeadLogRequest) GetDocKeys() [][]byte {
+	if x != nil {
+		return x.DocKeys
+	}
+	return nil
+}
+
+func (x *GetHeadLogRequest) GetSchemaRoots() [][]byte {
+	if x != nil {
+		return x.SchemaRoots
+	}
+	return nil
 }
 
 type PushLogReply struct {
@@ -400,7 +618,7 @@ type PushLogReply struct {
 func (x *PushLogReply) Reset() {
 	*x = PushLogReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_net_proto_msgTypes[9]
+		mi := &file_net_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -413,7 +631,7 @@ func (x *PushLogReply) String() string {
 func (*PushLogReply) ProtoMessage() {}
 
 func (x *PushLogReply) ProtoReflect() protoreflect.Message {
-	mi := &file_net_proto_msgTypes[9]
+	mi := &file_net_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -426,19 +644,22 @@ func (x *PushLogReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PushLogReply.ProtoReflect.Descriptor instead.
 func (*PushLogReply) Descriptor() ([]byte, []int) {
-	return file_net_proto_rawDescGZIP(), []int{9}
+	return file_net_proto_rawDescGZIP(), []int{10}
 }
 
 type GetHeadLogReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// heads hold the composite heads of the requested documents.
+	Heads []*GetHeadLogReply_Head `protobuf:"bytes,1,rep,name=heads,proto3" json:"heads,omitempty"`
 }
 
 func (x *GetHeadLogReply) Reset() {
 	*x = GetHeadLogReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_net_proto_msgTypes[10]
+		mi := &file_net_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -451,7 +672,7 @@ func (x *GetHeadLogReply) String() string {
 func (*GetHeadLogReply) ProtoMessage() {}
 
 func (x *GetHeadLogReply) ProtoReflect() protoreflect.Message {
-	mi := &file_net_proto_msgTypes[10]
+	mi := &file_net_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -464,7 +685,149 @@ func (x *GetHeadLogReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetHeadLogReply.ProtoReflect.Descriptor instead.
 func (*GetHeadLogReply) Descriptor() ([]byte, []int) {
-	return file_net_proto_rawDescGZIP(), []int{10}
+	return file_net_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetHeadLogReply) GetHeads() []*GetHeadLogReply_Head {
+	if x != nil {
+		return x.Heads
+	}
+	return nil
+}
+
+type GetCollectionGraphRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// schemaRoot is the SchemaRoot of the collection that the documents reside in.
+	SchemaRoot []byte `protobuf:"bytes,1,opt,name=schemaRoot,proto3" json:"schemaRoot,omitempty"`
+	// docKeys are the DocKeys of the documents to reconcile.
+	DocKeys [][]byte `protobuf:"bytes,2,rep,name=docKeys,proto3" json:"docKeys,omitempty"`
+	// filter is a Bloom filter over the CIDs of the blocks of the documents that the requester has.
+	Filter []byte `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// cursor is the cursor of the previous reply when requesting the next page of the graphs.
+	Cursor []byte `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *GetCollectionGraphRequest) Reset() {
+	*x = GetCollectionGraphRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_net_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCollectionGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionGraphRequest) ProtoMessage() {}
+
+func (x *GetCollectionGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_net_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionGraphRequest.ProtoReflect.Descriptor instead.
+func (*GetCollectionGraphRequest) Descriptor() ([]byte, []int) {
+	return file_net_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetCollectionGraphRequest) GetSchemaRoot() []byte {
+	if x != nil {
+		return x.SchemaRoot
+	}
+	return nil
+}
+
+func (x *GetCollectionGraphRequest) GetDocKeys() [][]byte {
+	if x != nil {
+		return x.DocKeys
+	}
+	return nil
+}
+
+func (x *GetCollectionGraphRequest) GetFilter() []byte {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *GetCollectionGraphRequest) GetCursor() []byte {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
+}
+
+type GetCollectionGraphReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// graphs are the graphs of the requested documents that this peer has.
+	Graphs []*GetCollectionGraphReply_Graph `protobuf:"bytes,1,rep,name=graphs,proto3" json:"graphs,omitempty"`
+	// cursor is set if the graphs don't fit in the reply, the rest of them are requested with it.
+	//
+	// The graph of a document can be split across several pages, in which case the pages after the
+	// first one hold the rest of its blocks.
+	Cursor []byte `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *GetCollectionGraphReply) Reset() {
+	*x = GetCollectionGraphReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_net_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCollectionGraphReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionGraphReply) ProtoMessage() {}
+
+func (x *GetCollectionGraphReply) ProtoReflect() protoreflect.Message {
+	mi := &file_net_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionGraphReply.ProtoReflect.Descriptor instead.
+func (*GetCollectionGraphReply) Descriptor() ([]byte, []int) {
+	return file_net_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetCollectionGraphReply) GetGraphs() []*GetCollectionGraphReply_Graph {
+	if x != nil {
+		return x.Graphs
+	}
+	return nil
+}
+
+func (x *GetCollectionGraphReply) GetCursor() []byte {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
 }
 
 // Record is a thread record containing link data.
@@ -480,7 +843,7 @@ type Document_Log struct {
 func (x *Document_Log) Reset() {
 	*x = Document_Log{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_net_proto_msgTypes[11]
+		mi := &file_net_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -493,7 +856,7 @@ func (x *Document_Log) String() string {
 func (*Document_Log) ProtoMessage() {}
 
 func (x *Document_Log) ProtoReflect() protoreflect.Message {
-	mi := &file_net_proto_msgTypes[11]
+	mi := &file_net_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -531,12 +894,16 @@ type PushLogRequest_Body struct {
 	Creator string `protobuf:"bytes,4,opt,name=creator,proto3" json:"creator,omitempty"`
 	// log hold the block that represent version of the document.
 	Log *Document_Log `protobuf:"bytes,6,opt,name=log,proto3" json:"log,omitempty"`
+	// filtered is true if the log is pushed by a replicator with a document filter, the
+	// receiver doesn't subscribe to the topic of the document so that it doesn't receive
+	// the updates that don't match the filter.
+	Filtered bool `protobuf:"varint,7,opt,name=filtered,proto3" json:"filtered,omitempty"`
 }
 
 func (x *PushLogRequest_Body) Reset() {
 	*x = PushLogRequest_Body{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_net_proto_msgTypes[12]
+		mi := &file_net_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -549,7 +916,7 @@ func (x *PushLogRequest_Body) String() string {
 func (*PushLogRequest_Body) ProtoMessage() {}
 
 func (x *PushLogRequest_Body) ProtoReflect() protoreflect.Message {
-	mi := &file_net_proto_msgTypes[12]
+	mi := &file_net_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -562,7 +929,7 @@ func (x *PushLogRequest_Body) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PushLogRequest_Body.ProtoReflect.Descriptor instead.
 func (*PushLogRequest_Body) Descriptor() ([]byte, []int) {
-	return file_net_proto_rawDescGZIP(), []int{7, 0}
+	return file_net_proto_rawDescGZIP(), []int{8, 0}
 }
 
 func (x *PushLogRequest_Body) GetDocKey() []byte {
@@ -600,6 +967,148 @@ func (x *PushLogRequest_Body) GetLog() *Document_Log {
 	return nil
 }
 
+func (x *PushLogRequest_Body) GetFiltered() bool {
+	if x != nil {
+		return x.Filtered
+	}
+	return false
+}
+
+// Head holds the composite heads of a document.
+type GetHeadLogReply_Head struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// docKey is the DocKey of the document.
+	DocKey []byte `protobuf:"bytes,1,opt,name=docKey,proto3" json:"docKey,omitempty"`
+	// schemaRoot is the SchemaRoot of the collection that the document resides in.
+	SchemaRoot []byte `protobuf:"bytes,2,opt,name=schemaRoot,proto3" json:"schemaRoot,omitempty"`
+	// cids are the CIDs of the composite heads of the document.
+	Cids [][]byte `protobuf:"bytes,3,rep,name=cids,proto3" json:"cids,omitempty"`
+}
+
+func (x *GetHeadLogReply_Head) Reset() {
+	*x = GetHeadLogReply_Head{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_net_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHeadLogReply_Head) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHeadLogReply_Head) ProtoMessage() {}
+
+func (x *GetHeadLogReply_Head) ProtoReflect() protoreflect.Message {
+	mi := &file_net_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHeadLogReply_Head.ProtoReflect.Descriptor instead.
+func (*GetHeadLogReply_Head) Descriptor() ([]byte, []int) {
+	return file_net_proto_rawDescGZIP(), []int{11, 0}
+}
+
+func (x *GetHeadLogReply_Head) GetDocKey() []byte {
+	if x != nil {
+		return x.DocKey
+	}
+	return nil
+}
+
+func (x *GetHeadLogReply_Head) GetSchemaRoot() []byte {
+	if x != nil {
+		return x.SchemaRoot
+	}
+	return nil
+}
+
+func (x *GetHeadLogReply_Head) GetCids() [][]byte {
+	if x != nil {
+		return x.Cids
+	}
+	return nil
+}
+
+// Graph holds the composite heads of a document and the blocks of the document
+// that are not in the filter.
+type GetCollectionGraphReply_Graph struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// docKey is the DocKey of the document.
+	DocKey []byte `protobuf:"bytes,1,opt,name=docKey,proto3" json:"docKey,omitempty"`
+	// heads are the CIDs of the composite heads of the document.
+	Heads [][]byte `protobuf:"bytes,2,rep,name=heads,proto3" json:"heads,omitempty"`
+	// blocks are the blocks of the document that are not in the filter.
+	Blocks []*Block `protobuf:"bytes,3,rep,name=blocks,proto3" json:"blocks,omitempty"`
+}
+
+func (x *GetCollectionGraphReply_Graph) Reset() {
+	*x = GetCollectionGraphReply_Graph{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_net_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCollectionGraphReply_Graph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionGraphReply_Graph) ProtoMessage() {}
+
+func (x *GetCollectionGraphReply_Graph) ProtoReflect() protoreflect.Message {
+	mi := &file_net_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionGraphReply_Graph.ProtoReflect.Descriptor instead.
+func (*GetCollectionGraphReply_Graph) Descriptor() ([]byte, []int) {
+	return file_net_proto_rawDescGZIP(), []int{13, 0}
+}
+
+func (x *GetCollectionGraphReply_Graph) GetDocKey() []byte {
+	if x != nil {
+		return x.DocKey
+	}
+	return nil
+}
+
+func (x *GetCollectionGraphReply_Graph) GetHeads() [][]byte {
+	if x != nil {
+		return x.Heads
+	}
+	return nil
+}
+
+func (x *GetCollectionGraphReply_Graph) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
 var File_net_proto protoreflect.FileDescriptor
 
 var file_net_proto_rawDesc = []byte{
@@ -609,54 +1118,124 @@ var file_net_proto_rawDesc = []byte{
 	0x06, 0x64, 0x6f, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18,
 	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x1a, 0x1b, 0x0a, 0x03, 0x4c,
 	0x6f, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x14, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x44,
-	0x6f, 0x63, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x12,
-	0x0a, 0x10, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x63, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x15, 0x0a, 0x13, 0x50, 0x75, 0x73, 0x68, 0x44, 0x6f, 0x63, 0x47, 0x72, 0x61,
-	0x70, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x13, 0x0a, 0x11, 0x50, 0x75, 0x73,
-	0x68, 0x44, 0x6f, 0x63, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x0f,
-	0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
-	0x0d, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xd6,
-	0x01, 0x0a, 0x0e, 0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x2f, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1b, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x04, 0x62, 0x6f,
-	0x64, 0x79, 0x1a, 0x92, 0x01, 0x0a, 0x04, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x64,
-	0x6f, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x6f, 0x63,
-	0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52,
-	0x6f, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x73, 0x63, 0x68, 0x65, 0x6d,
-	0x61, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12,
-	0x26, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6e,
-	0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x4c,
-	0x6f, 0x67, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x22, 0x13, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x48, 0x65,
-	0x61, 0x64, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x0e, 0x0a, 0x0c,
-	0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x11, 0x0a, 0x0f,
-	0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x32,
-	0xd1, 0x02, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x45, 0x0a, 0x0b, 0x47,
-	0x65, 0x74, 0x44, 0x6f, 0x63, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x1a, 0x2e, 0x6e, 0x65, 0x74,
-	0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x63, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e,
-	0x47, 0x65, 0x74, 0x44, 0x6f, 0x63, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x00, 0x12, 0x48, 0x0a, 0x0c, 0x50, 0x75, 0x73, 0x68, 0x44, 0x6f, 0x63, 0x47, 0x72, 0x61,
-	0x70, 0x68, 0x12, 0x1b, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x73, 0x68,
-	0x44, 0x6f, 0x63, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x19, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x44, 0x6f, 0x63,
-	0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x06,
-	0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x15, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e,
-	0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
-	0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x07, 0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67, 0x12,
-	0x16, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62,
-	0x2e, 0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x42, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x4c, 0x6f, 0x67, 0x12, 0x19, 0x2e,
-	0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x4c, 0x6f,
-	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70,
-	0x62, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x42, 0x0a, 0x5a, 0x08, 0x2f, 0x3b, 0x6e, 0x65, 0x74, 0x5f, 0x70, 0x62, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x0c, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x2d, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
+	0x63, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x5a, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x44, 0x6f,
+	0x63, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x64, 0x6f, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64,
+	0x6f, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x65, 0x61, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x68, 0x65, 0x61, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x22, 0x87, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x63, 0x47, 0x72,
+	0x61, 0x70, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x65, 0x61, 0x64,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x68, 0x65, 0x61, 0x64, 0x73, 0x12, 0x25,
+	0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x06, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0xa4, 0x01,
+	0x0a, 0x13, 0x50, 0x75, 0x73, 0x68, 0x44, 0x6f, 0x63, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x63, 0x4b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x6f, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1e, 0x0a,
+	0x0a, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0a, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x65, 0x61, 0x64, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x68, 0x65, 0x61, 0x64, 0x73, 0x12, 0x25, 0x0a,
+	0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x06, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x73, 0x22, 0x13, 0x0a, 0x11, 0x50, 0x75, 0x73, 0x68, 0x44, 0x6f, 0x63, 0x47,
+	0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x3b, 0x0a, 0x0d, 0x47, 0x65, 0x74,
+	0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f,
+	0x63, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x6f, 0x63, 0x4b,
+	0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x04, 0x63, 0x69, 0x64, 0x73, 0x22, 0x34, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x25, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0xf2, 0x01, 0x0a,
+	0x0e, 0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x2f, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79,
+	0x1a, 0xae, 0x01, 0x0a, 0x04, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x63,
+	0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x6f, 0x63, 0x4b, 0x65,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
+	0x63, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x6f, 0x6f,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52,
+	0x6f, 0x6f, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x26, 0x0a,
+	0x03, 0x6c, 0x6f, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6e, 0x65, 0x74,
+	0x2e, 0x70, 0x62, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x6f, 0x67,
+	0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x65,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x65,
+	0x64, 0x22, 0x4f, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x4c, 0x6f, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x6f, 0x63, 0x4b, 0x65, 0x79,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x64, 0x6f, 0x63, 0x4b, 0x65, 0x79, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x6f, 0x6f, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0b, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x6f, 0x6f,
+	0x74, 0x73, 0x22, 0x0e, 0x0a, 0x0c, 0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x99, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x4c, 0x6f,
+	0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x32, 0x0a, 0x05, 0x68, 0x65, 0x61, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47,
+	0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x48,
+	0x65, 0x61, 0x64, 0x52, 0x05, 0x68, 0x65, 0x61, 0x64, 0x73, 0x1a, 0x52, 0x0a, 0x04, 0x48, 0x65,
+	0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x64, 0x6f, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69,
+	0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x63, 0x69, 0x64, 0x73, 0x22, 0x85,
+	0x01, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0a, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x64, 0x6f, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x64,
+	0x6f, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0xce, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x3d, 0x0a, 0x06, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x06, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x1a, 0x5c, 0x0a, 0x05, 0x47, 0x72, 0x61,
+	0x70, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x64, 0x6f, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x65,
+	0x61, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x68, 0x65, 0x61, 0x64, 0x73,
+	0x12, 0x25, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
+	0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x32, 0xad, 0x03, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x45, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x63, 0x47, 0x72, 0x61,
+	0x70, 0x68, 0x12, 0x1a, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x44,
+	0x6f, 0x63, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x63, 0x47, 0x72,
+	0x61, 0x70, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0c, 0x50, 0x75,
+	0x73, 0x68, 0x44, 0x6f, 0x63, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x1b, 0x2e, 0x6e, 0x65, 0x74,
+	0x2e, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x44, 0x6f, 0x63, 0x47, 0x72, 0x61, 0x70, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62,
+	0x2e, 0x50, 0x75, 0x73, 0x68, 0x44, 0x6f, 0x63, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x15,
+	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47,
+	0x65, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x07,
+	0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67, 0x12, 0x16, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62,
+	0x2e, 0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x14, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x48, 0x65,
+	0x61, 0x64, 0x4c, 0x6f, 0x67, 0x12, 0x19, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47,
+	0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61,
+	0x64, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x12, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x72, 0x61, 0x70,
+	0x68, 0x12, 0x21, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x72, 0x61, 0x70, 0x68,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x0a, 0x5a, 0x08, 0x2f, 0x3b, 0x6e, 0x65, 0x74,
+	0x5f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -671,40 +1250,53 @@ func file_net_proto_rawDescGZIP() []byte {
 	return file_net_proto_rawDescData
 }
 
-var file_net_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_net_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_net_proto_goTypes = []interface{}{
-	(*Document)(nil),            // 0: net.pb.Document
-	(*GetDocGraphRequest)(nil),  // 1: net.pb.GetDocGraphRequest
-	(*GetDocGraphReply)(nil),    // 2: net.pb.GetDocGraphReply
-	(*PushDocGraphRequest)(nil), // 3: net.pb.PushDocGraphRequest
-	(*PushDocGraphReply)(nil),   // 4: net.pb.PushDocGraphReply
-	(*GetLogRequest)(nil),       // 5: net.pb.GetLogRequest
-	(*GetLogReply)(nil),         // 6: net.pb.GetLogReply
-	(*PushLogRequest)(nil),      // 7: net.pb.PushLogRequest
-	(*GetHeadLogRequest)(nil),   // 8: net.pb.GetHeadLogRequest
-	(*PushLogReply)(nil),        // 9: net.pb.PushLogReply
-	(*GetHeadLogReply)(nil),     // 10: net.pb.GetHeadLogReply
-	(*Document_Log)(nil),        // 11: net.pb.Document.Log
-	(*PushLogRequest_Body)(nil), // 12: net.pb.PushLogRequest.Body
+	(*Document)(nil),                      // 0: net.pb.Document
+	(*Block)(nil),                         // 1: net.pb.Block
+	(*GetDocGraphRequest)(nil),            // 2: net.pb.GetDocGraphRequest
+	(*GetDocGraphReply)(nil),              // 3: net.pb.GetDocGraphReply
+	(*PushDocGraphRequest)(nil),           // 4: net.pb.PushDocGraphRequest
+	(*PushDocGraphReply)(nil),             // 5: net.pb.PushDocGraphReply
+	(*GetLogRequest)(nil),                 // 6: net.pb.GetLogRequest
+	(*GetLogReply)(nil),                   // 7: net.pb.GetLogReply
+	(*PushLogRequest)(nil),                // 8: net.pb.PushLogRequest
+	(*GetHeadLogRequest)(nil),             // 9: net.pb.GetHeadLogRequest
+	(*PushLogReply)(nil),                  // 10: net.pb.PushLogReply
+	(*GetHeadLogReply)(nil),               // 11: net.pb.GetHeadLogReply
+	(*GetCollectionGraphRequest)(nil),     // 12: net.pb.GetCollectionGraphRequest
+	(*GetCollectionGraphReply)(nil),       // 13: net.pb.GetCollectionGraphReply
+	(*Document_Log)(nil),                  // 14: net.pb.Document.Log
+	(*PushLogRequest_Body)(nil),           // 15: net.pb.PushLogRequest.Body
+	(*GetHeadLogReply_Head)(nil),          // 16: net.pb.GetHeadLogReply.Head
+	(*GetCollectionGraphReply_Graph)(nil), // 17: net.pb.GetCollectionGraphReply.Graph
 }
 var file_net_proto_depIdxs = []int32{
-	12, // 0: net.pb.PushLogRequest.body:type_name -> net.pb.PushLogRequest.Body
-	11, // 1: net.pb.PushLogRequest.Body.log:type_name -> net.pb.Document.Log
-	1,  // 2: net.pb.Service.GetDocGraph:input_type -> net.pb.GetDocGraphRequest
-	3,  // 3: net.pb.Service.PushDocGraph:input_type -> net.pb.PushDocGraphRequest
-	5,  // 4: net.pb.Service.GetLog:input_type -> net.pb.GetLogRequest
-	7,  // 5: net.pb.Service.PushLog:input_type -> net.pb.PushLogRequest
-	8,  // 6: net.pb.Service.GetHeadLog:input_type -> net.pb.GetHeadLogRequest
-	2,  // 7: net.pb.Service.GetDocGraph:output_type -> net.pb.GetDocGraphReply
-	4,  // 8: net.pb.Service.PushDocGraph:output_type -> net.pb.PushDocGraphReply
-	6,  // 9: net.pb.Service.GetLog:output_type -> net.pb.GetLogReply
-	9,  // 10: net.pb.Service.PushLog:output_type -> net.pb.PushLogReply
-	10, // 11: net.pb.Service.GetHeadLog:output_type -> net.pb.GetHeadLogReply
-	7,  // [7:12] is the sub-list for method output_type
-	2,  // [2:7] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	1,  // 0: net.pb.GetDocGraphReply.blocks:type_name -> net.pb.Block
+	1,  // 1: net.pb.PushDocGraphRequest.blocks:type_name -> net.pb.Block
+	1,  // 2: net.pb.GetLogReply.blocks:type_name -> net.pb.Block
+	15, // 3: net.pb.PushLogRequest.body:type_name -> net.pb.PushLogRequest.Body
+	16, // 4: net.pb.GetHeadLogReply.heads:type_name -> net.pb.GetHeadLogReply.Head
+	17, // 5: net.pb.GetCollectionGraphReply.graphs:type_name -> net.pb.GetCollectionGraphReply.Graph
+	14, // 6: net.pb.PushLogRequest.Body.log:type_name -> net.pb.Document.Log
+	1,  // 7: net.pb.GetCollectionGraphReply.Graph.blocks:type_name -> net.pb.Block
+	2,  // 8: net.pb.Service.GetDocGraph:input_type -> net.pb.GetDocGraphRequest
+	4,  // 9: net.pb.Service.PushDocGraph:input_type -> net.pb.PushDocGraphRequest
+	6,  // 10: net.pb.Service.GetLog:input_type -> net.pb.GetLogRequest
+	8,  // 11: net.pb.Service.PushLog:input_type -> net.pb.PushLogRequest
+	9,  // 12: net.pb.Service.GetHeadLog:input_type -> net.pb.GetHeadLogRequest
+	12, // 13: net.pb.Service.GetCollectionGraph:input_type -> net.pb.GetCollectionGraphRequest
+	3,  // 14: net.pb.Service.GetDocGraph:output_type -> net.pb.GetDocGraphReply
+	5,  // 15: net.pb.Service.PushDocGraph:output_type -> net.pb.PushDocGraphReply
+	7,  // 16: net.pb.Service.GetLog:output_type -> net.pb.GetLogReply
+	10, // 17: net.pb.Service.PushLog:output_type -> net.pb.PushLogReply
+	11, // 18: net.pb.Service.GetHeadLog:output_type -> net.pb.GetHeadLogReply
+	13, // 19: net.pb.Service.GetCollectionGraph:output_type -> net.pb.GetCollectionGraphReply
+	14, // [14:20] is the sub-list for method output_type
+	8,  // [8:14] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_net_proto_init() }
@@ -726,7 +1318,7 @@ func file_net_proto_init() {
 			}
 		}
 		file_net_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetDocGraphRequest); i {
+			switch v := v.(*Block); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -738,7 +1330,7 @@ func file_net_proto_init() {
 			}
 		}
 		file_net_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetDocGraphReply); i {
+			switch v := v.(*GetDocGraphRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -750,7 +1342,7 @@ func file_net_proto_init() {
 			}
 		}
 		file_net_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushDocGraphRequest); i {
+			switch v := v.(*GetDocGraphReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -762,7 +1354,7 @@ func file_net_proto_init() {
 			}
 		}
 		file_net_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushDocGraphReply); i {
+			switch v := v.(*PushDocGraphRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -774,7 +1366,7 @@ func file_net_proto_init() {
 			}
 		}
 		file_net_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetLogRequest); i {
+			switch v := v.(*PushDocGraphReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -786,7 +1378,7 @@ func file_net_proto_init() {
 			}
 		}
 		file_net_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetLogReply); i {
+			switch v := v.(*GetLogRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -798,7 +1390,7 @@ func file_net_proto_init() {
 			}
 		}
 		file_net_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushLogRequest); i {
+			switch v := v.(*GetLogReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -810,7 +1402,7 @@ func file_net_proto_init() {
 			}
 		}
 		file_net_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetHeadLogRequest); i {
+			switch v := v.(*PushLogRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -822,7 +1414,7 @@ func file_net_proto_init() {
 			}
 		}
 		file_net_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushLogReply); i {
+			switch v := v.(*GetHeadLogRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -834,7 +1426,7 @@ func file_net_proto_init() {
 			}
 		}
 		file_net_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetHeadLogReply); i {
+			switch v := v.(*PushLogReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -846,7 +1438,7 @@ func file_net_proto_init() {
 			}
 		}
 		file_net_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Document_Log); i {
+			switch v := v.(*GetHeadLogReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -858,6 +1450,42 @@ func file_net_proto_init() {
 			}
 		}
 		file_net_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCollectionGraphRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_net_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCollectionGraphReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_net_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Document_Log); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_net_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PushLogRequest_Body); i {
 			case 0:
 				return &v.state
@@ -869,6 +1497,30 @@ func file_net_proto_init() {
 				return nil
 			}
 		}
+		file_net_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHeadLogReply_Head); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_net_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCollectionGraphReply_Graph); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -876,7 +1528,7 @@ func file_net_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_net_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
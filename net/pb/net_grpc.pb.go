@@ -19,11 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Service_GetDocGraph_FullMethodName  = "/net.pb.Service/GetDocGraph"
-	Service_PushDocGraph_FullMethodName = "/net.pb.Service/PushDocGraph"
-	Service_GetLog_FullMethodName       = "/net.pb.Service/GetLog"
-	Service_PushLog_FullMethodName      = "/net.pb.Service/PushLog"
-	Service_GetHeadLog_FullMethodName   = "/net.pb.Service/GetHeadLog"
+	Service_GetDocGraph_FullMethodName        = "/net.pb.Service/GetDocGraph"
+	Service_PushDocGraph_FullMethodName       = "/net.pb.Service/PushDocGraph"
+	Service_GetLog_FullMethodName             = "/net.pb.Service/GetLog"
+	Service_PushLog_FullMethodName            = "/net.pb.Service/PushLog"
+	Service_GetHeadLog_FullMethodName         = "/net.pb.Service/GetHeadLog"
+	Service_GetCollectionGraph_FullMethodName = "/net.pb.Service/GetCollectionGraph"
 )
 
 // ServiceClient is the client API for Service service.
@@ -40,6 +41,8 @@ type ServiceClient interface {
 	PushLog(ctx context.Context, in *PushLogRequest, opts ...grpc.CallOption) (*PushLogReply, error)
 	// GetHeadLog from this peer
 	GetHeadLog(ctx context.Context, in *GetHeadLogRequest, opts ...grpc.CallOption) (*GetHeadLogReply, error)
+	// GetCollectionGraph from this peer, without the blocks that the requester has.
+	GetCollectionGraph(ctx context.Context, in *GetCollectionGraphRequest, opts ...grpc.CallOption) (*GetCollectionGraphReply, error)
 }
 
 type serviceClient struct {
@@ -95,6 +98,15 @@ func (c *serviceClient) GetHeadLog(ctx context.Context, in *GetHeadLogRequest, o
 	return out, nil
 }
 
+func (c *serviceClient) GetCollectionGraph(ctx context.Context, in *GetCollectionGraphRequest, opts ...grpc.CallOption) (*GetCollectionGraphReply, error) {
+	out := new(GetCollectionGraphReply)
+	err := c.cc.Invoke(ctx, Service_GetCollectionGraph_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceServer is the server API for Service service.
 // All implementations must embed UnimplementedServiceServer
 // for forward compatibility
@@ -109,6 +121,8 @@ type ServiceServer interface {
 	PushLog(context.Context, *PushLogRequest) (*PushLogReply, error)
 	// GetHeadLog from this peer
 	GetHeadLog(context.Context, *GetHeadLogRequest) (*GetHeadLogReply, error)
+	// GetCollectionGraph from this peer, without the blocks that the requester has.
+	GetCollectionGraph(context.Context, *GetCollectionGraphRequest) (*GetCollectionGraphReply, error)
 	mustEmbedUnimplementedServiceServer()
 }
 
@@ -131,6 +145,9 @@ func (UnimplementedServiceServer) PushLog(context.Context, *PushLogRequest) (*Pu
 func (UnimplementedServiceServer) GetHeadLog(context.Context, *GetHeadLogRequest) (*GetHeadLogReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetHeadLog not implemented")
 }
+func (UnimplementedServiceServer) GetCollectionGraph(context.Context, *GetCollectionGraphRequest) (*GetCollectionGraphReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCollectionGraph not implemented")
+}
 func (UnimplementedServiceServer) mustEmbedUnimplementedServiceServer() {}
 
 // UnsafeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -234,6 +251,24 @@ func _Service_GetHeadLog_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_GetCollectionGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetCollectionGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetCollectionGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetCollectionGraph(ctx, req.(*GetCollectionGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Service_ServiceDesc is the grpc.ServiceDesc for Service service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -261,6 +296,10 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetHeadLog",
 			Handler:    _Service_GetHeadLog_Handler,
 		},
+		{
+			MethodName: "GetCollectionGraph",
+			Handler:    _Service_GetCollectionGraph_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "net.proto",
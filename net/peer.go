@@ -15,6 +15,7 @@ package net
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ipfs/boxo/bitswap"
@@ -27,6 +28,7 @@ import (
 	ipld "github.com/ipfs/go-ipld-format"
 	gostream "github.com/libp2p/go-libp2p-gostream"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/event"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	peerstore "github.com/libp2p/go-libp2p/core/peerstore"
@@ -63,6 +65,9 @@ type Peer struct {
 	server *server
 	p2pRPC *grpc.Server // rpc server over the P2P network
 
+	// catchUpSub receives the peer connection events that trigger catching up with the peers.
+	catchUpSub event.Subscription
+
 	// Used to close the dagWorker pool for a given document.
 	// The string represents a dockey.
 	closeJob chan string
@@ -73,7 +78,28 @@ type Peer struct {
 
 	// replicators is a map from collectionName => peerId
 	replicators map[string]map[peer.ID]struct{}
-	mu          sync.Mutex
+	// replicatorFilters holds the filter that the documents must match to be pushed to each
+	// replicator, the replicators without filter are not in the map.
+	replicatorFilters map[peer.ID]*docFilter
+	mu                sync.Mutex
+
+	// collectionFilters holds the filter that the documents received from other peers must
+	// match to be merged, by schema root, the P2P collections without filter are not in the map.
+	collectionFilters   map[string]*docFilter
+	collectionFiltersMu sync.RWMutex
+
+	// replicatorStatuses tracks the pushes to each replicator and the retries of their queued updates.
+	replicatorStatuses map[peer.ID]*replicatorStatus
+	statusMu           sync.Mutex
+
+	// replicatorRetryInterval is how often the outbox is checked for updates that are due a retry.
+	replicatorRetryInterval time.Duration
+	// replicatorMinBackoff is the delay before the first retry of a failed push to a replicator.
+	replicatorMinBackoff time.Duration
+	// replicatorMaxBackoff is the longest delay between two retries of a failed push to a replicator.
+	replicatorMaxBackoff time.Duration
+	// outboxSeq is the sequence number of the last update queued in the replicator outbox.
+	outboxSeq atomic.Uint64
 
 	// peer DAG service
 	ipld.DAGService
@@ -100,17 +126,24 @@ func NewPeer(
 
 	ctx, cancel := context.WithCancel(ctx)
 	p := &Peer{
-		host:           h,
-		dht:            dht,
-		ps:             ps,
-		db:             db,
-		p2pRPC:         grpc.NewServer(serverOptions...),
-		ctx:            ctx,
-		cancel:         cancel,
-		closeJob:       make(chan string),
-		sendJobs:       make(chan *dagJob),
-		replicators:    make(map[string]map[peer.ID]struct{}),
-		queuedChildren: newCidSafeSet(),
+		host:               h,
+		dht:                dht,
+		ps:                 ps,
+		db:                 db,
+		p2pRPC:             grpc.NewServer(serverOptions...),
+		ctx:                ctx,
+		cancel:             cancel,
+		closeJob:           make(chan string),
+		sendJobs:           make(chan *dagJob),
+		replicators:        make(map[string]map[peer.ID]struct{}),
+		replicatorFilters:  make(map[peer.ID]*docFilter),
+		replicatorStatuses: make(map[peer.ID]*replicatorStatus),
+		collectionFilters:  make(map[string]*docFilter),
+		queuedChildren:     newCidSafeSet(),
+
+		replicatorRetryInterval: defaultReplicatorRetryInterval,
+		replicatorMinBackoff:    defaultReplicatorMinBackoff,
+		replicatorMaxBackoff:    defaultReplicatorMaxBackoff,
 	}
 	var err error
 	p.server, err = newServer(p, db, dialOptions...)
@@ -123,6 +156,11 @@ func NewPeer(
 		return nil, err
 	}
 
+	err = p.loadReplicatorOutboxSeq(p.ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	p.setupBlockService()
 	p.setupDAGService()
 
@@ -134,6 +172,16 @@ func (p *Peer) Start() error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	if p.ps != nil {
+		sub, err := p.host.EventBus().Subscribe(new(event.EvtPeerConnectednessChanged))
+		if err != nil {
+			log.Info(p.ctx, "could not subscribe to peer connection events", logging.NewKV("Error", err.Error()))
+		} else {
+			p.catchUpSub = sub
+			go p.handleCatchUpLoop(sub)
+		}
+	}
+
 	// reconnect to known peers
 	var wg sync.WaitGroup
 	for _, id := range p.host.Peerstore().PeersWithAddrs() {
@@ -189,6 +237,9 @@ func (p *Peer) Start() error {
 	// start sendJobWorker
 	go p.sendJobWorker()
 
+	// retry the updates queued in the replicator outbox
+	go p.handleReplicatorRetryLoop()
+
 	return nil
 }
 
@@ -220,6 +271,12 @@ func (p *Peer) Close() {
 		}
 	}
 
+	if p.catchUpSub != nil {
+		if err := p.catchUpSub.Close(); err != nil {
+			log.Info(p.ctx, "Could not close peer connection subscription", logging.NewKV("Error", err.Error()))
+		}
+	}
+
 	if p.db.Events().Updates.HasValue() {
 		p.db.Events().Updates.Value().Unsubscribe(p.updateChannel)
 	}
@@ -311,12 +368,57 @@ func (p *Peer) pushToReplicator(
 	collection client.Collection,
 	keysCh <-chan client.DocKeysResult,
 	pid peer.ID,
+	filter *docFilter,
 ) {
 	for key := range keysCh {
 		if key.Err != nil {
 			log.ErrorE(ctx, "Key channel error", key.Err)
 			continue
 		}
+		matches, err := filter.matches(ctx, collection.WithTxn(txn), key.Key)
+		if err != nil {
+			log.ErrorE(
+				ctx,
+				"Failed to match document against replicator filter",
+				err,
+				logging.NewKV("DocKey", key.Key.String()),
+				logging.NewKV("PeerID", pid),
+			)
+			continue
+		}
+		if !matches {
+			continue
+		}
+		if filter != nil {
+			// the replicator holds the document, its updates are pushed until it leaves the filter
+			repDocKey := core.NewReplicatorDocKey(pid.String(), key.Key.String())
+			held, err := txn.Systemstore().Has(ctx, repDocKey.ToDS())
+			if err != nil {
+				log.ErrorE(
+					ctx,
+					"Failed to check document pushed to replicator",
+					err,
+					logging.NewKV("DocKey", key.Key.String()),
+					logging.NewKV("PeerID", pid),
+				)
+				continue
+			}
+			if held {
+				// the replicator already has the document and is pushed its updates as they are made
+				continue
+			}
+			err = txn.Systemstore().Put(ctx, repDocKey.ToDS(), []byte(collection.SchemaRoot()))
+			if err != nil {
+				log.ErrorE(
+					ctx,
+					"Failed to record document pushed to replicator",
+					err,
+					logging.NewKV("DocKey", key.Key.String()),
+					logging.NewKV("PeerID", pid),
+				)
+				continue
+			}
+		}
 		dockey := core.DataStoreKeyFromDocKey(key.Key)
 		headset := clock.NewHeadSet(
 			txn.Headstore(),
@@ -358,7 +460,7 @@ func (p *Peer) pushToReplicator(
 				Block:      nd,
 				Priority:   priority,
 			}
-			if err := p.server.pushLog(ctx, evt, pid); err != nil {
+			if err := p.server.pushLog(ctx, evt, pid, filter != nil); err != nil {
 				log.ErrorE(
 					ctx,
 					"Failed to replicate log",
@@ -366,7 +468,10 @@ func (p *Peer) pushToReplicator(
 					logging.NewKV("CID", c),
 					logging.NewKV("PeerID", pid),
 				)
+				p.queueReplicatorLog(ctx, pid, evt, err)
+				continue
 			}
+			p.setReplicatorPushed(pid, evt)
 		}
 	}
 }
@@ -379,6 +484,13 @@ func (p *Peer) loadReplicators(ctx context.Context) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	for _, rep := range reps {
+		filter, err := newDocFilter(rep.Filter)
+		if err != nil {
+			return err
+		}
+		if filter != nil {
+			p.replicatorFilters[rep.Info.ID] = filter
+		}
 		for _, schema := range rep.Schemas {
 			if pReps, exists := p.replicators[schema]; exists {
 				if _, exists := pReps[rep.Info.ID]; exists {
@@ -415,6 +527,9 @@ func (p *Peer) loadP2PCollections(ctx context.Context) (map[string]struct{}, err
 		}
 		colMap[col] = struct{}{}
 	}
+	if err := p.loadP2PCollectionFilters(ctx); err != nil {
+		return nil, err
+	}
 
 	return colMap, nil
 }
@@ -488,6 +603,12 @@ func (p *Peer) pushLogToReplicators(ctx context.Context, lg events.Update) {
 
 	p.mu.Lock()
 	reps, exists := p.replicators[lg.SchemaRoot]
+	filters := make(map[peer.ID]*docFilter)
+	for pid := range reps {
+		if filter, ok := p.replicatorFilters[pid]; ok {
+			filters[pid] = filter
+		}
+	}
 	p.mu.Unlock()
 
 	if exists {
@@ -497,8 +618,25 @@ func (p *Peer) pushLogToReplicators(ctx context.Context, lg events.Update) {
 			if _, ok := peers[pid.String()]; ok {
 				continue
 			}
+			filter, filtered := filters[pid]
+			if filtered {
+				matches, err := p.matchesReplicatorFilter(ctx, filter, pid, lg)
+				if err != nil {
+					log.ErrorE(
+						ctx,
+						"Failed to match document against replicator filter",
+						err,
+						logging.NewKV("DocKey", lg.DocKey),
+						logging.NewKV("PeerID", pid),
+					)
+					continue
+				}
+				if !matches {
+					continue
+				}
+			}
 			go func(peerID peer.ID) {
-				if err := p.server.pushLog(p.ctx, lg, peerID); err != nil {
+				if err := p.server.pushLog(p.ctx, lg, peerID, filtered); err != nil {
 					log.ErrorE(
 						p.ctx,
 						"Failed pushing log",
@@ -506,7 +644,10 @@ func (p *Peer) pushLogToReplicators(ctx context.Context, lg events.Update) {
 						logging.NewKV("DocKey", lg.DocKey),
 						logging.NewKV("CID", lg.Cid),
 						logging.NewKV("PeerID", peerID))
+					p.queueReplicatorLog(p.ctx, peerID, lg, err)
+					return
 				}
+				p.setReplicatorPushed(peerID, lg)
 			}(pid)
 		}
 	}
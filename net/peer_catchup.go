@@ -0,0 +1,234 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"context"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/logging"
+	pb "github.com/sourcenetwork/defradb/net/pb"
+)
+
+// handleCatchUpLoop catches up with the peers as they connect, so that the updates published
+// over pubsub while this node was offline are not missed.
+func (p *Peer) handleCatchUpLoop(sub event.Subscription) {
+	for e := range sub.Out() {
+		evt := e.(event.EvtPeerConnectednessChanged)
+		if evt.Connectedness != network.Connected {
+			continue
+		}
+		go func(pid peer.ID) {
+			if err := p.catchUp(p.ctx, pid); err != nil {
+				log.Info(
+					p.ctx,
+					"Failure while catching up with a peer",
+					logging.NewKV("PeerID", pid),
+					logging.NewKV("Error", err),
+				)
+			}
+		}(evt.Peer)
+	}
+}
+
+// reconcileBatchSize is the number of documents of a collection that are reconciled
+// with a peer per request.
+const reconcileBatchSize = 100
+
+// catchUp asks the given peer for the heads of the documents and collections this node
+// is subscribed to, and fetches the blocks of the documents that have heads missing locally.
+//
+// The documents of the subscribed collections are reconciled in batches, each request carrying
+// a Bloom filter of the blocks that this node has so that the peer only sends the missing ones.
+func (p *Peer) catchUp(ctx context.Context, pid peer.ID) error {
+	var dockeys, schemaRoots []string
+	for _, topic := range p.server.subscribedTopics() {
+		if _, err := client.NewDocKeyFromString(topic); err == nil {
+			dockeys = append(dockeys, topic)
+		} else {
+			schemaRoots = append(schemaRoots, topic)
+		}
+	}
+	if len(dockeys) == 0 && len(schemaRoots) == 0 {
+		return nil
+	}
+
+	reply, err := p.server.getHeadLog(ctx, pid, dockeys, schemaRoots)
+	if err != nil {
+		return err
+	}
+
+	collections := make(map[string][]string, len(schemaRoots))
+	for _, schemaRoot := range schemaRoots {
+		collections[schemaRoot] = nil
+	}
+	for _, head := range reply.Heads {
+		missing, err := p.hasMissingHeads(ctx, head)
+		if err == nil && missing {
+			schemaRoot := string(head.SchemaRoot)
+			if docs, ok := collections[schemaRoot]; ok {
+				collections[schemaRoot] = append(docs, string(head.DocKey))
+				continue
+			}
+			err = p.catchUpDoc(ctx, pid, head)
+		}
+		if err != nil {
+			log.ErrorE(
+				ctx,
+				"Failed to catch up with document",
+				err,
+				logging.NewKV("DocKey", string(head.DocKey)),
+				logging.NewKV("PeerID", pid),
+			)
+		}
+	}
+
+	for schemaRoot, docs := range collections {
+		for len(docs) > 0 {
+			n := reconcileBatchSize
+			if n > len(docs) {
+				n = len(docs)
+			}
+			if err := p.reconcileDocs(ctx, pid, schemaRoot, docs[:n]); err != nil {
+				log.ErrorE(
+					ctx,
+					"Failed to reconcile collection",
+					err,
+					logging.NewKV("SchemaRoot", schemaRoot),
+					logging.NewKV("PeerID", pid),
+				)
+			}
+			docs = docs[n:]
+		}
+	}
+	return nil
+}
+
+// hasMissingHeads returns true if any of the given heads of the peer is missing locally.
+func (p *Peer) hasMissingHeads(ctx context.Context, head *pb.GetHeadLogReply_Head) (bool, error) {
+	remoteHeads, err := cidsFromBytes(head.Cids)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range remoteHeads {
+		exists, err := p.db.Blockstore().Has(ctx, c)
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// catchUpDoc fetches the blocks of the document that can't be reached from the local heads
+// and merges them.
+func (p *Peer) catchUpDoc(ctx context.Context, pid peer.ID, head *pb.GetHeadLogReply_Head) error {
+	dockey, err := client.NewDocKeyFromString(string(head.DocKey))
+	if err != nil {
+		return err
+	}
+
+	txn, err := p.db.NewTxn(ctx, true)
+	if err != nil {
+		return err
+	}
+	localHeads, _, err := p.server.getDocHeads(ctx, txn, dockey)
+	txn.Discard(ctx)
+	if err != nil {
+		return err
+	}
+
+	reply, err := p.server.getDocGraph(ctx, pid, dockey.String(), localHeads)
+	if err != nil {
+		return err
+	}
+	return p.server.processDocGraph(ctx, dockey, string(reply.SchemaRoot), reply.Heads, reply.Blocks)
+}
+
+// reconcileDocs fetches the blocks of the given documents of a collection that are missing
+// locally and merges them.
+//
+// The peer is sent a Bloom filter of the local blocks of the documents and replies with the
+// blocks that are not in it. The blocks missed due to false positives are fetched one by one
+// while merging.
+func (p *Peer) reconcileDocs(ctx context.Context, pid peer.ID, schemaRoot string, dockeys []string) error {
+	filter, err := p.localBlocksFilter(ctx, dockeys)
+	if err != nil {
+		return err
+	}
+
+	reply, err := p.server.getCollectionGraph(ctx, pid, schemaRoot, dockeys, filter)
+	if err != nil {
+		return err
+	}
+	for _, graph := range reply.Graphs {
+		dockey, err := client.NewDocKeyFromString(string(graph.DocKey))
+		if err == nil {
+			err = p.server.processDocGraph(ctx, dockey, schemaRoot, graph.Heads, graph.Blocks)
+		}
+		if err != nil {
+			log.ErrorE(
+				ctx,
+				"Failed to merge reconciled document",
+				err,
+				logging.NewKV("DocKey", string(graph.DocKey)),
+				logging.NewKV("PeerID", pid),
+			)
+		}
+	}
+	return nil
+}
+
+// localBlocksFilter returns a filter of the blocks of the given documents that are present locally.
+func (p *Peer) localBlocksFilter(ctx context.Context, dockeys []string) (*cidFilter, error) {
+	txn, err := p.db.NewTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	var cids []cid.Cid
+	for _, key := range dockeys {
+		dockey, err := client.NewDocKeyFromString(key)
+		if err != nil {
+			return nil, err
+		}
+		heads, _, err := p.server.getDocHeads(ctx, txn, dockey)
+		if err != nil {
+			return nil, err
+		}
+		visited := make(map[cid.Cid]struct{})
+		err = walkDAG(ctx, txn.DAGstore(), heads, visited, nil, func(block blocks.Block) bool {
+			cids = append(cids, block.Cid())
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	filter, err := newCIDFilter(len(cids))
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cids {
+		filter.add(c)
+	}
+	return filter, nil
+}
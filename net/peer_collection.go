@@ -11,7 +11,9 @@
 package net
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 
 	dsq "github.com/ipfs/go-datastore/query"
 
@@ -45,6 +47,14 @@ func (p *Peer) AddP2PCollections(ctx context.Context, collectionIDs []string) er
 	// before adding to topics.
 	for _, col := range storeCollections {
 		key := core.NewP2PCollectionKey(col.SchemaRoot())
+		// Collections that are already added keep their filter.
+		exists, err := txn.Systemstore().Has(ctx, key.ToDS())
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
 		err = txn.Systemstore().Put(ctx, key.ToDS(), []byte{marker})
 		if err != nil {
 			return err
@@ -148,6 +158,111 @@ func (p *Peer) RemoveP2PCollections(ctx context.Context, collectionIDs []string)
 		return p.rollbackRemovePubSubTopics(removedTopics, err)
 	}
 
+	p.collectionFiltersMu.Lock()
+	for _, col := range storeCollections {
+		delete(p.collectionFilters, col.SchemaRoot())
+	}
+	p.collectionFiltersMu.Unlock()
+
+	return nil
+}
+
+func (p *Peer) SetP2PCollectionFilter(ctx context.Context, collectionID string, filter map[string]any) error {
+	docFilter, err := newDocFilter(filter)
+	if err != nil {
+		return err
+	}
+
+	txn, err := p.db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	key := core.NewP2PCollectionKey(collectionID)
+	exists, err := txn.Systemstore().Has(ctx, key.ToDS())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return NewErrP2PCollectionNotFound(collectionID)
+	}
+
+	// The filter is stored in place of the marker of the collection.
+	value := []byte{marker}
+	if docFilter != nil {
+		value, err = json.Marshal(docFilter.source)
+		if err != nil {
+			return err
+		}
+	}
+	if err := txn.Systemstore().Put(ctx, key.ToDS(), value); err != nil {
+		return err
+	}
+	if err := txn.Commit(ctx); err != nil {
+		return err
+	}
+
+	p.collectionFiltersMu.Lock()
+	defer p.collectionFiltersMu.Unlock()
+	if docFilter != nil {
+		p.collectionFilters[collectionID] = docFilter
+	} else {
+		delete(p.collectionFilters, collectionID)
+	}
+	return nil
+}
+
+// getCollectionFilter returns the filter of the P2P collection with the given schema root, if any.
+func (p *Peer) getCollectionFilter(schemaRoot string) *docFilter {
+	p.collectionFiltersMu.RLock()
+	defer p.collectionFiltersMu.RUnlock()
+
+	return p.collectionFilters[schemaRoot]
+}
+
+// loadP2PCollectionFilters loads the filters of the P2P collections from the store.
+func (p *Peer) loadP2PCollectionFilters(ctx context.Context) error {
+	txn, err := p.db.NewTxn(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	query := dsq.Query{
+		Prefix: core.NewP2PCollectionKey("").ToString(),
+	}
+	results, err := txn.Systemstore().Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return err
+	}
+
+	p.collectionFiltersMu.Lock()
+	defer p.collectionFiltersMu.Unlock()
+	for _, entry := range entries {
+		if bytes.Equal(entry.Value, []byte{marker}) {
+			continue
+		}
+		key, err := core.NewP2PCollectionKeyFromString(entry.Key)
+		if err != nil {
+			return err
+		}
+		var source map[string]any
+		if err := json.Unmarshal(entry.Value, &source); err != nil {
+			return err
+		}
+		filter, err := newDocFilter(source)
+		if err != nil {
+			return err
+		}
+		if filter != nil {
+			p.collectionFilters[key.CollectionID] = filter
+		}
+	}
 	return nil
 }
 
@@ -14,6 +14,7 @@ import (
 	"context"
 	"encoding/json"
 
+	ds "github.com/ipfs/go-datastore"
 	dsq "github.com/ipfs/go-datastore/query"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
@@ -38,6 +39,10 @@ func (p *Peer) SetReplicator(ctx context.Context, rep client.Replicator) error {
 	if err := rep.Info.ID.Validate(); err != nil {
 		return err
 	}
+	filter, err := newDocFilter(rep.Filter)
+	if err != nil {
+		return err
+	}
 
 	var collections []client.Collection
 	switch {
@@ -64,7 +69,9 @@ func (p *Peer) SetReplicator(ctx context.Context, rep client.Replicator) error {
 	// This will be used during connection and stream creation by libp2p.
 	p.host.Peerstore().AddAddrs(rep.Info.ID, rep.Info.Addrs, peerstore.PermanentAddrTTL)
 
+	prevFilter := p.replicatorFilters[rep.Info.ID]
 	var added []client.Collection
+	var refiltered []client.Collection
 	for _, col := range collections {
 		reps, exists := p.replicators[col.SchemaRoot()]
 		if !exists {
@@ -75,10 +82,21 @@ func (p *Peer) SetReplicator(ctx context.Context, rep client.Replicator) error {
 			// push logs to a replicator peer multiple times.
 			p.replicators[col.SchemaRoot()][rep.Info.ID] = struct{}{}
 			added = append(added, col)
+		} else if !prevFilter.equal(filter) {
+			// the documents of the collections that are already replicated are reconciled
+			// with the new filter
+			refiltered = append(refiltered, col)
 		}
 		rep.Schemas = append(rep.Schemas, col.SchemaRoot())
 	}
 
+	// the filter of the replicator replaces its previous one
+	if filter != nil {
+		p.replicatorFilters[rep.Info.ID] = filter
+	} else {
+		delete(p.replicatorFilters, rep.Info.ID)
+	}
+
 	// persist replicator to the datastore
 	repBytes, err := json.Marshal(rep)
 	if err != nil {
@@ -96,7 +114,13 @@ func (p *Peer) SetReplicator(ctx context.Context, rep client.Replicator) error {
 		if err != nil {
 			return NewErrReplicatorDocKey(err, col.Name(), rep.Info.ID)
 		}
-		p.pushToReplicator(ctx, txn, col, keysCh, rep.Info.ID)
+		p.pushToReplicator(ctx, txn, col, keysCh, rep.Info.ID, filter)
+	}
+	for _, col := range refiltered {
+		err := p.reconcileReplicatorDocs(ctx, txn, col, rep.Info.ID, prevFilter, filter)
+		if err != nil {
+			return err
+		}
 	}
 
 	return txn.Commit(ctx)
@@ -167,16 +191,46 @@ func (p *Peer) DeleteReplicator(ctx context.Context, rep client.Replicator) erro
 		p.host.Peerstore().ClearAddrs(rep.Info.ID)
 	}
 
-	// persist the replicator to the store, deleting it if no schemas remain
+	// persist the replicator to the store, deleting it and its outbox if no schemas remain
 	key := core.NewReplicatorKey(rep.Info.ID.String())
 	if len(rep.Schemas) == 0 {
-		return txn.Systemstore().Delete(ctx, key.ToDS())
+		if err := deleteReplicatorDocs(ctx, txn.Systemstore(), rep.Info.ID, nil); err != nil {
+			return err
+		}
+		outboxKeys, err := getReplicatorOutboxKeys(ctx, txn.Systemstore(), rep.Info.ID)
+		if err != nil {
+			return err
+		}
+		for _, outboxKey := range outboxKeys {
+			if err := txn.Systemstore().Delete(ctx, ds.NewKey(outboxKey)); err != nil {
+				return err
+			}
+		}
+		if err := txn.Systemstore().Delete(ctx, key.ToDS()); err != nil {
+			return err
+		}
+		delete(p.replicatorFilters, rep.Info.ID)
+		p.statusMu.Lock()
+		delete(p.replicatorStatuses, rep.Info.ID)
+		p.statusMu.Unlock()
+		return txn.Commit(ctx)
+	}
+	if err := deleteReplicatorDocs(ctx, txn.Systemstore(), rep.Info.ID, schemaMap); err != nil {
+		return err
+	}
+	// the replicator keeps its filter for the remaining schemas
+	rep.Filter = nil
+	if filter, ok := p.replicatorFilters[rep.Info.ID]; ok {
+		rep.Filter = filter.source
 	}
 	repBytes, err := json.Marshal(rep)
 	if err != nil {
 		return err
 	}
-	return txn.Systemstore().Put(ctx, key.ToDS(), repBytes)
+	if err := txn.Systemstore().Put(ctx, key.ToDS(), repBytes); err != nil {
+		return err
+	}
+	return txn.Commit(ctx)
 }
 
 func (p *Peer) GetAllReplicators(ctx context.Context) ([]client.Replicator, error) {
@@ -201,6 +255,12 @@ func (p *Peer) GetAllReplicators(ctx context.Context) ([]client.Replicator, erro
 		if err = json.Unmarshal(result.Value, &rep); err != nil {
 			return nil, err
 		}
+		outboxKeys, err := getReplicatorOutboxKeys(ctx, txn.Systemstore(), rep.Info.ID)
+		if err != nil {
+			return nil, err
+		}
+		rep.Lag = len(outboxKeys)
+		p.fillReplicatorStatus(&rep)
 		reps = append(reps, rep)
 	}
 	return reps, nil
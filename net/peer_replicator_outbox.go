@@ -0,0 +1,285 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"context"
+	"time"
+
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/ipfs/go-cid"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/events"
+	"github.com/sourcenetwork/defradb/logging"
+)
+
+const (
+	defaultReplicatorRetryInterval = time.Second
+	defaultReplicatorMinBackoff    = time.Second
+	defaultReplicatorMaxBackoff    = time.Minute * 5
+)
+
+// replicatorOutboxEntry is an update queued in the outbox of a replicator.
+type replicatorOutboxEntry struct {
+	key        core.ReplicatorOutboxKey
+	schemaRoot string
+}
+
+// queueReplicatorLog persists an update that failed to be pushed to the replicator in its outbox,
+// so that it is retried until the replicator can be reached again.
+func (p *Peer) queueReplicatorLog(ctx context.Context, pid peer.ID, evt events.Update, pushErr error) {
+	p.setReplicatorFailure(pid, pushErr)
+
+	if err := p.putReplicatorLog(ctx, pid, evt); err != nil {
+		log.ErrorE(
+			ctx,
+			"Failed to queue log for replicator",
+			err,
+			logging.NewKV("DocKey", evt.DocKey),
+			logging.NewKV("CID", evt.Cid),
+			logging.NewKV("PeerID", pid),
+		)
+	}
+}
+
+// putReplicatorLog adds an update to the outbox of the replicator.
+func (p *Peer) putReplicatorLog(ctx context.Context, pid peer.ID, evt events.Update) error {
+	txn, err := p.db.NewTxn(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard(ctx)
+
+	key := core.NewReplicatorOutboxKey(pid.String(), p.outboxSeq.Add(1), evt.DocKey, evt.Cid.String())
+	if err := txn.Systemstore().Put(ctx, key.ToDS(), []byte(evt.SchemaRoot)); err != nil {
+		return err
+	}
+	return txn.Commit(ctx)
+}
+
+// isReplicating returns true if the documents of the given schema are replicated to the peer.
+func (p *Peer) isReplicating(pid peer.ID, schemaRoot string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, ok := p.replicators[schemaRoot][pid]
+	return ok
+}
+
+// hasReplicatorFilter returns true if the replicator only replicates the documents matching a filter.
+func (p *Peer) hasReplicatorFilter(pid peer.ID) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, ok := p.replicatorFilters[pid]
+	return ok
+}
+
+// handleReplicatorRetryLoop periodically retries pushing the updates queued
+// in the replicator outbox.
+func (p *Peer) handleReplicatorRetryLoop() {
+	ticker := time.NewTicker(p.replicatorRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.retryReplicatorLogs(p.ctx); err != nil {
+				log.ErrorE(p.ctx, "Failed to retry queued replicator logs", err)
+			}
+		}
+	}
+}
+
+// retryReplicatorLogs pushes the queued updates of each replicator that is due a retry, in the
+// order they have been queued in.
+//
+// The updates are removed from the outbox as they are pushed. The first failure backs off the
+// replicator until its next retry.
+func (p *Peer) retryReplicatorLogs(ctx context.Context) error {
+	outbox, err := p.getReplicatorOutbox(ctx)
+	if err != nil {
+		return err
+	}
+
+	for pid, entries := range outbox {
+		if !p.replicatorRetryDue(pid) {
+			continue
+		}
+
+		done := true
+		for _, entry := range entries {
+			if !p.isReplicating(pid, entry.schemaRoot) {
+				// The replicator no longer replicates the collection of the document.
+				p.removeReplicatorLog(ctx, entry.key)
+				continue
+			}
+			evt, err := p.loadReplicatorLog(ctx, entry)
+			if err != nil {
+				log.ErrorE(
+					ctx,
+					"Failed to load queued replicator log, dropping it",
+					err,
+					logging.NewKV("DocKey", entry.key.DocKey),
+					logging.NewKV("CID", entry.key.Cid),
+					logging.NewKV("PeerID", pid),
+				)
+				p.removeReplicatorLog(ctx, entry.key)
+				continue
+			}
+			if err := p.server.pushLog(ctx, evt, pid, p.hasReplicatorFilter(pid)); err != nil {
+				log.Info(
+					ctx,
+					"Failed to retry pushing log to replicator",
+					logging.NewKV("DocKey", evt.DocKey),
+					logging.NewKV("CID", evt.Cid),
+					logging.NewKV("PeerID", pid),
+					logging.NewKV("Error", err),
+				)
+				p.backoffReplicator(pid, err)
+				done = false
+				break
+			}
+			p.setReplicatorPushed(pid, evt)
+			p.removeReplicatorLog(ctx, entry.key)
+		}
+		if done {
+			p.resetReplicatorRetry(pid)
+		}
+	}
+	return nil
+}
+
+// getReplicatorOutbox returns the updates queued in the outbox, grouped by replicator.
+func (p *Peer) getReplicatorOutbox(ctx context.Context) (map[peer.ID][]replicatorOutboxEntry, error) {
+	txn, err := p.db.NewTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	query := dsq.Query{
+		Prefix: core.NewReplicatorOutboxKey("", 0, "", "").ToString(),
+	}
+	results, err := txn.Systemstore().Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := results.Close(); err != nil {
+			log.ErrorE(ctx, "Failed to close replicator outbox query", err)
+		}
+	}()
+
+	outbox := make(map[peer.ID][]replicatorOutboxEntry)
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		key, err := core.NewReplicatorOutboxKeyFromString(result.Key)
+		if err != nil {
+			return nil, err
+		}
+		pid, err := peer.Decode(key.ReplicatorID)
+		if err != nil {
+			return nil, err
+		}
+		outbox[pid] = append(outbox[pid], replicatorOutboxEntry{
+			key:        key,
+			schemaRoot: string(result.Value),
+		})
+	}
+	return outbox, nil
+}
+
+// loadReplicatorOutboxSeq sets the sequence number of the updates queued in the outbox to the
+// greatest one of the updates already queued, so that the new updates are queued after them.
+func (p *Peer) loadReplicatorOutboxSeq(ctx context.Context) error {
+	outbox, err := p.getReplicatorOutbox(ctx)
+	if err != nil {
+		return err
+	}
+	var seq uint64
+	for _, entries := range outbox {
+		if len(entries) > 0 && entries[len(entries)-1].key.Seq > seq {
+			seq = entries[len(entries)-1].key.Seq
+		}
+	}
+	p.outboxSeq.Store(seq)
+	return nil
+}
+
+// loadReplicatorLog rebuilds the update of a queued entry from the block stored locally.
+func (p *Peer) loadReplicatorLog(ctx context.Context, entry replicatorOutboxEntry) (events.Update, error) {
+	c, err := cid.Decode(entry.key.Cid)
+	if err != nil {
+		return events.Update{}, err
+	}
+	blk, err := p.db.Blockstore().Get(ctx, c)
+	if err != nil {
+		return events.Update{}, err
+	}
+	nd, err := dag.DecodeProtobuf(blk.RawData())
+	if err != nil {
+		return events.Update{}, err
+	}
+	return events.Update{
+		DocKey:     entry.key.DocKey,
+		Cid:        c,
+		SchemaRoot: entry.schemaRoot,
+		Block:      nd,
+	}, nil
+}
+
+// removeReplicatorLog removes an update from the outbox.
+func (p *Peer) removeReplicatorLog(ctx context.Context, key core.ReplicatorOutboxKey) {
+	txn, err := p.db.NewTxn(ctx, false)
+	if err != nil {
+		log.ErrorE(ctx, "Failed to remove log from replicator outbox", err, logging.NewKV("Key", key.ToString()))
+		return
+	}
+	defer txn.Discard(ctx)
+
+	err = txn.Systemstore().Delete(ctx, key.ToDS())
+	if err == nil {
+		err = txn.Commit(ctx)
+	}
+	if err != nil {
+		log.ErrorE(ctx, "Failed to remove log from replicator outbox", err, logging.NewKV("Key", key.ToString()))
+	}
+}
+
+// getReplicatorOutboxKeys returns the keys of the updates queued for the replicator.
+func getReplicatorOutboxKeys(ctx context.Context, store datastore.DSReaderWriter, pid peer.ID) ([]string, error) {
+	query := dsq.Query{
+		Prefix:   core.NewReplicatorOutboxKey(pid.String(), 0, "", "").ToString(),
+		KeysOnly: true,
+	}
+	results, err := store.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(entries))
+	for i, entry := range entries {
+		keys[i] = entry.Key
+	}
+	return keys, nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package net
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/events"
+	"github.com/sourcenetwork/defradb/metric"
+)
+
+// replicatorStatus tracks the pushes to a replicator and the retry schedule
+// of the updates queued for it.
+type replicatorStatus struct {
+	// backoff is the delay until the next retry, it is doubled after each failed retry.
+	backoff time.Duration
+	// next is the time of the next retry, it is zero if no retry is scheduled.
+	next time.Time
+	// lastErr is the error of the last failed push to the replicator.
+	lastErr string
+	// lastPushedAt is the time of the last successful push to the replicator.
+	lastPushedAt time.Time
+	// heads maps the schema root of a collection to the cid of the last update
+	// of each of its documents acknowledged by the replicator.
+	heads map[string]map[string]string
+}
+
+// getReplicatorStatus returns the status of the replicator, creating it if needed.
+//
+// The caller must hold statusMu.
+func (p *Peer) getReplicatorStatus(pid peer.ID) *replicatorStatus {
+	r, ok := p.replicatorStatuses[pid]
+	if !ok {
+		r = &replicatorStatus{
+			backoff: p.replicatorMinBackoff,
+			heads:   make(map[string]map[string]string),
+		}
+		p.replicatorStatuses[pid] = r
+	}
+	return r
+}
+
+// setReplicatorPushed records an update acknowledged by the replicator.
+func (p *Peer) setReplicatorPushed(pid peer.ID, evt events.Update) {
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
+	r := p.getReplicatorStatus(pid)
+	r.lastPushedAt = time.Now()
+	if _, ok := r.heads[evt.SchemaRoot]; !ok {
+		r.heads[evt.SchemaRoot] = make(map[string]string)
+	}
+	r.heads[evt.SchemaRoot][evt.DocKey] = evt.Cid.String()
+}
+
+// setReplicatorFailure records the error of a failed push to the replicator and schedules
+// a retry, if none is scheduled yet.
+func (p *Peer) setReplicatorFailure(pid peer.ID, err error) {
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
+	r := p.getReplicatorStatus(pid)
+	if r.next.IsZero() {
+		r.next = time.Now().Add(r.backoff)
+	}
+	r.lastErr = err.Error()
+}
+
+// backoffReplicator records the error of a failed retry and reschedules the next one
+// after twice the previous delay.
+func (p *Peer) backoffReplicator(pid peer.ID, err error) {
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
+	r := p.getReplicatorStatus(pid)
+	if !r.next.IsZero() {
+		r.backoff *= 2
+		if r.backoff > p.replicatorMaxBackoff {
+			r.backoff = p.replicatorMaxBackoff
+		}
+	}
+	r.next = time.Now().Add(r.backoff)
+	r.lastErr = err.Error()
+}
+
+// resetReplicatorRetry clears the retry schedule of the replicator once its outbox is empty.
+//
+// The last error is kept so that it can still be inspected.
+func (p *Peer) resetReplicatorRetry(pid peer.ID) {
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
+	if r, ok := p.replicatorStatuses[pid]; ok {
+		r.backoff = p.replicatorMinBackoff
+		r.next = time.Time{}
+	}
+}
+
+// replicatorRetryDue returns true if the updates queued for the replicator are due a retry.
+func (p *Peer) replicatorRetryDue(pid peer.ID) bool {
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
+	r, ok := p.replicatorStatuses[pid]
+	return !ok || !time.Now().Before(r.next)
+}
+
+// fillReplicatorStatus sets the status fields of the given replicator, besides its lag
+// which is read from the outbox.
+func (p *Peer) fillReplicatorStatus(rep *client.Replicator) {
+	rep.Status = client.ReplicatorStatusDisconnected
+	if p.host.Network().Connectedness(rep.Info.ID) == network.Connected {
+		rep.Status = client.ReplicatorStatusConnected
+	}
+
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
+	r, ok := p.replicatorStatuses[rep.Info.ID]
+	if !ok {
+		return
+	}
+	rep.LastError = r.lastErr
+	rep.LastPushedAt = r.lastPushedAt
+	if len(r.heads) == 0 {
+		return
+	}
+	rep.Heads = make(map[string][]string, len(r.heads))
+	for schemaRoot, docHeads := range r.heads {
+		heads := make([]string, 0, len(docHeads))
+		for _, head := range docHeads {
+			heads = append(heads, head)
+		}
+		sort.Strings(heads)
+		rep.Heads[schemaRoot] = heads
+	}
+}
+
+// RegisterReplicatorMetrics registers gauges reporting the status of each replicator
+// with the given meter, they are observed whenever the metrics are collected.
+func (p *Peer) RegisterReplicatorMetrics(m *metric.Meter) error {
+	lag, err := m.GetAsyncGauge("replicator_lag", "{block}")
+	if err != nil {
+		return err
+	}
+	connected, err := m.GetAsyncGauge("replicator_connected", "")
+	if err != nil {
+		return err
+	}
+	lastPushedAt, err := m.GetAsyncGauge("replicator_last_pushed_at", "s")
+	if err != nil {
+		return err
+	}
+
+	_, err = m.Get().RegisterCallback(func(ctx context.Context, o otelmetric.Observer) error {
+		reps, err := p.GetAllReplicators(ctx)
+		if err != nil {
+			return err
+		}
+		for _, rep := range reps {
+			attrs := otelmetric.WithAttributes(attribute.String("peer_id", rep.Info.ID.String()))
+			o.ObserveInt64(lag, int64(rep.Lag), attrs)
+			isConnected := int64(0)
+			if rep.Status == client.ReplicatorStatusConnected {
+				isConnected = 1
+			}
+			o.ObserveInt64(connected, isConnected, attrs)
+			if !rep.LastPushedAt.IsZero() {
+				o.ObserveInt64(lastPushedAt, rep.LastPushedAt.Unix(), attrs)
+			}
+		}
+		return nil
+	}, lag, connected, lastPushedAt)
+	return err
+}
@@ -24,14 +24,18 @@ import (
 	mh "github.com/multiformats/go-multihash"
 	rpc "github.com/sourcenetwork/go-libp2p-pubsub-rpc"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/config"
+	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/core/crdt"
 	"github.com/sourcenetwork/defradb/datastore/memory"
 	"github.com/sourcenetwork/defradb/db"
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/events"
+	"github.com/sourcenetwork/defradb/metric"
+	net_pb "github.com/sourcenetwork/defradb/net/pb"
 	netutils "github.com/sourcenetwork/defradb/net/utils"
 )
 
@@ -488,7 +492,7 @@ func TestPushToReplicator_SingleDocumentNoPeer_FailedToReplicateLogError(t *test
 	txn, err := db.NewTxn(ctx, true)
 	require.NoError(t, err)
 
-	n.pushToReplicator(ctx, txn, col, keysCh, n.PeerID())
+	n.pushToReplicator(ctx, txn, col, keysCh, n.PeerID(), nil)
 }
 
 func TestDeleteReplicator_WithDBClosed_DataStoreClosedError(t *testing.T) {
@@ -693,6 +697,352 @@ func TestLoadReplicator_WithReplicatorAndEmptyReplicatorMap_NoError(t *testing.T
 	require.NoError(t, err)
 }
 
+func TestSetReplicator_WithUnreachableReplicator_QueueLogs(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	defer n.Close()
+
+	_, doc := addUserDoc(ctx, t, db)
+
+	_, n2 := newTestNode(ctx, t)
+	defer n2.Close()
+
+	// n2 isn't started so the existing document can't be pushed to it.
+	err := n.Peer.SetReplicator(ctx, client.Replicator{
+		Info: n2.PeerInfo(),
+	})
+	require.NoError(t, err)
+
+	reps, err := n.Peer.GetAllReplicators(ctx)
+	require.NoError(t, err)
+	require.Len(t, reps, 1)
+	require.Equal(t, 1, reps[0].Lag)
+	require.NotEmpty(t, reps[0].LastError)
+
+	outbox, err := n.Peer.getReplicatorOutbox(ctx)
+	require.NoError(t, err)
+	require.Len(t, outbox[n2.PeerID()], 1)
+	require.Equal(t, doc.Key().String(), outbox[n2.PeerID()][0].key.DocKey)
+}
+
+func TestSetReplicator_WithChangedFilter_ReconcileHeldDocs(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	defer n.Close()
+
+	col, john := addUserDoc(ctx, t, db)
+	fred, err := client.NewDocFromJSON([]byte(`{"name": "Fred", "age": 42}`))
+	require.NoError(t, err)
+	err = col.Create(ctx, fred)
+	require.NoError(t, err)
+
+	// n2 isn't started so the pushed documents are queued in the outbox.
+	_, n2 := newTestNode(ctx, t)
+	defer n2.Close()
+
+	err = n.Peer.SetReplicator(ctx, client.Replicator{
+		Info:   n2.PeerInfo(),
+		Filter: map[string]any{"name": map[string]any{"_eq": "John"}},
+	})
+	require.NoError(t, err)
+
+	err = n.Peer.SetReplicator(ctx, client.Replicator{
+		Info:   n2.PeerInfo(),
+		Filter: map[string]any{"name": map[string]any{"_eq": "Fred"}},
+	})
+	require.NoError(t, err)
+
+	outbox, err := n.Peer.getReplicatorOutbox(ctx)
+	require.NoError(t, err)
+	require.Len(t, outbox[n2.PeerID()], 2)
+	require.Equal(t, john.Key().String(), outbox[n2.PeerID()][0].key.DocKey)
+	require.Equal(t, fred.Key().String(), outbox[n2.PeerID()][1].key.DocKey)
+
+	txn, err := db.NewTxn(ctx, true)
+	require.NoError(t, err)
+	defer txn.Discard(ctx)
+	held, err := txn.Systemstore().Has(ctx, core.NewReplicatorDocKey(n2.PeerID().String(), john.Key().String()).ToDS())
+	require.NoError(t, err)
+	require.False(t, held)
+	held, err = txn.Systemstore().Has(ctx, core.NewReplicatorDocKey(n2.PeerID().String(), fred.Key().String()).ToDS())
+	require.NoError(t, err)
+	require.True(t, held)
+}
+
+func TestMatchesReplicatorFilter_ShouldMatchDocAsOfUpdate(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	defer n.Close()
+
+	col, doc := addUserDoc(ctx, t, db)
+	filter, err := newDocFilter(map[string]any{"age": map[string]any{"_gt": 35}})
+	require.NoError(t, err)
+	pid := n.PeerID()
+
+	err = doc.Set("age", 40)
+	require.NoError(t, err)
+	err = col.Update(ctx, doc)
+	require.NoError(t, err)
+	matchingUpdate := events.Update{DocKey: doc.Key().String(), Cid: doc.Head(), SchemaRoot: col.SchemaRoot()}
+
+	err = doc.Set("age", 20)
+	require.NoError(t, err)
+	err = col.Update(ctx, doc)
+	require.NoError(t, err)
+	leavingUpdate := events.Update{DocKey: doc.Key().String(), Cid: doc.Head(), SchemaRoot: col.SchemaRoot()}
+
+	// the updates are handled once the document no longer matches the filter
+	matches, err := n.Peer.matchesReplicatorFilter(ctx, filter, pid, matchingUpdate)
+	require.NoError(t, err)
+	require.True(t, matches)
+
+	// the held document is pushed the update that takes it out of the filter
+	matches, err = n.Peer.matchesReplicatorFilter(ctx, filter, pid, leavingUpdate)
+	require.NoError(t, err)
+	require.True(t, matches)
+
+	matches, err = n.Peer.matchesReplicatorFilter(ctx, filter, pid, leavingUpdate)
+	require.NoError(t, err)
+	require.False(t, matches)
+}
+
+func TestReplicatorOutbox_ShouldKeepOrderOfQueuedLogs(t *testing.T) {
+	ctx := context.Background()
+	_, n := newTestNode(ctx, t)
+	defer n.Close()
+	_, n2 := newTestNode(ctx, t)
+	defer n2.Close()
+
+	pid := n2.PeerID()
+	var queued []string
+	for i := 0; i < 5; i++ {
+		c, err := cid.V1Builder{Codec: cid.DagProtobuf, MhType: mh.SHA2_256}.Sum([]byte{byte(i)})
+		require.NoError(t, err)
+		err = n.Peer.putReplicatorLog(ctx, pid, events.Update{DocKey: "doc", Cid: c})
+		require.NoError(t, err)
+		queued = append(queued, c.String())
+	}
+
+	outbox, err := n.Peer.getReplicatorOutbox(ctx)
+	require.NoError(t, err)
+	var cids []string
+	for _, entry := range outbox[pid] {
+		cids = append(cids, entry.key.Cid)
+	}
+	require.Equal(t, queued, cids)
+
+	// the sequence continues after the queued logs once reloaded
+	n.Peer.outboxSeq.Store(0)
+	err = n.Peer.loadReplicatorOutboxSeq(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), n.Peer.outboxSeq.Load())
+}
+
+func TestDeleteReplicator_WithQueuedLogs_ClearOutbox(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	defer n.Close()
+
+	addUserDoc(ctx, t, db)
+
+	_, n2 := newTestNode(ctx, t)
+	defer n2.Close()
+
+	err := n.Peer.SetReplicator(ctx, client.Replicator{
+		Info: n2.PeerInfo(),
+	})
+	require.NoError(t, err)
+
+	err = n.Peer.DeleteReplicator(ctx, client.Replicator{
+		Info: n2.PeerInfo(),
+	})
+	require.NoError(t, err)
+
+	reps, err := n.Peer.GetAllReplicators(ctx)
+	require.NoError(t, err)
+	require.Len(t, reps, 0)
+
+	outbox, err := n.Peer.getReplicatorOutbox(ctx)
+	require.NoError(t, err)
+	require.Len(t, outbox, 0)
+	require.NotContains(t, n.Peer.replicatorStatuses, n2.PeerID())
+}
+
+func TestReplicatorOutbox_WithReplicatorBackOnline_PushQueuedLogs(t *testing.T) {
+	ctx := context.Background()
+	db1, n1 := newTestNode(ctx, t)
+	defer n1.Close()
+	n1.Peer.replicatorRetryInterval = 20 * time.Millisecond
+	n1.Peer.replicatorMinBackoff = 50 * time.Millisecond
+	db2, n2 := newTestNode(ctx, t)
+	defer n2.Close()
+
+	err := n1.Start()
+	require.NoError(t, err)
+
+	_, doc := addUserDoc(ctx, t, db1)
+	_, err = db2.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	err = n1.Peer.SetReplicator(ctx, client.Replicator{
+		Info: n2.PeerInfo(),
+	})
+	require.NoError(t, err)
+
+	reps, err := n1.Peer.GetAllReplicators(ctx)
+	require.NoError(t, err)
+	require.Len(t, reps, 1)
+	require.Equal(t, 1, reps[0].Lag)
+
+	err = n2.Start()
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		reps, err := n1.Peer.GetAllReplicators(ctx)
+		return err == nil && len(reps) == 1 && reps[0].Lag == 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	col2, err := db2.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	_, err = col2.Get(ctx, doc.Key(), false)
+	require.NoError(t, err)
+}
+
+func TestGetAllReplicator_WithPushedDocument_ReturnStatus(t *testing.T) {
+	ctx := context.Background()
+	db1, n1 := newTestNode(ctx, t)
+	defer n1.Close()
+	db2, n2 := newTestNode(ctx, t)
+	defer n2.Close()
+
+	err := n2.Start()
+	require.NoError(t, err)
+
+	col, doc := addUserDoc(ctx, t, db1)
+	_, err = db2.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	err = n1.Peer.SetReplicator(ctx, client.Replicator{
+		Info: n2.PeerInfo(),
+	})
+	require.NoError(t, err)
+
+	txn, err := db1.NewTxn(ctx, true)
+	require.NoError(t, err)
+	defer txn.Discard(ctx)
+	heads, _, err := n1.server.getDocHeads(ctx, txn, doc.Key())
+	require.NoError(t, err)
+	require.Len(t, heads, 1)
+
+	reps, err := n1.Peer.GetAllReplicators(ctx)
+	require.NoError(t, err)
+	require.Len(t, reps, 1)
+	require.Equal(t, client.ReplicatorStatusConnected, reps[0].Status)
+	require.False(t, reps[0].LastPushedAt.IsZero())
+	require.Equal(t, 0, reps[0].Lag)
+	require.Equal(t, "", reps[0].LastError)
+	require.Equal(t, map[string][]string{col.SchemaRoot(): {heads[0].String()}}, reps[0].Heads)
+}
+
+func TestGetAllReplicator_WithNoPush_ReturnDisconnected(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	defer n.Close()
+
+	_, err := db.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	_, n2 := newTestNode(ctx, t)
+	defer n2.Close()
+
+	err = n.Peer.SetReplicator(ctx, client.Replicator{
+		Info: n2.PeerInfo(),
+	})
+	require.NoError(t, err)
+
+	reps, err := n.Peer.GetAllReplicators(ctx)
+	require.NoError(t, err)
+	require.Len(t, reps, 1)
+	require.Equal(t, client.ReplicatorStatusDisconnected, reps[0].Status)
+	require.True(t, reps[0].LastPushedAt.IsZero())
+	require.Nil(t, reps[0].Heads)
+}
+
+func TestRegisterReplicatorMetrics_WithQueuedLogs_ReportLag(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	defer n.Close()
+
+	addUserDoc(ctx, t, db)
+
+	_, n2 := newTestNode(ctx, t)
+	defer n2.Close()
+
+	err := n.Peer.SetReplicator(ctx, client.Replicator{
+		Info: n2.PeerInfo(),
+	})
+	require.NoError(t, err)
+
+	meter := metric.NewMeter()
+	meter.Register("net")
+	defer func() {
+		require.NoError(t, meter.Close(ctx))
+	}()
+	err = n.Peer.RegisterReplicatorMetrics(&meter)
+	require.NoError(t, err)
+
+	data, err := meter.Dump(ctx)
+	require.NoError(t, err)
+	require.Len(t, data.ScopeMetrics, 1)
+
+	values := make(map[string]int64)
+	for _, m := range data.ScopeMetrics[0].Metrics {
+		gauge, ok := m.Data.(metricdata.Gauge[int64])
+		require.True(t, ok)
+		require.Len(t, gauge.DataPoints, 1)
+		peerID, ok := gauge.DataPoints[0].Attributes.Value("peer_id")
+		require.True(t, ok)
+		require.Equal(t, n2.PeerID().String(), peerID.AsString())
+		values[m.Name] = gauge.DataPoints[0].Value
+	}
+	require.Equal(t, int64(1), values["replicator_lag"])
+	require.Contains(t, values, "replicator_connected")
+	require.NotContains(t, values, "replicator_last_pushed_at")
+}
+
+func TestBackoffReplicator_DoublesBackoffUpToMax(t *testing.T) {
+	ctx := context.Background()
+	_, n := newTestNode(ctx, t)
+	defer n.Close()
+
+	pid := peer.ID("replicator")
+	n.Peer.setReplicatorFailure(pid, ErrEmptyNode)
+	require.Equal(t, n.Peer.replicatorMinBackoff, n.Peer.replicatorStatuses[pid].backoff)
+	require.False(t, n.Peer.replicatorRetryDue(pid))
+
+	n.Peer.backoffReplicator(pid, ErrEmptyNode)
+	require.Equal(t, 2*n.Peer.replicatorMinBackoff, n.Peer.replicatorStatuses[pid].backoff)
+
+	for i := 0; i < 20; i++ {
+		n.Peer.backoffReplicator(pid, ErrEmptyNode)
+	}
+	require.Equal(t, n.Peer.replicatorMaxBackoff, n.Peer.replicatorStatuses[pid].backoff)
+	require.Equal(t, ErrEmptyNode.Error(), n.Peer.replicatorStatuses[pid].lastErr)
+
+	n.Peer.resetReplicatorRetry(pid)
+	require.True(t, n.Peer.replicatorRetryDue(pid))
+	require.Equal(t, n.Peer.replicatorMinBackoff, n.Peer.replicatorStatuses[pid].backoff)
+}
+
 func TestAddP2PCollections_WithInvalidCollectionID_NotFoundError(t *testing.T) {
 	ctx := context.Background()
 	_, n := newTestNode(ctx, t)
@@ -779,6 +1129,92 @@ func TestGetAllP2PCollections(t *testing.T) {
 	require.ElementsMatch(t, []string{col.SchemaRoot()}, cols)
 }
 
+func TestSetP2PCollectionFilter_WithNotAddedCollection_NotFoundError(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	defer n.Close()
+
+	_, err := db.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	err = n.Peer.SetP2PCollectionFilter(ctx, col.SchemaRoot(), map[string]any{
+		"age": map[string]any{"_gt": 30},
+	})
+	require.ErrorContains(t, err, "P2P collection "+col.SchemaRoot()+" not found")
+}
+
+func TestLoadP2PCollections_WithFilter_LoadFilter(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	defer n.Close()
+
+	_, err := db.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	err = n.Peer.AddP2PCollections(ctx, []string{col.SchemaRoot()})
+	require.NoError(t, err)
+
+	err = n.Peer.SetP2PCollectionFilter(ctx, col.SchemaRoot(), map[string]any{
+		"age": map[string]any{"_gt": 30},
+	})
+	require.NoError(t, err)
+
+	n.Peer.collectionFilters = make(map[string]*docFilter)
+	err = n.Peer.loadP2PCollectionFilters(ctx)
+	require.NoError(t, err)
+
+	filter := n.Peer.getCollectionFilter(col.SchemaRoot())
+	require.NotNil(t, filter)
+	require.Equal(t, map[string]any{"age": map[string]any{"_gt": float64(30)}}, filter.source)
+
+	err = n.Peer.SetP2PCollectionFilter(ctx, col.SchemaRoot(), nil)
+	require.NoError(t, err)
+	require.Nil(t, n.Peer.getCollectionFilter(col.SchemaRoot()))
+
+	cols, err := n.Peer.GetAllP2PCollections(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{col.SchemaRoot()}, cols)
+}
+
+func TestGetAllReplicator_WithFilter_ReturnFilter(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	defer n.Close()
+
+	_, err := db.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	_, n2 := newTestNode(ctx, t)
+	defer n2.Close()
+
+	err = n.Peer.SetReplicator(ctx, client.Replicator{
+		Info:   n2.PeerInfo(),
+		Filter: map[string]any{"name": map[string]any{"_eq": "John"}},
+	})
+	require.NoError(t, err)
+
+	reps, err := n.Peer.GetAllReplicators(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, reps, 1)
+	require.Equal(t, map[string]any{"name": map[string]any{"_eq": "John"}}, reps[0].Filter)
+}
+
 func TestHandleDocCreateLog_NoError(t *testing.T) {
 	ctx := context.Background()
 	db, n := newTestNode(ctx, t)
@@ -1010,3 +1446,206 @@ func TestSession_NoError(t *testing.T) {
 	ng := n.Session(ctx)
 	require.Implements(t, (*ipld.NodeGetter)(nil), ng)
 }
+
+func TestCatchUp_WithUpdatesWhileOffline_FetchMissingBlocks(t *testing.T) {
+	ctx := context.Background()
+	db1, n1 := newTestNode(ctx, t)
+	defer n1.Close()
+	db2, n2 := newTestNode(ctx, t)
+	defer n2.Close()
+
+	err := n1.Start()
+	require.NoError(t, err)
+	err = n2.Start()
+	require.NoError(t, err)
+
+	col1, doc := addUserDoc(ctx, t, db1)
+	err = doc.Set("age", 31)
+	require.NoError(t, err)
+	err = col1.Update(ctx, doc)
+	require.NoError(t, err)
+
+	_, err = db2.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+	err = n2.AddP2PCollections(ctx, []string{col1.SchemaRoot()})
+	require.NoError(t, err)
+
+	// the updates have been made before the nodes were connected, they are fetched
+	// by catching up with the other node once connected.
+	err = n2.host.Connect(ctx, peer.AddrInfo{ID: n1.PeerID(), Addrs: n1.host.Addrs()})
+	require.NoError(t, err)
+
+	col2, err := db2.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		doc2, err := col2.Get(ctx, doc.Key(), false)
+		if err != nil {
+			return false
+		}
+		age, err := doc2.Get("age")
+		return err == nil && age == int64(31)
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestCatchUpDoc_WithGraphLargerThanOnePage_FetchAllPages(t *testing.T) {
+	ctx := context.Background()
+	db1, n1 := newTestNode(ctx, t)
+	defer n1.Close()
+	db2, n2 := newTestNode(ctx, t)
+	defer n2.Close()
+
+	err := n1.Start()
+	require.NoError(t, err)
+	err = n2.Start()
+	require.NoError(t, err)
+
+	col1, doc := addUserDoc(ctx, t, db1)
+	err = doc.Set("age", 31)
+	require.NoError(t, err)
+	err = col1.Update(ctx, doc)
+	require.NoError(t, err)
+
+	_, err = db2.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	// the graph of the document is split across several pages.
+	n1.server.maxGraphReplySize = 1
+
+	err = n2.host.Connect(ctx, n1.PeerInfo())
+	require.NoError(t, err)
+	err = n2.catchUpDoc(ctx, n1.PeerID(), &net_pb.GetHeadLogReply_Head{
+		DocKey: []byte(doc.Key().String()),
+	})
+	require.NoError(t, err)
+
+	col2, err := db2.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	fetched, err := col2.Get(ctx, doc.Key(), false)
+	require.NoError(t, err)
+	age, err := fetched.Get("age")
+	require.NoError(t, err)
+	require.Equal(t, int64(31), age)
+}
+
+func TestReconcileDocs_WithUpdatesWhileOffline_FetchMissingBlocks(t *testing.T) {
+	ctx := context.Background()
+	db1, n1 := newTestNode(ctx, t)
+	defer n1.Close()
+	db2, n2 := newTestNode(ctx, t)
+	defer n2.Close()
+
+	err := n1.Start()
+	require.NoError(t, err)
+	err = n2.Start()
+	require.NoError(t, err)
+
+	col1, doc := addUserDoc(ctx, t, db1)
+	_, err = db2.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	// both nodes have the document before it is updated.
+	graph, err := n1.server.GetCollectionGraph(ctx, &net_pb.GetCollectionGraphRequest{
+		SchemaRoot: []byte(col1.SchemaRoot()),
+		DocKeys:    [][]byte{[]byte(doc.Key().String())},
+	})
+	require.NoError(t, err)
+	err = n2.server.processDocGraph(
+		ctx,
+		doc.Key(),
+		col1.SchemaRoot(),
+		graph.Graphs[0].Heads,
+		graph.Graphs[0].Blocks,
+	)
+	require.NoError(t, err)
+
+	err = doc.Set("age", 31)
+	require.NoError(t, err)
+	err = col1.Update(ctx, doc)
+	require.NoError(t, err)
+
+	err = n2.host.Connect(ctx, n1.PeerInfo())
+	require.NoError(t, err)
+	err = n2.reconcileDocs(ctx, n1.PeerID(), col1.SchemaRoot(), []string{doc.Key().String()})
+	require.NoError(t, err)
+
+	col2, err := db2.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	doc2, err := col2.Get(ctx, doc.Key(), false)
+	require.NoError(t, err)
+	age, err := doc2.Get("age")
+	require.NoError(t, err)
+	require.Equal(t, int64(31), age)
+}
+
+func TestReconcileDocs_WithGraphsLargerThanOnePage_FetchAllPages(t *testing.T) {
+	ctx := context.Background()
+	db1, n1 := newTestNode(ctx, t)
+	defer n1.Close()
+	db2, n2 := newTestNode(ctx, t)
+	defer n2.Close()
+
+	err := n1.Start()
+	require.NoError(t, err)
+	err = n2.Start()
+	require.NoError(t, err)
+
+	col1, doc := addUserDoc(ctx, t, db1)
+	doc2, err := client.NewDocFromJSON([]byte(`{"name": "Fred", "age": 42}`))
+	require.NoError(t, err)
+	err = col1.Create(ctx, doc2)
+	require.NoError(t, err)
+	err = doc.Set("age", 31)
+	require.NoError(t, err)
+	err = col1.Update(ctx, doc)
+	require.NoError(t, err)
+
+	_, err = db2.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	// the graphs of both documents are split across several pages.
+	n1.server.maxGraphReplySize = 1
+
+	err = n2.host.Connect(ctx, n1.PeerInfo())
+	require.NoError(t, err)
+	err = n2.reconcileDocs(
+		ctx,
+		n1.PeerID(),
+		col1.SchemaRoot(),
+		[]string{doc.Key().String(), doc2.Key().String()},
+	)
+	require.NoError(t, err)
+
+	col2, err := db2.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	fetched, err := col2.Get(ctx, doc.Key(), false)
+	require.NoError(t, err)
+	age, err := fetched.Get("age")
+	require.NoError(t, err)
+	require.Equal(t, int64(31), age)
+	fetched, err = col2.Get(ctx, doc2.Key(), false)
+	require.NoError(t, err)
+	age, err = fetched.Get("age")
+	require.NoError(t, err)
+	require.Equal(t, int64(42), age)
+}
+
+func TestCatchUp_WithNoSubscribedTopics_NoError(t *testing.T) {
+	ctx := context.Background()
+	_, n := newTestNode(ctx, t)
+	defer n.Close()
+
+	err := n.catchUp(ctx, n.PeerID())
+	require.NoError(t, err)
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
+	corecrdt "github.com/sourcenetwork/defradb/core/crdt"
 	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/db/base"
 	"github.com/sourcenetwork/defradb/errors"
@@ -60,7 +61,20 @@ func newBlockProcessor(
 }
 
 // mergeBlock runs trough the list of composite blocks and sends them for processing.
-func (bp *blockProcessor) mergeBlocks(ctx context.Context) {
+//
+// Once the blocks are merged, the indexes of the collection are updated with the resulting
+// state of the document within the same transaction.
+func (bp *blockProcessor) mergeBlocks(ctx context.Context) error {
+	col := bp.col.WithTxn(bp.txn)
+	docKey, err := client.NewDocKeyFromString(bp.dsKey.DocKey)
+	if err != nil {
+		return err
+	}
+	oldDoc, err := getDocForIndexSync(ctx, col, docKey)
+	if err != nil {
+		return err
+	}
+
 	for e := bp.composites.Front(); e != nil; e = e.Next() {
 		nd := e.Value.(ipld.Node)
 		err := bp.processBlock(ctx, nd, "")
@@ -74,6 +88,64 @@ func (bp *blockProcessor) mergeBlocks(ctx context.Context) {
 			)
 		}
 	}
+
+	newDoc, err := getDocForIndexSync(ctx, col, docKey)
+	if err != nil {
+		return err
+	}
+	return syncDocIndex(ctx, col, oldDoc, newDoc)
+}
+
+// getDocForIndexSync returns the current state of the document or nil if
+// the document does not exist or is deleted.
+func getDocForIndexSync(
+	ctx context.Context,
+	col client.Collection,
+	docKey client.DocKey,
+) (*client.Document, error) {
+	doc, err := col.Get(ctx, docKey, false)
+	if errors.Is(err, client.ErrDocumentNotFound) {
+		return nil, nil
+	}
+	return doc, err
+}
+
+// docIndexer is implemented by the collections of the database, whose indexes
+// have to be kept in line with the documents merged from remote peers.
+type docIndexer interface {
+	CreateDocIndex(context.Context, *client.Document) error
+	UpdateDocIndex(ctx context.Context, oldDoc, newDoc *client.Document) error
+	DeleteDocIndex(context.Context, *client.Document) error
+}
+
+// syncDocIndex brings the indexes of the collection in line with the new state
+// of a document that was merged from a remote peer.
+func syncDocIndex(
+	ctx context.Context,
+	col client.Collection,
+	oldDoc *client.Document,
+	newDoc *client.Document,
+) error {
+	indexer, ok := col.(docIndexer)
+	if !ok {
+		return nil
+	}
+	switch {
+	case oldDoc == nil && newDoc == nil:
+		return nil
+	case oldDoc == nil:
+		return indexer.CreateDocIndex(ctx, newDoc)
+	case newDoc == nil:
+		return indexer.DeleteDocIndex(ctx, oldDoc)
+	default:
+		return indexer.UpdateDocIndex(ctx, oldDoc, newDoc)
+	}
+}
+
+// hlcCollection is implemented by the collections of the database, the timestamps of the
+// remote writes to their fields resolved by HLC have to be observed by the clock of the database.
+type hlcCollection interface {
+	HLC() *corecrdt.HLC
 }
 
 // processBlock merges the block and its children to the datastore and sets the head accordingly.
@@ -157,13 +229,23 @@ func initCRDTForType(
 	fieldID := fd.ID.String()
 	key = base.MakeCollectionKey(description).WithInstanceInfo(dsKey).WithFieldId(fieldID)
 
+	var hlc *corecrdt.HLC
+	if clocked, ok := col.(hlcCollection); ok {
+		hlc = clocked.HLC()
+	}
+	if ctype == client.LWW_HLC_REGISTER && hlc == nil {
+		return nil, ErrMissingHLC
+	}
+
 	log.Debug(ctx, "Got CRDT Type", logging.NewKV("CType", ctype), logging.NewKV("Field", field))
-	return merklecrdt.NewMerkleLWWRegister(
+	return merklecrdt.InstanceWithStore(
 		txn,
 		core.NewCollectionSchemaVersionKey(col.Schema().VersionID, col.ID()),
+		ctype,
 		key,
 		field,
-	), nil
+		hlc,
+	)
 }
 
 func decodeBlockBuffer(buf []byte, cid cid.Cid) (ipld.Node, error) {
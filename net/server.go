@@ -14,9 +14,12 @@ package net
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"sync"
 
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
 	format "github.com/ipfs/go-ipld-format"
 	"github.com/libp2p/go-libp2p/core/event"
@@ -29,9 +32,12 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/core"
+	"github.com/sourcenetwork/defradb/core/crdt"
+	"github.com/sourcenetwork/defradb/datastore"
 	"github.com/sourcenetwork/defradb/datastore/badger/v4"
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/logging"
+	"github.com/sourcenetwork/defradb/merkle/clock"
 	pb "github.com/sourcenetwork/defradb/net/pb"
 )
 
@@ -58,9 +64,17 @@ type server struct {
 	// limit unecessary transaction conflicts.
 	docQueue *docQueue
 
+	// maxGraphReplySize is the number of bytes of blocks above which a collection graph
+	// reply is split into several pages.
+	maxGraphReplySize int
+
 	pb.UnimplementedServiceServer
 }
 
+// defaultMaxGraphReplySize keeps the collection graph replies well below the
+// default 4MB message size limit of gRPC.
+const defaultMaxGraphReplySize = 2 << 20
+
 // pubsubTopic is a wrapper of rpc.Topic to be able to track if the topic has
 // been subscribed to.
 type pubsubTopic struct {
@@ -79,6 +93,7 @@ func newServer(p *Peer, db client.DB, opts ...grpc.DialOption) (*server, error)
 		docQueue: &docQueue{
 			docs: make(map[string]chan struct{}),
 		},
+		maxGraphReplySize: defaultMaxGraphReplySize,
 	}
 
 	cred := insecure.NewCredentials()
@@ -141,11 +156,74 @@ func newServer(p *Peer, db client.DB, opts ...grpc.DialOption) (*server, error)
 }
 
 // GetDocGraph receives a get graph request
+//
+// It replies with the composite heads of the document and all the blocks of its graph that
+// can't be reached from the heads that the requester already has.
+//
+// The reply is paged like the one of GetCollectionGraph, if the graph doesn't fit the reply
+// has a cursor that the rest of it is requested with.
 func (s *server) GetDocGraph(
 	ctx context.Context,
 	req *pb.GetDocGraphRequest,
 ) (*pb.GetDocGraphReply, error) {
-	return nil, nil
+	dockey, err := client.NewDocKeyFromString(string(req.DocKey))
+	if err != nil {
+		return nil, err
+	}
+	knownHeads, err := cidsFromBytes(req.Heads)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := decodeGraphCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.doc != 0 {
+		return nil, ErrInvalidGraphCursor
+	}
+
+	txn, err := s.db.NewTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	heads, schemaRoot, err := s.getDocHeads(ctx, txn, dockey)
+	if err != nil {
+		return nil, err
+	}
+	reply := &pb.GetDocGraphReply{
+		SchemaRoot: []byte(schemaRoot),
+	}
+	if len(heads) == 0 {
+		return reply, nil
+	}
+
+	// The requester has all the blocks that can be reached from its heads.
+	known := make(map[cid.Cid]struct{})
+	err = walkDAG(ctx, txn.DAGstore(), knownHeads, known, nil, func(blocks.Block) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor.block > 0 {
+		// the rest of the graph is walked from the heads of its first page
+		heads = cursor.heads
+	} else {
+		for _, head := range heads {
+			reply.Heads = append(reply.Heads, head.Bytes())
+		}
+	}
+	size := 0
+	pbBlocks, next, more, err := s.walkGraphPage(ctx, txn.DAGstore(), heads, known, nil, cursor.block, &size)
+	if err != nil {
+		return nil, err
+	}
+	reply.Blocks = pbBlocks
+	if more {
+		reply.Cursor = graphCursor{block: next, heads: heads}.encode()
+	}
+	return reply, nil
 }
 
 // PushDocGraph receives a push graph request
@@ -153,12 +231,93 @@ func (s *server) PushDocGraph(
 	ctx context.Context,
 	req *pb.PushDocGraphRequest,
 ) (*pb.PushDocGraphReply, error) {
-	return nil, nil
+	pid, err := peerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug(ctx, "Received a PushDocGraph request", logging.NewKV("PeerID", pid))
+
+	dockey, err := client.NewDocKeyFromString(string(req.DocKey))
+	if err != nil {
+		return nil, err
+	}
+	defer s.emitPushLog(ctx, pid, req.Creator)
+
+	err = s.processDocGraph(ctx, dockey, string(req.SchemaRoot), req.Heads, req.Blocks)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PushDocGraphReply{}, nil
 }
 
 // GetLog receives a get log request
+//
+// It replies with the requested blocks of the document that are present locally.
 func (s *server) GetLog(ctx context.Context, req *pb.GetLogRequest) (*pb.GetLogReply, error) {
-	return nil, nil
+	cids, err := cidsFromBytes(req.Cids)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.GetLogReply{}
+	for _, c := range cids {
+		exists, err := s.db.Blockstore().Has(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		block, err := s.db.Blockstore().Get(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		reply.Blocks = append(reply.Blocks, &pb.Block{
+			Cid:  block.Cid().Bytes(),
+			Data: block.RawData(),
+		})
+	}
+	return reply, nil
+}
+
+// processDocGraph merges the given graph of a document, starting from its composite heads.
+func (s *server) processDocGraph(
+	ctx context.Context,
+	dockey client.DocKey,
+	schemaRoot string,
+	heads [][]byte,
+	pbBlocks []*pb.Block,
+) error {
+	blocks, err := blocksFromProto(pbBlocks)
+	if err != nil {
+		return err
+	}
+	headCids, err := cidsFromBytes(heads)
+	if err != nil {
+		return err
+	}
+
+	s.docQueue.add(dockey.String())
+	defer s.docQueue.done(dockey.String())
+
+	for _, head := range headCids {
+		block, ok := blocks[head]
+		if !ok {
+			// The peers only send the blocks that the requester doesn't have.
+			exists, err := s.db.Blockstore().Has(ctx, head)
+			if err != nil {
+				return err
+			}
+			if exists {
+				continue
+			}
+			return NewErrMissingHeadBlock(head.String(), errors.NewKV("DocKey", dockey))
+		}
+		if err := s.processLog(ctx, dockey, schemaRoot, head, block, blocks, true); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 type docQueue struct {
@@ -213,40 +372,75 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 	s.docQueue.add(dockey.String())
 	defer func() {
 		s.docQueue.done(dockey.String())
-		if s.pushLogEmitter != nil {
-			byPeer, err := libpeer.Decode(req.Body.Creator)
-			if err != nil {
-				log.Info(ctx, "could not decode the PeerID of the log creator", logging.NewKV("Error", err.Error()))
-			}
-			err = s.pushLogEmitter.Emit(EvtReceivedPushLog{
-				FromPeer: pid,
-				ByPeer:   byPeer,
-			})
-			if err != nil {
-				// logging instead of returning an error because the event bus should
-				// not break the PushLog execution.
-				log.Info(ctx, "could not emit push log event", logging.NewKV("Error", err.Error()))
-			}
-		}
+		s.emitPushLog(ctx, pid, req.Body.Creator)
 	}()
 
+	err = s.processLog(
+		ctx,
+		dockey,
+		string(req.Body.SchemaRoot),
+		cid,
+		req.Body.Log.Block,
+		nil,
+		!req.Body.Filtered,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PushLogReply{}, nil
+}
+
+// emitPushLog emits the event of a log created by the given creator having been received from
+// the given peer.
+func (s *server) emitPushLog(ctx context.Context, pid libpeer.ID, creator string) {
+	if s.pushLogEmitter == nil {
+		return
+	}
+	byPeer, err := libpeer.Decode(creator)
+	if err != nil {
+		log.Info(ctx, "could not decode the PeerID of the log creator", logging.NewKV("Error", err.Error()))
+	}
+	err = s.pushLogEmitter.Emit(EvtReceivedPushLog{
+		FromPeer: pid,
+		ByPeer:   byPeer,
+	})
+	if err != nil {
+		// logging instead of returning an error because the event bus should
+		// not break the PushLog execution.
+		log.Info(ctx, "could not emit push log event", logging.NewKV("Error", err.Error()))
+	}
+}
+
+// processLog merges the given composite block of a document and the blocks it links to.
+//
+// The linked blocks are taken from the given blocks if present, otherwise they are fetched
+// from the network. If subscribe is true, the topic of the document is subscribed to once
+// the block is merged.
+func (s *server) processLog(
+	ctx context.Context,
+	dockey client.DocKey,
+	schemaRoot string,
+	cid cid.Cid,
+	block []byte,
+	blocks map[cid.Cid][]byte,
+	subscribe bool,
+) error {
 	// make sure were not processing twice
 	if canVisit := s.peer.queuedChildren.Visit(cid); !canVisit {
-		return &pb.PushLogReply{}, nil
+		return nil
 	}
 	defer s.peer.queuedChildren.Remove(cid)
 
 	// check if we already have this block
 	exists, err := s.db.Blockstore().Has(ctx, cid)
 	if err != nil {
-		return nil, errors.Wrap(fmt.Sprintf("failed to check for existing block %s", cid), err)
+		return errors.Wrap(fmt.Sprintf("failed to check for existing block %s", cid), err)
 	}
 	if exists {
 		log.Debug(ctx, fmt.Sprintf("Already have block %s locally, skipping.", cid))
-		return &pb.PushLogReply{}, nil
+		return nil
 	}
 
-	schemaRoot := string(req.Body.SchemaRoot)
 	dsKey := core.DataStoreKeyFromDocKey(dockey)
 
 	var txnErr error
@@ -255,7 +449,7 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 		// each process on a single transaction.
 		txn, err := s.db.NewConcurrentTxn(ctx, false)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		defer txn.Discard(ctx)
 		store := s.db.WithTxn(txn)
@@ -264,10 +458,10 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 		// this will change with https://github.com/sourcenetwork/defradb/issues/1085
 		cols, err := store.GetCollectionsBySchemaRoot(ctx, schemaRoot)
 		if err != nil {
-			return nil, errors.Wrap(fmt.Sprintf("Failed to get collection from schemaRoot %s", schemaRoot), err)
+			return errors.Wrap(fmt.Sprintf("Failed to get collection from schemaRoot %s", schemaRoot), err)
 		}
 		if len(cols) == 0 {
-			return nil, client.NewErrCollectionNotFoundForSchema(schemaRoot)
+			return client.NewErrCollectionNotFoundForSchema(schemaRoot)
 		}
 		col := cols[0]
 
@@ -277,11 +471,23 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 			log.Debug(ctx, "Upgrading DAGSyncer with a session")
 			getter = sessionMaker.Session(ctx)
 		}
+		if len(blocks) > 0 {
+			getter = &graphNodeGetter{blocks: blocks, fallback: getter}
+		}
+
+		// A document that matched the filter of its P2P collection before the update is held
+		// locally, the update is merged even if it takes the document out of the filter so
+		// that the local copy isn't left stale.
+		filter := s.peer.getCollectionFilter(col.SchemaRoot())
+		matchedBefore, err := filter.matches(ctx, col.WithTxn(txn), dockey)
+		if err != nil && !errors.Is(err, client.ErrDocumentNotFound) {
+			return err
+		}
 
 		// handleComposite
-		nd, err := decodeBlockBuffer(req.Body.Log.Block, cid)
+		nd, err := decodeBlockBuffer(block, cid)
 		if err != nil {
-			return nil, errors.Wrap("failed to decode block to ipld.Node", err)
+			return errors.Wrap("failed to decode block to ipld.Node", err)
 		}
 
 		var session sync.WaitGroup
@@ -297,7 +503,7 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 			)
 		}
 		session.Wait()
-		bp.mergeBlocks(ctx)
+		err = bp.mergeBlocks(ctx)
 
 		// dagWorkers specific to the dockey will have been spawned within handleChildBlocks.
 		// Once we are done with the dag syncing process, we can get rid of those workers.
@@ -305,33 +511,366 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 			s.peer.closeJob <- dsKey.DocKey
 		}
 
+		if err != nil {
+			return err
+		}
+
+		// Documents that don't match the filter of their P2P collection are not merged locally,
+		// unless they are held locally and the update is the one taking them out of the filter.
+		matches, err := filter.matches(ctx, col.WithTxn(txn), dockey)
+		if err != nil {
+			return err
+		}
+		if !matches && !matchedBefore {
+			log.Debug(
+				ctx,
+				"Document doesn't match the filter of its collection, skipping",
+				logging.NewKV("DocKey", dockey),
+				logging.NewKV("CID", cid),
+			)
+			return nil
+		}
+
 		if txnErr = txn.Commit(ctx); txnErr != nil {
 			if errors.Is(txnErr, badger.ErrTxnConflict) {
 				continue
 			}
-			return &pb.PushLogReply{}, txnErr
+			return txnErr
 		}
 
 		// Once processed, subscribe to the dockey topic on the pubsub network unless we already
 		// suscribe to the collection.
-		if !s.hasPubSubTopic(col.SchemaRoot()) {
+		if subscribe && !s.hasPubSubTopic(col.SchemaRoot()) {
 			err = s.addPubSubTopic(dsKey.DocKey, true)
 			if err != nil {
-				return nil, err
+				return err
 			}
 		}
-		return &pb.PushLogReply{}, nil
+		return nil
 	}
 
-	return &pb.PushLogReply{}, client.NewErrMaxTxnRetries(txnErr)
+	return client.NewErrMaxTxnRetries(txnErr)
 }
 
 // GetHeadLog receives a get head log request
+//
+// It replies with the composite heads of the requested documents, and of all the documents
+// of the requested collections.
 func (s *server) GetHeadLog(
 	ctx context.Context,
 	req *pb.GetHeadLogRequest,
 ) (*pb.GetHeadLogReply, error) {
-	return nil, nil
+	txn, err := s.db.NewTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	dockeys := make([]client.DocKey, 0, len(req.DocKeys))
+	for _, key := range req.DocKeys {
+		dockey, err := client.NewDocKeyFromString(string(key))
+		if err != nil {
+			return nil, err
+		}
+		dockeys = append(dockeys, dockey)
+	}
+
+	store := s.db.WithTxn(txn)
+	for _, schemaRoot := range req.SchemaRoots {
+		cols, err := store.GetCollectionsBySchemaRoot(ctx, string(schemaRoot))
+		if err != nil {
+			return nil, err
+		}
+		if len(cols) == 0 {
+			continue
+		}
+		keyChan, err := cols[0].GetAllDocKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for key := range keyChan {
+			if key.Err != nil {
+				return nil, key.Err
+			}
+			dockeys = append(dockeys, key.Key)
+		}
+	}
+
+	reply := &pb.GetHeadLogReply{}
+	seen := make(map[string]struct{})
+	for _, dockey := range dockeys {
+		if _, ok := seen[dockey.String()]; ok {
+			continue
+		}
+		seen[dockey.String()] = struct{}{}
+
+		heads, schemaRoot, err := s.getDocHeads(ctx, txn, dockey)
+		if err != nil {
+			return nil, err
+		}
+		if len(heads) == 0 {
+			continue
+		}
+		head := &pb.GetHeadLogReply_Head{
+			DocKey:     []byte(dockey.String()),
+			SchemaRoot: []byte(schemaRoot),
+		}
+		for _, c := range heads {
+			head.Cids = append(head.Cids, c.Bytes())
+		}
+		reply.Heads = append(reply.Heads, head)
+	}
+	return reply, nil
+}
+
+// GetCollectionGraph receives a get collection graph request
+//
+// It replies with the composite heads of the requested documents of the collection, and
+// the blocks of their graphs that are not in the filter of the requester. The blocks of the
+// heads are always sent, the filter only prunes the blocks that can be reached from them.
+//
+// The reply holds at most maxGraphReplySize bytes of blocks, unless a single block is larger.
+// If the graphs don't fit, the reply has a cursor that the rest of them are requested with.
+func (s *server) GetCollectionGraph(
+	ctx context.Context,
+	req *pb.GetCollectionGraphRequest,
+) (*pb.GetCollectionGraphReply, error) {
+	filter, err := decodeCIDFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := decodeGraphCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	txn, err := s.db.NewTxn(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard(ctx)
+
+	reply := &pb.GetCollectionGraphReply{}
+	size := 0
+	for i := cursor.doc; i < len(req.DocKeys) && reply.Cursor == nil; i++ {
+		dockey, err := client.NewDocKeyFromString(string(req.DocKeys[i]))
+		if err != nil {
+			return nil, err
+		}
+		heads, schemaRoot, err := s.getDocHeads(ctx, txn, dockey)
+		if err != nil {
+			return nil, err
+		}
+		if len(heads) == 0 || schemaRoot != string(req.SchemaRoot) {
+			continue
+		}
+
+		offset := 0
+		if i == cursor.doc && cursor.block > 0 {
+			// the rest of the graph is walked from the heads of its first page
+			heads = cursor.heads
+			offset = cursor.block
+		}
+		graph := &pb.GetCollectionGraphReply_Graph{
+			DocKey: []byte(dockey.String()),
+		}
+		isHead := make(map[cid.Cid]struct{}, len(heads))
+		for _, head := range heads {
+			// the heads are only sent along with the first page of the graph
+			if offset == 0 {
+				graph.Heads = append(graph.Heads, head.Bytes())
+			}
+			isHead[head] = struct{}{}
+		}
+		skip := func(c cid.Cid) bool {
+			_, ok := isHead[c]
+			return !ok && filter.has(c)
+		}
+		visited := make(map[cid.Cid]struct{})
+		pbBlocks, next, more, err := s.walkGraphPage(ctx, txn.DAGstore(), heads, visited, skip, offset, &size)
+		if err != nil {
+			return nil, err
+		}
+		if more && next == 0 {
+			// none of the graph fits, it is sent from its first page onwards
+			reply.Cursor = graphCursor{doc: i}.encode()
+			break
+		}
+		if more {
+			reply.Cursor = graphCursor{doc: i, block: next, heads: heads}.encode()
+		}
+		graph.Blocks = pbBlocks
+		if len(graph.Heads) > 0 || len(graph.Blocks) > 0 {
+			reply.Graphs = append(reply.Graphs, graph)
+		}
+	}
+	return reply, nil
+}
+
+// walkGraphPage returns the blocks of the graph of the given heads that fit in the reply,
+// skipping the first offset blocks of the walk that have been sent in previous pages.
+//
+// The given size is the size of the reply so far and is increased by the size of the blocks.
+// If not all of the blocks fit, the position in the walk of the first block that didn't fit
+// is returned along with more set to true.
+func (s *server) walkGraphPage(
+	ctx context.Context,
+	store datastore.DAGStore,
+	heads []cid.Cid,
+	visited map[cid.Cid]struct{},
+	skip func(cid.Cid) bool,
+	offset int,
+	size *int,
+) ([]*pb.Block, int, bool, error) {
+	var pbBlocks []*pb.Block
+	index := 0
+	more := false
+	err := walkDAG(ctx, store, heads, visited, skip, func(block blocks.Block) bool {
+		if index < offset {
+			// the block has been sent in a previous page
+			index++
+			return true
+		}
+		pbBlock := &pb.Block{
+			Cid:  block.Cid().Bytes(),
+			Data: block.RawData(),
+		}
+		blockSize := pbBlock.SizeVT()
+		if *size > 0 && *size+blockSize > s.maxGraphReplySize {
+			more = true
+			return false
+		}
+		*size += blockSize
+		pbBlocks = append(pbBlocks, pbBlock)
+		index++
+		return true
+	})
+	if err != nil {
+		return nil, 0, false, err
+	}
+	return pbBlocks, index, more, nil
+}
+
+// graphCursor is the position in the requested graphs that a paged reply stopped at.
+//
+// The graph is walked again from the same heads for each page, so that the blocks
+// of the following pages don't depend on the updates made in between pages.
+type graphCursor struct {
+	// doc is the index of the requested document.
+	doc int
+	// block is the position in the walk of the graph of the document.
+	block int
+	// heads are the heads that the graph of the document is walked from.
+	heads []cid.Cid
+}
+
+func (c graphCursor) encode() []byte {
+	buf := make([]byte, 0, 3*binary.MaxVarintLen64)
+	buf = binary.AppendUvarint(buf, uint64(c.doc))
+	buf = binary.AppendUvarint(buf, uint64(c.block))
+	buf = binary.AppendUvarint(buf, uint64(len(c.heads)))
+	for _, head := range c.heads {
+		b := head.Bytes()
+		buf = binary.AppendUvarint(buf, uint64(len(b)))
+		buf = append(buf, b...)
+	}
+	return buf
+}
+
+// decodeGraphCursor decodes the given cursor of a graph reply, it is the start of the
+// graphs if there is no cursor.
+func decodeGraphCursor(data []byte) (graphCursor, error) {
+	var cursor graphCursor
+	if len(data) == 0 {
+		return cursor, nil
+	}
+	next := func() (int, error) {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return 0, ErrInvalidGraphCursor
+		}
+		data = data[n:]
+		return int(v), nil
+	}
+	doc, err := next()
+	if err != nil {
+		return cursor, err
+	}
+	block, err := next()
+	if err != nil {
+		return cursor, err
+	}
+	count, err := next()
+	if err != nil {
+		return cursor, err
+	}
+	if count > len(data) {
+		return cursor, ErrInvalidGraphCursor
+	}
+	heads := make([]cid.Cid, 0, count)
+	for j := 0; j < count; j++ {
+		length, err := next()
+		if err != nil {
+			return cursor, err
+		}
+		if length > len(data) {
+			return cursor, ErrInvalidGraphCursor
+		}
+		head, err := cid.Cast(data[:length])
+		if err != nil {
+			return cursor, ErrInvalidGraphCursor
+		}
+		data = data[length:]
+		heads = append(heads, head)
+	}
+	if len(data) != 0 || (block > 0 && len(heads) == 0) {
+		return cursor, ErrInvalidGraphCursor
+	}
+	cursor.doc = doc
+	cursor.block = block
+	cursor.heads = heads
+	return cursor, nil
+}
+
+// getDocHeads returns the composite heads of the given document along with the SchemaRoot
+// of the collection that it resides in.
+//
+// No heads are returned if the document doesn't exist locally.
+func (s *server) getDocHeads(
+	ctx context.Context,
+	txn datastore.Txn,
+	dockey client.DocKey,
+) ([]cid.Cid, string, error) {
+	headset := clock.NewHeadSet(
+		txn.Headstore(),
+		core.DataStoreKeyFromDocKey(dockey).WithFieldId(core.COMPOSITE_NAMESPACE).ToHeadStoreKey(),
+	)
+	heads, _, err := headset.List(ctx)
+	if err != nil || len(heads) == 0 {
+		return nil, "", err
+	}
+
+	// The composite blocks hold the schema version that they were created at.
+	block, err := txn.DAGstore().Get(ctx, heads[0])
+	if err != nil {
+		return nil, "", err
+	}
+	nd, err := dag.DecodeProtobufBlock(block)
+	if err != nil {
+		return nil, "", err
+	}
+	delta, err := crdt.CompositeDAG{}.DeltaDecode(nd)
+	if err != nil {
+		return nil, "", err
+	}
+	schema, err := s.db.WithTxn(txn).GetSchemaByVersionID(
+		ctx,
+		delta.(*crdt.CompositeDAGDelta).SchemaVersionID,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	return heads, schema.Root, nil
 }
 
 // addPubSubTopic subscribes to a topic on the pubsub network
@@ -368,6 +907,19 @@ func (s *server) addPubSubTopic(topic string, subscribe bool) error {
 	return nil
 }
 
+// subscribedTopics returns the topics that we are subscribed to.
+func (s *server) subscribedTopics() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	topics := make([]string, 0, len(s.topics))
+	for topic, t := range s.topics {
+		if t.subscribed {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
+
 // hasPubSubTopic checks if we are subscribed to a topic.
 func (s *server) hasPubSubTopic(topic string) bool {
 	s.mu.Lock()
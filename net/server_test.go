@@ -189,36 +189,437 @@ func TestNewServerWithEmitterError(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func addUserDoc(ctx context.Context, t *testing.T, db client.DB) (client.Collection, *client.Document) {
+	_, err := db.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	col, err := db.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "John", "age": 30}`))
+	require.NoError(t, err)
+
+	err = col.Create(ctx, doc)
+	require.NoError(t, err)
+
+	return col, doc
+}
+
 func TestGetDocGraph(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	col, doc := addUserDoc(ctx, t, db)
+
+	r, err := n.server.GetDocGraph(ctx, &net_pb.GetDocGraphRequest{
+		DocKey: []byte(doc.Key().String()),
+	})
+	require.NoError(t, err)
+	require.Equal(t, col.SchemaRoot(), string(r.SchemaRoot))
+	require.Len(t, r.Heads, 1)
+	// the composite block and the blocks of the two fields
+	require.Len(t, r.Blocks, 3)
+}
+
+func TestGetDocGraph_WithKnownHeads_ReturnOnlyMissingBlocks(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	col, doc := addUserDoc(ctx, t, db)
+
+	headLog, err := n.server.GetHeadLog(ctx, &net_pb.GetHeadLogRequest{
+		DocKeys: [][]byte{[]byte(doc.Key().String())},
+	})
+	require.NoError(t, err)
+	require.Len(t, headLog.Heads, 1)
+
+	err = doc.Set("age", 31)
+	require.NoError(t, err)
+	err = col.Update(ctx, doc)
+	require.NoError(t, err)
+
+	r, err := n.server.GetDocGraph(ctx, &net_pb.GetDocGraphRequest{
+		DocKey: []byte(doc.Key().String()),
+		Heads:  headLog.Heads[0].Cids,
+	})
+	require.NoError(t, err)
+	require.Len(t, r.Heads, 1)
+	// the composite block and the block of the updated field
+	require.Len(t, r.Blocks, 2)
+}
+
+func TestGetDocGraph_WithUnknownDoc_ReturnEmptyGraph(t *testing.T) {
 	ctx := context.Background()
 	_, n := newTestNode(ctx, t)
-	r, err := n.server.GetDocGraph(ctx, &net_pb.GetDocGraphRequest{})
-	require.Nil(t, r)
-	require.Nil(t, err)
+
+	r, err := n.server.GetDocGraph(ctx, &net_pb.GetDocGraphRequest{
+		DocKey: []byte("bae-8a6e4ec4-a4c1-5d3a-8e4a-2cbe4b5ea2c8"),
+	})
+	require.NoError(t, err)
+	require.Empty(t, r.Heads)
+	require.Empty(t, r.Blocks)
+}
+
+func TestGetDocGraph_WithUpdateBetweenPages_ReturnGraphOfFirstPage(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	col, doc := addUserDoc(ctx, t, db)
+	for age := 31; age < 35; age++ {
+		err := doc.Set("age", age)
+		require.NoError(t, err)
+		err = col.Update(ctx, doc)
+		require.NoError(t, err)
+	}
+
+	req := &net_pb.GetDocGraphRequest{
+		DocKey: []byte(doc.Key().String()),
+	}
+	whole, err := n.server.GetDocGraph(ctx, req)
+	require.NoError(t, err)
+	require.Empty(t, whole.Cursor)
+
+	// each page only holds a single block.
+	n.server.maxGraphReplySize = 1
+
+	first, err := n.server.GetDocGraph(ctx, req)
+	require.NoError(t, err)
+	require.NotEmpty(t, first.Cursor)
+	require.Len(t, first.Blocks, 1)
+	heads := first.Heads
+	blocks := first.Blocks
+
+	// the heads of the document change in between pages.
+	err = doc.Set("age", 40)
+	require.NoError(t, err)
+	err = col.Update(ctx, doc)
+	require.NoError(t, err)
+
+	req.Cursor = first.Cursor
+	for len(req.Cursor) > 0 {
+		r, err := n.server.GetDocGraph(ctx, req)
+		require.NoError(t, err)
+		require.Len(t, r.Blocks, 1)
+		heads = append(heads, r.Heads...)
+		blocks = append(blocks, r.Blocks...)
+		req.Cursor = r.Cursor
+	}
+	require.Equal(t, whole.Heads, heads)
+	require.Equal(t, whole.Blocks, blocks)
+}
+
+func TestGetDocGraph_WithInvalidCursor_Error(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	_, doc := addUserDoc(ctx, t, db)
+
+	_, err := n.server.GetDocGraph(ctx, &net_pb.GetDocGraphRequest{
+		DocKey: []byte(doc.Key().String()),
+		Cursor: []byte{0xff},
+	})
+	require.ErrorIs(t, err, ErrInvalidGraphCursor)
 }
 
 func TestPushDocGraph(t *testing.T) {
 	ctx := context.Background()
-	_, n := newTestNode(ctx, t)
-	r, err := n.server.PushDocGraph(ctx, &net_pb.PushDocGraphRequest{})
-	require.Nil(t, r)
-	require.Nil(t, err)
+	db1, n1 := newTestNode(ctx, t)
+	db2, n2 := newTestNode(ctx, t)
+	err := n2.Start()
+	require.NoError(t, err)
+
+	col1, doc := addUserDoc(ctx, t, db1)
+	_, err = db2.AddSchema(ctx, `type User {
+		name: String
+		age: Int
+	}`)
+	require.NoError(t, err)
+
+	graph, err := n1.server.GetDocGraph(ctx, &net_pb.GetDocGraphRequest{
+		DocKey: []byte(doc.Key().String()),
+	})
+	require.NoError(t, err)
+
+	ctx = grpcpeer.NewContext(ctx, &grpcpeer.Peer{
+		Addr: addr{n1.PeerID()},
+	})
+	_, err = n2.server.PushDocGraph(ctx, &net_pb.PushDocGraphRequest{
+		DocKey:     []byte(doc.Key().String()),
+		SchemaRoot: []byte(col1.SchemaRoot()),
+		Creator:    n1.PeerID().String(),
+		Heads:      graph.Heads,
+		Blocks:     graph.Blocks,
+	})
+	require.NoError(t, err)
+
+	col2, err := db2.GetCollectionByName(ctx, "User")
+	require.NoError(t, err)
+	doc2, err := col2.Get(ctx, doc.Key(), false)
+	require.NoError(t, err)
+	name, err := doc2.Get("name")
+	require.NoError(t, err)
+	require.Equal(t, "John", name)
+}
+
+func TestPushDocGraph_WithAlteredBlock_Error(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	col, doc := addUserDoc(ctx, t, db)
+
+	graph, err := n.server.GetDocGraph(ctx, &net_pb.GetDocGraphRequest{
+		DocKey: []byte(doc.Key().String()),
+	})
+	require.NoError(t, err)
+	graph.Blocks[0].Data = append(graph.Blocks[0].Data, 0)
+
+	ctx = grpcpeer.NewContext(ctx, &grpcpeer.Peer{
+		Addr: addr{n.PeerID()},
+	})
+	_, err = n.server.PushDocGraph(ctx, &net_pb.PushDocGraphRequest{
+		DocKey:     []byte(doc.Key().String()),
+		SchemaRoot: []byte(col.SchemaRoot()),
+		Creator:    n.PeerID().String(),
+		Heads:      graph.Heads,
+		Blocks:     graph.Blocks,
+	})
+	require.ErrorContains(t, err, "block data doesn't match its CID")
 }
 
 func TestGetLog(t *testing.T) {
 	ctx := context.Background()
-	_, n := newTestNode(ctx, t)
-	r, err := n.server.GetLog(ctx, &net_pb.GetLogRequest{})
-	require.Nil(t, r)
-	require.Nil(t, err)
+	db, n := newTestNode(ctx, t)
+	_, doc := addUserDoc(ctx, t, db)
+
+	headLog, err := n.server.GetHeadLog(ctx, &net_pb.GetHeadLogRequest{
+		DocKeys: [][]byte{[]byte(doc.Key().String())},
+	})
+	require.NoError(t, err)
+	unknownCID, err := createCID(doc)
+	require.NoError(t, err)
+
+	r, err := n.server.GetLog(ctx, &net_pb.GetLogRequest{
+		DocKey: []byte(doc.Key().String()),
+		Cids:   [][]byte{headLog.Heads[0].Cids[0], unknownCID.Bytes()},
+	})
+	require.NoError(t, err)
+	require.Len(t, r.Blocks, 1)
+	require.Equal(t, headLog.Heads[0].Cids[0], r.Blocks[0].Cid)
 }
 
 func TestGetHeadLog(t *testing.T) {
 	ctx := context.Background()
-	_, n := newTestNode(ctx, t)
-	r, err := n.server.GetHeadLog(ctx, &net_pb.GetHeadLogRequest{})
-	require.Nil(t, r)
-	require.Nil(t, err)
+	db, n := newTestNode(ctx, t)
+	col, doc := addUserDoc(ctx, t, db)
+
+	r, err := n.server.GetHeadLog(ctx, &net_pb.GetHeadLogRequest{
+		DocKeys: [][]byte{[]byte(doc.Key().String())},
+	})
+	require.NoError(t, err)
+	require.Len(t, r.Heads, 1)
+	require.Equal(t, doc.Key().String(), string(r.Heads[0].DocKey))
+	require.Equal(t, col.SchemaRoot(), string(r.Heads[0].SchemaRoot))
+	require.Len(t, r.Heads[0].Cids, 1)
+}
+
+func TestGetHeadLog_WithSchemaRoot_ReturnHeadsOfAllDocs(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	col, _ := addUserDoc(ctx, t, db)
+
+	doc, err := client.NewDocFromJSON([]byte(`{"name": "Fred", "age": 42}`))
+	require.NoError(t, err)
+	err = col.Create(ctx, doc)
+	require.NoError(t, err)
+
+	r, err := n.server.GetHeadLog(ctx, &net_pb.GetHeadLogRequest{
+		DocKeys:     [][]byte{[]byte(doc.Key().String())},
+		SchemaRoots: [][]byte{[]byte(col.SchemaRoot())},
+	})
+	require.NoError(t, err)
+	require.Len(t, r.Heads, 2)
+}
+
+func TestGetCollectionGraph(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	col, doc := addUserDoc(ctx, t, db)
+
+	r, err := n.server.GetCollectionGraph(ctx, &net_pb.GetCollectionGraphRequest{
+		SchemaRoot: []byte(col.SchemaRoot()),
+		DocKeys:    [][]byte{[]byte(doc.Key().String())},
+	})
+	require.NoError(t, err)
+	require.Len(t, r.Graphs, 1)
+	require.Equal(t, doc.Key().String(), string(r.Graphs[0].DocKey))
+	require.Len(t, r.Graphs[0].Heads, 1)
+	// the composite block and the blocks of the two fields
+	require.Len(t, r.Graphs[0].Blocks, 3)
+}
+
+func TestGetCollectionGraph_WithFilter_ReturnOnlyMissingBlocks(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	col, doc := addUserDoc(ctx, t, db)
+
+	filter, err := n.localBlocksFilter(ctx, []string{doc.Key().String()})
+	require.NoError(t, err)
+
+	err = doc.Set("age", 31)
+	require.NoError(t, err)
+	err = col.Update(ctx, doc)
+	require.NoError(t, err)
+
+	r, err := n.server.GetCollectionGraph(ctx, &net_pb.GetCollectionGraphRequest{
+		SchemaRoot: []byte(col.SchemaRoot()),
+		DocKeys:    [][]byte{[]byte(doc.Key().String())},
+		Filter:     filter.encode(),
+	})
+	require.NoError(t, err)
+	require.Len(t, r.Graphs, 1)
+	// the composite block and the block of the updated field
+	require.Len(t, r.Graphs[0].Blocks, 2)
+}
+
+func TestGetCollectionGraph_WithUnknownDoc_ReturnNoGraph(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	col, _ := addUserDoc(ctx, t, db)
+
+	r, err := n.server.GetCollectionGraph(ctx, &net_pb.GetCollectionGraphRequest{
+		SchemaRoot: []byte(col.SchemaRoot()),
+		DocKeys:    [][]byte{[]byte("bae-8a6e4ec4-a4c1-5d3a-8e4a-2cbe4b5ea2c8")},
+	})
+	require.NoError(t, err)
+	require.Empty(t, r.Graphs)
+}
+
+func TestGetCollectionGraph_WithOtherSchemaRoot_ReturnNoGraph(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	_, doc := addUserDoc(ctx, t, db)
+
+	r, err := n.server.GetCollectionGraph(ctx, &net_pb.GetCollectionGraphRequest{
+		SchemaRoot: []byte("other"),
+		DocKeys:    [][]byte{[]byte(doc.Key().String())},
+	})
+	require.NoError(t, err)
+	require.Empty(t, r.Graphs)
+}
+
+func TestGetCollectionGraph_WithInvalidFilter_Error(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	col, doc := addUserDoc(ctx, t, db)
+
+	_, err := n.server.GetCollectionGraph(ctx, &net_pb.GetCollectionGraphRequest{
+		SchemaRoot: []byte(col.SchemaRoot()),
+		DocKeys:    [][]byte{[]byte(doc.Key().String())},
+		Filter:     []byte(`{"FilterSet": "AAAA", "SetLocs": 7}`),
+	})
+	require.ErrorIs(t, err, ErrInvalidCIDFilterSize)
+}
+
+func TestGetCollectionGraph_WithReplyLargerThanOnePage_ReturnCursor(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	col, doc := addUserDoc(ctx, t, db)
+	for age := 31; age < 35; age++ {
+		err := doc.Set("age", age)
+		require.NoError(t, err)
+		err = col.Update(ctx, doc)
+		require.NoError(t, err)
+	}
+
+	req := &net_pb.GetCollectionGraphRequest{
+		SchemaRoot: []byte(col.SchemaRoot()),
+		DocKeys:    [][]byte{[]byte(doc.Key().String())},
+	}
+	whole, err := n.server.GetCollectionGraph(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, whole.Graphs, 1)
+	require.Empty(t, whole.Cursor)
+
+	// each page only holds a single block.
+	n.server.maxGraphReplySize = 1
+
+	var heads [][]byte
+	var blocks []*net_pb.Block
+	pages := 0
+	for {
+		r, err := n.server.GetCollectionGraph(ctx, req)
+		require.NoError(t, err)
+		require.Len(t, r.Graphs, 1)
+		require.Len(t, r.Graphs[0].Blocks, 1)
+		heads = append(heads, r.Graphs[0].Heads...)
+		blocks = append(blocks, r.Graphs[0].Blocks...)
+		pages++
+		if len(r.Cursor) == 0 {
+			break
+		}
+		req.Cursor = r.Cursor
+	}
+	require.Equal(t, len(whole.Graphs[0].Blocks), pages)
+	require.Equal(t, whole.Graphs[0].Heads, heads)
+	require.Equal(t, whole.Graphs[0].Blocks, blocks)
+}
+
+func TestGetCollectionGraph_WithUpdateBetweenPages_ReturnGraphOfFirstPage(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	col, doc := addUserDoc(ctx, t, db)
+	for age := 31; age < 35; age++ {
+		err := doc.Set("age", age)
+		require.NoError(t, err)
+		err = col.Update(ctx, doc)
+		require.NoError(t, err)
+	}
+
+	req := &net_pb.GetCollectionGraphRequest{
+		SchemaRoot: []byte(col.SchemaRoot()),
+		DocKeys:    [][]byte{[]byte(doc.Key().String())},
+	}
+	whole, err := n.server.GetCollectionGraph(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, whole.Graphs, 1)
+
+	// each page only holds a single block.
+	n.server.maxGraphReplySize = 1
+
+	first, err := n.server.GetCollectionGraph(ctx, req)
+	require.NoError(t, err)
+	require.NotEmpty(t, first.Cursor)
+	heads := first.Graphs[0].Heads
+	blocks := first.Graphs[0].Blocks
+
+	// the heads of the document change in between pages.
+	err = doc.Set("age", 40)
+	require.NoError(t, err)
+	err = col.Update(ctx, doc)
+	require.NoError(t, err)
+
+	req.Cursor = first.Cursor
+	for len(req.Cursor) > 0 {
+		r, err := n.server.GetCollectionGraph(ctx, req)
+		require.NoError(t, err)
+		require.Len(t, r.Graphs, 1)
+		heads = append(heads, r.Graphs[0].Heads...)
+		blocks = append(blocks, r.Graphs[0].Blocks...)
+		req.Cursor = r.Cursor
+	}
+	require.Equal(t, whole.Graphs[0].Heads, heads)
+	require.Equal(t, whole.Graphs[0].Blocks, blocks)
+}
+
+func TestGetCollectionGraph_WithInvalidCursor_Error(t *testing.T) {
+	ctx := context.Background()
+	db, n := newTestNode(ctx, t)
+	col, doc := addUserDoc(ctx, t, db)
+
+	_, err := n.server.GetCollectionGraph(ctx, &net_pb.GetCollectionGraphRequest{
+		SchemaRoot: []byte(col.SchemaRoot()),
+		DocKeys:    [][]byte{[]byte(doc.Key().String())},
+		Cursor:     []byte{0xff},
+	})
+	require.ErrorIs(t, err, ErrInvalidGraphCursor)
 }
 
 func TestDocQueue(t *testing.T) {
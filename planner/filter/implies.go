@@ -0,0 +1,140 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+package filter
+
+import (
+	"reflect"
+
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/connor"
+	"github.com/sourcenetwork/defradb/connor/numbers"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+// Implies returns true if every document matching the given filter also matches the given conditions.
+//
+// The check doesn't evaluate the operators, it only looks for the same conditions in the filter:
+// every top level condition (or condition nested in top level _and) must be present at the top level
+// of the filter (or nested in its top level _and). A condition on a field is also implied by
+// a condition on the same field that has additional operators, e.g. {age: {_gt: 20, _lt: 30}}
+// implies {age: {_gt: 20}}.
+// So it may return false for a filter that does imply the conditions, but never the other way round.
+func Implies(filter *mapper.Filter, conditions map[connor.FilterKey]any) bool {
+	if len(conditions) == 0 {
+		return true
+	}
+	if filter == nil {
+		return false
+	}
+	filterConditions := flattenAnd(filter.Conditions)
+	for _, cond := range flattenAnd(conditions) {
+		isImplied := false
+		for _, filterCond := range filterConditions {
+			if isConditionImplied(filterCond, cond) {
+				isImplied = true
+				break
+			}
+		}
+		if !isImplied {
+			return false
+		}
+	}
+	return true
+}
+
+type condition struct {
+	key   connor.FilterKey
+	value any
+}
+
+// flattenAnd returns the given conditions, replacing _and operators by the conditions they hold.
+func flattenAnd(conditions map[connor.FilterKey]any) []condition {
+	result := make([]condition, 0, len(conditions))
+	for key, value := range conditions {
+		if op, ok := key.(*mapper.Operator); ok && op.Operation == request.FilterOpAnd {
+			if items, ok := value.([]any); ok && areAllConditionMaps(items) {
+				for _, item := range items {
+					result = append(result, flattenAnd(item.(map[connor.FilterKey]any))...)
+				}
+				continue
+			}
+		}
+		result = append(result, condition{key: key, value: value})
+	}
+	return result
+}
+
+func areAllConditionMaps(items []any) bool {
+	for _, item := range items {
+		if _, ok := item.(map[connor.FilterKey]any); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// isConditionImplied returns true if the filter condition implies the given condition.
+func isConditionImplied(filterCond, cond condition) bool {
+	if !filterCond.key.Equal(cond.key) {
+		return false
+	}
+	if _, ok := cond.key.(*mapper.PropertyIndex); ok {
+		filterOps, isFilterOpsMap := filterCond.value.(map[connor.FilterKey]any)
+		ops, isOpsMap := cond.value.(map[connor.FilterKey]any)
+		if isFilterOpsMap && isOpsMap {
+			for opKey, opValue := range ops {
+				if !hasEqualCondition(filterOps, opKey, opValue) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return areValuesEqual(filterCond.value, cond.value)
+}
+
+func hasEqualCondition(conditions map[connor.FilterKey]any, key connor.FilterKey, value any) bool {
+	for condKey, condValue := range conditions {
+		if condKey.Equal(key) && areValuesEqual(condValue, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// areValuesEqual compares condition values, considering numbers of different types
+// with the same value, e.g. values parsed from a request and from JSON, as equal.
+func areValuesEqual(v1, v2 any) bool {
+	switch typedV1 := v1.(type) {
+	case map[connor.FilterKey]any:
+		typedV2, ok := v2.(map[connor.FilterKey]any)
+		if !ok || len(typedV1) != len(typedV2) {
+			return false
+		}
+		for key, value := range typedV1 {
+			if !hasEqualCondition(typedV2, key, value) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		typedV2, ok := v2.([]any)
+		if !ok || len(typedV1) != len(typedV2) {
+			return false
+		}
+		for i := range typedV1 {
+			if !areValuesEqual(typedV1[i], typedV2[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return numbers.Equal(v1, v2) || reflect.DeepEqual(v1, v2)
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/planner/mapper"
+)
+
+func TestImplies(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     map[string]any
+		conditions map[string]any
+		expected   bool
+	}{
+		{
+			name:       "no conditions",
+			filter:     m("name", m("_eq", "John")),
+			conditions: nil,
+			expected:   true,
+		},
+		{
+			name:       "no filter",
+			filter:     nil,
+			conditions: m("verified", m("_eq", true)),
+			expected:   false,
+		},
+		{
+			name:       "same condition",
+			filter:     m("verified", m("_eq", true)),
+			conditions: m("verified", m("_eq", true)),
+			expected:   true,
+		},
+		{
+			name: "condition among others",
+			filter: map[string]any{
+				"name":     m("_eq", "John"),
+				"verified": m("_eq", true),
+			},
+			conditions: m("verified", m("_eq", true)),
+			expected:   true,
+		},
+		{
+			name:       "different value",
+			filter:     m("verified", m("_eq", false)),
+			conditions: m("verified", m("_eq", true)),
+			expected:   false,
+		},
+		{
+			name:       "different field",
+			filter:     m("name", m("_eq", "John")),
+			conditions: m("verified", m("_eq", true)),
+			expected:   false,
+		},
+		{
+			name:       "numbers of different types",
+			filter:     m("age", m("_gt", int64(30))),
+			conditions: m("age", m("_gt", float64(30))),
+			expected:   true,
+		},
+		{
+			name:       "field condition with additional operators",
+			filter:     m("age", map[string]any{"_gt": 30, "_lt": 50}),
+			conditions: m("age", m("_gt", 30)),
+			expected:   true,
+		},
+		{
+			name:       "field condition with missing operators",
+			filter:     m("age", m("_gt", 30)),
+			conditions: m("age", map[string]any{"_gt": 30, "_lt": 50}),
+			expected:   false,
+		},
+		{
+			name: "condition within _and of filter",
+			filter: r("_and",
+				m("name", m("_eq", "John")),
+				m("verified", m("_eq", true)),
+			),
+			conditions: m("verified", m("_eq", true)),
+			expected:   true,
+		},
+		{
+			name:   "conditions within _and",
+			filter: map[string]any{"name": m("_eq", "John"), "verified": m("_eq", true)},
+			conditions: r("_and",
+				m("name", m("_eq", "John")),
+				m("verified", m("_eq", true)),
+			),
+			expected: true,
+		},
+		{
+			name:       "condition within _or of filter",
+			filter:     r("_or", m("verified", m("_eq", true)), m("name", m("_eq", "John"))),
+			conditions: m("verified", m("_eq", true)),
+			expected:   false,
+		},
+		{
+			name:       "same _or condition",
+			filter:     r("_or", m("verified", m("_eq", true)), m("name", m("_eq", "John"))),
+			conditions: r("_or", m("verified", m("_eq", true)), m("name", m("_eq", "John"))),
+			expected:   true,
+		},
+	}
+
+	mapping := getDocMapping()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := mapper.ToFilter(request.Filter{Conditions: tt.filter}, mapping)
+			conditions := mapper.ToFilter(request.Filter{Conditions: tt.conditions}, mapping)
+			var actual bool
+			if conditions == nil {
+				actual = Implies(filter, nil)
+			} else {
+				actual = Implies(filter, conditions.Conditions)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -770,6 +770,7 @@ func getTopLevelInfo(
 		mapping.SetTypeName(collectionName)
 
 		mapping.Add(mapping.GetNextIndex(), request.DeletedFieldName)
+		mapping.Add(mapping.GetNextIndex(), request.ConflictsFieldName)
 
 		return mapping, collection, nil
 	}
@@ -1199,11 +1200,29 @@ func toFilterMap(
 		}
 		switch typedClause := sourceClause.(type) {
 		case map[string]any:
+			if path, isPath := toJSONPath(typedClause[request.FilterJSONPathProp]); isPath {
+				// The conditions of a JSON property with a path apply to the value at the path.
+				pathClause := map[connor.FilterKey]any{}
+				for innerSourceKey, innerSourceValue := range typedClause {
+					if innerSourceKey == request.FilterJSONPathProp {
+						continue
+					}
+					rKey, rValue := toFilterMap(innerSourceKey, innerSourceValue, mapping)
+					pathClause[rKey] = rValue
+				}
+				return key, map[connor.FilterKey]any{path: pathClause}
+			}
 			returnClause := map[connor.FilterKey]any{}
 			for innerSourceKey, innerSourceValue := range typedClause {
 				var innerMapping *core.DocumentMapping
 				switch innerSourceValue.(type) {
 				case map[string]any:
+					if strings.HasPrefix(innerSourceKey, "_") && innerSourceKey != request.KeyFieldName {
+						// Operators taking a map, such as the inline array operators,
+						// apply to the values of this property.
+						innerMapping = mapping
+						break
+					}
 					// If the innerSourceValue is also a map, then we should parse the nested clause
 					// using the child mapping, as this key must refer to a host property in a join
 					// and deeper keys must refer to properties on the child items.
@@ -1221,6 +1240,25 @@ func toFilterMap(
 	}
 }
 
+// toJSONPath converts the given path of a JSON property filter into a filter key.
+//
+// The second return value is false if the value is not a path, i.e. not an array of strings.
+func toJSONPath(source any) (*JSONPath, bool) {
+	items, ok := source.([]any)
+	if !ok {
+		return nil, false
+	}
+	path := make([]string, len(items))
+	for i, item := range items {
+		key, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		path[i] = key
+	}
+	return &JSONPath{Path: path}, true
+}
+
 func toLimit(limit immutable.Option[uint64], offset immutable.Option[uint64]) *Limit {
 	var limitValue uint64
 	var offsetValue uint64
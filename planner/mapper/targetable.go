@@ -21,6 +21,7 @@ import (
 var (
 	_ connor.FilterKey = (*PropertyIndex)(nil)
 	_ connor.FilterKey = (*Operator)(nil)
+	_ connor.FilterKey = (*JSONPath)(nil)
 )
 
 // PropertyIndex is a FilterKey that represents a property in a document.
@@ -71,6 +72,34 @@ func (k *Operator) Equal(other connor.FilterKey) bool {
 	return false
 }
 
+// JSONPath is a FilterKey that represents a value within the value of a JSON property.
+type JSONPath struct {
+	// The keys of the object properties and the indexes of the array elements leading
+	// to the target value.
+	Path []string
+}
+
+func (k *JSONPath) GetProp(data any) any {
+	return core.GetJSONPathValue(data, k.Path)
+}
+
+func (k *JSONPath) GetOperatorOrDefault(defaultOp string) string {
+	return defaultOp
+}
+
+func (k *JSONPath) Equal(other connor.FilterKey) bool {
+	otherKey, isOk := other.(*JSONPath)
+	if !isOk || len(k.Path) != len(otherKey.Path) {
+		return false
+	}
+	for i := range k.Path {
+		if k.Path[i] != otherKey.Path[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // Filter represents a series of conditions that may reduce the number of
 // records that a request returns.
 type Filter struct {
@@ -102,6 +131,27 @@ func (f *Filter) HasIndex(index int) bool {
 	return false
 }
 
+// HasJSONPath returns true if the filter has a condition that targets the value
+// at the given path within a property with the given index.
+func (f *Filter) HasJSONPath(index int, path []string) bool {
+	for k, v := range f.Conditions {
+		propIndex, isOk := k.(*PropertyIndex)
+		if !isOk || propIndex.Index != index {
+			continue
+		}
+		condMap, isOk := v.(map[connor.FilterKey]any)
+		if !isOk {
+			continue
+		}
+		for condKey := range condMap {
+			if condKey.Equal(&JSONPath{Path: path}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func filterObjectToMap(mapping *core.DocumentMapping, obj map[connor.FilterKey]any) map[string]any {
 	outmap := make(map[string]any)
 	if obj == nil {
@@ -119,6 +169,16 @@ func filterObjectToMap(mapping *core.DocumentMapping, obj map[connor.FilterKey]a
 				outmap[outkey] = filterObjectToMap(mapping, subObj)
 			}
 
+		case *JSONPath:
+			path := make([]any, len(keyType.Path))
+			for i, key := range keyType.Path {
+				path[i] = key
+			}
+			outmap[request.FilterJSONPathProp] = path
+			for opKey, opValue := range filterObjectToMap(mapping, v.(map[connor.FilterKey]any)) {
+				outmap[opKey] = opValue
+			}
+
 		case *Operator:
 			switch keyType.Operation {
 			case request.FilterOpAnd, request.FilterOpOr:
@@ -11,6 +11,8 @@
 package planner
 
 import (
+	"github.com/sourcenetwork/immutable"
+
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/core"
@@ -61,6 +63,11 @@ type orderNode struct {
 	// consuming and sorting data.
 	needSort bool
 
+	// index is the index by which the underlying plan yields its
+	// values already in the requested order, if any. In that case
+	// the values are streamed without being sorted.
+	index immutable.Option[client.IndexDescription]
+
 	execInfo orderExecInfo
 }
 
@@ -92,8 +99,11 @@ func (n *orderNode) Kind() string {
 
 func (n *orderNode) Init() error {
 	// reset stateful data
-	n.needSort = true
+	n.needSort = !n.index.HasValue()
 	n.orderStrategy = nil
+	if n.index.HasValue() {
+		n.valueIter = n.plan
+	}
 	return n.plan.Init()
 }
 func (n *orderNode) Start() error { return n.plan.Start() }
@@ -135,8 +145,14 @@ func (n *orderNode) simpleExplain() (map[string]any, error) {
 		)
 	}
 
+	var indexName any
+	if n.index.HasValue() {
+		indexName = n.index.Value().Name
+	}
+
 	return map[string]any{
 		"orderings": orderings,
+		"index":     indexName,
 	}, nil
 }
 
@@ -198,6 +214,11 @@ func (n *orderNode) Close() error {
 		return err
 	}
 
+	if n.index.HasValue() {
+		// the values are read directly from the plan
+		return nil
+	}
+
 	if n.valueIter != nil {
 		return n.valueIter.Close()
 	}
@@ -210,6 +231,51 @@ func (n *orderNode) Close() error {
 
 func (n *orderNode) Source() planNode { return n.plan }
 
+// isIndexMatchingOrder returns true if the documents read by the given index come out
+// in the requested order, i.e. if the ordered fields are the leading fields of the index
+// and have the same directions.
+//
+// Indexes on array fields are never in document order, as they hold a record for every element.
+func isIndexMatchingOrder(
+	index client.IndexDescription,
+	ordering []mapper.OrderCondition,
+	mapping *core.DocumentMapping,
+	schema client.SchemaDescription,
+) bool {
+	// a full-text index returns the documents ordered by relevance
+	if index.FullText != nil {
+		return false
+	}
+	for _, indexedField := range index.Fields {
+		if field, ok := schema.GetField(indexedField.Name); ok && field.IsArray() {
+			return false
+		}
+		// the values of a case insensitive field are ordered by their lowercased form
+		// and JSON fields are ordered by the value at a path
+		if indexedField.IsCaseInsensitive() || len(indexedField.Path) > 0 {
+			return false
+		}
+	}
+	if len(ordering) == 0 || len(ordering) > len(index.Fields) {
+		return false
+	}
+	for i, cond := range ordering {
+		// ordering by fields of related objects can not be satisfied by an index
+		if len(cond.FieldIndexes) != 1 {
+			return false
+		}
+		fieldName, found := mapping.TryToFindNameFromIndex(cond.FieldIndexes[0])
+		if !found || fieldName != index.Fields[i].Name {
+			return false
+		}
+		isDescending := index.Fields[i].Direction == client.Descending
+		if isDescending != (cond.Direction == mapper.DESC) {
+			return false
+		}
+	}
+	return true
+}
+
 // allSortStrategy is the simplest sort strategy available.
 // it consumes all the data into the underlying valueNode
 // document container, then sorts it. Its designed for an
@@ -13,6 +13,8 @@ package planner
 import (
 	"context"
 
+	"github.com/sourcenetwork/immutable"
+
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
 	"github.com/sourcenetwork/defradb/connor"
@@ -263,6 +265,9 @@ func (p *Planner) expandSelectTopNodePlan(plan *selectTopNode, parentPlan *selec
 
 	// if order
 	if plan.order != nil {
+		if parentPlan == nil && plan.group == nil {
+			p.tryOptimizeOrderWithIndex(plan)
+		}
 		plan.order.plan = plan.planNode
 		plan.planNode = plan.order
 	}
@@ -332,16 +337,58 @@ func findFilteredByRelationFields(
 	return filteredSubFields
 }
 
+// tryOptimizeOrderWithIndex makes the order node stream the documents in the order
+// of an index instead of sorting them, if there is an index that matches the ordering.
+//
+// This is done only for top-level selects that read directly from a collection and whose
+// filter does not reference related objects, as otherwise the documents are not guaranteed
+// to come out of the join in the order in which they were scanned.
+func (p *Planner) tryOptimizeOrderWithIndex(plan *selectTopNode) {
+	slct := plan.selectNode
+	scan, isScanNode := slct.origSource.(*scanNode)
+	if !isScanNode {
+		return
+	}
+	if slct.selectReq.Cid.HasValue() || slct.selectReq.DocKeys.HasValue() || slct.selectReq.ShowDeleted {
+		return
+	}
+	for _, f := range []*mapper.Filter{slct.filter, scan.filter} {
+		if f == nil {
+			continue
+		}
+		for _, prop := range filter.ExtractProperties(f.Conditions) {
+			if prop.IsRelation() {
+				return
+			}
+		}
+	}
+
+	if scan.index.HasValue() {
+		// the fetcher already uses the index picked for the filter
+		if isIndexMatchingOrder(scan.index.Value(), plan.order.ordering, scan.documentMapping, scan.col.Schema()) {
+			plan.order.index = scan.index
+		}
+		return
+	}
+
+	for _, index := range getUsableIndexes(scan.col, scan.filter, scan.documentMapping) {
+		if isIndexMatchingOrder(index, plan.order.ordering, scan.documentMapping, scan.col.Schema()) {
+			scan.initFetcher(immutable.None[string](), immutable.Some(index))
+			plan.order.index = immutable.Some(index)
+			return
+		}
+	}
+}
+
 func (p *Planner) tryOptimizeJoinDirection(node *invertibleTypeJoin, parentPlan *selectTopNode) error {
 	filteredSubFields := findFilteredByRelationFields(
 		parentPlan.selectNode.filter.Conditions,
 		node.documentMapping,
 	)
 	slct := node.subType.(*selectTopNode).selectNode
-	desc := slct.collection.Description()
-	schema := slct.collection.Schema()
-	indexedFields := desc.CollectIndexedFields(&schema)
-	for _, indField := range indexedFields {
+	// partial indexes are not considered, as the filter is split between the joined types
+	for _, index := range getUsableIndexes(slct.collection, nil, nil) {
+		indField := index.Fields[0]
 		if ind, ok := filteredSubFields[indField.Name]; ok {
 			subInd := node.documentMapping.FirstIndexOfName(node.subTypeName)
 			relatedField := mapper.Field{Name: node.subTypeName, Index: subInd}
@@ -350,7 +397,7 @@ func (p *Planner) tryOptimizeJoinDirection(node *invertibleTypeJoin, parentPlan
 				relatedField,
 				mapper.Field{Name: indField.Name, Index: ind},
 			), relatedField)
-			err := node.invertJoinDirectionWithIndex(fieldFilter, indField)
+			err := node.invertJoinDirectionWithIndex(fieldFilter, index)
 			if err != nil {
 				return err
 			}
@@ -11,6 +11,7 @@
 package planner
 
 import (
+	"github.com/fxamacker/cbor/v2"
 	"github.com/sourcenetwork/immutable"
 
 	"github.com/sourcenetwork/defradb/client"
@@ -19,6 +20,7 @@ import (
 	"github.com/sourcenetwork/defradb/db/base"
 	"github.com/sourcenetwork/defradb/db/fetcher"
 	"github.com/sourcenetwork/defradb/lens"
+	"github.com/sourcenetwork/defradb/merkle/clock"
 	"github.com/sourcenetwork/defradb/planner/filter"
 	"github.com/sourcenetwork/defradb/planner/mapper"
 	"github.com/sourcenetwork/defradb/request/graphql/parser"
@@ -43,6 +45,10 @@ type scanNode struct {
 
 	fields []client.FieldDescription
 
+	// conflictFields are the multi-value register fields whose concurrent values are returned
+	// by the _conflicts field, if it is requested.
+	conflictFields []client.FieldDescription
+
 	showDeleted bool
 
 	spans   core.Spans
@@ -51,6 +57,9 @@ type scanNode struct {
 	filter *mapper.Filter
 	slct   *mapper.Select
 
+	// index is the index used by the fetcher, if any
+	index immutable.Option[client.IndexDescription]
+
 	fetcher fetcher.Fetcher
 
 	execInfo scanExecInfo
@@ -79,9 +88,27 @@ func (n *scanNode) Init() error {
 
 func (n *scanNode) initCollection(col client.Collection) error {
 	n.col = col
+	n.initConflictFields()
 	return n.initFields(n.slct.Fields)
 }
 
+// initConflictFields sets the multi-value register fields of the collection as the fields in
+// conflict to return, if the _conflicts field is requested.
+func (n *scanNode) initConflictFields() {
+	for _, r := range n.slct.Fields {
+		field, ok := r.(*mapper.Field)
+		if !ok || field.Name != request.ConflictsFieldName {
+			continue
+		}
+		for _, fd := range n.col.Schema().Fields {
+			if fd.Typ == client.MV_REGISTER {
+				n.conflictFields = append(n.conflictFields, fd)
+			}
+		}
+		return
+	}
+}
+
 func (n *scanNode) initFields(fields []mapper.Requestable) error {
 	for _, r := range fields {
 		// add all the possible base level fields the fetcher is responsible
@@ -138,23 +165,41 @@ func (n *scanNode) tryAddField(fieldName string) bool {
 
 func (scan *scanNode) initFetcher(
 	cid immutable.Option[string],
-	indexedField immutable.Option[client.FieldDescription],
+	index immutable.Option[client.IndexDescription],
 ) {
 	var f fetcher.Fetcher
+	scan.index = immutable.None[client.IndexDescription]()
 	if cid.HasValue() {
 		f = new(fetcher.VersionedFetcher)
 	} else {
 		f = new(fetcher.DocumentFetcher)
 
-		if indexedField.HasValue() {
-			typeIndex := scan.documentMapping.FirstIndexOfName(indexedField.Value().Name)
-			field := mapper.Field{Index: typeIndex, Name: indexedField.Value().Name}
+		if index.HasValue() {
+			// conditions on all of the indexed fields are moved to the index filter,
+			// as the document fetcher does not fetch the indexed fields
 			var indexFilter *mapper.Filter
-			scan.filter, indexFilter = filter.SplitByField(scan.filter, field)
-			if indexFilter != nil {
-				fieldDesc, _ := scan.col.Schema().GetField(indexedField.Value().Name)
-				f = fetcher.NewIndexFetcher(f, fieldDesc, indexFilter)
+			for _, indexedField := range index.Value().Fields {
+				mappingIndexes, ok := scan.documentMapping.IndexesByName[indexedField.Name]
+				if !ok || len(mappingIndexes) == 0 {
+					continue
+				}
+				field := mapper.Field{Index: mappingIndexes[0], Name: indexedField.Name}
+				var fieldFilter *mapper.Filter
+				scan.filter, fieldFilter = filter.SplitByField(scan.filter, field)
+				if fieldFilter == nil {
+					continue
+				}
+				if indexFilter == nil {
+					indexFilter = fieldFilter
+					continue
+				}
+				// the fields are distinct, so their conditions can't collide
+				for key, cond := range fieldFilter.Conditions {
+					indexFilter.Conditions[key] = cond
+				}
 			}
+			f = fetcher.NewIndexFetcher(f, index.Value(), indexFilter)
+			scan.index = index
 		}
 
 		f = lens.NewFetcher(f, scan.p.db.LensRegistry())
@@ -162,6 +207,29 @@ func (scan *scanNode) initFetcher(
 	scan.fetcher = f
 }
 
+// tryUseIndexForLookup makes the scan look up the documents by an index on the given field,
+// if the collection has one and the scan is not already using an index on another field.
+//
+// It is used by type joins that fetch the related documents of every parent document
+// by their relation _id field. As the filter value changes between the lookups, the fetcher
+// is recreated with the current filter on every call.
+func (scan *scanNode) tryUseIndexForLookup(fieldName string) error {
+	if scan.index.HasValue() && scan.index.Value().Fields[0].Name != fieldName {
+		return nil
+	}
+	for _, index := range getUsableIndexes(scan.col, scan.filter, scan.documentMapping) {
+		if index.Fields[0].Name != fieldName {
+			continue
+		}
+		if err := scan.fetcher.Close(); err != nil {
+			return err
+		}
+		scan.initFetcher(immutable.None[string](), immutable.Some(index))
+		return nil
+	}
+	return nil
+}
+
 // Start starts the internal logic of the scanner
 // like the DocumentFetcher, and more.
 func (n *scanNode) Start() error {
@@ -213,9 +281,58 @@ func (n *scanNode) Next() (bool, error) {
 		n.currentValue.Status.IsDeleted(),
 	)
 
+	if len(n.conflictFields) > 0 {
+		conflicts, err := n.getConflicts(n.currentValue.GetKey())
+		if err != nil {
+			return false, err
+		}
+		n.documentMapping.SetFirstOfName(&n.currentValue, request.ConflictsFieldName, conflicts)
+	}
+
 	return true, nil
 }
 
+// getConflicts returns the values written concurrently to the multi-value register fields
+// of the given document, by field name.
+//
+// The concurrent values of a field are the values kept along with the heads of its merkle
+// clock. Fields with a single head are not in conflict and are omitted, nil is returned if
+// none of the fields are in conflict.
+func (n *scanNode) getConflicts(docKey string) (any, error) {
+	conflicts := map[string]any{}
+	for _, fd := range n.conflictFields {
+		headset := clock.NewHeadSet(
+			n.p.txn.Headstore(),
+			core.HeadStoreKey{DocKey: docKey, FieldId: fd.ID.String()},
+		)
+		values, err := headset.Values(n.p.ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) < 2 {
+			continue
+		}
+
+		decoded := make([]any, len(values))
+		for i, value := range values {
+			var val any
+			err := cbor.Unmarshal(value, &val)
+			if err != nil {
+				return nil, err
+			}
+			decoded[i], err = core.DecodeFieldValue(fd, val)
+			if err != nil {
+				return nil, err
+			}
+		}
+		conflicts[fd.Name] = decoded
+	}
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+	return conflicts, nil
+}
+
 func (n *scanNode) Spans(spans core.Spans) {
 	n.spans = spans
 }
@@ -263,10 +380,11 @@ func (n *scanNode) simpleExplain() (map[string]any, error) {
 
 func (n *scanNode) executeExplain() map[string]any {
 	return map[string]any{
-		"iterations":   n.execInfo.iterations,
-		"docFetches":   n.execInfo.fetches.DocsFetched,
-		"fieldFetches": n.execInfo.fetches.FieldsFetched,
-		"indexFetches": n.execInfo.fetches.IndexesFetched,
+		"iterations":        n.execInfo.iterations,
+		"docFetches":        n.execInfo.fetches.DocsFetched,
+		"fieldFetches":      n.execInfo.fetches.FieldsFetched,
+		"indexFetches":      n.execInfo.fetches.IndexesFetched,
+		"coveredDocFetches": n.execInfo.fetches.CoveredDocsFetched,
 	}
 }
 
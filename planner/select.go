@@ -16,9 +16,11 @@ import (
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
+	"github.com/sourcenetwork/defradb/connor"
 	"github.com/sourcenetwork/defradb/core"
 	"github.com/sourcenetwork/defradb/db/base"
 	"github.com/sourcenetwork/defradb/db/fetcher"
+	"github.com/sourcenetwork/defradb/planner/filter"
 	"github.com/sourcenetwork/defradb/planner/mapper"
 )
 
@@ -290,26 +292,129 @@ func (n *selectNode) initSource() ([]aggregateNode, error) {
 	}
 
 	if isScanNode {
-		origScan.initFetcher(n.selectReq.Cid, findFilteredByIndexedField(origScan))
+		origScan.initFetcher(n.selectReq.Cid, findIndexForFilter(origScan))
 	}
 
 	return aggregates, nil
 }
 
-func findFilteredByIndexedField(scanNode *scanNode) immutable.Option[client.FieldDescription] {
-	if scanNode.filter != nil {
-		schema := scanNode.col.Schema()
-		indexedFields := scanNode.col.Description().CollectIndexedFields(&schema)
-		for i := range indexedFields {
-			typeIndex := scanNode.documentMapping.FirstIndexOfName(indexedFields[i].Name)
-			if scanNode.filter.HasIndex(typeIndex) {
-				// we return the first found indexed field to keep it simple for now
-				// more sophisticated optimization logic can be added later
-				return immutable.Some(indexedFields[i])
+// getUsableIndexes returns the indexes of the collection that can be used by a query with
+// the given filter, i.e. all indexes except the ones still being built in the background,
+// the partial indexes whose filter is not implied by the given one and the full-text indexes
+// whose field has no _match condition.
+func getUsableIndexes(
+	col client.Collection,
+	f *mapper.Filter,
+	mapping *core.DocumentMapping,
+) []client.IndexDescription {
+	indexes := make([]client.IndexDescription, 0, len(col.Description().Indexes))
+	for _, index := range col.Description().Indexes {
+		if index.Status.Building || !isIndexFilterImplied(index, f, mapping) {
+			continue
+		}
+		if index.FullText != nil && !hasMatchCondition(f, mapping, index.Fields[0].Name) {
+			continue
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes
+}
+
+// hasMatchCondition returns true if the given filter has a top level _match condition
+// on the field with the given name.
+//
+// A full-text index can only fetch the documents that match such a condition, as it doesn't
+// hold the values of the field.
+func hasMatchCondition(f *mapper.Filter, mapping *core.DocumentMapping, fieldName string) bool {
+	if f == nil || mapping == nil {
+		return false
+	}
+	mappingIndexes, ok := mapping.IndexesByName[fieldName]
+	if !ok || len(mappingIndexes) == 0 {
+		return false
+	}
+	for filterKey, cond := range f.Conditions {
+		propKey, ok := filterKey.(*mapper.PropertyIndex)
+		if !ok || propKey.Index != mappingIndexes[0] {
+			continue
+		}
+		condMap, ok := cond.(map[connor.FilterKey]any)
+		if !ok {
+			continue
+		}
+		for opKey := range condMap {
+			if op, ok := opKey.(*mapper.Operator); ok && op.Operation == request.FilterOpMatch {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isIndexFilterImplied returns true if all documents matching the given filter are indexed
+// by the given index, i.e. if the index is not partial or its filter is implied by the given one.
+func isIndexFilterImplied(
+	index client.IndexDescription,
+	f *mapper.Filter,
+	mapping *core.DocumentMapping,
+) bool {
+	if len(index.Where) == 0 {
+		return true
+	}
+	if f == nil {
+		return false
+	}
+	for _, fieldName := range index.ReferencedFieldNames() {
+		// the filter can't refer to a field that is not part of the document mapping
+		if _, ok := mapping.IndexesByName[fieldName]; !ok {
+			return false
+		}
+	}
+	indexFilter := mapper.ToFilter(request.Filter{Conditions: index.Where}, mapping)
+	return filter.Implies(f, indexFilter.Conditions)
+}
+
+// findIndexForFilter returns the index that can be used to fetch the documents
+// that match the scan node's filter.
+//
+// An index can be used only if the filter constrains its first field. If there are
+// several such indexes, the one with the longest chain of leading filtered fields is picked,
+// preferring unique indexes on ties.
+//
+// A full-text index is always preferred, as it is usable only for a _match condition
+// which the other indexes can't narrow down, and it orders the documents by relevance.
+func findIndexForFilter(scanNode *scanNode) immutable.Option[client.IndexDescription] {
+	if scanNode.filter == nil {
+		return immutable.None[client.IndexDescription]()
+	}
+
+	var bestIndex client.IndexDescription
+	bestPrefixLen := 0
+	for _, index := range getUsableIndexes(scanNode.col, scanNode.filter, scanNode.documentMapping) {
+		if index.FullText != nil {
+			return immutable.Some(index)
+		}
+		prefixLen := 0
+		for _, field := range index.Fields {
+			mappingIndexes, ok := scanNode.documentMapping.IndexesByName[field.Name]
+			if !ok || len(mappingIndexes) == 0 || !scanNode.filter.HasIndex(mappingIndexes[0]) {
+				break
 			}
+			if len(field.Path) > 0 && !scanNode.filter.HasJSONPath(mappingIndexes[0], field.Path) {
+				break
+			}
+			prefixLen++
+		}
+		if prefixLen > bestPrefixLen || (prefixLen > 0 && prefixLen == bestPrefixLen && index.Unique && !bestIndex.Unique) {
+			bestIndex = index
+			bestPrefixLen = prefixLen
 		}
 	}
-	return immutable.None[client.FieldDescription]()
+
+	if bestPrefixLen == 0 {
+		return immutable.None[client.IndexDescription]()
+	}
+	return immutable.Some(bestIndex)
 }
 
 func (n *selectNode) initFields(selectReq *mapper.Select) ([]aggregateNode, error) {
@@ -308,6 +308,12 @@ func fetchDocsWithFieldValue(plan planNode, fieldName string, val any, limit uin
 	propIndex := plan.DocumentMap().FirstIndexOfName(fieldName)
 	setSubTypeFilterToScanNode(plan, propIndex, val)
 
+	if scan := getScanNode(plan); scan != nil {
+		if err := scan.tryUseIndexForLookup(fieldName); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := plan.Init(); err != nil {
 		return nil, NewErrSubTypeInit(err)
 	}
@@ -606,12 +612,12 @@ func (join *invertibleTypeJoin) Next() (bool, error) {
 
 func (join *invertibleTypeJoin) invertJoinDirectionWithIndex(
 	fieldFilter *mapper.Filter,
-	field client.FieldDescription,
+	index client.IndexDescription,
 ) error {
 	subScan := getScanNode(join.subType)
 	subScan.tryAddField(join.rootName + request.RelatedObjectID)
 	subScan.filter = fieldFilter
-	subScan.initFetcher(immutable.Option[string]{}, immutable.Some(field))
+	subScan.initFetcher(immutable.Option[string]{}, immutable.Some(index))
 
 	join.invert()
 
@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/sourcenetwork/defradb/client"
 	"github.com/sourcenetwork/defradb/client/request"
@@ -94,9 +95,20 @@ func fromAstDefinition(
 		},
 	}
 
+	// the @crdt directive of a type sets the CRDT type of its fields that would otherwise be
+	// last-writer-wins registers
+	defaultCType := client.LWW_REGISTER
+	if directive, exists := findObjectDirective(def, types.CRDTLabel); exists {
+		var err error
+		defaultCType, err = crdtTypeFromAST(def.Name.Value, directive)
+		if err != nil {
+			return client.CollectionDefinition{}, err
+		}
+	}
+
 	indexDescriptions := []client.IndexDescription{}
 	for _, field := range def.Fields {
-		tmpFieldsDescriptions, err := fieldsFromAST(field, relationManager, def)
+		tmpFieldsDescriptions, err := fieldsFromAST(field, relationManager, def, defaultCType)
 		if err != nil {
 			return client.CollectionDefinition{}, err
 		}
@@ -109,6 +121,11 @@ func fromAstDefinition(
 				if err != nil {
 					return client.CollectionDefinition{}, err
 				}
+				if isRelatedObjectField(tmpFieldsDescriptions) {
+					// relation objects are not stored in the document,
+					// so the index is created on their _id field instead.
+					index.Fields[0].Name = field.Name.Value + request.RelatedObjectID
+				}
 				indexDescriptions = append(indexDescriptions, index)
 			}
 		}
@@ -166,6 +183,17 @@ func IsValidIndexName(name string) bool {
 	return true
 }
 
+// isRelatedObjectField returns true if the given descriptions of a single AST field
+// describe a field holding a single related object, which is backed by an _id field.
+func isRelatedObjectField(fieldDescriptions []client.FieldDescription) bool {
+	for _, desc := range fieldDescriptions {
+		if desc.Kind == client.FieldKind_FOREIGN_OBJECT {
+			return true
+		}
+	}
+	return false
+}
+
 func fieldIndexFromAST(field *ast.FieldDefinition, directive *ast.Directive) (client.IndexDescription, error) {
 	desc := client.IndexDescription{
 		Fields: []client.IndexedFieldDescription{
@@ -186,6 +214,42 @@ func fieldIndexFromAST(field *ast.FieldDefinition, directive *ast.Directive) (cl
 			if !IsValidIndexName(desc.Name) {
 				return client.IndexDescription{}, NewErrIndexWithInvalidName(desc.Name)
 			}
+		case types.IndexDirectivePropUnique:
+			boolVal, ok := arg.Value.(*ast.BooleanValue)
+			if !ok {
+				return client.IndexDescription{}, ErrIndexWithInvalidArg
+			}
+			desc.Unique = boolVal.Value
+		case types.IndexDirectivePropWhere:
+			where, ok := types.IndexFilterScalarType.ParseLiteral(arg.Value).(map[string]any)
+			if !ok {
+				return client.IndexDescription{}, ErrIndexWithInvalidArg
+			}
+			desc.Where = where
+		case types.IndexDirectivePropFullText:
+			fullText, err := fullTextIndexOptionsFromAST(arg.Value)
+			if err != nil {
+				return client.IndexDescription{}, err
+			}
+			desc.FullText = fullText
+		case types.IndexDirectivePropCollation:
+			collation, err := indexCollationFromAST(arg.Value)
+			if err != nil {
+				return client.IndexDescription{}, err
+			}
+			desc.Fields[0].Collation = collation
+		case types.IndexDirectivePropPath:
+			pathVal, ok := arg.Value.(*ast.ListValue)
+			if !ok {
+				return client.IndexDescription{}, ErrIndexWithInvalidArg
+			}
+			for _, key := range pathVal.Values {
+				keyVal, ok := key.(*ast.StringValue)
+				if !ok {
+					return client.IndexDescription{}, ErrIndexWithInvalidArg
+				}
+				desc.Fields[0].Path = append(desc.Fields[0].Path, keyVal.Value)
+			}
 		default:
 			return client.IndexDescription{}, ErrIndexWithUnknownArg
 		}
@@ -195,7 +259,7 @@ func fieldIndexFromAST(field *ast.FieldDefinition, directive *ast.Directive) (cl
 
 func indexFromAST(directive *ast.Directive) (client.IndexDescription, error) {
 	desc := client.IndexDescription{}
-	var directions *ast.ListValue
+	var directions, collations *ast.ListValue
 	for _, arg := range directive.Arguments {
 		switch arg.Name.Value {
 		case types.IndexDirectivePropName:
@@ -217,9 +281,14 @@ func indexFromAST(directive *ast.Directive) (client.IndexDescription, error) {
 				if !ok {
 					return client.IndexDescription{}, ErrIndexWithInvalidArg
 				}
-				desc.Fields = append(desc.Fields, client.IndexedFieldDescription{
-					Name: fieldVal.Value,
-				})
+				// the value at a path within a JSON field is referred to by the dot separated
+				// name of the field followed by the path, e.g. `metadata.user.id`
+				indexedField := client.IndexedFieldDescription{Name: fieldVal.Value}
+				if path := strings.Split(fieldVal.Value, "."); len(path) > 1 {
+					indexedField.Name = path[0]
+					indexedField.Path = path[1:]
+				}
+				desc.Fields = append(desc.Fields, indexedField)
 			}
 		case types.IndexDirectivePropDirections:
 			var ok bool
@@ -227,6 +296,30 @@ func indexFromAST(directive *ast.Directive) (client.IndexDescription, error) {
 			if !ok {
 				return client.IndexDescription{}, ErrIndexWithInvalidArg
 			}
+		case types.IndexDirectivePropUnique:
+			boolVal, ok := arg.Value.(*ast.BooleanValue)
+			if !ok {
+				return client.IndexDescription{}, ErrIndexWithInvalidArg
+			}
+			desc.Unique = boolVal.Value
+		case types.IndexDirectivePropWhere:
+			where, ok := types.IndexFilterScalarType.ParseLiteral(arg.Value).(map[string]any)
+			if !ok {
+				return client.IndexDescription{}, ErrIndexWithInvalidArg
+			}
+			desc.Where = where
+		case types.IndexDirectivePropFullText:
+			fullText, err := fullTextIndexOptionsFromAST(arg.Value)
+			if err != nil {
+				return client.IndexDescription{}, err
+			}
+			desc.FullText = fullText
+		case types.IndexDirectivePropCollations:
+			var ok bool
+			collations, ok = arg.Value.(*ast.ListValue)
+			if !ok {
+				return client.IndexDescription{}, ErrIndexWithInvalidArg
+			}
 		default:
 			return client.IndexDescription{}, ErrIndexWithUnknownArg
 		}
@@ -254,12 +347,76 @@ func indexFromAST(directive *ast.Directive) (client.IndexDescription, error) {
 			desc.Fields[i].Direction = client.Ascending
 		}
 	}
+	if collations != nil {
+		if len(collations.Values) != len(desc.Fields) {
+			return client.IndexDescription{}, ErrIndexWithInvalidArg
+		}
+		for i := range desc.Fields {
+			collation, err := indexCollationFromAST(collations.Values[i])
+			if err != nil {
+				return client.IndexDescription{}, err
+			}
+			desc.Fields[i].Collation = collation
+		}
+	}
 	return desc, nil
 }
 
+func indexCollationFromAST(val ast.Value) (client.IndexCollation, error) {
+	enumVal, ok := val.(*ast.EnumValue)
+	if !ok {
+		return "", ErrIndexWithInvalidArg
+	}
+	switch collation := client.IndexCollation(enumVal.Value); collation {
+	case client.BinaryCollation, client.CaseInsensitiveCollation:
+		return collation, nil
+	default:
+		return "", ErrIndexWithInvalidArg
+	}
+}
+
+func fullTextIndexOptionsFromAST(val ast.Value) (*client.FullTextIndexOptions, error) {
+	objVal, ok := val.(*ast.ObjectValue)
+	if !ok {
+		return nil, ErrIndexWithInvalidArg
+	}
+	opts := &client.FullTextIndexOptions{}
+	for _, field := range objVal.Fields {
+		switch field.Name.Value {
+		case types.FullTextIndexPropTokenizer:
+			enumVal, ok := field.Value.(*ast.EnumValue)
+			if !ok {
+				return nil, ErrIndexWithInvalidArg
+			}
+			switch tokenizer := client.FullTextTokenizer(enumVal.Value); tokenizer {
+			case client.FullTextWordTokenizer, client.FullTextWhitespaceTokenizer:
+				opts.Tokenizer = tokenizer
+			default:
+				return nil, ErrIndexWithInvalidArg
+			}
+		case types.FullTextIndexPropCaseSensitive:
+			boolVal, ok := field.Value.(*ast.BooleanValue)
+			if !ok {
+				return nil, ErrIndexWithInvalidArg
+			}
+			opts.CaseSensitive = boolVal.Value
+		case types.FullTextIndexPropStem:
+			boolVal, ok := field.Value.(*ast.BooleanValue)
+			if !ok {
+				return nil, ErrIndexWithInvalidArg
+			}
+			opts.Stem = boolVal.Value
+		default:
+			return nil, ErrIndexWithUnknownArg
+		}
+	}
+	return opts, nil
+}
+
 func fieldsFromAST(field *ast.FieldDefinition,
 	relationManager *RelationManager,
 	def *ast.ObjectDefinition,
+	defaultCType client.CType,
 ) ([]client.FieldDescription, error) {
 	kind, err := astTypeToKind(field.Type)
 	if err != nil {
@@ -269,6 +426,7 @@ func fieldsFromAST(field *ast.FieldDefinition,
 	schema := ""
 	relationName := ""
 	relationType := client.RelationType(0)
+	var onDelete client.RelationOnDelete
 
 	fieldDescriptions := []client.FieldDescription{}
 
@@ -284,7 +442,7 @@ func fieldsFromAST(field *ast.FieldDefinition,
 			fieldDescriptions = append(fieldDescriptions, client.FieldDescription{
 				Name:         fmt.Sprintf("%s_id", field.Name.Value),
 				Kind:         client.FieldKind_DocKey,
-				Typ:          defaultCRDTForFieldKind[client.FieldKind_DocKey],
+				Typ:          defaultCRDTForFieldKindOf(client.FieldKind_DocKey, defaultCType),
 				RelationType: client.Relation_Type_INTERNAL_ID,
 			})
 		} else if kind == client.FieldKind_FOREIGN_OBJECT_ARRAY {
@@ -297,6 +455,11 @@ func fieldsFromAST(field *ast.FieldDefinition,
 			return nil, err
 		}
 
+		onDelete, err = relationOnDeleteFromAST(field)
+		if err != nil {
+			return nil, err
+		}
+
 		// Register the relationship so that the relationship manager can evaluate
 		// relationsip properties dependent on both collections in the relationship.
 		_, err := relationManager.RegisterSingle(
@@ -310,19 +473,71 @@ func fieldsFromAST(field *ast.FieldDefinition,
 		}
 	}
 
+	cType := defaultCRDTForFieldKindOf(kind, defaultCType)
+	if directive, exists := findDirective(field, types.CRDTLabel); exists {
+		cType, err = crdtTypeFromAST(field.Name.Value, directive)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	fieldDescription := client.FieldDescription{
 		Name:         field.Name.Value,
 		Kind:         kind,
-		Typ:          defaultCRDTForFieldKind[kind],
+		Typ:          cType,
 		Schema:       schema,
 		RelationName: relationName,
 		RelationType: relationType,
+		OnDelete:     onDelete,
 	}
 
 	fieldDescriptions = append(fieldDescriptions, fieldDescription)
 	return fieldDescriptions, nil
 }
 
+// defaultCRDTForFieldKindOf returns the CRDT type of a field of the given kind without a @crdt
+// directive, within a type whose fields default to the given CRDT type.
+func defaultCRDTForFieldKindOf(kind client.FieldKind, defaultCType client.CType) client.CType {
+	cType := defaultCRDTForFieldKind[kind]
+	if cType == client.LWW_REGISTER {
+		return defaultCType
+	}
+	return cType
+}
+
+// crdtTypeFromAST returns the CRDT type set by the given @crdt directive of the given field or type.
+func crdtTypeFromAST(name string, directive *ast.Directive) (client.CType, error) {
+	cType := client.LWW_REGISTER
+	for _, arg := range directive.Arguments {
+		if arg.Name.Value != types.CRDTDirectivePropType {
+			return client.NONE_CRDT, ErrCRDTWithInvalidArg
+		}
+		strVal, ok := arg.Value.(*ast.StringValue)
+		if !ok {
+			return client.NONE_CRDT, ErrCRDTWithInvalidArg
+		}
+		switch strVal.Value {
+		case types.CRDTTypeLWW:
+			cType = client.LWW_REGISTER
+		case types.CRDTTypePNCounter:
+			cType = client.PN_COUNTER
+		case types.CRDTTypePCounter:
+			cType = client.P_COUNTER
+		case types.CRDTTypeORSet:
+			cType = client.OR_SET
+		case types.CRDTTypeRGA:
+			cType = client.RGA
+		case types.CRDTTypeLWWHLC:
+			cType = client.LWW_HLC_REGISTER
+		case types.CRDTTypeMVReg:
+			cType = client.MV_REGISTER
+		default:
+			return client.NONE_CRDT, NewErrCRDTUnknownType(name, strVal.Value)
+		}
+	}
+	return cType, nil
+}
+
 func astTypeToKind(t ast.Type) (client.FieldKind, error) {
 	const (
 		typeID       string = "ID"
@@ -332,6 +547,7 @@ func astTypeToKind(t ast.Type) (client.FieldKind, error) {
 		typeDateTime string = "DateTime"
 		typeString   string = "String"
 		typeBlob     string = "Blob"
+		typeJSON     string = "JSON"
 	)
 
 	switch astTypeVal := t.(type) {
@@ -382,6 +598,8 @@ func astTypeToKind(t ast.Type) (client.FieldKind, error) {
 			return client.FieldKind_STRING, nil
 		case typeBlob:
 			return client.FieldKind_BLOB, nil
+		case typeJSON:
+			return client.FieldKind_JSON, nil
 		default:
 			return client.FieldKind_FOREIGN_OBJECT, nil
 		}
@@ -403,6 +621,15 @@ func findDirective(field *ast.FieldDefinition, directiveName string) (*ast.Direc
 	return nil, false
 }
 
+func findObjectDirective(def *ast.ObjectDefinition, directiveName string) (*ast.Directive, bool) {
+	for _, directive := range def.Directives {
+		if directive.Name.Value == directiveName {
+			return directive, true
+		}
+	}
+	return nil, false
+}
+
 // Gets the name of the relationship. Will return the provided name if one is specified,
 // otherwise will generate one
 func getRelationshipName(
@@ -429,6 +656,31 @@ func getRelationshipName(
 	return genRelationName(hostName, targetName)
 }
 
+// relationOnDeleteFromAST returns the onDelete option of the @relation directive of the given field,
+// or an empty option if none is specified.
+func relationOnDeleteFromAST(field *ast.FieldDefinition) (client.RelationOnDelete, error) {
+	directive, exists := findDirective(field, types.RelationLabel)
+	if !exists {
+		return "", nil
+	}
+	for _, arg := range directive.Arguments {
+		if arg.Name.Value != types.RelationDirectivePropOnDelete {
+			continue
+		}
+		enumVal, ok := arg.Value.(*ast.EnumValue)
+		if !ok {
+			return "", NewErrRelationInvalidOnDelete(field.Name.Value, arg.Value.GetValue())
+		}
+		switch onDelete := client.RelationOnDelete(enumVal.Value); onDelete {
+		case client.OnDeleteCascade, client.OnDeleteSetNull, client.OnDeleteRestrict:
+			return onDelete, nil
+		default:
+			return "", NewErrRelationInvalidOnDelete(field.Name.Value, enumVal.Value)
+		}
+	}
+	return "", nil
+}
+
 func finalizeRelations(relationManager *RelationManager, definitions []client.CollectionDefinition) error {
 	for _, definition := range definitions {
 		for i, field := range definition.Schema.Fields {
@@ -452,6 +704,9 @@ func finalizeRelations(relationManager *RelationManager, definitions []client.Co
 			}
 
 			field.RelationType = rel.Kind() | fieldRelationType
+			if field.OnDelete != "" && !field.IsPrimaryRelation() {
+				return NewErrRelationOnDeleteNotPrimary(definition.Description.Name, field.Name)
+			}
 			definition.Schema.Fields[i] = field
 		}
 	}
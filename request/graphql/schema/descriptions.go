@@ -34,8 +34,8 @@ var (
 		&gql.List{}:   client.FieldKind_FOREIGN_OBJECT_ARRAY,
 		// Custom scalars
 		schemaTypes.BlobScalarType: client.FieldKind_BLOB,
+		schemaTypes.JSONScalarType: client.FieldKind_JSON,
 		// More custom ones to come
-		// - JSON
 		// - Counters
 	}
 
@@ -55,6 +55,7 @@ var (
 		client.FieldKind_STRING_ARRAY:          gql.NewList(gql.NewNonNull(gql.String)),
 		client.FieldKind_NILLABLE_STRING_ARRAY: gql.NewList(gql.String),
 		client.FieldKind_BLOB:                  schemaTypes.BlobScalarType,
+		client.FieldKind_JSON:                  schemaTypes.JSONScalarType,
 	}
 
 	// This map is fine to use
@@ -74,6 +75,7 @@ var (
 		client.FieldKind_STRING_ARRAY:          client.LWW_REGISTER,
 		client.FieldKind_NILLABLE_STRING_ARRAY: client.LWW_REGISTER,
 		client.FieldKind_BLOB:                  client.LWW_REGISTER,
+		client.FieldKind_JSON:                  client.LWW_REGISTER,
 		client.FieldKind_FOREIGN_OBJECT:        client.NONE_CRDT,
 		client.FieldKind_FOREIGN_OBJECT_ARRAY:  client.NONE_CRDT,
 	}
@@ -184,5 +186,9 @@ Indicates as to whether or not this document has been deleted.
 `
 	versionFieldDescription string = `
 Returns the head commit for this document.
+`
+	conflictsFieldDescription string = `
+Returns the values written concurrently to the multi-value register fields of this document, by
+ field name. Only the fields in conflict are returned, until a later write replaces their values.
 `
 )
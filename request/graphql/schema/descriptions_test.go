@@ -634,3 +634,117 @@ type descriptionTestCase struct {
 	sdl         string
 	targetDescs []client.CollectionDefinition
 }
+
+func TestFieldWithCRDTDirective(t *testing.T) {
+	cases := []struct {
+		description  string
+		fieldType    string
+		crdtType     string
+		expectedType client.CType
+	}{
+		{
+			description:  "lww",
+			fieldType:    "Int",
+			crdtType:     "lww",
+			expectedType: client.LWW_REGISTER,
+		},
+		{
+			description:  "pn-counter",
+			fieldType:    "Int",
+			crdtType:     "pncounter",
+			expectedType: client.PN_COUNTER,
+		},
+		{
+			description:  "grow-only counter",
+			fieldType:    "Int",
+			crdtType:     "pcounter",
+			expectedType: client.P_COUNTER,
+		},
+		{
+			description:  "observed-remove set",
+			fieldType:    "[Int!]",
+			crdtType:     "orset",
+			expectedType: client.OR_SET,
+		},
+		{
+			description:  "text sequence",
+			fieldType:    "String",
+			crdtType:     "rga",
+			expectedType: client.RGA,
+		},
+		{
+			description:  "lww ordered by HLC",
+			fieldType:    "String",
+			crdtType:     "hlc",
+			expectedType: client.LWW_HLC_REGISTER,
+		},
+		{
+			description:  "multi-value register",
+			fieldType:    "String",
+			crdtType:     "mvreg",
+			expectedType: client.MV_REGISTER,
+		},
+	}
+
+	for _, test := range cases {
+		defs, err := FromString(context.Background(), `
+			type User {
+				points: `+test.fieldType+` @crdt(type: "`+test.crdtType+`")
+			}`)
+		assert.NoError(t, err, test.description)
+		assert.Len(t, defs, 1, test.description)
+
+		field, ok := defs[0].Schema.GetField("points")
+		assert.True(t, ok, test.description)
+		assert.Equal(t, test.expectedType, field.Typ, test.description)
+	}
+}
+
+func TestTypeWithCRDTDirective_ShouldSetTypeOfFieldsWithoutOne(t *testing.T) {
+	defs, err := FromString(context.Background(), `
+		type User @crdt(type: "hlc") {
+			name: String
+			points: Int @crdt(type: "pncounter")
+			tags: [String!] @crdt(type: "lww")
+			author: Author
+		}
+
+		type Author {
+			name: String
+			user: User @primary
+		}`)
+	assert.NoError(t, err)
+
+	expectedTypes := map[string]client.CType{
+		"name":      client.LWW_HLC_REGISTER,
+		"points":    client.PN_COUNTER,
+		"tags":      client.LWW_REGISTER,
+		"author":    client.NONE_CRDT,
+		"author_id": client.LWW_HLC_REGISTER,
+	}
+	for fieldName, expectedType := range expectedTypes {
+		field, ok := defs[0].Schema.GetField(fieldName)
+		assert.True(t, ok, fieldName)
+		assert.Equal(t, expectedType, field.Typ, fieldName)
+	}
+
+	authorName, ok := defs[1].Schema.GetField("name")
+	assert.True(t, ok)
+	assert.Equal(t, client.LWW_REGISTER, authorName.Typ)
+}
+
+func TestFieldWithCRDTDirective_IfTypeIsUnknown_ReturnError(t *testing.T) {
+	_, err := FromString(context.Background(), `
+		type User {
+			points: Int @crdt(type: "gcounter")
+		}`)
+	assert.ErrorIs(t, err, NewErrCRDTUnknownType("points", "gcounter"))
+}
+
+func TestFieldWithCRDTDirective_IfArgumentIsUnknown_ReturnError(t *testing.T) {
+	_, err := FromString(context.Background(), `
+		type User {
+			points: Int @crdt(kind: "pncounter")
+		}`)
+	assert.ErrorIs(t, err, ErrCRDTWithInvalidArg)
+}
@@ -26,6 +26,10 @@ const (
 	errIndexUnknownArgument       string = "index with unknown argument"
 	errIndexInvalidArgument       string = "index with invalid argument"
 	errIndexInvalidName           string = "index with invalid name"
+	errCRDTUnknownType            string = "unknown CRDT type"
+	errCRDTInvalidArgument        string = "crdt directive with invalid argument"
+	errRelationInvalidOnDelete    string = "relation with invalid onDelete argument"
+	errRelationOnDeleteNotPrimary string = "onDelete can only be set on the primary side of a relation"
 )
 
 var (
@@ -48,6 +52,7 @@ var (
 	ErrIndexMissingFields  = errors.New(errIndexMissingFields)
 	ErrIndexWithUnknownArg = errors.New(errIndexUnknownArgument)
 	ErrIndexWithInvalidArg = errors.New(errIndexInvalidArgument)
+	ErrCRDTWithInvalidArg  = errors.New(errCRDTInvalidArgument)
 )
 
 func NewErrDuplicateField(objectName, fieldName string) error {
@@ -62,6 +67,14 @@ func NewErrIndexWithInvalidName(name string) error {
 	return errors.New(errIndexInvalidName, errors.NewKV("Name", name))
 }
 
+func NewErrCRDTUnknownType(fieldName, crdtType string) error {
+	return errors.New(
+		errCRDTUnknownType,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("CRDTType", crdtType),
+	)
+}
+
 func NewErrFieldMissingRelation(objectName, fieldName string, objectType string) error {
 	return errors.New(
 		errFieldMissingRelation,
@@ -121,3 +134,19 @@ func NewErrRelationNotFound(relationName string) error {
 		errors.NewKV("RelationName", relationName),
 	)
 }
+
+func NewErrRelationInvalidOnDelete(fieldName string, onDelete any) error {
+	return errors.New(
+		errRelationInvalidOnDelete,
+		errors.NewKV("Field", fieldName),
+		errors.NewKV("OnDelete", onDelete),
+	)
+}
+
+func NewErrRelationOnDeleteNotPrimary(objectName, fieldName string) error {
+	return errors.New(
+		errRelationOnDeleteNotPrimary,
+		errors.NewKV("Object", objectName),
+		errors.NewKV("Field", fieldName),
+	)
+}
@@ -435,6 +435,17 @@ func (g *Generator) buildTypes(
 				Type:        gql.Boolean,
 			}
 
+			// add _conflicts field if the type has multi-value register fields
+			for _, field := range fieldDescriptions {
+				if field.Typ == client.MV_REGISTER {
+					fields[request.ConflictsFieldName] = &gql.Field{
+						Description: conflictsFieldDescription,
+						Type:        schemaTypes.JSONScalarType,
+					}
+					break
+				}
+			}
+
 			gqlType, ok := g.manager.schema.TypeMap()[collection.Description.Name]
 			if !ok {
 				return nil, NewErrObjectNotFoundDuringThunk(collection.Description.Name)
@@ -1013,11 +1024,16 @@ func (g *Generator) genTypeFilterArgInput(obj *gql.Object) *gql.InputObject {
 				}
 				// scalars (leafs)
 				if gql.IsLeafType(field.Type) {
-					if _, isList := field.Type.(*gql.List); isList {
-						// Filtering by inline array value is currently not supported
-						continue
+					operatorBlockName := field.Type.Name() + "OperatorBlock"
+					if list, isList := field.Type.(*gql.List); isList {
+						// inline arrays are filtered by the conditions on their elements
+						if notNull, isNotNull := list.OfType.(*gql.NonNull); isNotNull {
+							operatorBlockName = fmt.Sprintf("NotNull%sListOperatorBlock", notNull.OfType.Name())
+						} else {
+							operatorBlockName = list.OfType.Name() + "ListOperatorBlock"
+						}
 					}
-					operatorType, isFilterable := g.manager.schema.TypeMap()[field.Type.Name()+"OperatorBlock"]
+					operatorType, isFilterable := g.manager.schema.TypeMap()[operatorBlockName]
 					if !isFilterable {
 						continue
 					}
@@ -77,6 +77,42 @@ func TestStructIndex(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "Unique index",
+			sdl:         `type user @index(fields: ["name"], unique: true) {}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name", Direction: client.Ascending},
+					},
+					Unique: true,
+				},
+			},
+		},
+		{
+			description: "Index explicitly not unique",
+			sdl:         `type user @index(fields: ["name"], unique: false) {}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name", Direction: client.Ascending},
+					},
+					Unique: false,
+				},
+			},
+		},
+		{
+			description: "Index with 2 fields and 2 collations",
+			sdl:         `type user @index(fields: ["name", "email"], collations: [BINARY, CASE_INSENSITIVE]) {}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name", Direction: client.Ascending, Collation: client.BinaryCollation},
+						{Name: "email", Direction: client.Ascending, Collation: client.CaseInsensitiveCollation},
+					},
+				},
+			},
+		},
 		{
 			description: "Index with 2 fields and 2 directions",
 			sdl:         `type user @index(fields: ["name", "age"], directions: [ASC, DESC]) {}`,
@@ -89,6 +125,27 @@ func TestStructIndex(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "Index with filter",
+			sdl: `type user @index(fields: ["name"], where: {
+				deleted: {_eq: false},
+				_or: [{age: {_gt: 18}}, {points: {_in: [1.5, 2]}}]
+			}) {}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name", Direction: client.Ascending},
+					},
+					Where: map[string]any{
+						"deleted": map[string]any{"_eq": false},
+						"_or": []any{
+							map[string]any{"age": map[string]any{"_gt": int64(18)}},
+							map[string]any{"points": map[string]any{"_in": []any{1.5, int64(2)}}},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range cases {
@@ -163,11 +220,26 @@ func TestInvalidStructIndex(t *testing.T) {
 			sdl:         `type user @index(fields: ["name", "age"], directions: [ASC]) {}`,
 			expectedErr: errIndexInvalidArgument,
 		},
+		{
+			description: "invalid 'collations' value type (invalid element value)",
+			sdl:         `type user @index(fields: ["name"], collations: [UPPERCASE]) {}`,
+			expectedErr: errIndexInvalidArgument,
+		},
+		{
+			description: "fewer collations than fields",
+			sdl:         `type user @index(fields: ["name", "age"], collations: [CASE_INSENSITIVE]) {}`,
+			expectedErr: errIndexInvalidArgument,
+		},
 		{
 			description: "more directions than fields",
 			sdl:         `type user @index(fields: ["name"], directions: [ASC, DESC]) {}`,
 			expectedErr: errIndexInvalidArgument,
 		},
+		{
+			description: "invalid 'unique' value type",
+			sdl:         `type user @index(fields: ["name"], unique: "true") {}`,
+			expectedErr: errIndexInvalidArgument,
+		},
 	}
 
 	for _, test := range cases {
@@ -205,6 +277,82 @@ func TestFieldIndex(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "unique field index",
+			sdl: `type user {
+				name: String @index(unique: true)
+			}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name", Direction: client.Ascending},
+					},
+					Unique: true,
+				},
+			},
+		},
+		{
+			description: "field index with filter",
+			sdl: `type user {
+				name: String @index(where: {deleted: {_eq: false}})
+			}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "name", Direction: client.Ascending},
+					},
+					Where: map[string]any{
+						"deleted": map[string]any{"_eq": false},
+					},
+				},
+			},
+		},
+		{
+			description: "field full-text index with default options",
+			sdl: `type user {
+				bio: String @index(fullText: {})
+			}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "bio", Direction: client.Ascending},
+					},
+					FullText: &client.FullTextIndexOptions{},
+				},
+			},
+		},
+		{
+			description: "field full-text index with options",
+			sdl: `type user {
+				bio: String @index(fullText: {tokenizer: WHITESPACE, caseSensitive: true, stem: true})
+			}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "bio", Direction: client.Ascending},
+					},
+					FullText: &client.FullTextIndexOptions{
+						Tokenizer:     client.FullTextWhitespaceTokenizer,
+						CaseSensitive: true,
+						Stem:          true,
+					},
+				},
+			},
+		},
+		{
+			description: "field index with case insensitive collation",
+			sdl: `type user {
+				email: String @index(unique: true, collation: CASE_INSENSITIVE)
+			}`,
+			targetDescriptions: []client.IndexDescription{
+				{
+					Fields: []client.IndexedFieldDescription{
+						{Name: "email", Direction: client.Ascending, Collation: client.CaseInsensitiveCollation},
+					},
+					Unique: true,
+				},
+			},
+		},
 	}
 
 	for _, test := range cases {
@@ -212,6 +360,32 @@ func TestFieldIndex(t *testing.T) {
 	}
 }
 
+func TestFieldIndexOnRelationObject_ShouldIndexRelationIDField(t *testing.T) {
+	cols, err := FromString(context.Background(), `
+		type user {
+			devices: [device]
+		}
+
+		type device {
+			owner: user @index
+		}`)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(cols))
+
+	for _, col := range cols {
+		if col.Description.Name != "device" {
+			continue
+		}
+		assert.Equal(t, []client.IndexDescription{
+			{
+				Fields: []client.IndexedFieldDescription{
+					{Name: "owner_id", Direction: client.Ascending},
+				},
+			},
+		}, col.Description.Indexes)
+	}
+}
+
 func TestInvalidFieldIndex(t *testing.T) {
 	cases := []invalidIndexTestCase{
 		{
@@ -228,6 +402,34 @@ func TestInvalidFieldIndex(t *testing.T) {
 			}`,
 			expectedErr: errIndexUnknownArgument,
 		},
+		{
+			description: "invalid field index filter type",
+			sdl: `type user {
+				name: String @index(where: "deleted") 
+			}`,
+			expectedErr: errIndexInvalidArgument,
+		},
+		{
+			description: "invalid full-text index options type",
+			sdl: `type user {
+				bio: String @index(fullText: true) 
+			}`,
+			expectedErr: errIndexInvalidArgument,
+		},
+		{
+			description: "unknown full-text index tokenizer",
+			sdl: `type user {
+				bio: String @index(fullText: {tokenizer: NGRAM}) 
+			}`,
+			expectedErr: errIndexInvalidArgument,
+		},
+		{
+			description: "unknown full-text index option",
+			sdl: `type user {
+				bio: String @index(fullText: {language: "en"}) 
+			}`,
+			expectedErr: errIndexUnknownArgument,
+		},
 		{
 			description: "invalid field index name type",
 			sdl: `type user {
@@ -263,6 +465,13 @@ func TestInvalidFieldIndex(t *testing.T) {
 			}`,
 			expectedErr: errIndexInvalidName,
 		},
+		{
+			description: "invalid field index 'unique' value type",
+			sdl: `type user {
+				name: String @index(unique: 1) 
+			}`,
+			expectedErr: errIndexInvalidArgument,
+		},
 	}
 
 	for _, test := range cases {
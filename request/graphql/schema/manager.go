@@ -143,6 +143,7 @@ func defaultTypes() []gql.Type {
 
 		// Custom Scalar types
 		schemaTypes.BlobScalarType,
+		schemaTypes.JSONScalarType,
 
 		// Base Query types
 
@@ -160,6 +161,15 @@ func defaultTypes() []gql.Type {
 		schemaTypes.NotNullIntOperatorBlock,
 		schemaTypes.StringOperatorBlock,
 		schemaTypes.NotNullstringOperatorBlock,
+		schemaTypes.BooleanListOperatorBlock,
+		schemaTypes.NotNullBooleanListOperatorBlock,
+		schemaTypes.FloatListOperatorBlock,
+		schemaTypes.NotNullFloatListOperatorBlock,
+		schemaTypes.IntListOperatorBlock,
+		schemaTypes.NotNullIntListOperatorBlock,
+		schemaTypes.StringListOperatorBlock,
+		schemaTypes.NotNullStringListOperatorBlock,
+		schemaTypes.JSONOperatorBlock,
 
 		schemaTypes.CommitsOrderArg,
 		schemaTypes.CommitLinkObject,
@@ -11,7 +11,11 @@
 package types
 
 import (
+	"fmt"
+
 	gql "github.com/sourcenetwork/graphql-go"
+
+	"github.com/sourcenetwork/defradb/client/request"
 )
 
 // BooleanOperatorBlock filter block for boolean types.
@@ -291,6 +295,22 @@ var StringOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 			Description: nlikeStringOperatorDescription,
 			Type:        gql.String,
 		},
+		"_match": &gql.InputObjectFieldConfig{
+			Description: matchStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_ieq": &gql.InputObjectFieldConfig{
+			Description: ieqStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_ilike": &gql.InputObjectFieldConfig{
+			Description: ilikeStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_nilike": &gql.InputObjectFieldConfig{
+			Description: nilikeStringOperatorDescription,
+			Type:        gql.String,
+		},
 	},
 })
 
@@ -323,6 +343,22 @@ var NotNullstringOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 			Description: nlikeStringOperatorDescription,
 			Type:        gql.String,
 		},
+		"_match": &gql.InputObjectFieldConfig{
+			Description: matchStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_ieq": &gql.InputObjectFieldConfig{
+			Description: ieqStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_ilike": &gql.InputObjectFieldConfig{
+			Description: ilikeStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_nilike": &gql.InputObjectFieldConfig{
+			Description: nilikeStringOperatorDescription,
+			Type:        gql.String,
+		},
 	},
 })
 
@@ -349,3 +385,106 @@ var IdOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
 		},
 	},
 })
+
+// JSONOperatorBlock filter block for JSON types.
+var JSONOperatorBlock = gql.NewInputObject(gql.InputObjectConfig{
+	Name:        "JSONOperatorBlock",
+	Description: jsonOperatorBlockDescription,
+	Fields: gql.InputObjectConfigFieldMap{
+		request.FilterJSONPathProp: &gql.InputObjectFieldConfig{
+			Description: jsonPathDescription,
+			Type:        gql.NewList(gql.NewNonNull(gql.String)),
+		},
+		"_eq": &gql.InputObjectFieldConfig{
+			Description: eqOperatorDescription,
+			Type:        JSONScalarType,
+		},
+		"_ne": &gql.InputObjectFieldConfig{
+			Description: neOperatorDescription,
+			Type:        JSONScalarType,
+		},
+		"_gt": &gql.InputObjectFieldConfig{
+			Description: gtOperatorDescription,
+			Type:        JSONScalarType,
+		},
+		"_ge": &gql.InputObjectFieldConfig{
+			Description: geOperatorDescription,
+			Type:        JSONScalarType,
+		},
+		"_lt": &gql.InputObjectFieldConfig{
+			Description: ltOperatorDescription,
+			Type:        JSONScalarType,
+		},
+		"_le": &gql.InputObjectFieldConfig{
+			Description: leOperatorDescription,
+			Type:        JSONScalarType,
+		},
+		"_in": &gql.InputObjectFieldConfig{
+			Description: inOperatorDescription,
+			Type:        gql.NewList(JSONScalarType),
+		},
+		"_nin": &gql.InputObjectFieldConfig{
+			Description: ninOperatorDescription,
+			Type:        gql.NewList(JSONScalarType),
+		},
+		"_like": &gql.InputObjectFieldConfig{
+			Description: likeStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_nlike": &gql.InputObjectFieldConfig{
+			Description: nlikeStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_ilike": &gql.InputObjectFieldConfig{
+			Description: ilikeStringOperatorDescription,
+			Type:        gql.String,
+		},
+		"_nilike": &gql.InputObjectFieldConfig{
+			Description: nilikeStringOperatorDescription,
+			Type:        gql.String,
+		},
+	},
+})
+
+// BooleanListOperatorBlock filter block for [Boolean] types.
+var BooleanListOperatorBlock = newListOperatorBlock("Boolean", BooleanOperatorBlock)
+
+// NotNullBooleanListOperatorBlock filter block for [Boolean!] types.
+var NotNullBooleanListOperatorBlock = newListOperatorBlock("NotNullBoolean", NotNullBooleanOperatorBlock)
+
+// FloatListOperatorBlock filter block for [Float] types.
+var FloatListOperatorBlock = newListOperatorBlock("Float", FloatOperatorBlock)
+
+// NotNullFloatListOperatorBlock filter block for [Float!] types.
+var NotNullFloatListOperatorBlock = newListOperatorBlock("NotNullFloat", NotNullFloatOperatorBlock)
+
+// IntListOperatorBlock filter block for [Int] types.
+var IntListOperatorBlock = newListOperatorBlock("Int", IntOperatorBlock)
+
+// NotNullIntListOperatorBlock filter block for [Int!] types.
+var NotNullIntListOperatorBlock = newListOperatorBlock("NotNullInt", NotNullIntOperatorBlock)
+
+// StringListOperatorBlock filter block for [String] types.
+var StringListOperatorBlock = newListOperatorBlock("String", StringOperatorBlock)
+
+// NotNullStringListOperatorBlock filter block for [String!] types.
+var NotNullStringListOperatorBlock = newListOperatorBlock("NotNullString", NotNullstringOperatorBlock)
+
+// newListOperatorBlock creates the filter block for inline arrays whose elements
+// are filtered with the given element block.
+func newListOperatorBlock(elementTypeName string, elementBlock *gql.InputObject) *gql.InputObject {
+	return gql.NewInputObject(gql.InputObjectConfig{
+		Name:        elementTypeName + "ListOperatorBlock",
+		Description: fmt.Sprintf(listOperatorBlockDescription, elementTypeName),
+		Fields: gql.InputObjectConfigFieldMap{
+			"_any": &gql.InputObjectFieldConfig{
+				Description: anyOperatorDescription,
+				Type:        elementBlock,
+			},
+			"_all": &gql.InputObjectFieldConfig{
+				Description: allOperatorDescription,
+				Type:        elementBlock,
+			},
+		},
+	})
+}
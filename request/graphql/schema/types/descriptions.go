@@ -164,6 +164,20 @@ These are the set of filter operators available for use when filtering on String
 	idOperatorBlockDescription string = `
 These are the set of filter operators available for use when filtering on ID
  values.
+`
+	jsonOperatorBlockDescription string = `
+These are the set of filter operators available for use when filtering on JSON
+ values. The operators are applied to the value found at the given path, or to the
+ whole value if no path is given.
+`
+	jsonPathDescription string = `
+The path to the filtered value - every element of the path is the key of an object
+ property, or the index of an array element. For example 'path: ["a", "b"]' filters on
+ the value 1 of '{"a": {"b": 1}}'.
+`
+	listOperatorBlockDescription string = `
+These are the set of filter operators available for use when filtering on arrays of %s
+ values.
 `
 	eqOperatorDescription string = `
 The equality operator - if the target matches the value the check will pass.
@@ -202,6 +216,34 @@ The like operator - if the target value contains the given sub-string the check
 The not-like operator - if the target value does not contain the given sub-string the check will
  pass. '%' characters may be used as wildcards, for example '_nlike: "%Ritchie"' would match on
  the string 'Quentin Tarantino'.
+`
+	ieqStringOperatorDescription string = `
+The case insensitive equality operator - if the target value matches the given value regardless
+ of their case the check will pass. For example '_ieq: "ritchie"' would match on the string 'Ritchie'.
+`
+	ilikeStringOperatorDescription string = `
+The case insensitive like operator - works like the like operator but ignores the case of the
+ target value and of the given sub-string, for example '_ilike: "%ritchie"' would match on strings
+ ending in 'Ritchie' or 'RITCHIE'.
+`
+	nilikeStringOperatorDescription string = `
+The case insensitive not-like operator - if the target value does not contain the given sub-string,
+ regardless of their case, the check will pass. For example '_nilike: "%ritchie"' would not match on
+ the string 'Dennis Ritchie'.
+`
+	matchStringOperatorDescription string = `
+The full-text match operator - if the target value contains every word of the given text the check
+ will pass, regardless of their case and order. For example '_match: "ritchie dennis"' would match
+ on the string 'Dennis Ritchie'. If the field has a full-text index, the words are compared the way
+ the index stores them and the results are ordered by relevance.
+`
+	anyOperatorDescription string = `
+The any operator - if at least one element of the target array matches the given conditions
+ the check will pass.
+`
+	allOperatorDescription string = `
+The all operator - if the target array is not empty and all of its elements match the given
+ conditions the check will pass.
 `
 	AndOperatorDescription string = `
 The and operator - all checks within this clause must pass in order for this check to pass.
@@ -227,5 +269,24 @@ Allows the explicit definition of relationship attributes instead of using the s
 `
 	relationDirectiveNameArgDescription string = `
 Explicitly define the name of the relationship instead of using the system generated defaults.
+`
+	relationDirectiveOnDeleteArgDescription string = `
+What happens to the documents referencing a document through the relationship when it is deleted.
+ It can only be set on the primary side of the relationship, the side holding the reference.
+`
+	crdtDirectiveDescription string = `
+Sets the CRDT type of the field, i.e. the way concurrent updates of its value are merged. On a type,
+ it sets the CRDT type of its fields that don't have their own and would otherwise be lww.
+`
+	crdtDirectiveTypeArgDescription string = `
+The CRDT type of the field: lww (last writer wins, the default), hlc (last writer wins, ordered by
+ the hybrid logical clock timestamps of the writes instead of the height of their history), mvreg
+ (a multi-value register, whose concurrent values are returned by the _conflicts field until a
+ later write replaces them), pncounter (a counter that can be incremented and decremented),
+ pcounter (a grow-only counter), orset (an observed-remove set) or rga (a text sequence). Counters can only be Int or Float fields,
+ and the values written to them are added to their current value. Sets can only be array fields,
+ and their elements can be added and removed with the _add and _remove operators of an update.
+ Text sequences can only be String fields, and their text can be edited at positions with the
+ _insert and _delete operators of an update.
 `
 )
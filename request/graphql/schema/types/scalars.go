@@ -13,6 +13,7 @@ package types
 import (
 	"encoding/hex"
 	"regexp"
+	"strconv"
 
 	"github.com/sourcenetwork/graphql-go"
 	"github.com/sourcenetwork/graphql-go/language/ast"
@@ -63,3 +64,95 @@ var BlobScalarType = graphql.NewScalar(graphql.ScalarConfig{
 		}
 	},
 })
+
+var JSONScalarType = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "JSON",
+	Description: "The `JSON` scalar type represents arbitrary structured data: an object, " +
+		"an array, or a scalar value.",
+	Serialize: func(value any) any {
+		return value
+	},
+	ParseValue: func(value any) any {
+		return value
+	},
+	// ParseLiteral converts the ast value to its Go representation
+	ParseLiteral: func(valueAST ast.Value) any {
+		value, ok := parseIndexFilterValue(valueAST)
+		if !ok {
+			// return nil if the value cannot be parsed
+			return nil
+		}
+		return value
+	},
+})
+
+// parseIndexFilterValue converts the given ast value of an index filter or of a JSON literal
+// to its Go representation, the same one the values of request filters have.
+// The second return value is false if the value cannot be converted.
+func parseIndexFilterValue(valueAST ast.Value) (any, bool) {
+	switch valueAST := valueAST.(type) {
+	case *ast.ObjectValue:
+		result := make(map[string]any, len(valueAST.Fields))
+		for _, field := range valueAST.Fields {
+			value, ok := parseIndexFilterValue(field.Value)
+			if !ok {
+				return nil, false
+			}
+			result[field.Name.Value] = value
+		}
+		return result, true
+	case *ast.ListValue:
+		result := make([]any, 0, len(valueAST.Values))
+		for _, item := range valueAST.Values {
+			value, ok := parseIndexFilterValue(item)
+			if !ok {
+				return nil, false
+			}
+			result = append(result, value)
+		}
+		return result, true
+	case *ast.IntValue:
+		value, err := strconv.ParseInt(valueAST.Value, 10, 64)
+		return value, err == nil
+	case *ast.FloatValue:
+		value, err := strconv.ParseFloat(valueAST.Value, 64)
+		return value, err == nil
+	case *ast.StringValue:
+		return valueAST.Value, true
+	case *ast.EnumValue:
+		return valueAST.Value, true
+	case *ast.BooleanValue:
+		return valueAST.Value, true
+	case *ast.NullValue:
+		return nil, true
+	default:
+		return nil, false
+	}
+}
+
+var IndexFilterScalarType = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "IndexFilter",
+	Description: "The `IndexFilter` scalar type represents the filter a document has to match " +
+		"in order to be indexed. It has the same form as the filter argument of the indexed type.",
+	Serialize: func(value any) any {
+		return value
+	},
+	ParseValue: func(value any) any {
+		if conditions, ok := value.(map[string]any); ok {
+			return conditions
+		}
+		return nil
+	},
+	// ParseLiteral converts the ast value to a map of filter conditions
+	ParseLiteral: func(valueAST ast.Value) any {
+		if _, ok := valueAST.(*ast.ObjectValue); !ok {
+			return nil
+		}
+		value, ok := parseIndexFilterValue(valueAST)
+		if !ok {
+			// return nil if the value cannot be parsed
+			return nil
+		}
+		return value
+	},
+})
@@ -12,12 +12,27 @@ package types
 
 import (
 	gql "github.com/sourcenetwork/graphql-go"
+
+	"github.com/sourcenetwork/defradb/client"
 )
 
 const (
 	ExplainLabel  string = "explain"
 	PrimaryLabel  string = "primary"
 	RelationLabel string = "relation"
+	CRDTLabel     string = "crdt"
+
+	CRDTDirectivePropType string = "type"
+
+	RelationDirectivePropOnDelete string = "onDelete"
+
+	CRDTTypeLWW       string = "lww"
+	CRDTTypePNCounter string = "pncounter"
+	CRDTTypePCounter  string = "pcounter"
+	CRDTTypeORSet     string = "orset"
+	CRDTTypeRGA       string = "rga"
+	CRDTTypeLWWHLC    string = "hlc"
+	CRDTTypeMVReg     string = "mvreg"
 
 	ExplainArgNameType string = "type"
 	ExplainArgSimple   string = "simple"
@@ -28,6 +43,16 @@ const (
 	IndexDirectivePropName       = "name"
 	IndexDirectivePropFields     = "fields"
 	IndexDirectivePropDirections = "directions"
+	IndexDirectivePropUnique     = "unique"
+	IndexDirectivePropWhere      = "where"
+	IndexDirectivePropFullText   = "fullText"
+	IndexDirectivePropCollation  = "collation"
+	IndexDirectivePropCollations = "collations"
+	IndexDirectivePropPath       = "path"
+
+	FullTextIndexPropTokenizer     = "tokenizer"
+	FullTextIndexPropCaseSensitive = "caseSensitive"
+	FullTextIndexPropStem          = "stem"
 )
 
 var (
@@ -84,6 +109,52 @@ var (
 		},
 	})
 
+	// IndexCollationEnum is an enum for the collation of an indexed field.
+	IndexCollationEnum = gql.NewEnum(gql.EnumConfig{
+		Name: "IndexCollation",
+		Values: gql.EnumValueConfigMap{
+			string(client.BinaryCollation): &gql.EnumValueConfig{
+				Description: "Compares strings byte by byte.",
+				Value:       string(client.BinaryCollation),
+			},
+			string(client.CaseInsensitiveCollation): &gql.EnumValueConfig{
+				Description: "Compares strings regardless of their case.",
+				Value:       string(client.CaseInsensitiveCollation),
+			},
+		},
+	})
+
+	// FullTextTokenizerEnum is an enum for the tokenizer of a full-text index.
+	FullTextTokenizerEnum = gql.NewEnum(gql.EnumConfig{
+		Name: "FullTextTokenizer",
+		Values: gql.EnumValueConfigMap{
+			string(client.FullTextWordTokenizer): &gql.EnumValueConfig{
+				Description: "Splits the text at every character that is not a letter or a digit.",
+				Value:       string(client.FullTextWordTokenizer),
+			},
+			string(client.FullTextWhitespaceTokenizer): &gql.EnumValueConfig{
+				Description: "Splits the text at whitespace only.",
+				Value:       string(client.FullTextWhitespaceTokenizer),
+			},
+		},
+	})
+
+	// FullTextIndexOptionsInput holds the options of a full-text index.
+	FullTextIndexOptionsInput = gql.NewInputObject(gql.InputObjectConfig{
+		Name: "FullTextIndexOptions",
+		Fields: gql.InputObjectConfigFieldMap{
+			FullTextIndexPropTokenizer: &gql.InputObjectFieldConfig{
+				Type: FullTextTokenizerEnum,
+			},
+			FullTextIndexPropCaseSensitive: &gql.InputObjectFieldConfig{
+				Type: gql.Boolean,
+			},
+			FullTextIndexPropStem: &gql.InputObjectFieldConfig{
+				Type: gql.Boolean,
+			},
+		},
+	})
+
 	IndexDirective *gql.Directive = gql.NewDirective(gql.DirectiveConfig{
 		Name:        IndexDirectiveLabel,
 		Description: "@index is a directive that can be used to create an index on a type.",
@@ -97,6 +168,18 @@ var (
 			IndexDirectivePropDirections: &gql.ArgumentConfig{
 				Type: gql.NewList(OrderingEnum),
 			},
+			IndexDirectivePropUnique: &gql.ArgumentConfig{
+				Type: gql.Boolean,
+			},
+			IndexDirectivePropWhere: &gql.ArgumentConfig{
+				Type: IndexFilterScalarType,
+			},
+			IndexDirectivePropFullText: &gql.ArgumentConfig{
+				Type: FullTextIndexOptionsInput,
+			},
+			IndexDirectivePropCollations: &gql.ArgumentConfig{
+				Type: gql.NewList(IndexCollationEnum),
+			},
 		},
 		Locations: []string{
 			gql.DirectiveLocationObject,
@@ -110,6 +193,21 @@ var (
 			IndexDirectivePropName: &gql.ArgumentConfig{
 				Type: gql.String,
 			},
+			IndexDirectivePropUnique: &gql.ArgumentConfig{
+				Type: gql.Boolean,
+			},
+			IndexDirectivePropWhere: &gql.ArgumentConfig{
+				Type: IndexFilterScalarType,
+			},
+			IndexDirectivePropFullText: &gql.ArgumentConfig{
+				Type: FullTextIndexOptionsInput,
+			},
+			IndexDirectivePropCollation: &gql.ArgumentConfig{
+				Type: IndexCollationEnum,
+			},
+			IndexDirectivePropPath: &gql.ArgumentConfig{
+				Type: gql.NewList(gql.NewNonNull(gql.String)),
+			},
 		},
 		Locations: []string{
 			gql.DirectiveLocationField,
@@ -126,6 +224,25 @@ var (
 		},
 	})
 
+	// RelationOnDeleteEnum is an enum for what happens to the documents referencing a deleted document.
+	RelationOnDeleteEnum = gql.NewEnum(gql.EnumConfig{
+		Name: "RelationOnDelete",
+		Values: gql.EnumValueConfigMap{
+			string(client.OnDeleteCascade): &gql.EnumValueConfig{
+				Description: "Deletes the referencing documents along with the deleted document.",
+				Value:       string(client.OnDeleteCascade),
+			},
+			string(client.OnDeleteSetNull): &gql.EnumValueConfig{
+				Description: "Clears the reference of the referencing documents to the deleted document.",
+				Value:       string(client.OnDeleteSetNull),
+			},
+			string(client.OnDeleteRestrict): &gql.EnumValueConfig{
+				Description: "Prevents the deletion of a document while it is referenced.",
+				Value:       string(client.OnDeleteRestrict),
+			},
+		},
+	})
+
 	// RelationDirective @relation is used to explicitly define
 	// the attributes of a relationship, specifically, the name
 	// if you don't want to use the default generated relationship
@@ -138,9 +255,30 @@ var (
 				Description: relationDirectiveNameArgDescription,
 				Type:        gql.String,
 			},
+			RelationDirectivePropOnDelete: &gql.ArgumentConfig{
+				Description: relationDirectiveOnDeleteArgDescription,
+				Type:        RelationOnDeleteEnum,
+			},
+		},
+		Locations: []string{
+			gql.DirectiveLocationFieldDefinition,
+		},
+	})
+
+	// CRDTDirective @crdt is used to set the CRDT type of a field
+	// instead of the default one of its kind.
+	CRDTDirective = gql.NewDirective(gql.DirectiveConfig{
+		Name:        CRDTLabel,
+		Description: crdtDirectiveDescription,
+		Args: gql.FieldConfigArgument{
+			CRDTDirectivePropType: &gql.ArgumentConfig{
+				Description: crdtDirectiveTypeArgDescription,
+				Type:        gql.String,
+			},
 		},
 		Locations: []string{
 			gql.DirectiveLocationFieldDefinition,
+			gql.DirectiveLocationObject,
 		},
 	})
 )
@@ -73,6 +73,13 @@ func (w *Wrapper) PeerInfo() peer.AddrInfo {
 func (w *Wrapper) SetReplicator(ctx context.Context, rep client.Replicator) error {
 	args := []string{"client", "p2p", "replicator", "set"}
 	args = append(args, "--collection", strings.Join(rep.Schemas, ","))
+	if len(rep.Filter) > 0 {
+		filter, err := json.Marshal(rep.Filter)
+		if err != nil {
+			return err
+		}
+		args = append(args, "--filter", string(filter))
+	}
 
 	info, err := json.Marshal(rep.Info)
 	if err != nil {
@@ -120,6 +127,20 @@ func (w *Wrapper) AddP2PCollections(ctx context.Context, collectionIDs []string)
 	return err
 }
 
+func (w *Wrapper) SetP2PCollectionFilter(ctx context.Context, collectionID string, filter map[string]any) error {
+	args := []string{"client", "p2p", "collection", "filter", collectionID}
+	if len(filter) > 0 {
+		data, err := json.Marshal(filter)
+		if err != nil {
+			return err
+		}
+		args = append(args, string(data))
+	}
+
+	_, err := w.cmd.execute(ctx, args)
+	return err
+}
+
 func (w *Wrapper) RemoveP2PCollections(ctx context.Context, collectionIDs []string) error {
 	args := []string{"client", "p2p", "collection", "remove"}
 	args = append(args, strings.Join(collectionIDs, ","))
@@ -370,9 +370,40 @@ func (c *Collection) CreateIndex(
 
 	fields := make([]string, len(indexDesc.Fields))
 	for i := range indexDesc.Fields {
-		fields[i] = indexDesc.Fields[i].Name
+		fields[i] = strings.Join(append([]string{indexDesc.Fields[i].Name}, indexDesc.Fields[i].Path...), ".")
+		if indexDesc.Fields[i].Direction != "" {
+			fields[i] += ":" + string(indexDesc.Fields[i].Direction)
+		}
+		if indexDesc.Fields[i].Collation != "" {
+			fields[i] += ":" + string(indexDesc.Fields[i].Collation)
+		}
 	}
 	args = append(args, "--fields", strings.Join(fields, ","))
+	if indexDesc.Unique {
+		args = append(args, "--unique")
+	}
+	if indexDesc.Background {
+		args = append(args, "--background")
+	}
+	if len(indexDesc.Where) > 0 {
+		where, err := json.Marshal(indexDesc.Where)
+		if err != nil {
+			return index, err
+		}
+		args = append(args, "--where", string(where))
+	}
+	if indexDesc.FullText != nil {
+		args = append(args, "--full-text")
+		if indexDesc.FullText.Tokenizer != "" {
+			args = append(args, "--tokenizer", string(indexDesc.FullText.Tokenizer))
+		}
+		if indexDesc.FullText.CaseSensitive {
+			args = append(args, "--case-sensitive")
+		}
+		if indexDesc.FullText.Stem {
+			args = append(args, "--stem")
+		}
+	}
 
 	data, err := c.cmd.execute(ctx, args)
 	if err != nil {
@@ -407,3 +438,34 @@ func (c *Collection) GetIndexes(ctx context.Context) ([]client.IndexDescription,
 	}
 	return indexes, nil
 }
+
+func (c *Collection) VerifyIndex(ctx context.Context, indexName string) (client.IndexVerification, error) {
+	return c.verifyIndex(ctx, indexName, false)
+}
+
+func (c *Collection) RebuildIndex(ctx context.Context, indexName string) (client.IndexVerification, error) {
+	return c.verifyIndex(ctx, indexName, true)
+}
+
+func (c *Collection) verifyIndex(
+	ctx context.Context,
+	indexName string,
+	repair bool,
+) (client.IndexVerification, error) {
+	args := []string{"client", "index", "verify"}
+	args = append(args, "--collection", c.Description().Name)
+	args = append(args, "--name", indexName)
+	if repair {
+		args = append(args, "--repair")
+	}
+
+	data, err := c.cmd.execute(ctx, args)
+	if err != nil {
+		return client.IndexVerification{}, err
+	}
+	var result client.IndexVerification
+	if err := json.Unmarshal(data, &result); err != nil {
+		return client.IndexVerification{}, err
+	}
+	return result, nil
+}
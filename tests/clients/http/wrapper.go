@@ -75,6 +75,10 @@ func (w *Wrapper) AddP2PCollections(ctx context.Context, collectionIDs []string)
 	return w.client.AddP2PCollections(ctx, collectionIDs)
 }
 
+func (w *Wrapper) SetP2PCollectionFilter(ctx context.Context, collectionID string, filter map[string]any) error {
+	return w.client.SetP2PCollectionFilter(ctx, collectionID, filter)
+}
+
 func (w *Wrapper) RemoveP2PCollections(ctx context.Context, collectionIDs []string) error {
 	return w.client.RemoveP2PCollections(ctx, collectionIDs)
 }
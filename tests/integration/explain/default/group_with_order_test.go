@@ -70,6 +70,7 @@ func TestDefaultExplainRequestWithDescendingOrderOnParentGroupBy(t *testing.T) {
 						TargetNodeName:    "orderNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"index": nil,
 							"orderings": []dataMap{
 								{
 									"direction": "DESC",
@@ -125,6 +126,7 @@ func TestDefaultExplainRequestWithAscendingOrderOnParentGroupBy(t *testing.T) {
 						TargetNodeName:    "orderNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"index": nil,
 							"orderings": []dataMap{
 								{
 									"direction": "ASC",
@@ -192,6 +194,7 @@ func TestDefaultExplainRequestWithOrderOnParentGroupByAndOnInnerGroupSelection(t
 						TargetNodeName:    "orderNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"index": nil,
 							"orderings": []dataMap{
 								{
 									"direction": "DESC",
@@ -68,6 +68,7 @@ func TestDefaultExplainRequestWithOrderFieldOnRelatedChild(t *testing.T) {
 						TargetNodeName:    "orderNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"index": nil,
 							"orderings": []dataMap{
 								{
 									"direction": "DESC",
@@ -125,6 +126,7 @@ func TestDefaultExplainRequestWithOrderFieldOnParentAndRelatedChild(t *testing.T
 						OccurancesToSkip:  0,
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"index": nil,
 							"orderings": []dataMap{
 								{
 									"direction": "ASC",
@@ -140,6 +142,7 @@ func TestDefaultExplainRequestWithOrderFieldOnParentAndRelatedChild(t *testing.T
 						OccurancesToSkip:  1,
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"index": nil,
 							"orderings": []dataMap{
 								{
 									"direction": "DESC",
@@ -53,6 +53,7 @@ func TestDefaultExplainRequestWithAscendingOrderOnParent(t *testing.T) {
 						TargetNodeName:    "orderNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"index": nil,
 							"orderings": []dataMap{
 								{
 									"direction": "ASC",
@@ -95,6 +96,7 @@ func TestDefaultExplainRequestWithMultiOrderFieldsOnParent(t *testing.T) {
 						TargetNodeName:    "orderNode",
 						IncludeChildNodes: false,
 						ExpectedAttributes: dataMap{
+							"index": nil,
 							"orderings": []dataMap{
 								{
 									"direction": "ASC",
@@ -45,10 +45,11 @@ func TestExecuteExplainMutationRequestWithCreate(t *testing.T) {
 										"iterations":    uint64(1),
 										"filterMatches": uint64(1),
 										"scanNode": dataMap{
-											"iterations":   uint64(1),
-											"docFetches":   uint64(1),
-											"fieldFetches": uint64(1),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(1),
+											"docFetches":        uint64(1),
+											"fieldFetches":      uint64(1),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 									},
 								},
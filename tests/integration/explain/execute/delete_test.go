@@ -48,10 +48,11 @@ func TestExecuteExplainMutationRequestWithDeleteUsingID(t *testing.T) {
 										"iterations":    uint64(2),
 										"filterMatches": uint64(1),
 										"scanNode": dataMap{
-											"iterations":   uint64(2),
-											"docFetches":   uint64(1),
-											"fieldFetches": uint64(1),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(2),
+											"docFetches":        uint64(1),
+											"fieldFetches":      uint64(1),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 									},
 								},
@@ -97,10 +98,11 @@ func TestExecuteExplainMutationRequestWithDeleteUsingFilter(t *testing.T) {
 										"iterations":    uint64(2),
 										"filterMatches": uint64(1),
 										"scanNode": dataMap{
-											"iterations":   uint64(2),
-											"docFetches":   uint64(2),
-											"fieldFetches": uint64(2),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(2),
+											"docFetches":        uint64(2),
+											"fieldFetches":      uint64(2),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 									},
 								},
@@ -56,10 +56,11 @@ func TestExecuteExplainRequestWithGroup(t *testing.T) {
 										"iterations":    uint64(3),
 										"filterMatches": uint64(2),
 										"scanNode": dataMap{
-											"iterations":   uint64(4),
-											"docFetches":   uint64(2),
-											"fieldFetches": uint64(4),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(4),
+											"docFetches":        uint64(2),
+											"fieldFetches":      uint64(4),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 									},
 								},
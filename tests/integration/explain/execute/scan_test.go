@@ -64,10 +64,11 @@ func TestExecuteExplainRequestWithAllDocumentsMatching(t *testing.T) {
 									"iterations":    uint64(3),
 									"filterMatches": uint64(2),
 									"scanNode": dataMap{
-										"iterations":   uint64(3),
-										"docFetches":   uint64(2),
-										"fieldFetches": uint64(4),
-										"indexFetches": uint64(0),
+										"iterations":        uint64(3),
+										"docFetches":        uint64(2),
+										"fieldFetches":      uint64(4),
+										"indexFetches":      uint64(0),
+										"coveredDocFetches": uint64(0),
 									},
 								},
 							},
@@ -107,10 +108,11 @@ func TestExecuteExplainRequestWithNoDocuments(t *testing.T) {
 									"iterations":    uint64(1),
 									"filterMatches": uint64(0),
 									"scanNode": dataMap{
-										"iterations":   uint64(1),
-										"docFetches":   uint64(0),
-										"fieldFetches": uint64(0),
-										"indexFetches": uint64(0),
+										"iterations":        uint64(1),
+										"docFetches":        uint64(0),
+										"fieldFetches":      uint64(0),
+										"indexFetches":      uint64(0),
+										"coveredDocFetches": uint64(0),
 									},
 								},
 							},
@@ -171,10 +173,11 @@ func TestExecuteExplainRequestWithSomeDocumentsMatching(t *testing.T) {
 									"iterations":    uint64(2),
 									"filterMatches": uint64(1),
 									"scanNode": dataMap{
-										"iterations":   uint64(2),
-										"docFetches":   uint64(2),
-										"fieldFetches": uint64(4),
-										"indexFetches": uint64(0),
+										"iterations":        uint64(2),
+										"docFetches":        uint64(2),
+										"fieldFetches":      uint64(4),
+										"indexFetches":      uint64(0),
+										"coveredDocFetches": uint64(0),
 									},
 								},
 							},
@@ -235,10 +238,11 @@ func TestExecuteExplainRequestWithDocumentsButNoMatches(t *testing.T) {
 									"iterations":    uint64(1),
 									"filterMatches": uint64(0),
 									"scanNode": dataMap{
-										"iterations":   uint64(1),
-										"docFetches":   uint64(2),
-										"fieldFetches": uint64(4),
-										"indexFetches": uint64(0),
+										"iterations":        uint64(1),
+										"docFetches":        uint64(2),
+										"fieldFetches":      uint64(4),
+										"indexFetches":      uint64(0),
+										"coveredDocFetches": uint64(0),
 									},
 								},
 							},
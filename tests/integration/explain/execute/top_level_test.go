@@ -67,10 +67,11 @@ func TestExecuteExplainTopLevelAverageRequest(t *testing.T) {
 											"iterations":    uint64(3),
 											"filterMatches": uint64(2),
 											"scanNode": dataMap{
-												"iterations":   uint64(3),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(2),
-												"indexFetches": uint64(0),
+												"iterations":        uint64(3),
+												"docFetches":        uint64(2),
+												"fieldFetches":      uint64(2),
+												"indexFetches":      uint64(0),
+												"coveredDocFetches": uint64(0),
 											},
 										},
 									},
@@ -151,10 +152,11 @@ func TestExecuteExplainTopLevelCountRequest(t *testing.T) {
 											"iterations":    uint64(3),
 											"filterMatches": uint64(2),
 											"scanNode": dataMap{
-												"iterations":   uint64(3),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(4),
-												"indexFetches": uint64(0),
+												"iterations":        uint64(3),
+												"docFetches":        uint64(2),
+												"fieldFetches":      uint64(4),
+												"indexFetches":      uint64(0),
+												"coveredDocFetches": uint64(0),
 											},
 										},
 									},
@@ -226,10 +228,11 @@ func TestExecuteExplainTopLevelSumRequest(t *testing.T) {
 											"iterations":    uint64(3),
 											"filterMatches": uint64(2),
 											"scanNode": dataMap{
-												"iterations":   uint64(3),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(2),
-												"indexFetches": uint64(0),
+												"iterations":        uint64(3),
+												"docFetches":        uint64(2),
+												"fieldFetches":      uint64(2),
+												"indexFetches":      uint64(0),
+												"coveredDocFetches": uint64(0),
 											},
 										},
 									},
@@ -53,16 +53,18 @@ func TestExecuteExplainRequestWithAOneToOneJoin(t *testing.T) {
 									"typeIndexJoin": dataMap{
 										"iterations": uint64(3),
 										"scanNode": dataMap{
-											"iterations":   uint64(3),
-											"docFetches":   uint64(2),
-											"fieldFetches": uint64(2),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(3),
+											"docFetches":        uint64(2),
+											"fieldFetches":      uint64(2),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 										"subTypeScanNode": dataMap{
-											"iterations":   uint64(2),
-											"docFetches":   uint64(2),
-											"fieldFetches": uint64(2),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(2),
+											"docFetches":        uint64(2),
+											"fieldFetches":      uint64(2),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 									},
 								},
@@ -119,16 +121,18 @@ func TestExecuteExplainWithMultipleOneToOneJoins(t *testing.T) {
 											"typeIndexJoin": dataMap{
 												"iterations": uint64(3),
 												"scanNode": dataMap{
-													"iterations":   uint64(3),
-													"docFetches":   uint64(2),
-													"fieldFetches": uint64(2),
-													"indexFetches": uint64(0),
+													"iterations":        uint64(3),
+													"docFetches":        uint64(2),
+													"fieldFetches":      uint64(2),
+													"indexFetches":      uint64(0),
+													"coveredDocFetches": uint64(0),
 												},
 												"subTypeScanNode": dataMap{
-													"iterations":   uint64(2),
-													"docFetches":   uint64(2),
-													"fieldFetches": uint64(2),
-													"indexFetches": uint64(0),
+													"iterations":        uint64(2),
+													"docFetches":        uint64(2),
+													"fieldFetches":      uint64(2),
+													"indexFetches":      uint64(0),
+													"coveredDocFetches": uint64(0),
 												},
 											},
 										},
@@ -136,16 +140,18 @@ func TestExecuteExplainWithMultipleOneToOneJoins(t *testing.T) {
 											"typeIndexJoin": dataMap{
 												"iterations": uint64(3),
 												"scanNode": dataMap{
-													"iterations":   uint64(3),
-													"docFetches":   uint64(2),
-													"fieldFetches": uint64(2),
-													"indexFetches": uint64(0),
+													"iterations":        uint64(3),
+													"docFetches":        uint64(2),
+													"fieldFetches":      uint64(2),
+													"indexFetches":      uint64(0),
+													"coveredDocFetches": uint64(0),
 												},
 												"subTypeScanNode": dataMap{
-													"iterations":   uint64(2),
-													"docFetches":   uint64(2),
-													"fieldFetches": uint64(4),
-													"indexFetches": uint64(0),
+													"iterations":        uint64(2),
+													"docFetches":        uint64(2),
+													"fieldFetches":      uint64(4),
+													"indexFetches":      uint64(0),
+													"coveredDocFetches": uint64(0),
 												},
 											},
 										},
@@ -205,16 +211,18 @@ func TestExecuteExplainWithTwoLevelDeepNestedJoins(t *testing.T) {
 									"typeIndexJoin": dataMap{
 										"iterations": uint64(3),
 										"scanNode": dataMap{
-											"iterations":   uint64(3),
-											"docFetches":   uint64(2),
-											"fieldFetches": uint64(4),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(3),
+											"docFetches":        uint64(2),
+											"fieldFetches":      uint64(4),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 										"subTypeScanNode": dataMap{
-											"iterations":   uint64(2),
-											"docFetches":   uint64(2),
-											"fieldFetches": uint64(4),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(2),
+											"docFetches":        uint64(2),
+											"fieldFetches":      uint64(4),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 									},
 								},
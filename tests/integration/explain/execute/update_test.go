@@ -56,10 +56,11 @@ func TestExecuteExplainMutationRequestWithUpdateUsingIDs(t *testing.T) {
 										"iterations":    uint64(6),
 										"filterMatches": uint64(4),
 										"scanNode": dataMap{
-											"iterations":   uint64(6),
-											"docFetches":   uint64(4),
-											"fieldFetches": uint64(8),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(6),
+											"docFetches":        uint64(4),
+											"fieldFetches":      uint64(8),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 									},
 								},
@@ -114,10 +115,11 @@ func TestExecuteExplainMutationRequestWithUpdateUsingFilter(t *testing.T) {
 										"iterations":    uint64(4),
 										"filterMatches": uint64(2),
 										"scanNode": dataMap{
-											"iterations":   uint64(4),
-											"docFetches":   uint64(4),
-											"fieldFetches": uint64(6),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(4),
+											"docFetches":        uint64(4),
+											"fieldFetches":      uint64(6),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 									},
 								},
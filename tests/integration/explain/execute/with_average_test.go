@@ -53,10 +53,11 @@ func TestExecuteExplainAverageRequestOnArrayField(t *testing.T) {
 												"iterations":    uint64(4),
 												"filterMatches": uint64(3),
 												"scanNode": dataMap{
-													"iterations":   uint64(4),
-													"docFetches":   uint64(3),
-													"fieldFetches": uint64(5),
-													"indexFetches": uint64(0),
+													"iterations":        uint64(4),
+													"docFetches":        uint64(3),
+													"fieldFetches":      uint64(5),
+													"indexFetches":      uint64(0),
+													"coveredDocFetches": uint64(0),
 												},
 											},
 										},
@@ -114,16 +115,18 @@ func TestExplainExplainAverageRequestOnJoinedField(t *testing.T) {
 												"typeIndexJoin": dataMap{
 													"iterations": uint64(3),
 													"scanNode": dataMap{
-														"iterations":   uint64(3),
-														"docFetches":   uint64(2),
-														"fieldFetches": uint64(2),
-														"indexFetches": uint64(0),
+														"iterations":        uint64(3),
+														"docFetches":        uint64(2),
+														"fieldFetches":      uint64(2),
+														"indexFetches":      uint64(0),
+														"coveredDocFetches": uint64(0),
 													},
 													"subTypeScanNode": dataMap{
-														"iterations":   uint64(5),
-														"docFetches":   uint64(6),
-														"fieldFetches": uint64(12),
-														"indexFetches": uint64(0),
+														"iterations":        uint64(5),
+														"docFetches":        uint64(6),
+														"fieldFetches":      uint64(12),
+														"indexFetches":      uint64(0),
+														"coveredDocFetches": uint64(0),
 													},
 												},
 											},
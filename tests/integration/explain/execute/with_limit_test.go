@@ -48,10 +48,11 @@ func TestExecuteExplainRequestWithBothLimitAndOffsetOnParent(t *testing.T) {
 										"iterations":    uint64(2),
 										"filterMatches": uint64(2),
 										"scanNode": dataMap{
-											"iterations":   uint64(2),
-											"docFetches":   uint64(2),
-											"fieldFetches": uint64(2),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(2),
+											"docFetches":        uint64(2),
+											"fieldFetches":      uint64(2),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 									},
 								},
@@ -105,16 +106,18 @@ func TestExecuteExplainRequestWithBothLimitAndOffsetOnParentAndLimitOnChild(t *t
 										"typeIndexJoin": dataMap{
 											"iterations": uint64(2),
 											"scanNode": dataMap{
-												"iterations":   uint64(2),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(2),
-												"indexFetches": uint64(0),
+												"iterations":        uint64(2),
+												"docFetches":        uint64(2),
+												"fieldFetches":      uint64(2),
+												"indexFetches":      uint64(0),
+												"coveredDocFetches": uint64(0),
 											},
 											"subTypeScanNode": dataMap{
-												"iterations":   uint64(2),
-												"docFetches":   uint64(4),
-												"fieldFetches": uint64(6),
-												"indexFetches": uint64(0),
+												"iterations":        uint64(2),
+												"docFetches":        uint64(4),
+												"fieldFetches":      uint64(6),
+												"indexFetches":      uint64(0),
+												"coveredDocFetches": uint64(0),
 											},
 										},
 									},
@@ -49,10 +49,11 @@ func TestExecuteExplainRequestWithOrderFieldOnParent(t *testing.T) {
 										"filterMatches": uint64(2),
 										"iterations":    uint64(3),
 										"scanNode": dataMap{
-											"iterations":   uint64(3),
-											"docFetches":   uint64(2),
-											"fieldFetches": uint64(4),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(3),
+											"docFetches":        uint64(2),
+											"fieldFetches":      uint64(4),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 									},
 								},
@@ -133,10 +134,11 @@ func TestExecuteExplainRequestWithMultiOrderFieldsOnParent(t *testing.T) {
 										"filterMatches": uint64(4),
 										"iterations":    uint64(5),
 										"scanNode": dataMap{
-											"iterations":   uint64(5),
-											"docFetches":   uint64(4),
-											"fieldFetches": uint64(8),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(5),
+											"docFetches":        uint64(4),
+											"fieldFetches":      uint64(8),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 									},
 								},
@@ -188,16 +190,18 @@ func TestExecuteExplainRequestWithOrderFieldOnChild(t *testing.T) {
 									"typeIndexJoin": dataMap{
 										"iterations": uint64(3),
 										"scanNode": dataMap{
-											"iterations":   uint64(3),
-											"docFetches":   uint64(2),
-											"fieldFetches": uint64(2),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(3),
+											"docFetches":        uint64(2),
+											"fieldFetches":      uint64(2),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 										"subTypeScanNode": dataMap{
-											"iterations":   uint64(5),
-											"docFetches":   uint64(6),
-											"fieldFetches": uint64(9),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(5),
+											"docFetches":        uint64(6),
+											"fieldFetches":      uint64(9),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 									},
 								},
@@ -252,16 +256,18 @@ func TestExecuteExplainRequestWithOrderFieldOnBothParentAndChild(t *testing.T) {
 										"typeIndexJoin": dataMap{
 											"iterations": uint64(3),
 											"scanNode": dataMap{
-												"iterations":   uint64(3),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(4),
-												"indexFetches": uint64(0),
+												"iterations":        uint64(3),
+												"docFetches":        uint64(2),
+												"fieldFetches":      uint64(4),
+												"indexFetches":      uint64(0),
+												"coveredDocFetches": uint64(0),
 											},
 											"subTypeScanNode": dataMap{
-												"iterations":   uint64(5),
-												"docFetches":   uint64(6),
-												"fieldFetches": uint64(9),
-												"indexFetches": uint64(0),
+												"iterations":        uint64(5),
+												"docFetches":        uint64(6),
+												"fieldFetches":      uint64(9),
+												"indexFetches":      uint64(0),
+												"coveredDocFetches": uint64(0),
 											},
 										},
 									},
@@ -49,10 +49,11 @@ func TestExecuteExplainRequestWithSumOfInlineArrayField(t *testing.T) {
 										"iterations":    uint64(4),
 										"filterMatches": uint64(3),
 										"scanNode": dataMap{
-											"iterations":   uint64(4),
-											"docFetches":   uint64(3),
-											"fieldFetches": uint64(5),
-											"indexFetches": uint64(0),
+											"iterations":        uint64(4),
+											"docFetches":        uint64(3),
+											"fieldFetches":      uint64(5),
+											"indexFetches":      uint64(0),
+											"coveredDocFetches": uint64(0),
 										},
 									},
 								},
@@ -108,16 +109,18 @@ func TestExecuteExplainRequestSumOfRelatedOneToManyField(t *testing.T) {
 										"typeIndexJoin": dataMap{
 											"iterations": uint64(3),
 											"scanNode": dataMap{
-												"iterations":   uint64(3),
-												"docFetches":   uint64(2),
-												"fieldFetches": uint64(2),
-												"indexFetches": uint64(0),
+												"iterations":        uint64(3),
+												"docFetches":        uint64(2),
+												"fieldFetches":      uint64(2),
+												"indexFetches":      uint64(0),
+												"coveredDocFetches": uint64(0),
 											},
 											"subTypeScanNode": dataMap{
-												"iterations":   uint64(5),
-												"docFetches":   uint64(6),
-												"fieldFetches": uint64(9),
-												"indexFetches": uint64(0),
+												"iterations":        uint64(5),
+												"docFetches":        uint64(6),
+												"fieldFetches":      uint64(9),
+												"indexFetches":      uint64(0),
+												"coveredDocFetches": uint64(0),
 											},
 										},
 									},
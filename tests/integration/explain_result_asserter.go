@@ -21,10 +21,11 @@ import (
 )
 
 const (
-	iterationsProp   = "iterations"
-	docFetchesProp   = "docFetches"
-	fieldFetchesProp = "fieldFetches"
-	indexFetchesProp = "indexFetches"
+	iterationsProp        = "iterations"
+	docFetchesProp        = "docFetches"
+	fieldFetchesProp      = "fieldFetches"
+	indexFetchesProp      = "indexFetches"
+	coveredDocFetchesProp = "coveredDocFetches"
 )
 
 type dataMap = map[string]any
@@ -32,13 +33,14 @@ type dataMap = map[string]any
 // ExplainResultAsserter is a helper for asserting the result of an explain query.
 // It allows asserting on a selected set of properties.
 type ExplainResultAsserter struct {
-	iterations     immutable.Option[int]
-	docFetches     immutable.Option[int]
-	fieldFetches   immutable.Option[int]
-	indexFetches   immutable.Option[int]
-	filterMatches  immutable.Option[int]
-	sizeOfResults  immutable.Option[int]
-	planExecutions immutable.Option[uint64]
+	iterations        immutable.Option[int]
+	docFetches        immutable.Option[int]
+	fieldFetches      immutable.Option[int]
+	indexFetches      immutable.Option[int]
+	coveredDocFetches immutable.Option[int]
+	filterMatches     immutable.Option[int]
+	sizeOfResults     immutable.Option[int]
+	planExecutions    immutable.Option[uint64]
 }
 
 func readNumberProp(t *testing.T, val any, prop string) uint64 {
@@ -55,6 +57,18 @@ func readNumberProp(t *testing.T, val any, prop string) uint64 {
 	return 0
 }
 
+// findSelectNode returns the selectNode of the given selectTopNode skipping the
+// limit and order nodes that might be placed in between.
+func findSelectNode(node dataMap) (dataMap, bool) {
+	for _, name := range []string{"limitNode", "orderNode"} {
+		if child, ok := node[name].(dataMap); ok {
+			node = child
+		}
+	}
+	selectNode, ok := node["selectNode"].(dataMap)
+	return selectNode, ok
+}
+
 func (a *ExplainResultAsserter) Assert(t *testing.T, result []dataMap) {
 	require.Len(t, result, 1, "Expected len(result) = 1, got %d", len(result))
 	explainNode, ok := result[0]["explain"].(dataMap)
@@ -72,14 +86,15 @@ func (a *ExplainResultAsserter) Assert(t *testing.T, result []dataMap) {
 	}
 	selectTopNode, ok := explainNode["selectTopNode"].(dataMap)
 	require.True(t, ok, "Expected selectTopNode")
-	selectNode, ok := selectTopNode["selectNode"].(dataMap)
+	selectNode, ok := findSelectNode(selectTopNode)
 	require.True(t, ok, "Expected selectNode")
 
 	if a.filterMatches.HasValue() {
 		filterMatches, hasFilterMatches := selectNode["filterMatches"]
 		require.True(t, hasFilterMatches, "Expected filterMatches property")
-		assert.Equal(t, filterMatches, uint64(a.filterMatches.Value()),
-			"Expected %d filterMatches, got %d", a.filterMatches, filterMatches)
+		actual := readNumberProp(t, filterMatches, "filterMatches")
+		assert.Equal(t, actual, uint64(a.filterMatches.Value()),
+			"Expected %d filterMatches, got %d", a.filterMatches.Value(), actual)
 	}
 
 	scanNode, ok := selectNode["scanNode"].(dataMap)
@@ -120,6 +135,11 @@ func (a *ExplainResultAsserter) Assert(t *testing.T, result []dataMap) {
 		assert.Equal(t, actual, uint64(a.indexFetches.Value()),
 			"Expected %d indexFetches, got %d", a.indexFetches.Value(), actual)
 	}
+	if a.coveredDocFetches.HasValue() {
+		actual := getScanNodesProp(coveredDocFetchesProp)
+		assert.Equal(t, actual, uint64(a.coveredDocFetches.Value()),
+			"Expected %d coveredDocFetches, got %d", a.coveredDocFetches.Value(), actual)
+	}
 }
 
 func (a *ExplainResultAsserter) WithIterations(iterations int) *ExplainResultAsserter {
@@ -142,6 +162,11 @@ func (a *ExplainResultAsserter) WithIndexFetches(indexFetches int) *ExplainResul
 	return a
 }
 
+func (a *ExplainResultAsserter) WithCoveredDocFetches(coveredDocFetches int) *ExplainResultAsserter {
+	a.coveredDocFetches = immutable.Some[int](coveredDocFetches)
+	return a
+}
+
 func (a *ExplainResultAsserter) WithFilterMatches(filterMatches int) *ExplainResultAsserter {
 	a.filterMatches = immutable.Some[int](filterMatches)
 	return a
@@ -0,0 +1,76 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestIndexCreateInBackground_ShouldNotChangeQueryResults(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Creating an index in the background should not change the query results",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `
+					{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `
+					{
+						"name":	"Islam",
+						"age":	18
+					}`,
+			},
+			testUtils.CreateIndex{
+				CollectionID: 0,
+				FieldName:    "name",
+				Background:   true,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `
+					{
+						"name":	"Andy",
+						"age":	33
+					}`,
+			},
+			testUtils.Request{
+				Request: `
+					query {
+						User(filter: {name: {_in: ["Andy", "John"]}}, order: {age: ASC}) {
+							age
+						}
+					}`,
+				Results: []map[string]any{
+					{"age": int64(21)},
+					{"age": int64(33)},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,247 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func createNotesWithTags() []any {
+	return []any{
+		testUtils.CreateDoc{
+			Doc: `{
+				"title": "Review",
+				"tags": ["urgent", "later"],
+				"points": [1, 5, 8]
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"title": "Release",
+				"tags": ["urgent"],
+				"points": [2]
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"title": "Plan",
+				"tags": ["later"],
+				"points": [3, 4]
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"title": "Backlog",
+				"tags": [],
+				"points": []
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"title": "Draft"
+			}`,
+		},
+	}
+}
+
+func TestQueryWithArrayIndex_WithAnyFilter_ShouldFetchDocsWithMatchingElement(t *testing.T) {
+	req := `query {
+		Note(filter: {tags: {_any: {_eq: "urgent"}}}) {
+			title
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index on array field with _any filter",
+		Actions: append([]any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Note {
+						title: String
+						tags: [String!] @index
+						points: [Int!]
+					}`,
+			},
+		}, append(createNotesWithTags(),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"title": "Release"},
+					{"title": "Review"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithIndexFetches(2),
+			},
+		)...),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithArrayIndex_WithAllFilter_ShouldFetchDocsWithAllElementsMatching(t *testing.T) {
+	req := `query {
+		Note(filter: {tags: {_all: {_eq: "urgent"}}}) {
+			title
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index on array field with _all filter",
+		Actions: append([]any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Note {
+						title: String
+						tags: [String!] @index
+						points: [Int!]
+					}`,
+			},
+		}, append(createNotesWithTags(),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"title": "Release"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithIndexFetches(2),
+			},
+		)...),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithArrayIndex_IfSeveralElementsMatch_ShouldReturnDocOnce(t *testing.T) {
+	req := `query {
+		Note(filter: {points: {_any: {_gt: 2}}}) {
+			title
+			points
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index on array field returns a document once if several of its elements match",
+		Actions: append([]any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Note {
+						title: String
+						tags: [String!]
+						points: [Int!] @index
+					}`,
+			},
+		}, append(createNotesWithTags(),
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"title": "Plan", "points": []int64{3, 4}},
+					{"title": "Review", "points": []int64{1, 5, 8}},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithIndexFetches(4),
+			},
+		)...),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithArrayIndex_AfterUpdate_ShouldFetchByNewElements(t *testing.T) {
+	req := `query {
+		Note(filter: {tags: {_any: {_in: ["urgent", "blocked"]}}}) {
+			title
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index on array field is updated with the document",
+		Actions: append([]any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Note {
+						title: String
+						tags: [String!] @index
+						points: [Int!]
+					}`,
+			},
+		}, append(createNotesWithTags(),
+			testUtils.UpdateDoc{
+				DocID: 0,
+				Doc: `{
+					"tags": ["later"]
+				}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 2,
+				Doc: `{
+					"tags": ["later", "blocked"]
+				}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"title": "Plan"},
+					{"title": "Release"},
+				},
+			},
+		)...),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithArrayIndex_OnNillableArray_ShouldFetchDocsWithMatchingElement(t *testing.T) {
+	req := `query {
+		Note(filter: {labels: {_any: {_like: "b%"}}}) {
+			title
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index on nillable array field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Note {
+						title: String
+						labels: [String] @index
+					}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "Review",
+					"labels": [null, "bug"]
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "Release",
+					"labels": ["feature", null]
+				}`,
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"title": "Review"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(1).WithIndexFetches(4),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
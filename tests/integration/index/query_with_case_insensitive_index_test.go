@@ -0,0 +1,331 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/defradb/client"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func getCaseInsensitiveIndexUserDocs() []any {
+	return []any{
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"name":	"John",
+					"age":	21
+				}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"name":	"JOHN",
+					"age":	32
+				}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"name":	"Johnny",
+					"age":	33
+				}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"name":	"Andy",
+					"age":	40
+				}`,
+		},
+	}
+}
+
+func TestQueryWithCaseInsensitiveIndex_WithIEqFilter_ShouldUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_ieq: "john"}}) {
+			age
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type User {
+					name: String @index(collation: CASE_INSENSITIVE)
+					age: Int
+				}`,
+		},
+	}
+	actions = append(actions, getCaseInsensitiveIndexUserDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"age": 32},
+				{"age": 21},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithIndexFetches(2),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test an _ieq filter uses a case insensitive index",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCaseInsensitiveIndex_WithEqFilter_ShouldCheckActualValue(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "John"}}) {
+			age
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type User {
+					name: String @index(collation: CASE_INSENSITIVE)
+					age: Int
+				}`,
+		},
+	}
+	actions = append(actions, getCaseInsensitiveIndexUserDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"age": 21},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithIndexFetches(2),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test an _eq filter on a case insensitive index checks the actual value of the field",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCaseInsensitiveIndex_WithILikeFilter_ShouldUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_ilike: "JOHN%"}}) {
+			name
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type User {
+					name: String
+					age: Int
+				}`,
+		},
+	}
+	actions = append(actions, getCaseInsensitiveIndexUserDocs()...)
+	actions = append(actions,
+		testUtils.CreateIndex{
+			CollectionID: 0,
+			FieldName:    "name",
+			Collations:   []client.IndexCollation{client.CaseInsensitiveCollation},
+		},
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"name": "JOHN"},
+				{"name": "John"},
+				{"name": "Johnny"},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(3).WithIndexFetches(4),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test an _ilike filter uses a case insensitive index",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCaseInsensitiveIndex_WithNILikeFilter_ShouldUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_nilike: "john%"}}) {
+			name
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type User {
+					name: String @index(collation: CASE_INSENSITIVE)
+					age: Int
+				}`,
+		},
+	}
+	actions = append(actions, getCaseInsensitiveIndexUserDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"name": "Andy"},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(1).WithIndexFetches(4),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test an _nilike filter uses a case insensitive index",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithIEqFilter_ShouldUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_ieq: "john"}}) {
+			age
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type User {
+					name: String @index
+					age: Int
+				}`,
+		},
+	}
+	actions = append(actions, getCaseInsensitiveIndexUserDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"age": 32},
+				{"age": 21},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithIndexFetches(4),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test an _ieq filter uses a binary index by matching every indexed value",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithILikeFilter_ShouldUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_ilike: "%OHN"}}) {
+			age
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type User {
+					name: String @index
+					age: Int
+				}`,
+		},
+	}
+	actions = append(actions, getCaseInsensitiveIndexUserDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"age": 32},
+				{"age": 21},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithIndexFetches(4),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test an _ilike filter uses a binary index by matching every indexed value",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestCaseInsensitiveUniqueIndex_IfValueDiffersOnlyByCase_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test a unique case insensitive index rejects values that differ only by their case",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						email: String @index(unique: true, collation: CASE_INSENSITIVE)
+					}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `
+					{
+						"email":	"john@example.com"
+					}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID:  0,
+				Doc:           `{"email": "John@Example.com"}`,
+				ExpectedError: "can not index a doc's field(s) that violates unique index",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestCaseInsensitiveIndex_IfFieldIsNotString_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test a case insensitive index can't be created on a non string field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						age: Int
+					}`,
+			},
+			testUtils.CreateIndex{
+				CollectionID:  0,
+				FieldName:     "age",
+				Collations:    []client.IndexCollation{client.CaseInsensitiveCollation},
+				ExpectedError: "invalid index collation",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
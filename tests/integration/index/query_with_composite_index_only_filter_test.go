@@ -0,0 +1,232 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithCompositeIndex_WithEqualFilterOnFirstField_ShouldFetch(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "Islam"}}) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test composite index filtering with _eq filter on the first field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User @index(fields: ["name", "age"]) {
+						name: String
+						age: Int
+						email: String
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Islam", "age": int64(32)},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(1).WithFieldFetches(2).WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCompositeIndex_WithEqualAndGreaterThanFilter_ShouldFetch(t *testing.T) {
+	req := `query {
+		User(filter: {verified: {_eq: true}, age: {_gt: 40}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test composite index filtering with _eq filter on the first field and _gt on the second",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User @index(fields: ["verified", "age"]) {
+						name: String
+						age: Int
+						verified: Boolean
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Addo"},
+					{"name": "Roy"},
+					{"name": "Keenan"},
+					{"name": "Chris"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(4).WithFieldFetches(12).WithIndexFetches(4),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCompositeIndex_WithInFilterOnFirstField_ShouldFetchAllPrefixes(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_in: ["Islam", "Andy"]}, age: {_eq: 33}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test composite index filtering with _in filter on the first field and _eq on the second",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User @index(fields: ["name", "age"]) {
+						name: String
+						age: Int
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Andy"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(1).WithFieldFetches(2).WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCompositeIndex_WithFilterOnSecondFieldOnly_ShouldNotUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_eq: 32}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test composite index is not used if the filter does not constrain the first field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User @index(fields: ["name", "age"]) {
+						name: String
+						age: Int
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Islam"},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(10).WithIndexFetches(0),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithCompositeIndex_WithDescendingField_ShouldFetch(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "Chris"}, age: {_lt: 60}}) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test composite index with a descending field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User @index(fields: ["name", "age"], directions: [ASC, DESC]) {
+						name: String
+						age: Int
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Chris", "age": int64(55)},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithUniqueCompositeIndex_WithEqualFilterOnAllFields_ShouldFetch(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "Bruno"}, age: {_eq: 23}}) {
+			name
+			verified
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test unique composite index filtering with _eq filter on all fields",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User @index(unique: true, fields: ["name", "age"]) {
+						name: String
+						age: Int
+						verified: Boolean
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Bruno", "verified": true},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(1).WithFieldFetches(3).WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,343 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/defradb/client"
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func getFullTextIndexArticleDocs() []any {
+	return []any{
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"title":	"Fast databases",
+					"body":	"Databases store data. A database is fast."
+				}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"title":	"Kinds of databases",
+					"body":	"Graph databases and document databases"
+				}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"title":	"Quick meals",
+					"body":	"Cooking recipes for busy people"
+				}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"title":	"Recipe book",
+					"body":	"The database of recipes"
+				}`,
+		},
+	}
+}
+
+func TestQueryWithFullTextIndex_WithMatchFilter_ShouldReturnDocsByRelevance(t *testing.T) {
+	req := `query {
+		Article(filter: {body: {_match: "Databases"}}) {
+			title
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Article {
+					title: String
+					body: String @index(fullText: {})
+				}`,
+		},
+	}
+	actions = append(actions, getFullTextIndexArticleDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"title": "Kinds of databases"},
+				{"title": "Fast databases"},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithIndexFetches(2),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test a _match filter uses the full-text index and orders the documents by relevance",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithFullTextIndex_WithSeveralTerms_ShouldReturnDocsWithAllTerms(t *testing.T) {
+	req := `query {
+		Article(filter: {body: {_match: "recipes database"}}) {
+			title
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Article {
+					title: String
+					body: String @index(fullText: {})
+				}`,
+		},
+	}
+	actions = append(actions, getFullTextIndexArticleDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"title": "Recipe book"},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(1).WithIndexFetches(4),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test a _match filter with several terms returns only the documents containing all of them",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithFullTextIndex_WithStemming_ShouldMatchWordForms(t *testing.T) {
+	req := `query {
+		Article(filter: {body: {_match: "database"}}) {
+			title
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Article {
+					title: String
+					body: String
+				}`,
+		},
+	}
+	actions = append(actions, getFullTextIndexArticleDocs()...)
+	actions = append(actions,
+		testUtils.CreateIndex{
+			CollectionID: 0,
+			FieldName:    "body",
+			FullText:     &client.FullTextIndexOptions{Stem: true},
+		},
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"title": "Kinds of databases"},
+				{"title": "Fast databases"},
+				{"title": "Recipe book"},
+			},
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test a full-text index with stemming matches the different forms of a word",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithFullTextIndex_WithOtherConditionOnField_ShouldFilterDocs(t *testing.T) {
+	req := `query {
+		Article(filter: {body: {_match: "databases", _nlike: "Graph%"}}) {
+			title
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Article {
+					title: String
+					body: String @index(fullText: {})
+				}`,
+		},
+	}
+	actions = append(actions, getFullTextIndexArticleDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"title": "Fast databases"},
+			},
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test the other conditions on a full-text indexed field are checked against the documents",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithFullTextIndex_WithoutMatchFilter_ShouldNotUseIndex(t *testing.T) {
+	req := `query {
+		Article(filter: {body: {_eq: "Cooking recipes for busy people"}}) {
+			title
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Article {
+					title: String
+					body: String @index(fullText: {})
+				}`,
+		},
+	}
+	actions = append(actions, getFullTextIndexArticleDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"title": "Quick meals"},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(4).WithIndexFetches(0),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test a full-text index is not used by a filter without _match",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithFullTextIndex_IfDocIsUpdated_ShouldMatchNewTerms(t *testing.T) {
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Article {
+					title: String
+					body: String @index(fullText: {})
+				}`,
+		},
+	}
+	actions = append(actions, getFullTextIndexArticleDocs()...)
+	actions = append(actions,
+		testUtils.UpdateDoc{
+			CollectionID: 0,
+			DocID:        2,
+			Doc: `
+				{
+					"body": "Cooking for database engineers"
+				}`,
+		},
+		testUtils.Request{
+			Request: `query {
+				Article(filter: {body: {_match: "cooking"}}) {
+					title
+				}
+			}`,
+			Results: []map[string]any{
+				{"title": "Quick meals"},
+			},
+		},
+		testUtils.Request{
+			Request: `query {
+				Article(filter: {body: {_match: "recipes"}}) {
+					title
+				}
+			}`,
+			Results: []map[string]any{
+				{"title": "Recipe book"},
+			},
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test the terms of an updated document replace its old terms in a full-text index",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithMatchFilter_ShouldMatchIndexedValues(t *testing.T) {
+	req := `query {
+		Article(filter: {body: {_match: "recipes"}}) {
+			title
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Article {
+					title: String
+					body: String @index
+				}`,
+		},
+	}
+	actions = append(actions, getFullTextIndexArticleDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"title": "Quick meals"},
+				{"title": "Recipe book"},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithIndexFetches(4),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test a _match filter on a field with a regular index is matched against the index values",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestFullTextIndex_OnIntField_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test a full-text index can't be created on a non String field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Article {
+						views: Int
+					}`,
+			},
+			testUtils.CreateIndex{
+				CollectionID:  0,
+				FieldName:     "views",
+				FullText:      &client.FullTextIndexOptions{},
+				ExpectedError: "full-text index field must be of type String",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,265 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithIndex_IfOnlyIndexedFieldIsRequested_ShouldNotFetchDocs(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_gt: 40}}) {
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test covering index scan returns values read from the index",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"age": int64(42)},
+					{"age": int64(44)},
+					{"age": int64(48)},
+					{"age": int64(55)},
+				},
+			},
+			testUtils.Request{
+				Request: makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(4).WithFieldFetches(4).
+					WithIndexFetches(4).WithCoveredDocFetches(4),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_IfNotIndexedFieldIsRequested_ShouldFetchDocs(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_gt: 40}}) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index scan fetches documents if not all fields are in the index",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(4).WithCoveredDocFetches(0),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithCompositeIndexCoveringAllFields_ShouldNotFetchDocs(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_in: ["Keenan", "Addo"]}}) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test covering scan of a composite index",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User @index(fields: ["name", "age"]) {
+						name: String
+						age: Int
+						email: String
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Addo", "age": int64(42)},
+					{"name": "Keenan", "age": int64(48)},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(2).WithFieldFetches(4).WithCoveredDocFetches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithCoveringScanAndCompoundFilter_ShouldApplyWholeFilter(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_ge: 0}, _or: [{age: {_lt: 21}}, {age: {_gt: 50}}]}) {
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test covering index scan applies the conditions the index can not match",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"age": int64(20)},
+					{"age": int64(55)},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithCoveredDocFetches(10).WithFilterMatches(2),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithCountOfIndexedField_ShouldNotFetchDocs(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test counting documents filtered by an indexed field reads only the index",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: `query {
+					_count(User: {filter: {age: {_gt: 40}}})
+				}`,
+				Results: []map[string]any{
+					{"_count": 4},
+				},
+			},
+			testUtils.ExplainRequest{
+				Request: `query @explain(type: execute) {
+					_count(User: {filter: {age: {_gt: 40}}})
+				}`,
+				ExpectedFullGraph: []map[string]any{
+					{
+						"explain": map[string]any{
+							"executionSuccess": true,
+							"sizeOfResult":     1,
+							"planExecutions":   uint64(2),
+							"topLevelNode": []map[string]any{
+								{
+									"selectTopNode": map[string]any{
+										"selectNode": map[string]any{
+											"iterations":    uint64(5),
+											"filterMatches": uint64(4),
+											"scanNode": map[string]any{
+												"iterations":        uint64(5),
+												"docFetches":        uint64(4),
+												"fieldFetches":      uint64(4),
+												"indexFetches":      uint64(4),
+												"coveredDocFetches": uint64(4),
+											},
+										},
+									},
+								},
+								{
+									"countNode": map[string]any{
+										"iterations": uint64(1),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithCoveringScanAfterDocUpdate_ShouldReturnNewValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test covering index scan returns the updated values",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String @index
+						age: Int
+					}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "Fred",
+					"age": 30
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 0,
+				DocID:        0,
+				Doc: `{
+					"name": "Freddy"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					User(filter: {name: {_like: "Fred%"}}) {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{"name": "Freddy"},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,254 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithIndex_WithOrderAndLimit_ShouldStopAfterLimit(t *testing.T) {
+	req := `query {
+		User(order: {age: DESC}, limit: 3) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test ordering by a descending index with limit fetches only the needed docs",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User @index(fields: ["age"], directions: [DESC]) {
+						name: String
+						age: Int
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Chris", "age": int64(55)},
+					{"name": "Keenan", "age": int64(48)},
+					{"name": "Roy", "age": int64(44)},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(3).WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithOrderLimitAndOffset_ShouldStopAfterLimit(t *testing.T) {
+	req := `query {
+		User(order: {age: ASC}, limit: 2, offset: 1) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test ordering by an ascending index with limit and offset",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Bruno", "age": int64(23)},
+					{"name": "Fred", "age": int64(28)},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(3).WithIndexFetches(3),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithOrderOnFilteredIndexedField_ShouldUseFilterIndexOrder(t *testing.T) {
+	req := `query {
+		User(filter: {age: {_in: [48, 20, 33]}}, order: {age: ASC}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test ordering by the field of the index used for filtering",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Shahzad"},
+					{"name": "Andy"},
+					{"name": "Keenan"},
+				},
+			},
+			testUtils.ExplainRequest{
+				Request: `query @explain {
+					User(filter: {age: {_in: [48, 20, 33]}}, order: {age: ASC}) {
+						name
+					}
+				}`,
+				ExpectedTargets: []testUtils.PlanNodeTargetCase{
+					{
+						TargetNodeName:    "orderNode",
+						IncludeChildNodes: false,
+						ExpectedAttributes: map[string]any{
+							"index": "User_age_ASC",
+							"orderings": []map[string]any{
+								{
+									"direction": "ASC",
+									"fields":    []string{"age"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithOrderInOppositeDirection_ShouldSort(t *testing.T) {
+	req := `query {
+		User(order: {age: DESC}, limit: 2) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test ordering in the direction opposite to the index sorts all docs",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Chris"},
+					{"name": "Keenan"},
+				},
+			},
+			testUtils.ExplainRequest{
+				Request: `query @explain {
+					User(order: {age: DESC}, limit: 2) {
+						name
+					}
+				}`,
+				ExpectedTargets: []testUtils.PlanNodeTargetCase{
+					{
+						TargetNodeName:    "orderNode",
+						IncludeChildNodes: false,
+						ExpectedAttributes: map[string]any{
+							"index": nil,
+							"orderings": []map[string]any{
+								{
+									"direction": "DESC",
+									"fields":    []string{"age"},
+								},
+							},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(10).WithIndexFetches(0),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithOrderOnDocsWithoutValue_ShouldReturnThemFirst(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test ordering by an index returns documents without the field value first",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "Alice",
+					"age": 22
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "Bob"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "Carol",
+					"age": 19
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					User(order: {age: ASC}) {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{"name": "Bob"},
+					{"name": "Carol"},
+					{"name": "Alice"},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
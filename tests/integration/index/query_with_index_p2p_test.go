@@ -0,0 +1,274 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithIndex_WithDocCreatedOnReplicatedPeer_ShouldFetchByIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "Islam"}}) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index filtering on a document synced from another peer",
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String @index
+						age: Int
+					}
+				`,
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"name": "Islam",
+					"age": 33
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				NodeID:  immutable.Some(1),
+				Request: req,
+				Results: []map[string]any{
+					{"name": "Islam", "age": int64(33)},
+				},
+			},
+			testUtils.Request{
+				NodeID:   immutable.Some(1),
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(1).WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithDocUpdatedOnReplicatedPeer_ShouldFetchByNewValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test index filtering on a document updated on another peer",
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						age: Int @index
+					}
+				`,
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"name": "Islam",
+					"age": 33
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.UpdateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"age": 34
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				NodeID: immutable.Some(1),
+				Request: `query {
+					User(filter: {age: {_eq: 33}}) {
+						name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+			testUtils.Request{
+				NodeID: immutable.Some(1),
+				Request: `query {
+					User(filter: {age: {_eq: 34}}) {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{"name": "Islam"},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithUniqueIndex_WithDocDeletedOnReplicatedPeer_ShouldRemoveIndexEntry(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test unique index entry is removed when the document is deleted on another peer",
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String @index(unique: true)
+						age: Int
+					}
+				`,
+			},
+			testUtils.ConfigureReplicator{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"name": "Islam",
+					"age": 33
+				}`,
+			},
+			testUtils.WaitForSync{},
+			testUtils.DeleteDoc{
+				NodeID: immutable.Some(0),
+				DocID:  0,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				NodeID: immutable.Some(1),
+				Request: `query {
+					User(filter: {name: {_eq: "Islam"}}) {
+						name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+			testUtils.CreateDoc{
+				// the name is unique again, so a different document can use it
+				NodeID: immutable.Some(1),
+				Doc: `{
+					"name": "Islam",
+					"age": 34
+				}`,
+			},
+			testUtils.Request{
+				NodeID: immutable.Some(1),
+				Request: `query {
+					User(filter: {name: {_eq: "Islam"}}) {
+						age
+					}
+				}`,
+				Results: []map[string]any{
+					{"age": int64(34)},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithUniqueIndex_WithSameValueCreatedOnDisconnectedPeers_ShouldConverge(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "John"}}, order: {age: ASC}) {
+			name
+			age
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test unique index on documents with the same value created on peers while disconnected",
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String @index(unique: true)
+						age: Int
+					}
+				`,
+			},
+			testUtils.SubscribeToCollection{
+				NodeID:        0,
+				CollectionIDs: []int{0},
+			},
+			testUtils.SubscribeToCollection{
+				NodeID:        1,
+				CollectionIDs: []int{0},
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"name": "John",
+					"age": 21
+				}`,
+				DontSync: true,
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(1),
+				Doc: `{
+					"name": "John",
+					"age": 30
+				}`,
+				DontSync: true,
+			},
+			testUtils.ConnectPeers{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			// The peers catch up with the documents created by the other peer on connect,
+			// and both documents are kept despite the unique index.
+			testUtils.Request{
+				NodeID:  immutable.Some(0),
+				Request: req,
+				Results: []map[string]any{
+					{"name": "John", "age": int64(21)},
+					{"name": "John", "age": int64(30)},
+				},
+			},
+			testUtils.Request{
+				NodeID:  immutable.Some(1),
+				Request: req,
+				Results: []map[string]any{
+					{"name": "John", "age": int64(21)},
+					{"name": "John", "age": int64(30)},
+				},
+			},
+			testUtils.CreateDoc{
+				NodeID: immutable.Some(0),
+				Doc: `{
+					"name": "John",
+					"age": 40
+				}`,
+				DontSync:      true,
+				ExpectedError: "can not index a doc's field(s) that violates unique index",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func getProductSchema(indexDirective string) string {
+	return `
+		type Product ` + indexDirective + ` {
+			name: String
+			price: Float
+			rating: Int
+			listed: DateTime
+		}`
+}
+
+func getProductDocsActions() []any {
+	docs := []string{
+		`{"name": "Apple", "price": 1.5, "rating": 4, "listed": "2023-01-10T10:00:00Z"}`,
+		`{"name": "Banana", "price": 0.25, "rating": -2, "listed": "2023-03-05T09:00:00+02:00"}`,
+		`{"name": "Cherry", "price": 12.75, "rating": 5, "listed": "2022-12-24T18:30:00Z"}`,
+		`{"name": "Durian", "price": -3.5, "rating": 1, "listed": "2023-03-05T08:00:00Z"}`,
+		`{"name": "Elderberry", "price": 7, "rating": 3, "listed": "2023-06-01T00:00:00-05:00"}`,
+		`{"name": "Fig"}`,
+	}
+	actions := make([]any, 0, len(docs))
+	for _, doc := range docs {
+		actions = append(actions, testUtils.CreateDoc{CollectionID: 0, Doc: doc})
+	}
+	return actions
+}
+
+func TestQueryWithIndex_WithRangeFilterOnFloatField_ShouldSeekToBounds(t *testing.T) {
+	req := `query {
+		Product(filter: {price: {_gt: 0, _le: 7.0}}) {
+			name
+			price
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index range filtering on a float field",
+		Actions: append([]any{
+			testUtils.SchemaUpdate{
+				Schema: getProductSchema(`@index(fields: ["price"])`),
+			}},
+			append(getProductDocsActions(),
+				testUtils.Request{
+					Request: req,
+					Results: []map[string]any{
+						{"name": "Banana", "price": 0.25},
+						{"name": "Apple", "price": 1.5},
+						{"name": "Elderberry", "price": float64(7)},
+					},
+				},
+				testUtils.Request{
+					Request:  makeExplainQuery(req),
+					Asserter: testUtils.NewExplainAsserter().WithDocFetches(3).WithIndexFetches(4),
+				},
+			)...,
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithLessThanFilterOnNegativeInts_ShouldNotReturnNil(t *testing.T) {
+	req := `query {
+		Product(filter: {rating: {_lt: 3}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index range filtering does not return documents without the field value",
+		Actions: append([]any{
+			testUtils.SchemaUpdate{
+				Schema: getProductSchema(`@index(fields: ["rating"])`),
+			}},
+			append(getProductDocsActions(),
+				testUtils.Request{
+					Request: req,
+					Results: []map[string]any{
+						{"name": "Banana"},
+						{"name": "Durian"},
+					},
+				},
+			)...,
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithRangeFilterOnDescendingField_ShouldSeekToBounds(t *testing.T) {
+	req := `query {
+		Product(filter: {rating: {_ge: 3}}) {
+			name
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index range filtering on a field indexed in descending order",
+		Actions: append([]any{
+			testUtils.SchemaUpdate{
+				Schema: getProductSchema(`@index(fields: ["rating"], directions: [DESC])`),
+			}},
+			append(getProductDocsActions(),
+				testUtils.Request{
+					Request: req,
+					Results: []map[string]any{
+						{"name": "Cherry"},
+						{"name": "Apple"},
+						{"name": "Elderberry"},
+					},
+				},
+				testUtils.Request{
+					Request:  makeExplainQuery(req),
+					Asserter: testUtils.NewExplainAsserter().WithDocFetches(3).WithIndexFetches(4),
+				},
+			)...,
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndex_WithRangeFilterOnDateTimeField_ShouldCompareInstants(t *testing.T) {
+	req := `query {
+		Product(filter: {listed: {_gt: "2023-01-10T10:00:00Z", _lt: "2023-03-05T08:00:00Z"}}) {
+			name
+			listed
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test index range filtering on a datetime field with different time zones",
+		Actions: append([]any{
+			testUtils.SchemaUpdate{
+				Schema: getProductSchema(`@index(fields: ["listed"])`),
+			}},
+			append(getProductDocsActions(),
+				testUtils.Request{
+					Request: req,
+					Results: []map[string]any{
+						{"name": "Banana", "listed": "2023-03-05T09:00:00+02:00"},
+					},
+				},
+			)...,
+		),
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
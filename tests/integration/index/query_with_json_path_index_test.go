@@ -0,0 +1,304 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func getJSONPathIndexEventDocs() []any {
+	return []any{
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"name":	"click",
+					"payload": {"user": {"id": 1, "country": "FR"}}
+				}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"name":	"view",
+					"payload": {"user": {"id": 2, "country": "US"}}
+				}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"name":	"scroll",
+					"payload": {"user": {"id": 3, "country": "US"}}
+				}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"name":	"open",
+					"payload": {"user": {"id": "admin"}}
+				}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"name":	"close",
+					"payload": {"user": [1, 2]}
+				}`,
+		},
+	}
+}
+
+func TestQueryWithJSONPathIndex_WithEqFilterOnPath_ShouldUseIndex(t *testing.T) {
+	req := `query {
+		Event(filter: {payload: {path: ["user", "id"], _eq: 2}}) {
+			name
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Event {
+					name: String
+					payload: JSON @index(path: ["user", "id"])
+				}`,
+		},
+	}
+	actions = append(actions, getJSONPathIndexEventDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"name": "view"},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(1).WithIndexFetches(1),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test an _eq filter on the indexed path of a JSON field uses the index",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithJSONPathIndex_WithRangeFilterOnPath_ShouldCheckActualValue(t *testing.T) {
+	req := `query {
+		Event(filter: {payload: {path: ["user", "id"], _ge: 2}}) {
+			name
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Event {
+					name: String
+					payload: JSON @index(path: ["user", "id"])
+				}`,
+		},
+	}
+	actions = append(actions, getJSONPathIndexEventDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"name": "view"},
+				{"name": "scroll"},
+			},
+		},
+		testUtils.Request{
+			Request: makeExplainQuery(req),
+			// the string value is stored after the numbers and is discarded by the check
+			// of the fetched document
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(3).WithIndexFetches(3),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test a range filter on the indexed path of a JSON field checks the actual value",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithJSONPathIndex_WithFilterOnOtherPath_ShouldNotUseIndex(t *testing.T) {
+	req := `query {
+		Event(filter: {payload: {path: ["user", "country"], _eq: "US"}}) {
+			name
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Event {
+					name: String
+					payload: JSON @index(path: ["user", "id"])
+				}`,
+		},
+	}
+	actions = append(actions, getJSONPathIndexEventDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"name": "view"},
+				{"name": "scroll"},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(5).WithIndexFetches(0),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test a filter on another path of a JSON field doesn't use the index",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithJSONPathIndex_CreatedOnExistingDocs_ShouldIndexThem(t *testing.T) {
+	req := `query {
+		Event(filter: {payload: {path: ["user", "country"], _in: ["FR", "DE"]}}) {
+			name
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Event {
+					name: String
+					payload: JSON
+				}`,
+		},
+	}
+	actions = append(actions, getJSONPathIndexEventDocs()...)
+	actions = append(actions,
+		testUtils.CreateIndex{
+			CollectionID: 0,
+			FieldName:    "payload",
+			Paths:        [][]string{{"user", "country"}},
+		},
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"name": "click"},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(1).WithIndexFetches(1),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test an index on the path of a JSON field indexes the existing documents",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithJSONPathIndex_AfterUpdate_ShouldUseNewValue(t *testing.T) {
+	req := `query {
+		Event(filter: {payload: {path: ["user", "id"], _eq: 4}}) {
+			name
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Event @index(fields: ["payload.user.id"]) {
+					name: String
+					payload: JSON
+				}`,
+		},
+	}
+	actions = append(actions, getJSONPathIndexEventDocs()...)
+	actions = append(actions,
+		testUtils.UpdateDoc{
+			CollectionID: 0,
+			DocID:        0,
+			Doc: `
+				{
+					"payload": {"user": {"id": 4}}
+				}`,
+		},
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"name": "click"},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(1).WithIndexFetches(1),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test an index on the path of a JSON field is updated along with the field",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestJSONPathIndex_IfJSONFieldHasNoPath_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test a JSON field can't be indexed without a path",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Event {
+						name: String
+						payload: JSON
+					}`,
+			},
+			testUtils.CreateIndex{
+				CollectionID:  0,
+				FieldName:     "payload",
+				ExpectedError: "JSON fields can only be indexed by path",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestJSONPathIndex_IfFieldIsNotJSON_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test a field that is not a JSON field can't be indexed by path",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Event {
+						name: String
+						payload: JSON
+					}`,
+			},
+			testUtils.CreateIndex{
+				CollectionID:  0,
+				FieldName:     "name",
+				Paths:         [][]string{{"first"}},
+				ExpectedError: "only JSON fields can be indexed by path",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,197 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func getPartialIndexUserDocs() []any {
+	return []any{
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"name":	"John",
+					"age":	21,
+					"verified": true
+				}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"name":	"Islam",
+					"age":	32,
+					"verified": false
+				}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `
+				{
+					"name":	"Andy",
+					"age":	33,
+					"verified": true
+				}`,
+		},
+	}
+}
+
+func TestQueryWithPartialIndex_IfFilterImpliesIndexFilter_ShouldUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "John"}, verified: {_eq: true}}) {
+			name
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type User @index(fields: ["name"], where: {verified: {_eq: true}}) {
+					name: String
+					age: Int
+					verified: Boolean
+				}`,
+		},
+	}
+	actions = append(actions, getPartialIndexUserDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"name": "John"},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(1).WithFieldFetches(2).WithIndexFetches(1),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test a partial index is used if the query filter implies the index filter",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithPartialIndex_IfFilterDoesNotImplyIndexFilter_ShouldNotUseIndex(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "Islam"}}) {
+			name
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type User @index(fields: ["name"], where: {verified: {_eq: true}}) {
+					name: String
+					age: Int
+					verified: Boolean
+				}`,
+		},
+	}
+	actions = append(actions, getPartialIndexUserDocs()...)
+	actions = append(actions,
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{
+				{"name": "Islam"},
+			},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(3).WithIndexFetches(0),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test a partial index is not used if the query filter doesn't imply the index filter",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithPartialIndex_IfDocStopsMatchingIndexFilter_ShouldNotBeFound(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "John"}, verified: {_eq: true}}) {
+			name
+		}
+	}`
+	actions := []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type User {
+					name: String
+					age: Int
+					verified: Boolean
+				}`,
+		},
+	}
+	actions = append(actions, getPartialIndexUserDocs()...)
+	actions = append(actions,
+		testUtils.CreateIndex{
+			CollectionID: 0,
+			FieldName:    "name",
+			Where: map[string]any{
+				"verified": map[string]any{"_eq": true},
+			},
+		},
+		testUtils.UpdateDoc{
+			CollectionID: 0,
+			DocID:        0,
+			Doc: `
+				{
+					"verified": false
+				}`,
+		},
+		testUtils.Request{
+			Request: req,
+			Results: []map[string]any{},
+		},
+		testUtils.Request{
+			Request:  makeExplainQuery(req),
+			Asserter: testUtils.NewExplainAsserter().WithDocFetches(0).WithIndexFetches(0),
+		},
+	)
+	test := testUtils.TestCase{
+		Description: "Test a document is removed from a partial index once it doesn't match its filter",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestPartialIndex_IfFilterRefersToNonExistingField_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test a partial index can't be created with a filter on a non existing field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+					}`,
+			},
+			testUtils.CreateIndex{
+				CollectionID: 0,
+				FieldName:    "name",
+				Where: map[string]any{
+					"deleted": map[string]any{"_eq": false},
+				},
+				ExpectedError: "invalid index filter",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,242 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryWithIndexOnOneToManyRelationID_ShouldLookUpRelatedDocs(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "Addo"}}) {
+			name
+			devices {
+				model
+			}
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test join of 1-N relation looks up the related docs by the index on the relation field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						devices: [Device]
+					}
+
+					type Device {
+						model: String
+						owner: User @index
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{
+						"name": "Addo",
+						"devices": []map[string]any{
+							{"model": "Playstation 5"},
+							{"model": "Acer Aspire 5"},
+							{"model": "HyperX Headset"},
+							{"model": "iPhone 10"},
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(14).WithIndexFetches(4),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndexOnOneToManyRelationID_WithFilterOnRelatedDocs_ShouldFilter(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test join using the index on the relation field applies the filter of the related docs",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						devices: [Device]
+					}
+
+					type Device @index(fields: ["owner_id"]) {
+						model: String
+						owner: User
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: `query {
+					User(filter: {name: {_in: ["Addo", "Keenan", "Bruno"]}}) {
+						name
+						devices(filter: {model: {_like: "i%"}}) {
+							model
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Addo",
+						"devices": []map[string]any{
+							{"model": "iPhone 10"},
+						},
+					},
+					{
+						"name": "Keenan",
+						"devices": []map[string]any{
+							{"model": "iPhone 13"},
+							{"model": "iPad Mini"},
+						},
+					},
+					{
+						"name":    "Bruno",
+						"devices": []map[string]any{},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndexOnOneToOneSecondaryRelationID_ShouldLookUpRelatedDoc(t *testing.T) {
+	req := `query {
+		User(filter: {name: {_eq: "Fred"}}) {
+			name
+			address {
+				city
+			}
+		}
+	}`
+	test := testUtils.TestCase{
+		Description: "Test join of 1-1 relation looks up the related doc by the index on the relation field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						address: Address
+					}
+
+					type Address {
+						user: User @primary @index
+						city: String
+					}`,
+			},
+			testUtils.CreatePredefinedDocs{
+				Docs: getUserDocs(),
+			},
+			testUtils.Request{
+				Request: req,
+				Results: []map[string]any{
+					{
+						"name": "Fred",
+						"address": map[string]any{
+							"city": "Montreal",
+						},
+					},
+				},
+			},
+			testUtils.Request{
+				Request:  makeExplainQuery(req),
+				Asserter: testUtils.NewExplainAsserter().WithDocFetches(11).WithIndexFetches(1),
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryWithIndexOnRelationID_IfRelationIsUpdated_ShouldLookUpNewRelatedDocs(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Test join using the index on the relation field after the relation changed",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						devices: [Device]
+					}
+
+					type Device {
+						model: String
+						owner: User @index
+					}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				// bae-d7546ac1-c133-5853-b866-9b9f926fe7e5
+				Doc: `{
+					"name": "Shahzad"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				// bae-caba9876-89aa-5bcf-bc1c-387a52499b27
+				Doc: `{
+					"name": "Keenan"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc: `{
+					"model": "Walkman",
+					"owner_id": "bae-d7546ac1-c133-5853-b866-9b9f926fe7e5"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				CollectionID: 1,
+				DocID:        0,
+				Doc: `{
+					"owner_id": "bae-caba9876-89aa-5bcf-bc1c-387a52499b27"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					User {
+						name
+						devices {
+							model
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Keenan",
+						"devices": []map[string]any{
+							{"model": "Walkman"},
+						},
+					},
+					{
+						"name":    "Shahzad",
+						"devices": []map[string]any{},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
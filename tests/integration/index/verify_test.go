@@ -0,0 +1,119 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package index
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestIndexVerify_IfIndexIsConsistent_ReportNoDifferences(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Verify index should report no differences for a consistent index",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @index
+						age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `
+					{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `
+					{
+						"name":	"Islam",
+						"age":	18
+					}`,
+			},
+			testUtils.VerifyIndex{
+				CollectionID: 0,
+				IndexID:      0,
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestIndexVerify_WithRepairOfConsistentIndex_ShouldNotChangeQueryResults(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Rebuild of a consistent index should keep the index usable",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @index
+						age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `
+					{
+						"name":	"John",
+						"age":	21
+					}`,
+			},
+			testUtils.VerifyIndex{
+				CollectionID: 0,
+				IndexID:      0,
+				Repair:       true,
+			},
+			testUtils.Request{
+				Request: `
+					query {
+						Users(filter: {name: {_eq: "John"}}) {
+							age
+						}
+					}`,
+				Results: []map[string]any{
+					{"age": int64(21)},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestIndexVerify_IfIndexDoesNotExist_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Verify index should return error if index does not exist",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.VerifyIndex{
+				CollectionID:  0,
+				IndexName:     "non_existing_index",
+				ExpectedError: "index with name doesn't exists. Name: non_existing_index",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package on_delete
+
+import (
+	"fmt"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+// authorKey is the key of the author document created by getAuthorAndBooksActions.
+const authorKey = "bae-2edb7fdd-cad7-5ad4-9c7d-6920245a96ed"
+
+// getAuthorAndBooksActions returns the actions creating an author and two books referencing
+// the author through a relation with the given onDelete option.
+func getAuthorAndBooksActions(onDelete string) []any {
+	return []any{
+		testUtils.SchemaUpdate{
+			Schema: fmt.Sprintf(`
+				type Book {
+					name: String
+					author: Author @relation(onDelete: %s)
+				}
+
+				type Author {
+					name: String
+					published: [Book]
+				}
+			`, onDelete),
+		},
+		testUtils.CreateDoc{
+			CollectionID: 1,
+			Doc: `{
+				"name": "John Grisham"
+			}`,
+		},
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: fmt.Sprintf(`{
+				"name": "Painted House",
+				"author_id": "%s"
+			}`, authorKey),
+		},
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: fmt.Sprintf(`{
+				"name": "A Time for Mercy",
+				"author_id": "%s"
+			}`, authorKey),
+		},
+	}
+}
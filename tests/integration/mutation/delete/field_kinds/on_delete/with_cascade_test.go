@@ -0,0 +1,179 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package on_delete
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestMutationDeletion_WithCascadeOnDelete_ShouldDeleteReferencingDocs(t *testing.T) {
+	actions := getAuthorAndBooksActions("CASCADE")
+	actions = append(actions,
+		testUtils.CreateDoc{
+			CollectionID: 0,
+			Doc: `{
+				"name": "Thief of Time"
+			}`,
+		},
+		testUtils.DeleteDoc{
+			CollectionID: 1,
+			DocID:        0,
+		},
+		testUtils.Request{
+			Request: `query {
+				Book(showDeleted: true) {
+					name
+					_deleted
+				}
+			}`,
+			Results: []map[string]any{
+				{
+					"name":     "Painted House",
+					"_deleted": true,
+				},
+				{
+					"name":     "Thief of Time",
+					"_deleted": false,
+				},
+				{
+					"name":     "A Time for Mercy",
+					"_deleted": true,
+				},
+			},
+		},
+	)
+
+	test := testUtils.TestCase{
+		Description: "Deleting a document deletes the documents referencing it with the CASCADE option",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationDeletion_WithCascadeOnDeleteOfChainedRelations_ShouldDeleteAllReferencingDocs(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Deleting a document cascades through the relations with the CASCADE option",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Review {
+						text: String
+						book: Book @relation(onDelete: CASCADE)
+					}
+
+					type Book {
+						name: String
+						author: Author @relation(onDelete: CASCADE)
+						reviews: [Review]
+					}
+
+					type Author {
+						name: String
+						published: [Book]
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 2,
+				Doc: `{
+					"name": "John Grisham"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc: `{
+					"name": "Painted House",
+					"author_id": "bae-2edb7fdd-cad7-5ad4-9c7d-6920245a96ed"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"text": "Great",
+					"book_id": "bae-22e0a1c2-d12b-5bfd-b039-0cf72f963991"
+				}`,
+			},
+			testUtils.DeleteDoc{
+				CollectionID: 2,
+				DocID:        0,
+			},
+			testUtils.Request{
+				Request: `query {
+					Review {
+						text
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+			testUtils.Request{
+				Request: `query {
+					Book {
+						name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationDeletion_WithCascadeOnDeleteOfOneToOne_ShouldDeleteReferencingDoc(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Deleting a document deletes the document referencing it through a one-to-one relation",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type User {
+						name: String
+						address: Address @primary @relation(onDelete: CASCADE)
+					}
+
+					type Address {
+						city: String
+						user: User
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc: `{
+					"city": "Montreal"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"name": "John",
+					"address_id": "bae-7ee4276c-f0f7-5e50-a63e-d54216fb4255"
+				}`,
+			},
+			testUtils.DeleteDoc{
+				CollectionID: 1,
+				DocID:        0,
+			},
+			testUtils.Request{
+				Request: `query {
+					User {
+						name
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
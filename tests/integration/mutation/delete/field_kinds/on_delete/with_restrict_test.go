@@ -0,0 +1,166 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package on_delete
+
+import (
+	"fmt"
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestMutationDeletion_WithRestrictOnDelete_ShouldNotDeleteReferencedDoc(t *testing.T) {
+	actions := getAuthorAndBooksActions("RESTRICT")
+	actions = append(actions,
+		testUtils.DeleteDoc{
+			CollectionID:  1,
+			DocID:         0,
+			ExpectedError: "cannot delete a document that is still referenced",
+		},
+		testUtils.Request{
+			Request: `query {
+				Author {
+					name
+					published {
+						name
+					}
+				}
+			}`,
+			Results: []map[string]any{
+				{
+					"name": "John Grisham",
+					"published": []map[string]any{
+						{
+							"name": "Painted House",
+						},
+						{
+							"name": "A Time for Mercy",
+						},
+					},
+				},
+			},
+		},
+	)
+
+	test := testUtils.TestCase{
+		Description: "Deleting a document referenced with the RESTRICT option returns an error",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationDeletion_WithRestrictOnDeleteOfDeletedReferences_ShouldDeleteDoc(t *testing.T) {
+	actions := getAuthorAndBooksActions("RESTRICT")
+	actions = append(actions,
+		testUtils.DeleteDoc{
+			CollectionID: 0,
+			DocID:        0,
+		},
+		testUtils.DeleteDoc{
+			CollectionID: 0,
+			DocID:        1,
+		},
+		testUtils.Request{
+			Request: fmt.Sprintf(`mutation {
+				delete_Author(id: "%s") {
+					_key
+				}
+			}`, authorKey),
+			Results: []map[string]any{
+				{
+					"_key": authorKey,
+				},
+			},
+		},
+	)
+
+	test := testUtils.TestCase{
+		Description: "Deleting a document that is no longer referenced with the RESTRICT option succeeds",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationDeletion_WithRestrictOnDeleteAndCascade_ShouldNotDeleteAnyDoc(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "A RESTRICT option reached through a CASCADE option prevents the whole deletion",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Review {
+						text: String
+						book: Book @relation(onDelete: RESTRICT)
+					}
+
+					type Book {
+						name: String
+						author: Author @relation(onDelete: CASCADE)
+						reviews: [Review]
+					}
+
+					type Author {
+						name: String
+						published: [Book]
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 2,
+				Doc: `{
+					"name": "John Grisham"
+				}`,
+			},
+			testUtils.CreateDoc{
+				CollectionID: 1,
+				Doc: fmt.Sprintf(`{
+					"name": "Painted House",
+					"author_id": "%s"
+				}`, authorKey),
+			},
+			testUtils.CreateDoc{
+				CollectionID: 0,
+				Doc: `{
+					"text": "Great",
+					"book_id": "bae-22e0a1c2-d12b-5bfd-b039-0cf72f963991"
+				}`,
+			},
+			testUtils.DeleteDoc{
+				CollectionID:  2,
+				DocID:         0,
+				ExpectedError: "cannot delete a document that is still referenced",
+			},
+			testUtils.Request{
+				Request: `query {
+					Author {
+						name
+						published {
+							name
+						}
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John Grisham",
+						"published": []map[string]any{
+							{
+								"name": "Painted House",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package on_delete
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestMutationDeletion_WithSetNullOnDelete_ShouldClearReferences(t *testing.T) {
+	actions := getAuthorAndBooksActions("SET_NULL")
+	actions = append(actions,
+		testUtils.DeleteDoc{
+			CollectionID: 1,
+			DocID:        0,
+		},
+		testUtils.Request{
+			Request: `query {
+				Book {
+					name
+					author_id
+				}
+			}`,
+			Results: []map[string]any{
+				{
+					"name":      "Painted House",
+					"author_id": nil,
+				},
+				{
+					"name":      "A Time for Mercy",
+					"author_id": nil,
+				},
+			},
+		},
+	)
+
+	test := testUtils.TestCase{
+		Description: "Deleting a document clears the references to it with the SET_NULL option",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
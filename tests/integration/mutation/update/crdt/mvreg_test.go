@@ -0,0 +1,136 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestMVRegisterUpdate_WithSequentialUpdates_ShouldHaveNoConflicts(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Sequential updates of a multi-value register don't conflict",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @crdt(type: "mvreg")
+						age: Int
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"age": 21
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"name": "Johnny"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"name": "Jon"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						_conflicts
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":       "Jon",
+						"_conflicts": nil,
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMVRegisterUpdate_WithFilterOnField_ShouldFilterOnValue(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "The value of a multi-value register can be filtered on",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @crdt(type: "mvreg")
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "Fred"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				DocID: 1,
+				Doc: `{
+					"name": "Freddy"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users(filter: {name: {_eq: "Freddy"}}) {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "Freddy",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMVRegisterQuery_ConflictsOfTypeWithoutMVRegister_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "The _conflicts field only exists on types with multi-value register fields",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						_conflicts
+					}
+				}`,
+				ExpectedError: `Cannot query field "_conflicts" on type "Users".`,
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,222 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestORSetUpdate_WithAddAndRemove_ShouldPatchElements(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Add and remove elements of an OR-Set with String array type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: "orset")
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["a", "b"]
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"tags\": {\"_add\": [\"c\"], \"_remove\": [\"a\"]}}") {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						tags
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"tags": []string{"b", "c"},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestORSetUpdate_WithAddOfExistingElement_ShouldNotDuplicateElement(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Add an element already held by an OR-Set with Int array type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						favouriteIntegers: [Int!] @crdt(type: "orset")
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"favouriteIntegers": [1, 2]
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"favouriteIntegers\": {\"_add\": [2, 3]}}") {
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						favouriteIntegers
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"favouriteIntegers": []int64{1, 2, 3},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestORSetUpdate_WithArray_ShouldReplaceElements(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Replace the elements of an OR-Set with String array type",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: "orset")
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["a", "b"]
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"tags": ["c"]
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						name
+						tags
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"tags": []string{"c"},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestORSetUpdate_WithUnknownOperator_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update an OR-Set with an unknown operator",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!] @crdt(type: "orset")
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["a"]
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"tags\": {\"_append\": [\"c\"]}}") {
+						name
+					}
+				}`,
+				ExpectedError: "invalid set operator, it must be _add or _remove. Field: tags, Operator: _append",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestORSetUpdate_WithOperatorOnNonSetField_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Update a LWW array field with a set operator",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						tags: [String!]
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"tags": ["a"]
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Users(data: "{\"tags\": {\"_add\": [\"c\"]}}") {
+						name
+					}
+				}`,
+				ExpectedError: "set operators can only be used on set fields. Field: tags",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,222 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestRGAUpdate_WithInsertAndDelete_ShouldEditText(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Insert and delete text of a text sequence",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Notes {
+						title: String
+						body: String @crdt(type: "rga")
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "Greeting",
+					"body": "hello world"
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Notes(data: "{\"body\": {\"_delete\": {\"position\": 0, \"length\": 1}, \"_insert\": [{\"position\": 0, \"text\": \"J\"}, {\"position\": 11, \"text\": \"!\"}]}}") {
+						title
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"title": "Greeting",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Notes {
+						title
+						body
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"title": "Greeting",
+						"body":  "Jello world!",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestRGAUpdate_WithString_ShouldReplaceText(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Replace the text of a text sequence",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Notes {
+						title: String
+						body: String @crdt(type: "rga")
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "Greeting",
+					"body": "hello world"
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"body": "goodbye"
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Notes {
+						body
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"body": "goodbye",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestRGAUpdate_WithIndexedField_ShouldUpdateIndex(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Edit the text of an indexed text sequence",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Notes {
+						title: String @index @crdt(type: "rga")
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"title": "Draft"
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Notes(data: "{\"title\": {\"_insert\": {\"position\": 5, \"text\": \" 2\"}}}") {
+						title
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"title": "Draft 2",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Notes(filter: {title: {_eq: "Draft 2"}}) {
+						title
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"title": "Draft 2",
+					},
+				},
+			},
+			testUtils.Request{
+				Request: `query {
+					Notes(filter: {title: {_eq: "Draft"}}) {
+						title
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestRGAUpdate_WithPositionOutOfRange_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Delete text beyond the end of a text sequence",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Notes {
+						body: String @crdt(type: "rga")
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"body": "hello"
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Notes(data: "{\"body\": {\"_delete\": {\"position\": 3, \"length\": 5}}}") {
+						body
+					}
+				}`,
+				ExpectedError: "text position out of range. Position: 3, Length: 5",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestRGAUpdate_WithOperatorOnNonTextField_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Insert text into a LWW String field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Notes {
+						body: String
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"body": "hello"
+				}`,
+			},
+			testUtils.Request{
+				Request: `mutation {
+					update_Notes(data: "{\"body\": {\"_insert\": {\"position\": 0, \"text\": \"!\"}}}") {
+						body
+					}
+				}`,
+				ExpectedError: "text operators can only be used on text sequence fields. Field: body",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package field_kinds
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestMutationUpdate_WithJSONField(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple update of JSON field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						metadata: JSON
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"metadata": {"a": {"b": 1}}
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"metadata": {"a": {"b": 2, "c": [true, null]}}
+				}`,
+			},
+			testUtils.Request{
+				Request: `
+					query {
+						Users {
+							name
+							metadata
+						}
+					}
+				`,
+				Results: []map[string]any{
+					{
+						"name": "John",
+						"metadata": map[string]any{
+							"a": map[string]any{
+								"b": int64(2),
+								"c": []any{true, nil},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestMutationUpdate_WithJSONFieldSetToScalar(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple update of JSON field from an object to a scalar",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String
+						metadata: JSON
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "John",
+					"metadata": {"a": 1}
+				}`,
+			},
+			testUtils.UpdateDoc{
+				Doc: `{
+					"metadata": 3.5
+				}`,
+			},
+			testUtils.Request{
+				Request: `
+					query {
+						Users(filter: {metadata: {_gt: 3}}) {
+							name
+							metadata
+						}
+					}
+				`,
+				Results: []map[string]any{
+					{
+						"name":     "John",
+						"metadata": 3.5,
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
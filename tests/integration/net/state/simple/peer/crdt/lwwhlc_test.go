@@ -0,0 +1,179 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+// getOfflineEditsActions returns the actions of a test in which the first node updates the
+// name of a document several times, then the second node updates it once, before the nodes
+// are connected and synced.
+//
+// Each node has its own clock, the test waits before the update of the second node so that it
+// is given a later timestamp.
+func getOfflineEditsActions(schema string) []any {
+	return []any{
+		testUtils.RandomNetworkingConfig(),
+		testUtils.RandomNetworkingConfig(),
+		testUtils.SchemaUpdate{
+			Schema: schema,
+		},
+		testUtils.CreateDoc{
+			// Create John on all nodes
+			Doc: `{
+				"Name": "John",
+				"Age": 43
+			}`,
+		},
+		testUtils.UpdateDoc{
+			NodeID: immutable.Some(0),
+			Doc: `{
+				"Name": "Johnny"
+			}`,
+			DontSync: true,
+		},
+		testUtils.UpdateDoc{
+			NodeID: immutable.Some(0),
+			Doc: `{
+				"Name": "Jon"
+			}`,
+			DontSync: true,
+		},
+		testUtils.UpdateDoc{
+			NodeID: immutable.Some(0),
+			Doc: `{
+				"Name": "Jonathan"
+			}`,
+			DontSync: true,
+		},
+		testUtils.Wait{
+			Duration: 10 * time.Millisecond,
+		},
+		testUtils.UpdateDoc{
+			NodeID: immutable.Some(1),
+			Doc: `{
+				"Name": "Fred"
+			}`,
+			DontSync: true,
+		},
+		testUtils.ConnectPeers{
+			SourceNodeID: 0,
+			TargetNodeID: 1,
+		},
+		testUtils.UpdateDoc{
+			// The offline edits of each node are synced along with their next update
+			NodeID: immutable.Some(0),
+			Doc: `{
+				"Age": 44
+			}`,
+		},
+		testUtils.WaitForSync{},
+		testUtils.UpdateDoc{
+			NodeID: immutable.Some(1),
+			Doc: `{
+				"Age": 45
+			}`,
+		},
+		testUtils.WaitForSync{},
+	}
+}
+
+// TestP2PUpdate_WithOfflineEdits_ShouldKeepValueOfLongestHistory documents the default
+// behaviour, where the value with the highest priority wins even if it was written first.
+func TestP2PUpdate_WithOfflineEdits_ShouldKeepValueOfLongestHistory(t *testing.T) {
+	actions := getOfflineEditsActions(`
+		type Users {
+			Name: String
+			Age: Int
+		}
+	`)
+	actions = append(actions, testUtils.Request{
+		Request: `query {
+			Users {
+				Name
+				Age
+			}
+		}`,
+		Results: []map[string]any{
+			{
+				"Name": "Jonathan",
+				"Age":  int64(45),
+			},
+		},
+	})
+	test := testUtils.TestCase{
+		Actions: actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestP2PUpdate_WithOfflineEditsOfHLCField_ShouldKeepValueWrittenLast(t *testing.T) {
+	actions := getOfflineEditsActions(`
+		type Users {
+			Name: String @crdt(type: "hlc")
+			Age: Int
+		}
+	`)
+	actions = append(actions, testUtils.Request{
+		Request: `query {
+			Users {
+				Name
+				Age
+			}
+		}`,
+		Results: []map[string]any{
+			{
+				"Name": "Fred",
+				"Age":  int64(45),
+			},
+		},
+	})
+	test := testUtils.TestCase{
+		Actions: actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestP2PUpdate_WithOfflineEditsOfHLCCollection_ShouldKeepValueWrittenLast(t *testing.T) {
+	actions := getOfflineEditsActions(`
+		type Users @crdt(type: "hlc") {
+			Name: String
+			Age: Int
+		}
+	`)
+	actions = append(actions, testUtils.Request{
+		Request: `query {
+			Users {
+				Name
+				Age
+			}
+		}`,
+		Results: []map[string]any{
+			{
+				"Name": "Fred",
+				"Age":  int64(45),
+			},
+		},
+	})
+	test := testUtils.TestCase{
+		Actions: actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package crdt_test
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+// getMVRegisterConflictActions returns the actions of a test in which the name of a document
+// is updated on both nodes before they are connected and synced.
+func getMVRegisterConflictActions() []any {
+	return []any{
+		testUtils.RandomNetworkingConfig(),
+		testUtils.RandomNetworkingConfig(),
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Users {
+					Name: String @crdt(type: "mvreg")
+					Age: Int
+				}
+			`,
+		},
+		testUtils.CreateDoc{
+			// Create John on all nodes
+			Doc: `{
+				"Name": "John",
+				"Age": 43
+			}`,
+		},
+		testUtils.UpdateDoc{
+			NodeID: immutable.Some(0),
+			Doc: `{
+				"Name": "Johnny"
+			}`,
+			DontSync: true,
+		},
+		testUtils.UpdateDoc{
+			NodeID: immutable.Some(1),
+			Doc: `{
+				"Name": "Fred"
+			}`,
+			DontSync: true,
+		},
+		testUtils.ConnectPeers{
+			SourceNodeID: 0,
+			TargetNodeID: 1,
+		},
+		testUtils.UpdateDoc{
+			// The offline edits of each node are synced along with their next update
+			NodeID: immutable.Some(0),
+			Doc: `{
+				"Age": 44
+			}`,
+		},
+		testUtils.WaitForSync{},
+		testUtils.UpdateDoc{
+			NodeID: immutable.Some(1),
+			Doc: `{
+				"Age": 45
+			}`,
+		},
+		testUtils.WaitForSync{},
+	}
+}
+
+func TestP2PUpdate_WithMVRegisterConcurrentUpdates_ShouldReturnConflicts(t *testing.T) {
+	actions := getMVRegisterConflictActions()
+	actions = append(actions, testUtils.Request{
+		Request: `query {
+			Users {
+				Name
+				_conflicts
+			}
+		}`,
+		Results: []map[string]any{
+			{
+				"Name": "Johnny",
+				"_conflicts": map[string]any{
+					"Name": []any{"Johnny", "Fred"},
+				},
+			},
+		},
+	})
+	test := testUtils.TestCase{
+		Actions: actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestP2PUpdate_WithMVRegisterConcurrentUpdatesThenUpdate_ShouldResolveConflicts(t *testing.T) {
+	actions := getMVRegisterConflictActions()
+	actions = append(actions,
+		testUtils.UpdateDoc{
+			NodeID: immutable.Some(1),
+			Doc: `{
+				"Name": "Jon"
+			}`,
+		},
+		testUtils.WaitForSync{},
+		testUtils.Request{
+			Request: `query {
+				Users {
+					Name
+					_conflicts
+				}
+			}`,
+			Results: []map[string]any{
+				{
+					"Name":       "Jon",
+					"_conflicts": nil,
+				},
+			},
+		},
+	)
+	test := testUtils.TestCase{
+		Actions: actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -344,10 +344,6 @@ func TestP2PWithMultipleDocumentsWithUpdateAndDeleteBeforeConnectSingleDeleteWit
 				DocID:    0,
 				DontSync: true,
 			},
-			testUtils.ConnectPeers{
-				SourceNodeID: 0,
-				TargetNodeID: 1,
-			},
 			testUtils.UpdateDoc{
 				// Update John's Age on the second node only
 				NodeID: immutable.Some(1),
@@ -355,8 +351,13 @@ func TestP2PWithMultipleDocumentsWithUpdateAndDeleteBeforeConnectSingleDeleteWit
 				Doc: `{
 					"Age": 66
 				}`,
+				DontSync: true,
 			},
-			testUtils.WaitForSync{},
+			testUtils.ConnectPeers{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			// The nodes catch up with each other's pre-connection updates on connect.
 			testUtils.Request{
 				NodeID: immutable.Some(0),
 				Request: `query {
@@ -379,8 +380,6 @@ func TestP2PWithMultipleDocumentsWithUpdateAndDeleteBeforeConnectSingleDeleteWit
 					},
 				},
 			},
-			// The target node currently won't receive the pre-connection updates from the source.
-			// We should look into adding a head exchange mechanic on connect.
 			testUtils.Request{
 				NodeID: immutable.Some(1),
 				Request: `query {
@@ -397,9 +396,146 @@ func TestP2PWithMultipleDocumentsWithUpdateAndDeleteBeforeConnectSingleDeleteWit
 						"Age":      int64(74),
 					},
 					{
-						"_deleted": false,
+						"_deleted": true,
 						"Name":     "John",
-						"Age":      int64(66),
+						"Age":      int64(62),
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestP2PWithCascadeOnDelete_ShouldSyncDeletionOfReferencingDocs(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Book {
+						name: String
+						author: Author @relation(onDelete: CASCADE)
+					}
+					type Author {
+						name: String
+						published: [Book]
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// Create John Grisham on all nodes
+				CollectionID: 1,
+				Doc: `{
+					"name": "John Grisham"
+				}`,
+			},
+			testUtils.CreateDoc{
+				// Create Painted House on all nodes
+				CollectionID: 0,
+				Doc: `{
+					"name": "Painted House",
+					"author_id": "bae-2edb7fdd-cad7-5ad4-9c7d-6920245a96ed"
+				}`,
+			},
+			testUtils.CreateDoc{
+				// Create Thief of Time on all nodes
+				CollectionID: 0,
+				Doc: `{
+					"name": "Thief of Time"
+				}`,
+			},
+			testUtils.ConnectPeers{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.DeleteDoc{
+				NodeID:        immutable.Some(0),
+				CollectionID:  1,
+				DocID:         0,
+				OnDeleteCount: 1,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				Request: `query {
+					Book(showDeleted: true) {
+						_deleted
+						name
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"_deleted": true,
+						"name":     "Painted House",
+					},
+					{
+						"_deleted": false,
+						"name":     "Thief of Time",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestP2PWithSetNullOnDelete_ShouldSyncClearedReferences(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.RandomNetworkingConfig(),
+			testUtils.RandomNetworkingConfig(),
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Book {
+						name: String
+						author: Author @relation(onDelete: SET_NULL)
+					}
+					type Author {
+						name: String
+						published: [Book]
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				// Create John Grisham on all nodes
+				CollectionID: 1,
+				Doc: `{
+					"name": "John Grisham"
+				}`,
+			},
+			testUtils.CreateDoc{
+				// Create Painted House on all nodes
+				CollectionID: 0,
+				Doc: `{
+					"name": "Painted House",
+					"author_id": "bae-2edb7fdd-cad7-5ad4-9c7d-6920245a96ed"
+				}`,
+			},
+			testUtils.ConnectPeers{
+				SourceNodeID: 0,
+				TargetNodeID: 1,
+			},
+			testUtils.DeleteDoc{
+				NodeID:        immutable.Some(0),
+				CollectionID:  1,
+				DocID:         0,
+				OnDeleteCount: 1,
+			},
+			testUtils.WaitForSync{},
+			testUtils.Request{
+				Request: `query {
+					Book {
+						name
+						author_id
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":      "Painted House",
+						"author_id": nil,
 					},
 				},
 			},
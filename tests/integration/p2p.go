@@ -55,6 +55,11 @@ type ConfigureReplicator struct {
 
 	// TargetNodeID is the node ID (index) of the node to which data should be replicated.
 	TargetNodeID int
+
+	// Filter is the filter that the documents must match to be replicated. Optional.
+	//
+	// The creates of documents that don't match it must set DontSync.
+	Filter map[string]any
 }
 
 // DeleteReplicator deletes a directional replicator relationship between two nodes.
@@ -89,6 +94,10 @@ type SubscribeToCollection struct {
 	// A [NonExistentCollectionID] may be provided to test non-existent collection IDs.
 	CollectionIDs []int
 
+	// Filter is the filter that the documents of the collections must match to be synced
+	// to this node. Optional.
+	Filter map[string]any
+
 	// Any error expected from the action. Optional.
 	//
 	// String can be a partial, and the test will pass if an error is returned that
@@ -211,7 +220,7 @@ func setupPeerWaitSync(
 			// Peers sync trigger sync events for documents that exist prior to configuration, even if they already
 			// exist at the destination, so we need to wait for documents created on all nodes, as well as those
 			// created on the target.
-			if (!action.NodeID.HasValue() ||
+			if !action.DontSync && (!action.NodeID.HasValue() ||
 				action.NodeID.Value() == cfg.TargetNodeID) &&
 				sourceCollectionSubscribed {
 				targetToSourceEvents[waitIndex] += 1
@@ -220,7 +229,7 @@ func setupPeerWaitSync(
 			// Peers sync trigger sync events for documents that exist prior to configuration, even if they already
 			// exist at the destination, so we need to wait for documents created on all nodes, as well as those
 			// created on the source.
-			if (!action.NodeID.HasValue() ||
+			if !action.DontSync && (!action.NodeID.HasValue() ||
 				action.NodeID.Value() == cfg.SourceNodeID) &&
 				targetCollectionSubscribed {
 				sourceToTargetEvents[waitIndex] += 1
@@ -229,10 +238,10 @@ func setupPeerWaitSync(
 		case DeleteDoc:
 			// Updates to existing docs should always sync (no-sub required)
 			if !action.DontSync && action.NodeID.HasValue() && action.NodeID.Value() == cfg.TargetNodeID {
-				targetToSourceEvents[waitIndex] += 1
+				targetToSourceEvents[waitIndex] += 1 + action.OnDeleteCount
 			}
 			if !action.DontSync && action.NodeID.HasValue() && action.NodeID.Value() == cfg.SourceNodeID {
-				sourceToTargetEvents[waitIndex] += 1
+				sourceToTargetEvents[waitIndex] += 1 + action.OnDeleteCount
 			}
 
 		case UpdateDoc:
@@ -305,7 +314,8 @@ func configureReplicator(
 	targetNode := s.nodes[cfg.TargetNodeID]
 
 	err := sourceNode.SetReplicator(s.ctx, client.Replicator{
-		Info: targetNode.PeerInfo(),
+		Info:   targetNode.PeerInfo(),
+		Filter: cfg.Filter,
 	})
 	require.NoError(s.t, err)
 	setupReplicatorWaitSync(s, 0, cfg, sourceNode, targetNode)
@@ -349,7 +359,7 @@ func setupReplicatorWaitSync(
 
 			// A document created on the source or one that is created on all nodes will be sent to the target even
 			// it already has it. It will create a `received push log` event on the target which we need to wait for.
-			if !action.NodeID.HasValue() || action.NodeID.Value() == cfg.SourceNodeID {
+			if !action.DontSync && (!action.NodeID.HasValue() || action.NodeID.Value() == cfg.SourceNodeID) {
 				sourceToTargetEvents[waitIndex] += 1
 			}
 
@@ -358,11 +368,11 @@ func setupReplicatorWaitSync(
 		case DeleteDoc:
 			if _, shouldSyncFromTarget := docIDsSyncedToSource[action.DocID]; shouldSyncFromTarget &&
 				action.NodeID.HasValue() && action.NodeID.Value() == cfg.TargetNodeID {
-				targetToSourceEvents[waitIndex] += 1
+				targetToSourceEvents[waitIndex] += 1 + action.OnDeleteCount
 			}
 
 			if action.NodeID.HasValue() && action.NodeID.Value() == cfg.SourceNodeID {
-				sourceToTargetEvents[waitIndex] += 1
+				sourceToTargetEvents[waitIndex] += 1 + action.OnDeleteCount
 			}
 
 		case UpdateDoc:
@@ -425,6 +435,14 @@ func subscribeToCollection(
 	}
 
 	err := n.AddP2PCollections(s.ctx, schemaRoots)
+	if err == nil && len(action.Filter) > 0 {
+		for _, schemaRoot := range schemaRoots {
+			err = n.SetP2PCollectionFilter(s.ctx, schemaRoot, action.Filter)
+			if err != nil {
+				break
+			}
+		}
+	}
 	expectedErrorRaised := AssertError(s.t, s.testCase.Description, err, action.ExpectedError)
 	assertExpectedErrorRaised(s.t, s.testCase.Description, action.ExpectedError, expectedErrorRaised)
 
@@ -0,0 +1,108 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package inline_array
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+var inlineArrayFilterDocs = map[int][]string{
+	0: {
+		`{
+			"name": "John",
+			"favouriteIntegers": [1, 2, 3],
+			"testScores": [null, 2],
+			"preferredStrings": ["urgent", "later"]
+		}`,
+		`{
+			"name": "Shahzad",
+			"favouriteIntegers": [5, 6],
+			"testScores": [3],
+			"preferredStrings": ["later"]
+		}`,
+		`{
+			"name": "Islam",
+			"favouriteIntegers": [],
+			"preferredStrings": null
+		}`,
+	},
+}
+
+func TestQueryInlineArrayWithAnyFilter_ShouldReturnDocsWithMatchingElement(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by any element",
+		Request: `query {
+					Users(filter: {preferredStrings: {_any: {_eq: "urgent"}}}) {
+						name
+					}
+				}`,
+		Docs: inlineArrayFilterDocs,
+		Results: []map[string]any{
+			{"name": "John"},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineArrayWithAnyFilterOnNillableArray_ShouldReturnDocsWithMatchingElement(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by any element of nillable array",
+		Request: `query {
+					Users(filter: {testScores: {_any: {_gt: 2}}}) {
+						name
+					}
+				}`,
+		Docs: inlineArrayFilterDocs,
+		Results: []map[string]any{
+			{"name": "Shahzad"},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineArrayWithAllFilter_ShouldReturnDocsWithAllElementsMatching(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by all elements",
+		Request: `query {
+					Users(filter: {favouriteIntegers: {_all: {_gt: 4}}}) {
+						name
+					}
+				}`,
+		Docs: inlineArrayFilterDocs,
+		Results: []map[string]any{
+			{"name": "Shahzad"},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQueryInlineArrayWithNotAnyFilter_ShouldReturnDocsWithoutMatchingElement(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple inline array, filtered by negated any element",
+		Request: `query {
+					Users(filter: {_not: {preferredStrings: {_any: {_eq: "urgent"}}}}) {
+						name
+					}
+				}`,
+		Docs: inlineArrayFilterDocs,
+		Results: []map[string]any{
+			{"name": "Shahzad"},
+			{"name": "Islam"},
+		},
+	}
+
+	executeTestCase(t, test)
+}
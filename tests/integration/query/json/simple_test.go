@@ -0,0 +1,152 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package json
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQueryJSON_WithObjectValue_ShouldReturnObject(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query of a JSON field holding an object",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Event {
+						name: String
+						payload: JSON
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "click",
+					"payload": {"user": {"id": 7, "tags": ["a", "b"]}, "score": 1.5, "valid": true}
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Event {
+						name
+						payload
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name": "click",
+						"payload": map[string]any{
+							"user": map[string]any{
+								"id":   int64(7),
+								"tags": []any{"a", "b"},
+							},
+							"score": 1.5,
+							"valid": true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryJSON_WithScalarAndArrayValues_ShouldReturnValues(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query of JSON fields holding scalars and arrays",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Event {
+						name: String
+						payload: JSON
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "scalar",
+					"payload": "hello"
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"name": "array",
+					"payload": [1, {"a": "b"}]
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Event(order: {name: ASC}) {
+						name
+						payload
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"name":    "array",
+						"payload": []any{int64(1), map[string]any{"a": "b"}},
+					},
+					{
+						"name":    "scalar",
+						"payload": "hello",
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryJSON_WithDocsDifferingOnlyByJSONValue_ShouldReturnAllDocs(t *testing.T) {
+	test := testUtils.TestCase{
+		Description: "Simple query of documents differing only by the value of a JSON field",
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Event {
+						payload: JSON
+					}
+				`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"payload": {"a": 1}
+				}`,
+			},
+			testUtils.CreateDoc{
+				Doc: `{
+					"payload": {"a": 2}
+				}`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Event {
+						payload
+					}
+				}`,
+				Results: []map[string]any{
+					{
+						"payload": map[string]any{"a": int64(2)},
+					},
+					{
+						"payload": map[string]any{"a": int64(1)},
+					},
+				},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
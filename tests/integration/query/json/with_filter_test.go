@@ -0,0 +1,159 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package json
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func getEventDocs() []any {
+	return []any{
+		testUtils.SchemaUpdate{
+			Schema: `
+				type Event {
+					name: String
+					payload: JSON
+				}
+			`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"name": "click",
+				"payload": {"user": {"id": 1, "country": "FR"}, "items": [{"price": 10}]}
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"name": "view",
+				"payload": {"user": {"id": 2, "country": "US"}, "items": [{"price": 2.5}]}
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"name": "scroll",
+				"payload": {"user": {"id": 3}}
+			}`,
+		},
+		testUtils.CreateDoc{
+			Doc: `{
+				"name": "close",
+				"payload": "done"
+			}`,
+		},
+	}
+}
+
+func TestQueryJSONWithFilter_WithEqOnPath_ShouldFilter(t *testing.T) {
+	actions := getEventDocs()
+	actions = append(actions, testUtils.Request{
+		Request: `query {
+			Event(filter: {payload: {path: ["user", "id"], _eq: 2}}) {
+				name
+			}
+		}`,
+		Results: []map[string]any{
+			{"name": "view"},
+		},
+	})
+	test := testUtils.TestCase{
+		Description: "Query JSON field with _eq filter on a nested path",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryJSONWithFilter_WithGtOnArrayElementPath_ShouldFilter(t *testing.T) {
+	actions := getEventDocs()
+	actions = append(actions, testUtils.Request{
+		Request: `query {
+			Event(filter: {payload: {path: ["items", "0", "price"], _gt: 5}}) {
+				name
+			}
+		}`,
+		Results: []map[string]any{
+			{"name": "click"},
+		},
+	})
+	test := testUtils.TestCase{
+		Description: "Query JSON field with _gt filter on a path through an array",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryJSONWithFilter_WithEqNilOnMissingPath_ShouldMatchDocsWithoutValue(t *testing.T) {
+	actions := getEventDocs()
+	actions = append(actions, testUtils.Request{
+		Request: `query {
+			Event(filter: {payload: {path: ["user", "country"], _eq: null}}, order: {name: ASC}) {
+				name
+			}
+		}`,
+		Results: []map[string]any{
+			{"name": "close"},
+			{"name": "scroll"},
+		},
+	})
+	test := testUtils.TestCase{
+		Description: "Query JSON field with _eq nil filter on a path missing from some documents",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryJSONWithFilter_WithInAndLikeOnPaths_ShouldFilter(t *testing.T) {
+	actions := getEventDocs()
+	actions = append(actions, testUtils.Request{
+		Request: `query {
+			Event(filter: {_or: [
+				{payload: {path: ["user", "country"], _in: ["FR", "DE"]}},
+				{payload: {path: [], _like: "do%"}}
+			]}, order: {name: ASC}) {
+				name
+			}
+		}`,
+		Results: []map[string]any{
+			{"name": "click"},
+			{"name": "close"},
+		},
+	})
+	test := testUtils.TestCase{
+		Description: "Query JSON field with _in and _like filters on different paths",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestQueryJSONWithFilter_WithEqWithoutPath_ShouldCompareWholeValue(t *testing.T) {
+	actions := getEventDocs()
+	actions = append(actions, testUtils.Request{
+		Request: `query {
+			Event(filter: {payload: {_eq: "done"}}) {
+				name
+			}
+		}`,
+		Results: []map[string]any{
+			{"name": "close"},
+		},
+	})
+	test := testUtils.TestCase{
+		Description: "Query JSON field with _eq filter without a path",
+		Actions:     actions,
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
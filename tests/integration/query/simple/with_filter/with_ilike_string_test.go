@@ -0,0 +1,137 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package simple
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestQuerySimpleWithILikeStringContainsFilterBlockContainsString(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with basic ilike-string filter contains string",
+		Request: `query {
+					Users(filter: {Name: {_ilike: "%stormborn%"}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "Daenerys Stormborn of House Targaryen, the First of Her Name",
+					"HeightM": 1.65
+				}`,
+				`{
+					"Name": "Viserys I Targaryen, King of the Andals",
+					"HeightM": 1.82
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Daenerys Stormborn of House Targaryen, the First of Her Name",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithILikeStringContainsFilterBlockAsPrefixString(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with basic ilike-string filter with string as prefix",
+		Request: `query {
+					Users(filter: {Name: {_ilike: "VISERYS%"}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "Daenerys Stormborn of House Targaryen, the First of Her Name",
+					"HeightM": 1.65
+				}`,
+				`{
+					"Name": "Viserys I Targaryen, King of the Andals",
+					"HeightM": 1.82
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Viserys I Targaryen, King of the Andals",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithNILikeStringContainsFilterBlockContainsString(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with basic nilike-string filter contains string",
+		Request: `query {
+					Users(filter: {Name: {_nilike: "%STORMBORN%"}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "Daenerys Stormborn of House Targaryen, the First of Her Name",
+					"HeightM": 1.65
+				}`,
+				`{
+					"Name": "Viserys I Targaryen, King of the Andals",
+					"HeightM": 1.82
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "Viserys I Targaryen, King of the Andals",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
+
+func TestQuerySimpleWithIEqStringFilterBlock(t *testing.T) {
+	test := testUtils.RequestTestCase{
+		Description: "Simple query with basic case insensitive equality filter",
+		Request: `query {
+					Users(filter: {Name: {_ieq: "jOHN"}}) {
+						Name
+					}
+				}`,
+		Docs: map[int][]string{
+			0: {
+				`{
+					"Name": "John",
+					"Age": 21
+				}`,
+				`{
+					"Name": "Johnny",
+					"Age": 32
+				}`,
+			},
+		},
+		Results: []map[string]any{
+			{
+				"Name": "John",
+			},
+		},
+	}
+
+	executeTestCase(t, test)
+}
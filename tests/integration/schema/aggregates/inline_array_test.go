@@ -388,59 +388,67 @@ func TestSchemaAggregateInlineArrayCreatesUsersAverage(t *testing.T) {
 }
 */
 
-var aggregateGroupArg = map[string]any{
-	"name": "_group",
-	"type": map[string]any{
-		"name": "Users__CountSelector",
-		"inputFields": []any{
-			map[string]any{
-				"name": "filter",
-				"type": map[string]any{
-					"name": "UsersFilterArg",
-					"inputFields": []any{
-						map[string]any{
-							"name": "_and",
-							"type": map[string]any{
-								"name": nil,
+func aggregateGroupArg(favouritesOperatorBlock string) map[string]any {
+	return map[string]any{
+		"name": "_group",
+		"type": map[string]any{
+			"name": "Users__CountSelector",
+			"inputFields": []any{
+				map[string]any{
+					"name": "filter",
+					"type": map[string]any{
+						"name": "UsersFilterArg",
+						"inputFields": []any{
+							map[string]any{
+								"name": "Favourites",
+								"type": map[string]any{
+									"name": favouritesOperatorBlock,
+								},
 							},
-						},
-						map[string]any{
-							"name": "_key",
-							"type": map[string]any{
-								"name": "IDOperatorBlock",
+							map[string]any{
+								"name": "_and",
+								"type": map[string]any{
+									"name": nil,
+								},
 							},
-						},
-						map[string]any{
-							"name": "_not",
-							"type": map[string]any{
-								"name": "UsersFilterArg",
+							map[string]any{
+								"name": "_key",
+								"type": map[string]any{
+									"name": "IDOperatorBlock",
+								},
 							},
-						},
-						map[string]any{
-							"name": "_or",
-							"type": map[string]any{
-								"name": nil,
+							map[string]any{
+								"name": "_not",
+								"type": map[string]any{
+									"name": "UsersFilterArg",
+								},
+							},
+							map[string]any{
+								"name": "_or",
+								"type": map[string]any{
+									"name": nil,
+								},
 							},
 						},
 					},
 				},
-			},
-			map[string]any{
-				"name": "limit",
-				"type": map[string]any{
-					"name":        "Int",
-					"inputFields": nil,
+				map[string]any{
+					"name": "limit",
+					"type": map[string]any{
+						"name":        "Int",
+						"inputFields": nil,
+					},
 				},
-			},
-			map[string]any{
-				"name": "offset",
-				"type": map[string]any{
-					"name":        "Int",
-					"inputFields": nil,
+				map[string]any{
+					"name": "offset",
+					"type": map[string]any{
+						"name":        "Int",
+						"inputFields": nil,
+					},
 				},
 			},
 		},
-	},
+	}
 }
 
 var aggregateVersionArg = map[string]any{
@@ -578,7 +586,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableBooleanCountFilter(t *tes
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("BooleanListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -704,7 +712,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersBooleanCountFilter(t *testing.T)
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("NotNullBooleanListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -854,7 +862,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableIntegerCountFilter(t *tes
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("IntListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -1004,7 +1012,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersIntegerCountFilter(t *testing.T)
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("NotNullIntListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -1154,7 +1162,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableFloatCountFilter(t *testi
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("FloatListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -1304,7 +1312,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersFloatCountFilter(t *testing.T) {
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("NotNullFloatListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -1386,6 +1394,18 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_ieq",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
+															map[string]any{
+																"name": "_ilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_in",
 																"type": map[string]any{
@@ -1398,12 +1418,24 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_match",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_ne",
 																"type": map[string]any{
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_nilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_nin",
 																"type": map[string]any{
@@ -1442,7 +1474,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersNillableStringCountFilter(t *test
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("StringListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
@@ -1524,6 +1556,18 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_ieq",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
+															map[string]any{
+																"name": "_ilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_in",
 																"type": map[string]any{
@@ -1536,12 +1580,24 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_match",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_ne",
 																"type": map[string]any{
 																	"name": "String",
 																},
 															},
+															map[string]any{
+																"name": "_nilike",
+																"type": map[string]any{
+																	"name": "String",
+																},
+															},
 															map[string]any{
 																"name": "_nin",
 																"type": map[string]any{
@@ -1580,7 +1636,7 @@ func TestSchemaAggregateInlineArrayCreatesUsersStringCountFilter(t *testing.T) {
 											},
 										},
 									},
-									aggregateGroupArg,
+									aggregateGroupArg("NotNullStringListOperatorBlock"),
 									aggregateVersionArg,
 								},
 							},
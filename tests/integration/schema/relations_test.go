@@ -180,3 +180,86 @@ func TestSchemaRelation_GivenSelfReferemceRelationField_ReturnError(t *testing.T
 
 	testUtils.ExecuteTestCase(t, test)
 }
+
+func TestSchemaRelation_GivenOnDeleteOnPrimarySide_ShouldCreateSchema(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Dog {
+						name: String
+						owner: User @relation(onDelete: CASCADE)
+					}
+					type User {
+						dogs: [Dog]
+					}
+				`,
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaRelation_GivenOnDeleteOnSecondarySide_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Dog {
+						name: String
+						owner: User @primary
+					}
+					type User {
+						dog: Dog @relation(onDelete: CASCADE)
+					}
+				`,
+				ExpectedError: "onDelete can only be set on the primary side of a relation. Object: User, Field: dog",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaRelation_GivenOnDeleteOnManySide_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Dog {
+						name: String
+						owner: User
+					}
+					type User {
+						dogs: [Dog] @relation(onDelete: SET_NULL)
+					}
+				`,
+				ExpectedError: "onDelete can only be set on the primary side of a relation. Object: User, Field: dogs",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaRelation_GivenUnknownOnDelete_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Dog {
+						name: String
+						owner: User @relation(onDelete: DESTROY)
+					}
+					type User {
+						dogs: [Dog]
+					}
+				`,
+				ExpectedError: "relation with invalid onDelete argument. Field: owner, OnDelete: DESTROY",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -33,7 +33,7 @@ func TestSchemaUpdatesAddFieldCRDTCompositeErrors(t *testing.T) {
 						{ "op": "add", "path": "/Users/Fields/-", "value": {"Name": "foo", "Kind": 2, "Typ":3} }
 					]
 				`,
-				ExpectedError: "only default or LWW (last writer wins) CRDT types are supported. Name: foo, CRDTType: 3",
+				ExpectedError: "only default, LWW (last writer wins) or counter CRDT types are supported. Name: foo, CRDTType: 3",
 			},
 		},
 	}
@@ -0,0 +1,109 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schema
+
+import (
+	"testing"
+
+	testUtils "github.com/sourcenetwork/defradb/tests/integration"
+)
+
+func TestSchemaWithCRDTType_GivenPNCounterOnIntField_ShouldCreateSchema(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						points: Int @crdt(type: "pncounter")
+					}
+				`,
+			},
+			testUtils.Request{
+				Request: `query {
+					Users {
+						points
+					}
+				}`,
+				Results: []map[string]any{},
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithCRDTType_GivenCounterOnStringField_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @crdt(type: "pncounter")
+					}
+				`,
+				ExpectedError: "CRDT type not supported for the field kind. Name: name",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithCRDTType_GivenUnknownType_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						points: Int @crdt(type: "gcounter")
+					}
+				`,
+				ExpectedError: "unknown CRDT type. Field: points, CRDTType: gcounter",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithCRDTType_GivenORSetOnStringField_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						name: String @crdt(type: "orset")
+					}
+				`,
+				ExpectedError: "CRDT type not supported for the field kind. Name: name",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
+
+func TestSchemaWithCRDTType_GivenRGAOnIntField_ReturnError(t *testing.T) {
+	test := testUtils.TestCase{
+		Actions: []any{
+			testUtils.SchemaUpdate{
+				Schema: `
+					type Users {
+						age: Int @crdt(type: "rga")
+					}
+				`,
+				ExpectedError: "CRDT type not supported for the field kind. Name: age",
+			},
+		},
+	}
+
+	testUtils.ExecuteTestCase(t, test)
+}
@@ -12,6 +12,7 @@ package tests
 
 import (
 	"testing"
+	"time"
 
 	"github.com/sourcenetwork/immutable"
 
@@ -59,6 +60,12 @@ type ConfigureNode func() config.Config
 // Restart is an action that will close and then start all nodes.
 type Restart struct{}
 
+// Wait is an action that will pause the test for the given duration, so that the
+// physical time of the nodes has advanced before the next action is executed.
+type Wait struct {
+	Duration time.Duration
+}
+
 // SchemaUpdate is an action that will update the database schema.
 //
 // WARNING: getCollectionNames will not work with schemas ending in `type`, e.g. `user_type`
@@ -149,6 +156,10 @@ type CreateDoc struct {
 	// String can be a partial, and the test will pass if an error is returned that
 	// contains this string.
 	ExpectedError string
+
+	// Setting DontSync to true will prevent waiting for that create, for example when
+	// the document doesn't match the filter of a replicator.
+	DontSync bool
 }
 
 // DeleteDoc will attempt to delete the given document in the given collection
@@ -175,6 +186,12 @@ type DeleteDoc struct {
 
 	// Setting DontSync to true will prevent waiting for that delete.
 	DontSync bool
+
+	// The number of referencing documents that are expected to be deleted or updated along
+	// with this document, through the onDelete options of their relations.
+	//
+	// Their updates are synced along with the delete and must also be waited for.
+	OnDeleteCount int
 }
 
 // UpdateDoc will attempt to update the given document using the set [MutationType].
@@ -227,6 +244,22 @@ type CreateIndex struct {
 	FieldsNames []string
 	// The directions of the 'FieldsNames' to index. Used only for composite indexes.
 	Directions []client.IndexDirection
+	// The collations of the fields to index. Used for both single field and composite indexes.
+	Collations []client.IndexCollation
+	// The paths within the JSON fields to index. Used for both single field and composite indexes.
+	Paths [][]string
+
+	// If Unique is true, the index will be created as a unique index.
+	Unique bool
+
+	// If Background is true, the existing documents will be indexed in the background.
+	Background bool
+
+	// The filter of the documents to index. If not provided, all documents will be indexed.
+	Where map[string]any
+
+	// The options of a full-text index. If not provided, the index will be a regular index.
+	FullText *client.FullTextIndexOptions
 
 	// Any error expected from the action. Optional.
 	//
@@ -262,6 +295,41 @@ type DropIndex struct {
 	ExpectedError string
 }
 
+// VerifyIndex will attempt to verify the given secondary index of the given collection
+// against the collection documents using the collection api.
+type VerifyIndex struct {
+	// NodeID may hold the ID (index) of a node to verify the secondary index on.
+	//
+	// If a value is not provided the index will be verified on all nodes.
+	NodeID immutable.Option[int]
+
+	// The collection of the index to be verified.
+	CollectionID int
+
+	// The index-identifier of the secondary index within the collection.
+	// This is based on the order in which it was created, not the ordering of
+	// the indexes within the database.
+	IndexID int
+
+	// The index name of the secondary index within the collection.
+	// If it is provided, `IndexID` is ignored.
+	IndexName string
+
+	// Repair indicates whether the differences found should be repaired.
+	Repair bool
+
+	// The expected result of the verification.
+	//
+	// The `Index` and `Repaired` fields are not compared.
+	ExpectedResult client.IndexVerification
+
+	// Any error expected from the action. Optional.
+	//
+	// String can be a partial, and the test will pass if an error is returned that
+	// contains this string.
+	ExpectedError string
+}
+
 // GetIndex will attempt to get the given secondary index from the given collection
 // using the collection api.
 type GetIndexes struct {
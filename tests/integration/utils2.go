@@ -230,6 +230,9 @@ func performAction(
 	case ConfigureNode:
 		configureNode(s, action)
 
+	case Wait:
+		time.Sleep(action.Duration)
+
 	case Restart:
 		restartNodes(s, actionIndex)
 
@@ -287,6 +290,9 @@ func performAction(
 	case GetIndexes:
 		getIndexes(s, action)
 
+	case VerifyIndex:
+		verifyIndex(s, action)
+
 	case BackupExport:
 		backupExport(s, action)
 
@@ -1303,7 +1309,11 @@ func createIndex(
 	actionNodes := getNodes(action.NodeID, s.nodes)
 	for nodeID, collections := range getNodeCollections(action.NodeID, s.collections) {
 		indexDesc := client.IndexDescription{
-			Name: action.IndexName,
+			Name:       action.IndexName,
+			Unique:     action.Unique,
+			Where:      action.Where,
+			FullText:   action.FullText,
+			Background: action.Background,
 		}
 		if action.FieldName != "" {
 			indexDesc.Fields = []client.IndexedFieldDescription{
@@ -1313,12 +1323,24 @@ func createIndex(
 			}
 		} else if len(action.FieldsNames) > 0 {
 			for i := range action.FieldsNames {
+				dir := client.Ascending
+				if i < len(action.Directions) {
+					dir = action.Directions[i]
+				}
 				indexDesc.Fields = append(indexDesc.Fields, client.IndexedFieldDescription{
 					Name:      action.FieldsNames[i],
-					Direction: action.Directions[i],
+					Direction: dir,
 				})
 			}
 		}
+		for i := range indexDesc.Fields {
+			if i < len(action.Collations) {
+				indexDesc.Fields[i].Collation = action.Collations[i]
+			}
+			if i < len(action.Paths) {
+				indexDesc.Fields[i].Path = action.Paths[i]
+			}
+		}
 		err := withRetry(
 			actionNodes,
 			nodeID,
@@ -1366,6 +1388,51 @@ func dropIndex(
 	assertExpectedErrorRaised(s.t, s.testCase.Description, action.ExpectedError, expectedErrorRaised)
 }
 
+// verifyIndex verifies, and if requested repairs, a secondary index using the collection api.
+func verifyIndex(
+	s *state,
+	action VerifyIndex,
+) {
+	var expectedErrorRaised bool
+	actionNodes := getNodes(action.NodeID, s.nodes)
+	for nodeID, collections := range getNodeCollections(action.NodeID, s.collections) {
+		indexName := action.IndexName
+		if indexName == "" {
+			indexName = s.indexes[nodeID][action.CollectionID][action.IndexID].Name
+		}
+
+		err := withRetry(
+			actionNodes,
+			nodeID,
+			func() error {
+				var result client.IndexVerification
+				var err error
+				if action.Repair {
+					result, err = collections[action.CollectionID].RebuildIndex(s.ctx, indexName)
+				} else {
+					result, err = collections[action.CollectionID].VerifyIndex(s.ctx, indexName)
+				}
+				if err != nil {
+					return err
+				}
+
+				assert.Equal(s.t, indexName, result.Index, s.testCase.Description)
+				assert.Equal(s.t, action.Repair, result.Repaired, s.testCase.Description)
+				assert.ElementsMatch(s.t, action.ExpectedResult.MissingKeys, result.MissingKeys,
+					s.testCase.Description)
+				assert.ElementsMatch(s.t, action.ExpectedResult.OrphanedKeys, result.OrphanedKeys,
+					s.testCase.Description)
+				assert.ElementsMatch(s.t, action.ExpectedResult.MismatchedKeys, result.MismatchedKeys,
+					s.testCase.Description)
+				return nil
+			},
+		)
+		expectedErrorRaised = AssertError(s.t, s.testCase.Description, err, action.ExpectedError)
+	}
+
+	assertExpectedErrorRaised(s.t, s.testCase.Description, action.ExpectedError, expectedErrorRaised)
+}
+
 // backupExport generates a backup using the db api.
 func backupExport(
 	s *state,